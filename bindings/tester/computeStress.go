@@ -0,0 +1,234 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package tester
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ComputeStressMetaData contains all meta data concerning the ComputeStress contract.
+var ComputeStressMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"hash\",\"inputs\":[{\"name\":\"rounds\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"stateMutability\":\"view\"}]",
+	Bin: "0x600d380380600d6000396000f360003560e01c63b189fd4c14630000001657600080fd5b60043560005b8115630000003757600052600190036020600020630000001c565b6000525060206000f3",
+}
+
+// ComputeStressABI is the input ABI used to generate the binding from.
+// Deprecated: Use ComputeStressMetaData.ABI instead.
+var ComputeStressABI = ComputeStressMetaData.ABI
+
+// ComputeStressBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use ComputeStressMetaData.Bin instead.
+var ComputeStressBin = ComputeStressMetaData.Bin
+
+// DeployComputeStress deploys a new Ethereum contract, binding an instance of ComputeStress to it.
+func DeployComputeStress(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *ComputeStress, error) {
+	parsed, err := ComputeStressMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(ComputeStressBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &ComputeStress{ComputeStressCaller: ComputeStressCaller{contract: contract}, ComputeStressTransactor: ComputeStressTransactor{contract: contract}, ComputeStressFilterer: ComputeStressFilterer{contract: contract}}, nil
+}
+
+// ComputeStress is an auto generated Go binding around an Ethereum contract.
+type ComputeStress struct {
+	ComputeStressCaller     // Read-only binding to the contract
+	ComputeStressTransactor // Write-only binding to the contract
+	ComputeStressFilterer   // Log filterer for contract events
+}
+
+// ComputeStressCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ComputeStressCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ComputeStressTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ComputeStressTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ComputeStressFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ComputeStressFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ComputeStressSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ComputeStressSession struct {
+	Contract     *ComputeStress    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ComputeStressCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ComputeStressCallerSession struct {
+	Contract *ComputeStressCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// ComputeStressTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ComputeStressTransactorSession struct {
+	Contract     *ComputeStressTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// ComputeStressRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ComputeStressRaw struct {
+	Contract *ComputeStress // Generic contract binding to access the raw methods on
+}
+
+// ComputeStressCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ComputeStressCallerRaw struct {
+	Contract *ComputeStressCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ComputeStressTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ComputeStressTransactorRaw struct {
+	Contract *ComputeStressTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewComputeStress creates a new instance of ComputeStress, bound to a specific deployed contract.
+func NewComputeStress(address common.Address, backend bind.ContractBackend) (*ComputeStress, error) {
+	contract, err := bindComputeStress(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ComputeStress{ComputeStressCaller: ComputeStressCaller{contract: contract}, ComputeStressTransactor: ComputeStressTransactor{contract: contract}, ComputeStressFilterer: ComputeStressFilterer{contract: contract}}, nil
+}
+
+// NewComputeStressCaller creates a new read-only instance of ComputeStress, bound to a specific deployed contract.
+func NewComputeStressCaller(address common.Address, caller bind.ContractCaller) (*ComputeStressCaller, error) {
+	contract, err := bindComputeStress(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ComputeStressCaller{contract: contract}, nil
+}
+
+// NewComputeStressTransactor creates a new write-only instance of ComputeStress, bound to a specific deployed contract.
+func NewComputeStressTransactor(address common.Address, transactor bind.ContractTransactor) (*ComputeStressTransactor, error) {
+	contract, err := bindComputeStress(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ComputeStressTransactor{contract: contract}, nil
+}
+
+// NewComputeStressFilterer creates a new log filterer instance of ComputeStress, bound to a specific deployed contract.
+func NewComputeStressFilterer(address common.Address, filterer bind.ContractFilterer) (*ComputeStressFilterer, error) {
+	contract, err := bindComputeStress(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ComputeStressFilterer{contract: contract}, nil
+}
+
+// bindComputeStress binds a generic wrapper to an already deployed contract.
+func bindComputeStress(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ComputeStressMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ComputeStress *ComputeStressRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ComputeStress.Contract.ComputeStressCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ComputeStress *ComputeStressRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ComputeStress.Contract.ComputeStressTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ComputeStress *ComputeStressRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ComputeStress.Contract.ComputeStressTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ComputeStress *ComputeStressCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ComputeStress.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ComputeStress *ComputeStressTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ComputeStress.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ComputeStress *ComputeStressTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ComputeStress.Contract.contract.Transact(opts, method, params...)
+}
+
+// Hash is a free data retrieval call binding the contract method 0xb189fd4c.
+//
+// Solidity: function hash(uint256 rounds) view returns(bytes32)
+func (_ComputeStress *ComputeStressCaller) Hash(opts *bind.CallOpts, rounds *big.Int) ([32]byte, error) {
+	var out []interface{}
+	err := _ComputeStress.contract.Call(opts, &out, "hash", rounds)
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// Hash is a free data retrieval call binding the contract method 0xb189fd4c.
+//
+// Solidity: function hash(uint256 rounds) view returns(bytes32)
+func (_ComputeStress *ComputeStressSession) Hash(rounds *big.Int) ([32]byte, error) {
+	return _ComputeStress.Contract.Hash(&_ComputeStress.CallOpts, rounds)
+}
+
+// Hash is a free data retrieval call binding the contract method 0xb189fd4c.
+//
+// Solidity: function hash(uint256 rounds) view returns(bytes32)
+func (_ComputeStress *ComputeStressCallerSession) Hash(rounds *big.Int) ([32]byte, error) {
+	return _ComputeStress.Contract.Hash(&_ComputeStress.CallOpts, rounds)
+}
@@ -0,0 +1,245 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package tester
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ReverterMetaData contains all meta data concerning the Reverter contract.
+var ReverterMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"fallback\",\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"fail\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"}]",
+	Bin: "0x600d380380600d6000396000f36308c379a060e01b600052602060045260186024527752657665727465723a20616c77617973207265766572747360401b60445260646000fd",
+}
+
+// ReverterABI is the input ABI used to generate the binding from.
+// Deprecated: Use ReverterMetaData.ABI instead.
+var ReverterABI = ReverterMetaData.ABI
+
+// ReverterBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use ReverterMetaData.Bin instead.
+var ReverterBin = ReverterMetaData.Bin
+
+// DeployReverter deploys a new Ethereum contract, binding an instance of Reverter to it.
+func DeployReverter(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Reverter, error) {
+	parsed, err := ReverterMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(ReverterBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Reverter{ReverterCaller: ReverterCaller{contract: contract}, ReverterTransactor: ReverterTransactor{contract: contract}, ReverterFilterer: ReverterFilterer{contract: contract}}, nil
+}
+
+// Reverter is an auto generated Go binding around an Ethereum contract.
+type Reverter struct {
+	ReverterCaller     // Read-only binding to the contract
+	ReverterTransactor // Write-only binding to the contract
+	ReverterFilterer   // Log filterer for contract events
+}
+
+// ReverterCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ReverterCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ReverterTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ReverterTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ReverterFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ReverterFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ReverterSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ReverterSession struct {
+	Contract     *Reverter         // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ReverterCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ReverterCallerSession struct {
+	Contract *ReverterCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts   // Call options to use throughout this session
+}
+
+// ReverterTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ReverterTransactorSession struct {
+	Contract     *ReverterTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// ReverterRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ReverterRaw struct {
+	Contract *Reverter // Generic contract binding to access the raw methods on
+}
+
+// ReverterCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ReverterCallerRaw struct {
+	Contract *ReverterCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ReverterTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ReverterTransactorRaw struct {
+	Contract *ReverterTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewReverter creates a new instance of Reverter, bound to a specific deployed contract.
+func NewReverter(address common.Address, backend bind.ContractBackend) (*Reverter, error) {
+	contract, err := bindReverter(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Reverter{ReverterCaller: ReverterCaller{contract: contract}, ReverterTransactor: ReverterTransactor{contract: contract}, ReverterFilterer: ReverterFilterer{contract: contract}}, nil
+}
+
+// NewReverterCaller creates a new read-only instance of Reverter, bound to a specific deployed contract.
+func NewReverterCaller(address common.Address, caller bind.ContractCaller) (*ReverterCaller, error) {
+	contract, err := bindReverter(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ReverterCaller{contract: contract}, nil
+}
+
+// NewReverterTransactor creates a new write-only instance of Reverter, bound to a specific deployed contract.
+func NewReverterTransactor(address common.Address, transactor bind.ContractTransactor) (*ReverterTransactor, error) {
+	contract, err := bindReverter(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ReverterTransactor{contract: contract}, nil
+}
+
+// NewReverterFilterer creates a new log filterer instance of Reverter, bound to a specific deployed contract.
+func NewReverterFilterer(address common.Address, filterer bind.ContractFilterer) (*ReverterFilterer, error) {
+	contract, err := bindReverter(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ReverterFilterer{contract: contract}, nil
+}
+
+// bindReverter binds a generic wrapper to an already deployed contract.
+func bindReverter(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ReverterMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Reverter *ReverterRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Reverter.Contract.ReverterCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Reverter *ReverterRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Reverter.Contract.ReverterTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Reverter *ReverterRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Reverter.Contract.ReverterTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Reverter *ReverterCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Reverter.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Reverter *ReverterTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Reverter.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Reverter *ReverterTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Reverter.Contract.contract.Transact(opts, method, params...)
+}
+
+// Fail is a paid mutator transaction binding the contract method 0xa9cc4718.
+//
+// Solidity: function fail() returns()
+func (_Reverter *ReverterTransactor) Fail(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Reverter.contract.Transact(opts, "fail")
+}
+
+// Fail is a paid mutator transaction binding the contract method 0xa9cc4718.
+//
+// Solidity: function fail() returns()
+func (_Reverter *ReverterSession) Fail() (*types.Transaction, error) {
+	return _Reverter.Contract.Fail(&_Reverter.TransactOpts)
+}
+
+// Fail is a paid mutator transaction binding the contract method 0xa9cc4718.
+//
+// Solidity: function fail() returns()
+func (_Reverter *ReverterTransactorSession) Fail() (*types.Transaction, error) {
+	return _Reverter.Contract.Fail(&_Reverter.TransactOpts)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() returns()
+func (_Reverter *ReverterTransactor) Fallback(opts *bind.TransactOpts, calldata []byte) (*types.Transaction, error) {
+	return _Reverter.contract.RawTransact(opts, calldata)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() returns()
+func (_Reverter *ReverterSession) Fallback(calldata []byte) (*types.Transaction, error) {
+	return _Reverter.Contract.Fallback(&_Reverter.TransactOpts, calldata)
+}
+
+// Fallback is a paid mutator transaction binding the contract fallback function.
+//
+// Solidity: fallback() returns()
+func (_Reverter *ReverterTransactorSession) Fallback(calldata []byte) (*types.Transaction, error) {
+	return _Reverter.Contract.Fallback(&_Reverter.TransactOpts, calldata)
+}
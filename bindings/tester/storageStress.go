@@ -0,0 +1,255 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package tester
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// StorageStressMetaData contains all meta data concerning the StorageStress contract.
+var StorageStressMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"fill\",\"inputs\":[{\"name\":\"count\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"slots\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"}]",
+	Bin: "0x600d380380600d6000396000f360003560e01c80633fda5389146300000023576348547d6914630000004c57600080fd5b50600435600054016000545b81811015630000004657600101808055630000002f565b50600055005b60005460005260206000f3",
+}
+
+// StorageStressABI is the input ABI used to generate the binding from.
+// Deprecated: Use StorageStressMetaData.ABI instead.
+var StorageStressABI = StorageStressMetaData.ABI
+
+// StorageStressBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use StorageStressMetaData.Bin instead.
+var StorageStressBin = StorageStressMetaData.Bin
+
+// DeployStorageStress deploys a new Ethereum contract, binding an instance of StorageStress to it.
+func DeployStorageStress(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *StorageStress, error) {
+	parsed, err := StorageStressMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(StorageStressBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &StorageStress{StorageStressCaller: StorageStressCaller{contract: contract}, StorageStressTransactor: StorageStressTransactor{contract: contract}, StorageStressFilterer: StorageStressFilterer{contract: contract}}, nil
+}
+
+// StorageStress is an auto generated Go binding around an Ethereum contract.
+type StorageStress struct {
+	StorageStressCaller     // Read-only binding to the contract
+	StorageStressTransactor // Write-only binding to the contract
+	StorageStressFilterer   // Log filterer for contract events
+}
+
+// StorageStressCaller is an auto generated read-only Go binding around an Ethereum contract.
+type StorageStressCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StorageStressTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type StorageStressTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StorageStressFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type StorageStressFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StorageStressSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type StorageStressSession struct {
+	Contract     *StorageStress    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// StorageStressCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type StorageStressCallerSession struct {
+	Contract *StorageStressCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// StorageStressTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type StorageStressTransactorSession struct {
+	Contract     *StorageStressTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// StorageStressRaw is an auto generated low-level Go binding around an Ethereum contract.
+type StorageStressRaw struct {
+	Contract *StorageStress // Generic contract binding to access the raw methods on
+}
+
+// StorageStressCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type StorageStressCallerRaw struct {
+	Contract *StorageStressCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// StorageStressTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type StorageStressTransactorRaw struct {
+	Contract *StorageStressTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewStorageStress creates a new instance of StorageStress, bound to a specific deployed contract.
+func NewStorageStress(address common.Address, backend bind.ContractBackend) (*StorageStress, error) {
+	contract, err := bindStorageStress(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageStress{StorageStressCaller: StorageStressCaller{contract: contract}, StorageStressTransactor: StorageStressTransactor{contract: contract}, StorageStressFilterer: StorageStressFilterer{contract: contract}}, nil
+}
+
+// NewStorageStressCaller creates a new read-only instance of StorageStress, bound to a specific deployed contract.
+func NewStorageStressCaller(address common.Address, caller bind.ContractCaller) (*StorageStressCaller, error) {
+	contract, err := bindStorageStress(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageStressCaller{contract: contract}, nil
+}
+
+// NewStorageStressTransactor creates a new write-only instance of StorageStress, bound to a specific deployed contract.
+func NewStorageStressTransactor(address common.Address, transactor bind.ContractTransactor) (*StorageStressTransactor, error) {
+	contract, err := bindStorageStress(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageStressTransactor{contract: contract}, nil
+}
+
+// NewStorageStressFilterer creates a new log filterer instance of StorageStress, bound to a specific deployed contract.
+func NewStorageStressFilterer(address common.Address, filterer bind.ContractFilterer) (*StorageStressFilterer, error) {
+	contract, err := bindStorageStress(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageStressFilterer{contract: contract}, nil
+}
+
+// bindStorageStress binds a generic wrapper to an already deployed contract.
+func bindStorageStress(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := StorageStressMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_StorageStress *StorageStressRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _StorageStress.Contract.StorageStressCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_StorageStress *StorageStressRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _StorageStress.Contract.StorageStressTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_StorageStress *StorageStressRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _StorageStress.Contract.StorageStressTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_StorageStress *StorageStressCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _StorageStress.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_StorageStress *StorageStressTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _StorageStress.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_StorageStress *StorageStressTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _StorageStress.Contract.contract.Transact(opts, method, params...)
+}
+
+// Slots is a free data retrieval call binding the contract method 0x48547d69.
+//
+// Solidity: function slots() view returns(uint256)
+func (_StorageStress *StorageStressCaller) Slots(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _StorageStress.contract.Call(opts, &out, "slots")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Slots is a free data retrieval call binding the contract method 0x48547d69.
+//
+// Solidity: function slots() view returns(uint256)
+func (_StorageStress *StorageStressSession) Slots() (*big.Int, error) {
+	return _StorageStress.Contract.Slots(&_StorageStress.CallOpts)
+}
+
+// Slots is a free data retrieval call binding the contract method 0x48547d69.
+//
+// Solidity: function slots() view returns(uint256)
+func (_StorageStress *StorageStressCallerSession) Slots() (*big.Int, error) {
+	return _StorageStress.Contract.Slots(&_StorageStress.CallOpts)
+}
+
+// Fill is a paid mutator transaction binding the contract method 0x3fda5389.
+//
+// Solidity: function fill(uint256 count) returns()
+func (_StorageStress *StorageStressTransactor) Fill(opts *bind.TransactOpts, count *big.Int) (*types.Transaction, error) {
+	return _StorageStress.contract.Transact(opts, "fill", count)
+}
+
+// Fill is a paid mutator transaction binding the contract method 0x3fda5389.
+//
+// Solidity: function fill(uint256 count) returns()
+func (_StorageStress *StorageStressSession) Fill(count *big.Int) (*types.Transaction, error) {
+	return _StorageStress.Contract.Fill(&_StorageStress.TransactOpts, count)
+}
+
+// Fill is a paid mutator transaction binding the contract method 0x3fda5389.
+//
+// Solidity: function fill(uint256 count) returns()
+func (_StorageStress *StorageStressTransactorSession) Fill(count *big.Int) (*types.Transaction, error) {
+	return _StorageStress.Contract.Fill(&_StorageStress.TransactOpts, count)
+}
@@ -0,0 +1,338 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ulxly
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// PolygonRollupManagerMetaData contains all meta data concerning the PolygonRollupManager contract.
+var PolygonRollupManagerMetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"getRollupExitRoot\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"rollupCount\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint32\"}]},{\"type\":\"function\",\"name\":\"rollupIDToRollupData\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"rollupID\",\"type\":\"uint32\"}],\"outputs\":[{\"name\":\"rollupContract\",\"type\":\"address\"},{\"name\":\"chainID\",\"type\":\"uint64\"},{\"name\":\"verifier\",\"type\":\"address\"},{\"name\":\"forkID\",\"type\":\"uint64\"},{\"name\":\"lastLocalExitRoot\",\"type\":\"bytes32\"},{\"name\":\"lastBatchSequenced\",\"type\":\"uint64\"},{\"name\":\"lastVerifiedBatch\",\"type\":\"uint64\"},{\"name\":\"lastPendingState\",\"type\":\"uint64\"},{\"name\":\"lastPendingStateConsolidated\",\"type\":\"uint64\"},{\"name\":\"lastVerifiedBatchBeforeUpgrade\",\"type\":\"uint64\"},{\"name\":\"rollupTypeID\",\"type\":\"uint64\"},{\"name\":\"rollupCompatibilityID\",\"type\":\"uint8\"}]}]",
+}
+
+// PolygonRollupManagerABI is the input ABI used to generate the binding from.
+// Deprecated: Use PolygonRollupManagerMetaData.ABI instead.
+var PolygonRollupManagerABI = PolygonRollupManagerMetaData.ABI
+
+// PolygonRollupManager is an auto generated Go binding around an Ethereum contract.
+type PolygonRollupManager struct {
+	PolygonRollupManagerCaller     // Read-only binding to the contract
+	PolygonRollupManagerTransactor // Write-only binding to the contract
+	PolygonRollupManagerFilterer   // Log filterer for contract events
+}
+
+// PolygonRollupManagerCaller is an auto generated read-only Go binding around an Ethereum contract.
+type PolygonRollupManagerCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolygonRollupManagerTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type PolygonRollupManagerTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolygonRollupManagerFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type PolygonRollupManagerFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolygonRollupManagerSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type PolygonRollupManagerSession struct {
+	Contract     *PolygonRollupManager // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts         // Call options to use throughout this session
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// PolygonRollupManagerCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type PolygonRollupManagerCallerSession struct {
+	Contract *PolygonRollupManagerCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts               // Call options to use throughout this session
+}
+
+// PolygonRollupManagerTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type PolygonRollupManagerTransactorSession struct {
+	Contract     *PolygonRollupManagerTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts               // Transaction auth options to use throughout this session
+}
+
+// PolygonRollupManagerRaw is an auto generated low-level Go binding around an Ethereum contract.
+type PolygonRollupManagerRaw struct {
+	Contract *PolygonRollupManager // Generic contract binding to access the raw methods on
+}
+
+// PolygonRollupManagerCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type PolygonRollupManagerCallerRaw struct {
+	Contract *PolygonRollupManagerCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// PolygonRollupManagerTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type PolygonRollupManagerTransactorRaw struct {
+	Contract *PolygonRollupManagerTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewPolygonRollupManager creates a new instance of PolygonRollupManager, bound to a specific deployed contract.
+func NewPolygonRollupManager(address common.Address, backend bind.ContractBackend) (*PolygonRollupManager, error) {
+	contract, err := bindPolygonRollupManager(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonRollupManager{PolygonRollupManagerCaller: PolygonRollupManagerCaller{contract: contract}, PolygonRollupManagerTransactor: PolygonRollupManagerTransactor{contract: contract}, PolygonRollupManagerFilterer: PolygonRollupManagerFilterer{contract: contract}}, nil
+}
+
+// NewPolygonRollupManagerCaller creates a new read-only instance of PolygonRollupManager, bound to a specific deployed contract.
+func NewPolygonRollupManagerCaller(address common.Address, caller bind.ContractCaller) (*PolygonRollupManagerCaller, error) {
+	contract, err := bindPolygonRollupManager(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonRollupManagerCaller{contract: contract}, nil
+}
+
+// NewPolygonRollupManagerTransactor creates a new write-only instance of PolygonRollupManager, bound to a specific deployed contract.
+func NewPolygonRollupManagerTransactor(address common.Address, transactor bind.ContractTransactor) (*PolygonRollupManagerTransactor, error) {
+	contract, err := bindPolygonRollupManager(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonRollupManagerTransactor{contract: contract}, nil
+}
+
+// NewPolygonRollupManagerFilterer creates a new log filterer instance of PolygonRollupManager, bound to a specific deployed contract.
+func NewPolygonRollupManagerFilterer(address common.Address, filterer bind.ContractFilterer) (*PolygonRollupManagerFilterer, error) {
+	contract, err := bindPolygonRollupManager(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonRollupManagerFilterer{contract: contract}, nil
+}
+
+// bindPolygonRollupManager binds a generic wrapper to an already deployed contract.
+func bindPolygonRollupManager(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := PolygonRollupManagerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PolygonRollupManager *PolygonRollupManagerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PolygonRollupManager.Contract.PolygonRollupManagerCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PolygonRollupManager *PolygonRollupManagerRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolygonRollupManager.Contract.PolygonRollupManagerTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PolygonRollupManager *PolygonRollupManagerRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PolygonRollupManager.Contract.PolygonRollupManagerTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PolygonRollupManager *PolygonRollupManagerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PolygonRollupManager.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PolygonRollupManager *PolygonRollupManagerTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolygonRollupManager.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PolygonRollupManager *PolygonRollupManagerTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PolygonRollupManager.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetRollupExitRoot is a free data retrieval call binding the contract method 0xa2967d99.
+//
+// Solidity: function getRollupExitRoot() view returns(bytes32)
+func (_PolygonRollupManager *PolygonRollupManagerCaller) GetRollupExitRoot(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _PolygonRollupManager.contract.Call(opts, &out, "getRollupExitRoot")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetRollupExitRoot is a free data retrieval call binding the contract method 0xa2967d99.
+//
+// Solidity: function getRollupExitRoot() view returns(bytes32)
+func (_PolygonRollupManager *PolygonRollupManagerSession) GetRollupExitRoot() ([32]byte, error) {
+	return _PolygonRollupManager.Contract.GetRollupExitRoot(&_PolygonRollupManager.CallOpts)
+}
+
+// GetRollupExitRoot is a free data retrieval call binding the contract method 0xa2967d99.
+//
+// Solidity: function getRollupExitRoot() view returns(bytes32)
+func (_PolygonRollupManager *PolygonRollupManagerCallerSession) GetRollupExitRoot() ([32]byte, error) {
+	return _PolygonRollupManager.Contract.GetRollupExitRoot(&_PolygonRollupManager.CallOpts)
+}
+
+// RollupCount is a free data retrieval call binding the contract method 0xf4e92675.
+//
+// Solidity: function rollupCount() view returns(uint32)
+func (_PolygonRollupManager *PolygonRollupManagerCaller) RollupCount(opts *bind.CallOpts) (uint32, error) {
+	var out []interface{}
+	err := _PolygonRollupManager.contract.Call(opts, &out, "rollupCount")
+
+	if err != nil {
+		return *new(uint32), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+
+	return out0, err
+
+}
+
+// RollupCount is a free data retrieval call binding the contract method 0xf4e92675.
+//
+// Solidity: function rollupCount() view returns(uint32)
+func (_PolygonRollupManager *PolygonRollupManagerSession) RollupCount() (uint32, error) {
+	return _PolygonRollupManager.Contract.RollupCount(&_PolygonRollupManager.CallOpts)
+}
+
+// RollupCount is a free data retrieval call binding the contract method 0xf4e92675.
+//
+// Solidity: function rollupCount() view returns(uint32)
+func (_PolygonRollupManager *PolygonRollupManagerCallerSession) RollupCount() (uint32, error) {
+	return _PolygonRollupManager.Contract.RollupCount(&_PolygonRollupManager.CallOpts)
+}
+
+// RollupIDToRollupData is a free data retrieval call binding the contract method 0xf9c4c2ae.
+//
+// Solidity: function rollupIDToRollupData(uint32 rollupID) view returns(address rollupContract, uint64 chainID, address verifier, uint64 forkID, bytes32 lastLocalExitRoot, uint64 lastBatchSequenced, uint64 lastVerifiedBatch, uint64 lastPendingState, uint64 lastPendingStateConsolidated, uint64 lastVerifiedBatchBeforeUpgrade, uint64 rollupTypeID, uint8 rollupCompatibilityID)
+func (_PolygonRollupManager *PolygonRollupManagerCaller) RollupIDToRollupData(opts *bind.CallOpts, rollupID uint32) (struct {
+	RollupContract                 common.Address
+	ChainID                        uint64
+	Verifier                       common.Address
+	ForkID                         uint64
+	LastLocalExitRoot              [32]byte
+	LastBatchSequenced             uint64
+	LastVerifiedBatch              uint64
+	LastPendingState               uint64
+	LastPendingStateConsolidated   uint64
+	LastVerifiedBatchBeforeUpgrade uint64
+	RollupTypeID                   uint64
+	RollupCompatibilityID          uint8
+}, error) {
+	var out []interface{}
+	err := _PolygonRollupManager.contract.Call(opts, &out, "rollupIDToRollupData", rollupID)
+
+	outstruct := new(struct {
+		RollupContract                 common.Address
+		ChainID                        uint64
+		Verifier                       common.Address
+		ForkID                         uint64
+		LastLocalExitRoot              [32]byte
+		LastBatchSequenced             uint64
+		LastVerifiedBatch              uint64
+		LastPendingState               uint64
+		LastPendingStateConsolidated   uint64
+		LastVerifiedBatchBeforeUpgrade uint64
+		RollupTypeID                   uint64
+		RollupCompatibilityID          uint8
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.RollupContract = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.ChainID = *abi.ConvertType(out[1], new(uint64)).(*uint64)
+	outstruct.Verifier = *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+	outstruct.ForkID = *abi.ConvertType(out[3], new(uint64)).(*uint64)
+	outstruct.LastLocalExitRoot = *abi.ConvertType(out[4], new([32]byte)).(*[32]byte)
+	outstruct.LastBatchSequenced = *abi.ConvertType(out[5], new(uint64)).(*uint64)
+	outstruct.LastVerifiedBatch = *abi.ConvertType(out[6], new(uint64)).(*uint64)
+	outstruct.LastPendingState = *abi.ConvertType(out[7], new(uint64)).(*uint64)
+	outstruct.LastPendingStateConsolidated = *abi.ConvertType(out[8], new(uint64)).(*uint64)
+	outstruct.LastVerifiedBatchBeforeUpgrade = *abi.ConvertType(out[9], new(uint64)).(*uint64)
+	outstruct.RollupTypeID = *abi.ConvertType(out[10], new(uint64)).(*uint64)
+	outstruct.RollupCompatibilityID = *abi.ConvertType(out[11], new(uint8)).(*uint8)
+
+	return *outstruct, err
+
+}
+
+// RollupIDToRollupData is a free data retrieval call binding the contract method 0xf9c4c2ae.
+//
+// Solidity: function rollupIDToRollupData(uint32 rollupID) view returns(address rollupContract, uint64 chainID, address verifier, uint64 forkID, bytes32 lastLocalExitRoot, uint64 lastBatchSequenced, uint64 lastVerifiedBatch, uint64 lastPendingState, uint64 lastPendingStateConsolidated, uint64 lastVerifiedBatchBeforeUpgrade, uint64 rollupTypeID, uint8 rollupCompatibilityID)
+func (_PolygonRollupManager *PolygonRollupManagerSession) RollupIDToRollupData(rollupID uint32) (struct {
+	RollupContract                 common.Address
+	ChainID                        uint64
+	Verifier                       common.Address
+	ForkID                         uint64
+	LastLocalExitRoot              [32]byte
+	LastBatchSequenced             uint64
+	LastVerifiedBatch              uint64
+	LastPendingState               uint64
+	LastPendingStateConsolidated   uint64
+	LastVerifiedBatchBeforeUpgrade uint64
+	RollupTypeID                   uint64
+	RollupCompatibilityID          uint8
+}, error) {
+	return _PolygonRollupManager.Contract.RollupIDToRollupData(&_PolygonRollupManager.CallOpts, rollupID)
+}
+
+// RollupIDToRollupData is a free data retrieval call binding the contract method 0xf9c4c2ae.
+//
+// Solidity: function rollupIDToRollupData(uint32 rollupID) view returns(address rollupContract, uint64 chainID, address verifier, uint64 forkID, bytes32 lastLocalExitRoot, uint64 lastBatchSequenced, uint64 lastVerifiedBatch, uint64 lastPendingState, uint64 lastPendingStateConsolidated, uint64 lastVerifiedBatchBeforeUpgrade, uint64 rollupTypeID, uint8 rollupCompatibilityID)
+func (_PolygonRollupManager *PolygonRollupManagerCallerSession) RollupIDToRollupData(rollupID uint32) (struct {
+	RollupContract                 common.Address
+	ChainID                        uint64
+	Verifier                       common.Address
+	ForkID                         uint64
+	LastLocalExitRoot              [32]byte
+	LastBatchSequenced             uint64
+	LastVerifiedBatch              uint64
+	LastPendingState               uint64
+	LastPendingStateConsolidated   uint64
+	LastVerifiedBatchBeforeUpgrade uint64
+	RollupTypeID                   uint64
+	RollupCompatibilityID          uint8
+}, error) {
+	return _PolygonRollupManager.Contract.RollupIDToRollupData(&_PolygonRollupManager.CallOpts, rollupID)
+}
@@ -0,0 +1,730 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ulxly
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// PolygonZkEVMBridgeV2MetaData contains all meta data concerning the PolygonZkEVMBridgeV2 contract.
+var PolygonZkEVMBridgeV2MetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"bridgeAsset\",\"stateMutability\":\"payable\",\"inputs\":[{\"name\":\"destinationNetwork\",\"type\":\"uint32\"},{\"name\":\"destinationAddress\",\"type\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\"},{\"name\":\"token\",\"type\":\"address\"},{\"name\":\"forceUpdateGlobalExitRoot\",\"type\":\"bool\"},{\"name\":\"permitData\",\"type\":\"bytes\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"bridgeMessage\",\"stateMutability\":\"payable\",\"inputs\":[{\"name\":\"destinationNetwork\",\"type\":\"uint32\"},{\"name\":\"destinationAddress\",\"type\":\"address\"},{\"name\":\"forceUpdateGlobalExitRoot\",\"type\":\"bool\"},{\"name\":\"metadata\",\"type\":\"bytes\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"claimAsset\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"smtProofLocalExitRoot\",\"type\":\"bytes32[32]\"},{\"name\":\"smtProofRollupExitRoot\",\"type\":\"bytes32[32]\"},{\"name\":\"globalIndex\",\"type\":\"uint256\"},{\"name\":\"mainnetExitRoot\",\"type\":\"bytes32\"},{\"name\":\"rollupExitRoot\",\"type\":\"bytes32\"},{\"name\":\"originNetwork\",\"type\":\"uint32\"},{\"name\":\"originTokenAddress\",\"type\":\"address\"},{\"name\":\"destinationNetwork\",\"type\":\"uint32\"},{\"name\":\"destinationAddress\",\"type\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\"},{\"name\":\"metadata\",\"type\":\"bytes\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"claimMessage\",\"stateMutability\":\"nonpayable\",\"inputs\":[{\"name\":\"smtProofLocalExitRoot\",\"type\":\"bytes32[32]\"},{\"name\":\"smtProofRollupExitRoot\",\"type\":\"bytes32[32]\"},{\"name\":\"globalIndex\",\"type\":\"uint256\"},{\"name\":\"mainnetExitRoot\",\"type\":\"bytes32\"},{\"name\":\"rollupExitRoot\",\"type\":\"bytes32\"},{\"name\":\"originNetwork\",\"type\":\"uint32\"},{\"name\":\"originAddress\",\"type\":\"address\"},{\"name\":\"destinationNetwork\",\"type\":\"uint32\"},{\"name\":\"destinationAddress\",\"type\":\"address\"},{\"name\":\"amount\",\"type\":\"uint256\"},{\"name\":\"metadata\",\"type\":\"bytes\"}],\"outputs\":[]},{\"type\":\"function\",\"name\":\"depositCount\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"getDepositRoot\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"getTokenWrappedAddress\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"originNetwork\",\"type\":\"uint32\"},{\"name\":\"originTokenAddress\",\"type\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address\"}]},{\"type\":\"function\",\"name\":\"globalExitRootManager\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\"}]},{\"type\":\"function\",\"name\":\"isClaimed\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"leafIndex\",\"type\":\"uint32\"},{\"name\":\"sourceBridgeNetwork\",\"type\":\"uint32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}]},{\"type\":\"function\",\"name\":\"networkID\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint32\"}]},{\"type\":\"event\",\"name\":\"BridgeEvent\",\"anonymous\":false,\"inputs\":[{\"name\":\"leafType\",\"type\":\"uint8\",\"indexed\":false},{\"name\":\"originNetwork\",\"type\":\"uint32\",\"indexed\":false},{\"name\":\"originAddress\",\"type\":\"address\",\"indexed\":false},{\"name\":\"destinationNetwork\",\"type\":\"uint32\",\"indexed\":false},{\"name\":\"destinationAddress\",\"type\":\"address\",\"indexed\":false},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false},{\"name\":\"metadata\",\"type\":\"bytes\",\"indexed\":false},{\"name\":\"depositCount\",\"type\":\"uint32\",\"indexed\":false}]},{\"type\":\"event\",\"name\":\"ClaimEvent\",\"anonymous\":false,\"inputs\":[{\"name\":\"globalIndex\",\"type\":\"uint256\",\"indexed\":false},{\"name\":\"originNetwork\",\"type\":\"uint32\",\"indexed\":false},{\"name\":\"originAddress\",\"type\":\"address\",\"indexed\":false},{\"name\":\"destinationAddress\",\"type\":\"address\",\"indexed\":false},{\"name\":\"amount\",\"type\":\"uint256\",\"indexed\":false}]}]",
+}
+
+// PolygonZkEVMBridgeV2ABI is the input ABI used to generate the binding from.
+// Deprecated: Use PolygonZkEVMBridgeV2MetaData.ABI instead.
+var PolygonZkEVMBridgeV2ABI = PolygonZkEVMBridgeV2MetaData.ABI
+
+// PolygonZkEVMBridgeV2 is an auto generated Go binding around an Ethereum contract.
+type PolygonZkEVMBridgeV2 struct {
+	PolygonZkEVMBridgeV2Caller     // Read-only binding to the contract
+	PolygonZkEVMBridgeV2Transactor // Write-only binding to the contract
+	PolygonZkEVMBridgeV2Filterer   // Log filterer for contract events
+}
+
+// PolygonZkEVMBridgeV2Caller is an auto generated read-only Go binding around an Ethereum contract.
+type PolygonZkEVMBridgeV2Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolygonZkEVMBridgeV2Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type PolygonZkEVMBridgeV2Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolygonZkEVMBridgeV2Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type PolygonZkEVMBridgeV2Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolygonZkEVMBridgeV2Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type PolygonZkEVMBridgeV2Session struct {
+	Contract     *PolygonZkEVMBridgeV2 // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts         // Call options to use throughout this session
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// PolygonZkEVMBridgeV2CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type PolygonZkEVMBridgeV2CallerSession struct {
+	Contract *PolygonZkEVMBridgeV2Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts               // Call options to use throughout this session
+}
+
+// PolygonZkEVMBridgeV2TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type PolygonZkEVMBridgeV2TransactorSession struct {
+	Contract     *PolygonZkEVMBridgeV2Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts               // Transaction auth options to use throughout this session
+}
+
+// PolygonZkEVMBridgeV2Raw is an auto generated low-level Go binding around an Ethereum contract.
+type PolygonZkEVMBridgeV2Raw struct {
+	Contract *PolygonZkEVMBridgeV2 // Generic contract binding to access the raw methods on
+}
+
+// PolygonZkEVMBridgeV2CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type PolygonZkEVMBridgeV2CallerRaw struct {
+	Contract *PolygonZkEVMBridgeV2Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// PolygonZkEVMBridgeV2TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type PolygonZkEVMBridgeV2TransactorRaw struct {
+	Contract *PolygonZkEVMBridgeV2Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewPolygonZkEVMBridgeV2 creates a new instance of PolygonZkEVMBridgeV2, bound to a specific deployed contract.
+func NewPolygonZkEVMBridgeV2(address common.Address, backend bind.ContractBackend) (*PolygonZkEVMBridgeV2, error) {
+	contract, err := bindPolygonZkEVMBridgeV2(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonZkEVMBridgeV2{PolygonZkEVMBridgeV2Caller: PolygonZkEVMBridgeV2Caller{contract: contract}, PolygonZkEVMBridgeV2Transactor: PolygonZkEVMBridgeV2Transactor{contract: contract}, PolygonZkEVMBridgeV2Filterer: PolygonZkEVMBridgeV2Filterer{contract: contract}}, nil
+}
+
+// NewPolygonZkEVMBridgeV2Caller creates a new read-only instance of PolygonZkEVMBridgeV2, bound to a specific deployed contract.
+func NewPolygonZkEVMBridgeV2Caller(address common.Address, caller bind.ContractCaller) (*PolygonZkEVMBridgeV2Caller, error) {
+	contract, err := bindPolygonZkEVMBridgeV2(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonZkEVMBridgeV2Caller{contract: contract}, nil
+}
+
+// NewPolygonZkEVMBridgeV2Transactor creates a new write-only instance of PolygonZkEVMBridgeV2, bound to a specific deployed contract.
+func NewPolygonZkEVMBridgeV2Transactor(address common.Address, transactor bind.ContractTransactor) (*PolygonZkEVMBridgeV2Transactor, error) {
+	contract, err := bindPolygonZkEVMBridgeV2(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonZkEVMBridgeV2Transactor{contract: contract}, nil
+}
+
+// NewPolygonZkEVMBridgeV2Filterer creates a new log filterer instance of PolygonZkEVMBridgeV2, bound to a specific deployed contract.
+func NewPolygonZkEVMBridgeV2Filterer(address common.Address, filterer bind.ContractFilterer) (*PolygonZkEVMBridgeV2Filterer, error) {
+	contract, err := bindPolygonZkEVMBridgeV2(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonZkEVMBridgeV2Filterer{contract: contract}, nil
+}
+
+// bindPolygonZkEVMBridgeV2 binds a generic wrapper to an already deployed contract.
+func bindPolygonZkEVMBridgeV2(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := PolygonZkEVMBridgeV2MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PolygonZkEVMBridgeV2.Contract.PolygonZkEVMBridgeV2Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.PolygonZkEVMBridgeV2Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.PolygonZkEVMBridgeV2Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PolygonZkEVMBridgeV2.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.contract.Transact(opts, method, params...)
+}
+
+// DepositCount is a free data retrieval call binding the contract method 0x2dfdf0b5.
+//
+// Solidity: function depositCount() view returns(uint256)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Caller) DepositCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _PolygonZkEVMBridgeV2.contract.Call(opts, &out, "depositCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// DepositCount is a free data retrieval call binding the contract method 0x2dfdf0b5.
+//
+// Solidity: function depositCount() view returns(uint256)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Session) DepositCount() (*big.Int, error) {
+	return _PolygonZkEVMBridgeV2.Contract.DepositCount(&_PolygonZkEVMBridgeV2.CallOpts)
+}
+
+// DepositCount is a free data retrieval call binding the contract method 0x2dfdf0b5.
+//
+// Solidity: function depositCount() view returns(uint256)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2CallerSession) DepositCount() (*big.Int, error) {
+	return _PolygonZkEVMBridgeV2.Contract.DepositCount(&_PolygonZkEVMBridgeV2.CallOpts)
+}
+
+// GetDepositRoot is a free data retrieval call binding the contract method 0x3ae05047.
+//
+// Solidity: function getDepositRoot() view returns(bytes32)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Caller) GetDepositRoot(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _PolygonZkEVMBridgeV2.contract.Call(opts, &out, "getDepositRoot")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetDepositRoot is a free data retrieval call binding the contract method 0x3ae05047.
+//
+// Solidity: function getDepositRoot() view returns(bytes32)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Session) GetDepositRoot() ([32]byte, error) {
+	return _PolygonZkEVMBridgeV2.Contract.GetDepositRoot(&_PolygonZkEVMBridgeV2.CallOpts)
+}
+
+// GetDepositRoot is a free data retrieval call binding the contract method 0x3ae05047.
+//
+// Solidity: function getDepositRoot() view returns(bytes32)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2CallerSession) GetDepositRoot() ([32]byte, error) {
+	return _PolygonZkEVMBridgeV2.Contract.GetDepositRoot(&_PolygonZkEVMBridgeV2.CallOpts)
+}
+
+// GetTokenWrappedAddress is a free data retrieval call binding the contract method 0x22e95f2c.
+//
+// Solidity: function getTokenWrappedAddress(uint32 originNetwork, address originTokenAddress) view returns(address)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Caller) GetTokenWrappedAddress(opts *bind.CallOpts, originNetwork uint32, originTokenAddress common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _PolygonZkEVMBridgeV2.contract.Call(opts, &out, "getTokenWrappedAddress", originNetwork, originTokenAddress)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetTokenWrappedAddress is a free data retrieval call binding the contract method 0x22e95f2c.
+//
+// Solidity: function getTokenWrappedAddress(uint32 originNetwork, address originTokenAddress) view returns(address)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Session) GetTokenWrappedAddress(originNetwork uint32, originTokenAddress common.Address) (common.Address, error) {
+	return _PolygonZkEVMBridgeV2.Contract.GetTokenWrappedAddress(&_PolygonZkEVMBridgeV2.CallOpts, originNetwork, originTokenAddress)
+}
+
+// GetTokenWrappedAddress is a free data retrieval call binding the contract method 0x22e95f2c.
+//
+// Solidity: function getTokenWrappedAddress(uint32 originNetwork, address originTokenAddress) view returns(address)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2CallerSession) GetTokenWrappedAddress(originNetwork uint32, originTokenAddress common.Address) (common.Address, error) {
+	return _PolygonZkEVMBridgeV2.Contract.GetTokenWrappedAddress(&_PolygonZkEVMBridgeV2.CallOpts, originNetwork, originTokenAddress)
+}
+
+// GlobalExitRootManager is a free data retrieval call binding the contract method 0xd02103ca.
+//
+// Solidity: function globalExitRootManager() view returns(address)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Caller) GlobalExitRootManager(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _PolygonZkEVMBridgeV2.contract.Call(opts, &out, "globalExitRootManager")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GlobalExitRootManager is a free data retrieval call binding the contract method 0xd02103ca.
+//
+// Solidity: function globalExitRootManager() view returns(address)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Session) GlobalExitRootManager() (common.Address, error) {
+	return _PolygonZkEVMBridgeV2.Contract.GlobalExitRootManager(&_PolygonZkEVMBridgeV2.CallOpts)
+}
+
+// GlobalExitRootManager is a free data retrieval call binding the contract method 0xd02103ca.
+//
+// Solidity: function globalExitRootManager() view returns(address)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2CallerSession) GlobalExitRootManager() (common.Address, error) {
+	return _PolygonZkEVMBridgeV2.Contract.GlobalExitRootManager(&_PolygonZkEVMBridgeV2.CallOpts)
+}
+
+// IsClaimed is a free data retrieval call binding the contract method 0xcc461632.
+//
+// Solidity: function isClaimed(uint32 leafIndex, uint32 sourceBridgeNetwork) view returns(bool)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Caller) IsClaimed(opts *bind.CallOpts, leafIndex uint32, sourceBridgeNetwork uint32) (bool, error) {
+	var out []interface{}
+	err := _PolygonZkEVMBridgeV2.contract.Call(opts, &out, "isClaimed", leafIndex, sourceBridgeNetwork)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// IsClaimed is a free data retrieval call binding the contract method 0xcc461632.
+//
+// Solidity: function isClaimed(uint32 leafIndex, uint32 sourceBridgeNetwork) view returns(bool)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Session) IsClaimed(leafIndex uint32, sourceBridgeNetwork uint32) (bool, error) {
+	return _PolygonZkEVMBridgeV2.Contract.IsClaimed(&_PolygonZkEVMBridgeV2.CallOpts, leafIndex, sourceBridgeNetwork)
+}
+
+// IsClaimed is a free data retrieval call binding the contract method 0xcc461632.
+//
+// Solidity: function isClaimed(uint32 leafIndex, uint32 sourceBridgeNetwork) view returns(bool)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2CallerSession) IsClaimed(leafIndex uint32, sourceBridgeNetwork uint32) (bool, error) {
+	return _PolygonZkEVMBridgeV2.Contract.IsClaimed(&_PolygonZkEVMBridgeV2.CallOpts, leafIndex, sourceBridgeNetwork)
+}
+
+// NetworkID is a free data retrieval call binding the contract method 0xbab161bf.
+//
+// Solidity: function networkID() view returns(uint32)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Caller) NetworkID(opts *bind.CallOpts) (uint32, error) {
+	var out []interface{}
+	err := _PolygonZkEVMBridgeV2.contract.Call(opts, &out, "networkID")
+
+	if err != nil {
+		return *new(uint32), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint32)).(*uint32)
+
+	return out0, err
+
+}
+
+// NetworkID is a free data retrieval call binding the contract method 0xbab161bf.
+//
+// Solidity: function networkID() view returns(uint32)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Session) NetworkID() (uint32, error) {
+	return _PolygonZkEVMBridgeV2.Contract.NetworkID(&_PolygonZkEVMBridgeV2.CallOpts)
+}
+
+// NetworkID is a free data retrieval call binding the contract method 0xbab161bf.
+//
+// Solidity: function networkID() view returns(uint32)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2CallerSession) NetworkID() (uint32, error) {
+	return _PolygonZkEVMBridgeV2.Contract.NetworkID(&_PolygonZkEVMBridgeV2.CallOpts)
+}
+
+// BridgeAsset is a paid mutator transaction binding the contract method 0xcd586579.
+//
+// Solidity: function bridgeAsset(uint32 destinationNetwork, address destinationAddress, uint256 amount, address token, bool forceUpdateGlobalExitRoot, bytes permitData) payable returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Transactor) BridgeAsset(opts *bind.TransactOpts, destinationNetwork uint32, destinationAddress common.Address, amount *big.Int, token common.Address, forceUpdateGlobalExitRoot bool, permitData []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.contract.Transact(opts, "bridgeAsset", destinationNetwork, destinationAddress, amount, token, forceUpdateGlobalExitRoot, permitData)
+}
+
+// BridgeAsset is a paid mutator transaction binding the contract method 0xcd586579.
+//
+// Solidity: function bridgeAsset(uint32 destinationNetwork, address destinationAddress, uint256 amount, address token, bool forceUpdateGlobalExitRoot, bytes permitData) payable returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Session) BridgeAsset(destinationNetwork uint32, destinationAddress common.Address, amount *big.Int, token common.Address, forceUpdateGlobalExitRoot bool, permitData []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.BridgeAsset(&_PolygonZkEVMBridgeV2.TransactOpts, destinationNetwork, destinationAddress, amount, token, forceUpdateGlobalExitRoot, permitData)
+}
+
+// BridgeAsset is a paid mutator transaction binding the contract method 0xcd586579.
+//
+// Solidity: function bridgeAsset(uint32 destinationNetwork, address destinationAddress, uint256 amount, address token, bool forceUpdateGlobalExitRoot, bytes permitData) payable returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2TransactorSession) BridgeAsset(destinationNetwork uint32, destinationAddress common.Address, amount *big.Int, token common.Address, forceUpdateGlobalExitRoot bool, permitData []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.BridgeAsset(&_PolygonZkEVMBridgeV2.TransactOpts, destinationNetwork, destinationAddress, amount, token, forceUpdateGlobalExitRoot, permitData)
+}
+
+// BridgeMessage is a paid mutator transaction binding the contract method 0x240ff378.
+//
+// Solidity: function bridgeMessage(uint32 destinationNetwork, address destinationAddress, bool forceUpdateGlobalExitRoot, bytes metadata) payable returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Transactor) BridgeMessage(opts *bind.TransactOpts, destinationNetwork uint32, destinationAddress common.Address, forceUpdateGlobalExitRoot bool, metadata []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.contract.Transact(opts, "bridgeMessage", destinationNetwork, destinationAddress, forceUpdateGlobalExitRoot, metadata)
+}
+
+// BridgeMessage is a paid mutator transaction binding the contract method 0x240ff378.
+//
+// Solidity: function bridgeMessage(uint32 destinationNetwork, address destinationAddress, bool forceUpdateGlobalExitRoot, bytes metadata) payable returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Session) BridgeMessage(destinationNetwork uint32, destinationAddress common.Address, forceUpdateGlobalExitRoot bool, metadata []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.BridgeMessage(&_PolygonZkEVMBridgeV2.TransactOpts, destinationNetwork, destinationAddress, forceUpdateGlobalExitRoot, metadata)
+}
+
+// BridgeMessage is a paid mutator transaction binding the contract method 0x240ff378.
+//
+// Solidity: function bridgeMessage(uint32 destinationNetwork, address destinationAddress, bool forceUpdateGlobalExitRoot, bytes metadata) payable returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2TransactorSession) BridgeMessage(destinationNetwork uint32, destinationAddress common.Address, forceUpdateGlobalExitRoot bool, metadata []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.BridgeMessage(&_PolygonZkEVMBridgeV2.TransactOpts, destinationNetwork, destinationAddress, forceUpdateGlobalExitRoot, metadata)
+}
+
+// ClaimAsset is a paid mutator transaction binding the contract method 0xccaa2d11.
+//
+// Solidity: function claimAsset(bytes32[32] smtProofLocalExitRoot, bytes32[32] smtProofRollupExitRoot, uint256 globalIndex, bytes32 mainnetExitRoot, bytes32 rollupExitRoot, uint32 originNetwork, address originTokenAddress, uint32 destinationNetwork, address destinationAddress, uint256 amount, bytes metadata) returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Transactor) ClaimAsset(opts *bind.TransactOpts, smtProofLocalExitRoot [32][32]byte, smtProofRollupExitRoot [32][32]byte, globalIndex *big.Int, mainnetExitRoot [32]byte, rollupExitRoot [32]byte, originNetwork uint32, originTokenAddress common.Address, destinationNetwork uint32, destinationAddress common.Address, amount *big.Int, metadata []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.contract.Transact(opts, "claimAsset", smtProofLocalExitRoot, smtProofRollupExitRoot, globalIndex, mainnetExitRoot, rollupExitRoot, originNetwork, originTokenAddress, destinationNetwork, destinationAddress, amount, metadata)
+}
+
+// ClaimAsset is a paid mutator transaction binding the contract method 0xccaa2d11.
+//
+// Solidity: function claimAsset(bytes32[32] smtProofLocalExitRoot, bytes32[32] smtProofRollupExitRoot, uint256 globalIndex, bytes32 mainnetExitRoot, bytes32 rollupExitRoot, uint32 originNetwork, address originTokenAddress, uint32 destinationNetwork, address destinationAddress, uint256 amount, bytes metadata) returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Session) ClaimAsset(smtProofLocalExitRoot [32][32]byte, smtProofRollupExitRoot [32][32]byte, globalIndex *big.Int, mainnetExitRoot [32]byte, rollupExitRoot [32]byte, originNetwork uint32, originTokenAddress common.Address, destinationNetwork uint32, destinationAddress common.Address, amount *big.Int, metadata []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.ClaimAsset(&_PolygonZkEVMBridgeV2.TransactOpts, smtProofLocalExitRoot, smtProofRollupExitRoot, globalIndex, mainnetExitRoot, rollupExitRoot, originNetwork, originTokenAddress, destinationNetwork, destinationAddress, amount, metadata)
+}
+
+// ClaimAsset is a paid mutator transaction binding the contract method 0xccaa2d11.
+//
+// Solidity: function claimAsset(bytes32[32] smtProofLocalExitRoot, bytes32[32] smtProofRollupExitRoot, uint256 globalIndex, bytes32 mainnetExitRoot, bytes32 rollupExitRoot, uint32 originNetwork, address originTokenAddress, uint32 destinationNetwork, address destinationAddress, uint256 amount, bytes metadata) returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2TransactorSession) ClaimAsset(smtProofLocalExitRoot [32][32]byte, smtProofRollupExitRoot [32][32]byte, globalIndex *big.Int, mainnetExitRoot [32]byte, rollupExitRoot [32]byte, originNetwork uint32, originTokenAddress common.Address, destinationNetwork uint32, destinationAddress common.Address, amount *big.Int, metadata []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.ClaimAsset(&_PolygonZkEVMBridgeV2.TransactOpts, smtProofLocalExitRoot, smtProofRollupExitRoot, globalIndex, mainnetExitRoot, rollupExitRoot, originNetwork, originTokenAddress, destinationNetwork, destinationAddress, amount, metadata)
+}
+
+// ClaimMessage is a paid mutator transaction binding the contract method 0xf5efcd79.
+//
+// Solidity: function claimMessage(bytes32[32] smtProofLocalExitRoot, bytes32[32] smtProofRollupExitRoot, uint256 globalIndex, bytes32 mainnetExitRoot, bytes32 rollupExitRoot, uint32 originNetwork, address originAddress, uint32 destinationNetwork, address destinationAddress, uint256 amount, bytes metadata) returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Transactor) ClaimMessage(opts *bind.TransactOpts, smtProofLocalExitRoot [32][32]byte, smtProofRollupExitRoot [32][32]byte, globalIndex *big.Int, mainnetExitRoot [32]byte, rollupExitRoot [32]byte, originNetwork uint32, originAddress common.Address, destinationNetwork uint32, destinationAddress common.Address, amount *big.Int, metadata []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.contract.Transact(opts, "claimMessage", smtProofLocalExitRoot, smtProofRollupExitRoot, globalIndex, mainnetExitRoot, rollupExitRoot, originNetwork, originAddress, destinationNetwork, destinationAddress, amount, metadata)
+}
+
+// ClaimMessage is a paid mutator transaction binding the contract method 0xf5efcd79.
+//
+// Solidity: function claimMessage(bytes32[32] smtProofLocalExitRoot, bytes32[32] smtProofRollupExitRoot, uint256 globalIndex, bytes32 mainnetExitRoot, bytes32 rollupExitRoot, uint32 originNetwork, address originAddress, uint32 destinationNetwork, address destinationAddress, uint256 amount, bytes metadata) returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Session) ClaimMessage(smtProofLocalExitRoot [32][32]byte, smtProofRollupExitRoot [32][32]byte, globalIndex *big.Int, mainnetExitRoot [32]byte, rollupExitRoot [32]byte, originNetwork uint32, originAddress common.Address, destinationNetwork uint32, destinationAddress common.Address, amount *big.Int, metadata []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.ClaimMessage(&_PolygonZkEVMBridgeV2.TransactOpts, smtProofLocalExitRoot, smtProofRollupExitRoot, globalIndex, mainnetExitRoot, rollupExitRoot, originNetwork, originAddress, destinationNetwork, destinationAddress, amount, metadata)
+}
+
+// ClaimMessage is a paid mutator transaction binding the contract method 0xf5efcd79.
+//
+// Solidity: function claimMessage(bytes32[32] smtProofLocalExitRoot, bytes32[32] smtProofRollupExitRoot, uint256 globalIndex, bytes32 mainnetExitRoot, bytes32 rollupExitRoot, uint32 originNetwork, address originAddress, uint32 destinationNetwork, address destinationAddress, uint256 amount, bytes metadata) returns()
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2TransactorSession) ClaimMessage(smtProofLocalExitRoot [32][32]byte, smtProofRollupExitRoot [32][32]byte, globalIndex *big.Int, mainnetExitRoot [32]byte, rollupExitRoot [32]byte, originNetwork uint32, originAddress common.Address, destinationNetwork uint32, destinationAddress common.Address, amount *big.Int, metadata []byte) (*types.Transaction, error) {
+	return _PolygonZkEVMBridgeV2.Contract.ClaimMessage(&_PolygonZkEVMBridgeV2.TransactOpts, smtProofLocalExitRoot, smtProofRollupExitRoot, globalIndex, mainnetExitRoot, rollupExitRoot, originNetwork, originAddress, destinationNetwork, destinationAddress, amount, metadata)
+}
+
+// PolygonZkEVMBridgeV2BridgeEventIterator is returned from FilterBridgeEvent and is used to iterate over the raw logs and unpacked data for BridgeEvent events raised by the PolygonZkEVMBridgeV2 contract.
+type PolygonZkEVMBridgeV2BridgeEventIterator struct {
+	Event *PolygonZkEVMBridgeV2BridgeEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PolygonZkEVMBridgeV2BridgeEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PolygonZkEVMBridgeV2BridgeEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PolygonZkEVMBridgeV2BridgeEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PolygonZkEVMBridgeV2BridgeEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PolygonZkEVMBridgeV2BridgeEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PolygonZkEVMBridgeV2BridgeEvent represents a BridgeEvent event raised by the PolygonZkEVMBridgeV2 contract.
+type PolygonZkEVMBridgeV2BridgeEvent struct {
+	LeafType           uint8
+	OriginNetwork      uint32
+	OriginAddress      common.Address
+	DestinationNetwork uint32
+	DestinationAddress common.Address
+	Amount             *big.Int
+	Metadata           []byte
+	DepositCount       uint32
+	Raw                types.Log // Blockchain specific contextual infos
+}
+
+// FilterBridgeEvent is a free log retrieval operation binding the contract event 0x501781209a1f8899323b96b4ef08b168df93e0a90c673d1e4cce39366cb62f9b.
+//
+// Solidity: event BridgeEvent(uint8 leafType, uint32 originNetwork, address originAddress, uint32 destinationNetwork, address destinationAddress, uint256 amount, bytes metadata, uint32 depositCount)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Filterer) FilterBridgeEvent(opts *bind.FilterOpts) (*PolygonZkEVMBridgeV2BridgeEventIterator, error) {
+
+	logs, sub, err := _PolygonZkEVMBridgeV2.contract.FilterLogs(opts, "BridgeEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonZkEVMBridgeV2BridgeEventIterator{contract: _PolygonZkEVMBridgeV2.contract, event: "BridgeEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchBridgeEvent is a free log subscription operation binding the contract event 0x501781209a1f8899323b96b4ef08b168df93e0a90c673d1e4cce39366cb62f9b.
+//
+// Solidity: event BridgeEvent(uint8 leafType, uint32 originNetwork, address originAddress, uint32 destinationNetwork, address destinationAddress, uint256 amount, bytes metadata, uint32 depositCount)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Filterer) WatchBridgeEvent(opts *bind.WatchOpts, sink chan<- *PolygonZkEVMBridgeV2BridgeEvent) (event.Subscription, error) {
+
+	logs, sub, err := _PolygonZkEVMBridgeV2.contract.WatchLogs(opts, "BridgeEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PolygonZkEVMBridgeV2BridgeEvent)
+				if err := _PolygonZkEVMBridgeV2.contract.UnpackLog(event, "BridgeEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseBridgeEvent is a log parse operation binding the contract event 0x501781209a1f8899323b96b4ef08b168df93e0a90c673d1e4cce39366cb62f9b.
+//
+// Solidity: event BridgeEvent(uint8 leafType, uint32 originNetwork, address originAddress, uint32 destinationNetwork, address destinationAddress, uint256 amount, bytes metadata, uint32 depositCount)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Filterer) ParseBridgeEvent(log types.Log) (*PolygonZkEVMBridgeV2BridgeEvent, error) {
+	event := new(PolygonZkEVMBridgeV2BridgeEvent)
+	if err := _PolygonZkEVMBridgeV2.contract.UnpackLog(event, "BridgeEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// PolygonZkEVMBridgeV2ClaimEventIterator is returned from FilterClaimEvent and is used to iterate over the raw logs and unpacked data for ClaimEvent events raised by the PolygonZkEVMBridgeV2 contract.
+type PolygonZkEVMBridgeV2ClaimEventIterator struct {
+	Event *PolygonZkEVMBridgeV2ClaimEvent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *PolygonZkEVMBridgeV2ClaimEventIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(PolygonZkEVMBridgeV2ClaimEvent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(PolygonZkEVMBridgeV2ClaimEvent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *PolygonZkEVMBridgeV2ClaimEventIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *PolygonZkEVMBridgeV2ClaimEventIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// PolygonZkEVMBridgeV2ClaimEvent represents a ClaimEvent event raised by the PolygonZkEVMBridgeV2 contract.
+type PolygonZkEVMBridgeV2ClaimEvent struct {
+	GlobalIndex        *big.Int
+	OriginNetwork      uint32
+	OriginAddress      common.Address
+	DestinationAddress common.Address
+	Amount             *big.Int
+	Raw                types.Log // Blockchain specific contextual infos
+}
+
+// FilterClaimEvent is a free log retrieval operation binding the contract event 0x1df3f2a973a00d6635911755c260704e95e8a5876997546798770f76396fda4d.
+//
+// Solidity: event ClaimEvent(uint256 globalIndex, uint32 originNetwork, address originAddress, address destinationAddress, uint256 amount)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Filterer) FilterClaimEvent(opts *bind.FilterOpts) (*PolygonZkEVMBridgeV2ClaimEventIterator, error) {
+
+	logs, sub, err := _PolygonZkEVMBridgeV2.contract.FilterLogs(opts, "ClaimEvent")
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonZkEVMBridgeV2ClaimEventIterator{contract: _PolygonZkEVMBridgeV2.contract, event: "ClaimEvent", logs: logs, sub: sub}, nil
+}
+
+// WatchClaimEvent is a free log subscription operation binding the contract event 0x1df3f2a973a00d6635911755c260704e95e8a5876997546798770f76396fda4d.
+//
+// Solidity: event ClaimEvent(uint256 globalIndex, uint32 originNetwork, address originAddress, address destinationAddress, uint256 amount)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Filterer) WatchClaimEvent(opts *bind.WatchOpts, sink chan<- *PolygonZkEVMBridgeV2ClaimEvent) (event.Subscription, error) {
+
+	logs, sub, err := _PolygonZkEVMBridgeV2.contract.WatchLogs(opts, "ClaimEvent")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(PolygonZkEVMBridgeV2ClaimEvent)
+				if err := _PolygonZkEVMBridgeV2.contract.UnpackLog(event, "ClaimEvent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseClaimEvent is a log parse operation binding the contract event 0x1df3f2a973a00d6635911755c260704e95e8a5876997546798770f76396fda4d.
+//
+// Solidity: event ClaimEvent(uint256 globalIndex, uint32 originNetwork, address originAddress, address destinationAddress, uint256 amount)
+func (_PolygonZkEVMBridgeV2 *PolygonZkEVMBridgeV2Filterer) ParseClaimEvent(log types.Log) (*PolygonZkEVMBridgeV2ClaimEvent, error) {
+	event := new(PolygonZkEVMBridgeV2ClaimEvent)
+	if err := _PolygonZkEVMBridgeV2.contract.UnpackLog(event, "ClaimEvent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
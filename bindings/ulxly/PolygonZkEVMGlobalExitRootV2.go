@@ -0,0 +1,336 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ulxly
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// PolygonZkEVMGlobalExitRootV2MetaData contains all meta data concerning the PolygonZkEVMGlobalExitRootV2 contract.
+var PolygonZkEVMGlobalExitRootV2MetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"getLastGlobalExitRoot\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"globalExitRootMap\",\"stateMutability\":\"view\",\"inputs\":[{\"name\":\"\",\"type\":\"bytes32\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}]},{\"type\":\"function\",\"name\":\"lastMainnetExitRoot\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"lastRollupExitRoot\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}]},{\"type\":\"function\",\"name\":\"rollupManager\",\"stateMutability\":\"view\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\"}]}]",
+}
+
+// PolygonZkEVMGlobalExitRootV2ABI is the input ABI used to generate the binding from.
+// Deprecated: Use PolygonZkEVMGlobalExitRootV2MetaData.ABI instead.
+var PolygonZkEVMGlobalExitRootV2ABI = PolygonZkEVMGlobalExitRootV2MetaData.ABI
+
+// PolygonZkEVMGlobalExitRootV2 is an auto generated Go binding around an Ethereum contract.
+type PolygonZkEVMGlobalExitRootV2 struct {
+	PolygonZkEVMGlobalExitRootV2Caller     // Read-only binding to the contract
+	PolygonZkEVMGlobalExitRootV2Transactor // Write-only binding to the contract
+	PolygonZkEVMGlobalExitRootV2Filterer   // Log filterer for contract events
+}
+
+// PolygonZkEVMGlobalExitRootV2Caller is an auto generated read-only Go binding around an Ethereum contract.
+type PolygonZkEVMGlobalExitRootV2Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolygonZkEVMGlobalExitRootV2Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type PolygonZkEVMGlobalExitRootV2Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolygonZkEVMGlobalExitRootV2Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type PolygonZkEVMGlobalExitRootV2Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// PolygonZkEVMGlobalExitRootV2Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type PolygonZkEVMGlobalExitRootV2Session struct {
+	Contract     *PolygonZkEVMGlobalExitRootV2 // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts                 // Call options to use throughout this session
+	TransactOpts bind.TransactOpts             // Transaction auth options to use throughout this session
+}
+
+// PolygonZkEVMGlobalExitRootV2CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type PolygonZkEVMGlobalExitRootV2CallerSession struct {
+	Contract *PolygonZkEVMGlobalExitRootV2Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                       // Call options to use throughout this session
+}
+
+// PolygonZkEVMGlobalExitRootV2TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type PolygonZkEVMGlobalExitRootV2TransactorSession struct {
+	Contract     *PolygonZkEVMGlobalExitRootV2Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                       // Transaction auth options to use throughout this session
+}
+
+// PolygonZkEVMGlobalExitRootV2Raw is an auto generated low-level Go binding around an Ethereum contract.
+type PolygonZkEVMGlobalExitRootV2Raw struct {
+	Contract *PolygonZkEVMGlobalExitRootV2 // Generic contract binding to access the raw methods on
+}
+
+// PolygonZkEVMGlobalExitRootV2CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type PolygonZkEVMGlobalExitRootV2CallerRaw struct {
+	Contract *PolygonZkEVMGlobalExitRootV2Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// PolygonZkEVMGlobalExitRootV2TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type PolygonZkEVMGlobalExitRootV2TransactorRaw struct {
+	Contract *PolygonZkEVMGlobalExitRootV2Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewPolygonZkEVMGlobalExitRootV2 creates a new instance of PolygonZkEVMGlobalExitRootV2, bound to a specific deployed contract.
+func NewPolygonZkEVMGlobalExitRootV2(address common.Address, backend bind.ContractBackend) (*PolygonZkEVMGlobalExitRootV2, error) {
+	contract, err := bindPolygonZkEVMGlobalExitRootV2(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonZkEVMGlobalExitRootV2{PolygonZkEVMGlobalExitRootV2Caller: PolygonZkEVMGlobalExitRootV2Caller{contract: contract}, PolygonZkEVMGlobalExitRootV2Transactor: PolygonZkEVMGlobalExitRootV2Transactor{contract: contract}, PolygonZkEVMGlobalExitRootV2Filterer: PolygonZkEVMGlobalExitRootV2Filterer{contract: contract}}, nil
+}
+
+// NewPolygonZkEVMGlobalExitRootV2Caller creates a new read-only instance of PolygonZkEVMGlobalExitRootV2, bound to a specific deployed contract.
+func NewPolygonZkEVMGlobalExitRootV2Caller(address common.Address, caller bind.ContractCaller) (*PolygonZkEVMGlobalExitRootV2Caller, error) {
+	contract, err := bindPolygonZkEVMGlobalExitRootV2(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonZkEVMGlobalExitRootV2Caller{contract: contract}, nil
+}
+
+// NewPolygonZkEVMGlobalExitRootV2Transactor creates a new write-only instance of PolygonZkEVMGlobalExitRootV2, bound to a specific deployed contract.
+func NewPolygonZkEVMGlobalExitRootV2Transactor(address common.Address, transactor bind.ContractTransactor) (*PolygonZkEVMGlobalExitRootV2Transactor, error) {
+	contract, err := bindPolygonZkEVMGlobalExitRootV2(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonZkEVMGlobalExitRootV2Transactor{contract: contract}, nil
+}
+
+// NewPolygonZkEVMGlobalExitRootV2Filterer creates a new log filterer instance of PolygonZkEVMGlobalExitRootV2, bound to a specific deployed contract.
+func NewPolygonZkEVMGlobalExitRootV2Filterer(address common.Address, filterer bind.ContractFilterer) (*PolygonZkEVMGlobalExitRootV2Filterer, error) {
+	contract, err := bindPolygonZkEVMGlobalExitRootV2(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &PolygonZkEVMGlobalExitRootV2Filterer{contract: contract}, nil
+}
+
+// bindPolygonZkEVMGlobalExitRootV2 binds a generic wrapper to an already deployed contract.
+func bindPolygonZkEVMGlobalExitRootV2(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := PolygonZkEVMGlobalExitRootV2MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.PolygonZkEVMGlobalExitRootV2Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.PolygonZkEVMGlobalExitRootV2Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.PolygonZkEVMGlobalExitRootV2Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetLastGlobalExitRoot is a free data retrieval call binding the contract method 0x3ed691ef.
+//
+// Solidity: function getLastGlobalExitRoot() view returns(bytes32)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Caller) GetLastGlobalExitRoot(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _PolygonZkEVMGlobalExitRootV2.contract.Call(opts, &out, "getLastGlobalExitRoot")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// GetLastGlobalExitRoot is a free data retrieval call binding the contract method 0x3ed691ef.
+//
+// Solidity: function getLastGlobalExitRoot() view returns(bytes32)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Session) GetLastGlobalExitRoot() ([32]byte, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.GetLastGlobalExitRoot(&_PolygonZkEVMGlobalExitRootV2.CallOpts)
+}
+
+// GetLastGlobalExitRoot is a free data retrieval call binding the contract method 0x3ed691ef.
+//
+// Solidity: function getLastGlobalExitRoot() view returns(bytes32)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2CallerSession) GetLastGlobalExitRoot() ([32]byte, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.GetLastGlobalExitRoot(&_PolygonZkEVMGlobalExitRootV2.CallOpts)
+}
+
+// GlobalExitRootMap is a free data retrieval call binding the contract method 0x257b3632.
+//
+// Solidity: function globalExitRootMap(bytes32 ) view returns(uint256)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Caller) GlobalExitRootMap(opts *bind.CallOpts, arg0 [32]byte) (*big.Int, error) {
+	var out []interface{}
+	err := _PolygonZkEVMGlobalExitRootV2.contract.Call(opts, &out, "globalExitRootMap", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GlobalExitRootMap is a free data retrieval call binding the contract method 0x257b3632.
+//
+// Solidity: function globalExitRootMap(bytes32 ) view returns(uint256)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Session) GlobalExitRootMap(arg0 [32]byte) (*big.Int, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.GlobalExitRootMap(&_PolygonZkEVMGlobalExitRootV2.CallOpts, arg0)
+}
+
+// GlobalExitRootMap is a free data retrieval call binding the contract method 0x257b3632.
+//
+// Solidity: function globalExitRootMap(bytes32 ) view returns(uint256)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2CallerSession) GlobalExitRootMap(arg0 [32]byte) (*big.Int, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.GlobalExitRootMap(&_PolygonZkEVMGlobalExitRootV2.CallOpts, arg0)
+}
+
+// LastMainnetExitRoot is a free data retrieval call binding the contract method 0x319cf735.
+//
+// Solidity: function lastMainnetExitRoot() view returns(bytes32)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Caller) LastMainnetExitRoot(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _PolygonZkEVMGlobalExitRootV2.contract.Call(opts, &out, "lastMainnetExitRoot")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// LastMainnetExitRoot is a free data retrieval call binding the contract method 0x319cf735.
+//
+// Solidity: function lastMainnetExitRoot() view returns(bytes32)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Session) LastMainnetExitRoot() ([32]byte, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.LastMainnetExitRoot(&_PolygonZkEVMGlobalExitRootV2.CallOpts)
+}
+
+// LastMainnetExitRoot is a free data retrieval call binding the contract method 0x319cf735.
+//
+// Solidity: function lastMainnetExitRoot() view returns(bytes32)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2CallerSession) LastMainnetExitRoot() ([32]byte, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.LastMainnetExitRoot(&_PolygonZkEVMGlobalExitRootV2.CallOpts)
+}
+
+// LastRollupExitRoot is a free data retrieval call binding the contract method 0x01fd9044.
+//
+// Solidity: function lastRollupExitRoot() view returns(bytes32)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Caller) LastRollupExitRoot(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _PolygonZkEVMGlobalExitRootV2.contract.Call(opts, &out, "lastRollupExitRoot")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// LastRollupExitRoot is a free data retrieval call binding the contract method 0x01fd9044.
+//
+// Solidity: function lastRollupExitRoot() view returns(bytes32)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Session) LastRollupExitRoot() ([32]byte, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.LastRollupExitRoot(&_PolygonZkEVMGlobalExitRootV2.CallOpts)
+}
+
+// LastRollupExitRoot is a free data retrieval call binding the contract method 0x01fd9044.
+//
+// Solidity: function lastRollupExitRoot() view returns(bytes32)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2CallerSession) LastRollupExitRoot() ([32]byte, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.LastRollupExitRoot(&_PolygonZkEVMGlobalExitRootV2.CallOpts)
+}
+
+// RollupManager is a free data retrieval call binding the contract method 0x49b7b802.
+//
+// Solidity: function rollupManager() view returns(address)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Caller) RollupManager(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _PolygonZkEVMGlobalExitRootV2.contract.Call(opts, &out, "rollupManager")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// RollupManager is a free data retrieval call binding the contract method 0x49b7b802.
+//
+// Solidity: function rollupManager() view returns(address)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2Session) RollupManager() (common.Address, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.RollupManager(&_PolygonZkEVMGlobalExitRootV2.CallOpts)
+}
+
+// RollupManager is a free data retrieval call binding the contract method 0x49b7b802.
+//
+// Solidity: function rollupManager() view returns(address)
+func (_PolygonZkEVMGlobalExitRootV2 *PolygonZkEVMGlobalExitRootV2CallerSession) RollupManager() (common.Address, error) {
+	return _PolygonZkEVMGlobalExitRootV2.Contract.RollupManager(&_PolygonZkEVMGlobalExitRootV2.CallOpts)
+}
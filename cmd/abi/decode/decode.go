@@ -6,20 +6,31 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"strings"
 
 	_ "embed"
 
 	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cobra"
 )
 
 var (
-	inputFileName *string
-	inputData     *string
+	inputFileName  *string
+	inputData      *string
+	inputLogTopics *[]string
+	inputLogData   *string
 )
 
+// etherscanResponse is the envelope returned by the Etherscan `getabi` API.
+type etherscanResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Result  string `json:"result"`
+}
+
 var ABIDecodeCmd = &cobra.Command{
 	Use:   "decode Contract.abi",
 	Short: "Parse an ABI and print the encoded signatures.",
@@ -39,6 +50,14 @@ var ABIDecodeCmd = &cobra.Command{
 		for _, meth := range abi.Methods {
 			fmt.Printf("Selector:%s\tSignature:%s%s\n", hex.EncodeToString(meth.ID), meth.Sig, getReturnSignature(meth.Outputs))
 		}
+		if *inputData == "-" {
+			var stdinData []byte
+			stdinData, err = io.ReadAll(os.Stdin)
+			if err != nil {
+				return err
+			}
+			*inputData = strings.TrimSpace(string(stdinData))
+		}
 		if *inputData != "" {
 			id, callData, err := parseContractInputData(*inputData)
 			fmt.Printf("id: %x, %x\n", id, callData)
@@ -73,17 +92,65 @@ var ABIDecodeCmd = &cobra.Command{
 			}
 			fmt.Println()
 		}
+		if len(*inputLogTopics) > 0 {
+			return decodeLog(abi, *inputLogTopics, *inputLogData)
+		}
 		return nil
 	},
 	Args: func(cmd *cobra.Command, args []string) error {
+		if *inputData == "-" && (inputFileName == nil || *inputFileName == "") && len(args) <= 1 {
+			return fmt.Errorf("the abi must be given with --file when the call data is read from stdin")
+		}
 		return nil
 	},
 }
 
 func init() {
 	flagSet := ABIDecodeCmd.PersistentFlags()
-	inputFileName = flagSet.String("file", "", "Provide a filename to read and analyze")
-	inputData = flagSet.String("data", "", "Provide input data to be unpacked based on the ABI definition")
+	inputFileName = flagSet.String("file", "", "Provide a filename or an Etherscan getabi URL to read and analyze")
+	inputData = flagSet.String("data", "", "Provide input data to be unpacked based on the ABI definition. Use - to read it from stdin")
+	inputLogTopics = flagSet.StringSlice("log-topics", []string{}, "The topics of an event log to be decoded based on the ABI definition")
+	inputLogData = flagSet.String("log-data", "", "The data of an event log to be decoded based on the ABI definition")
+}
+
+// decodeLog matches the first topic against the events of the ABI and prints
+// both the indexed and non-indexed values of the event.
+func decodeLog(abi gethabi.ABI, rawTopics []string, rawData string) error {
+	topics := make([]common.Hash, 0, len(rawTopics))
+	for _, t := range rawTopics {
+		b, err := hex.DecodeString(strings.TrimPrefix(t, "0x"))
+		if err != nil {
+			return fmt.Errorf("unable to decode topic %s: %w", t, err)
+		}
+		topics = append(topics, common.BytesToHash(b))
+	}
+	event, err := abi.EventByID(topics[0])
+	if err != nil {
+		return err
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(rawData, "0x"))
+	if err != nil {
+		return fmt.Errorf("unable to decode log data: %w", err)
+	}
+
+	logVals := make(map[string]any, 0)
+	if err = event.Inputs.UnpackIntoMap(logVals, data); err != nil {
+		return err
+	}
+	var indexed gethabi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if err = gethabi.ParseTopicsIntoMap(logVals, indexed, topics[1:]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Event: %s\n", event.Sig)
+	prettyLog, _ := json.MarshalIndent(logVals, "", "  ")
+	fmt.Println(string(prettyLog))
+	return nil
 }
 
 func parseContractInputData(data string) ([]byte, []byte, error) {
@@ -108,6 +175,9 @@ func parseContractInputData(data string) ([]byte, []byte, error) {
 
 func getInputData(cmd *cobra.Command, args []string) ([]byte, error) {
 	if inputFileName != nil && *inputFileName != "" {
+		if strings.HasPrefix(*inputFileName, "http://") || strings.HasPrefix(*inputFileName, "https://") {
+			return fetchABI(*inputFileName)
+		}
 		return os.ReadFile(*inputFileName)
 	}
 
@@ -119,6 +189,33 @@ func getInputData(cmd *cobra.Command, args []string) ([]byte, error) {
 	return io.ReadAll(os.Stdin)
 }
 
+// fetchABI downloads an ABI. Etherscan style responses are unwrapped, anything
+// else is assumed to be the raw ABI JSON.
+func fetchABI(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code fetching abi: %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var es etherscanResponse
+	if err = json.Unmarshal(body, &es); err != nil {
+		// Not an object, most likely the raw abi array.
+		return body, nil
+	}
+	if es.Status != "1" {
+		return nil, fmt.Errorf("etherscan returned an error: %s %s", es.Message, es.Result)
+	}
+	return []byte(es.Result), nil
+}
+
 func getReturnSignature(funcReturns gethabi.Arguments) string {
 	returnSig := "("
 	for key, ret := range funcReturns {
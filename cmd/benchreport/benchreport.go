@@ -0,0 +1,353 @@
+package benchreport
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+type (
+	reportParams struct {
+		File  *string
+		Title *string
+	}
+
+	// run is a result file, either a dbbench report or a loadtest summary.
+	run struct {
+		Name     string
+		DBBench  *dbbenchReport
+		Loadtest *loadtestSummary
+	}
+
+	// dbbenchReport mirrors the JSON output of dbbench.
+	dbbenchReport struct {
+		Manifest *struct {
+			StartTime time.Time
+			Seed      int64
+			Version   string
+			Host      struct {
+				Hostname string
+				CPUModel string
+				CPUCount int
+			}
+		}
+		Results []struct {
+			Description   string
+			TestDuration  time.Duration
+			OpCount       uint64
+			OpRate        float64
+			IteratorSetup *latencySummary
+			ReadLatency   *latencySummary
+		}
+	}
+	latencySummary struct {
+		P50 time.Duration
+		P99 time.Duration
+	}
+
+	// loadtestSummary mirrors the JSON summary of loadtest.
+	loadtestSummary struct {
+		Summaries []struct {
+			BlockNumber uint64
+			GasUsed     uint64
+			NumTx       int
+			Utilization float64
+			Latencies   latency
+		}
+		SuccessfulTx       int64
+		TotalTx            int64
+		TotalMiningTime    time.Duration
+		TotalGasUsed       uint64
+		TransactionsPerSec float64
+		GasPerSecond       float64
+		Latencies          latency
+	}
+	latency struct {
+		Min    float64
+		Median float64
+		Max    float64
+	}
+
+	// page is the data of the HTML template.
+	page struct {
+		Title     string
+		Generated time.Time
+		Runs      []runInfo
+		Sections  []section
+	}
+	runInfo struct {
+		Name    string
+		Color   string
+		Details string
+	}
+	section struct {
+		Title  string
+		Charts []template.HTML
+		Table  *htmlTable
+	}
+	htmlTable struct {
+		Header []string
+		Rows   [][]string
+	}
+)
+
+var (
+	//go:embed usage.md
+	usage string
+	//go:embed report.html
+	reportTemplate string
+)
+
+// NewReportCmd returns the report command. Each call returns a new command so
+// it can be added to both dbbench and loadtest.
+func NewReportCmd() *cobra.Command {
+	p := new(reportParams)
+	cmd := &cobra.Command{
+		Use:   "report result-file [result-file...]",
+		Short: "Generate an HTML report with charts from benchmark result JSON files.",
+		Long:  usage,
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			runs, err := readRuns(args)
+			if err != nil {
+				return err
+			}
+			return writeReport(*p, runs)
+		},
+	}
+
+	flagSet := cmd.Flags()
+	p.File = flagSet.StringP("file", "f", "report.html", "The path of the HTML report")
+	p.Title = flagSet.String("title", "Benchmark Report", "The title of the report")
+	return cmd
+}
+
+// readRuns decodes the result files, which must all come from the same
+// benchmark to be compared.
+func readRuns(paths []string) ([]*run, error) {
+	runs := make([]*run, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var fields map[string]json.RawMessage
+		if err = json.Unmarshal(data, &fields); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+
+		r := &run{Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+		switch {
+		case fields["Results"] != nil:
+			r.DBBench = new(dbbenchReport)
+			err = json.Unmarshal(data, r.DBBench)
+		case fields["Summaries"] != nil:
+			r.Loadtest = new(loadtestSummary)
+			err = json.Unmarshal(data, r.Loadtest)
+		default:
+			return nil, fmt.Errorf("%s is neither a dbbench nor a loadtest JSON result", path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+		}
+		if len(runs) > 0 && (r.DBBench == nil) != (runs[0].DBBench == nil) {
+			return nil, errors.New("the results must all come from dbbench or all from loadtest")
+		}
+		runs = append(runs, r)
+	}
+	return runs, nil
+}
+
+func writeReport(p reportParams, runs []*run) error {
+	pg := page{Title: *p.Title, Generated: time.Now()}
+	for i, r := range runs {
+		pg.Runs = append(pg.Runs, runInfo{Name: r.Name, Color: color(i), Details: r.details()})
+	}
+	if runs[0].DBBench != nil {
+		pg.Sections = dbbenchSections(runs)
+	} else {
+		pg.Sections = loadtestSections(runs)
+	}
+
+	tmpl, err := template.New("report").Parse(reportTemplate)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(*p.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err = tmpl.Execute(f, pg); err != nil {
+		return err
+	}
+	log.Info().Str("file", *p.File).Int("runs", len(runs)).Msg("Wrote report")
+	return nil
+}
+
+func (r *run) details() string {
+	if r.Loadtest != nil {
+		return fmt.Sprintf("loadtest, %d blocks, %d/%d successful transactions", len(r.Loadtest.Summaries), r.Loadtest.SuccessfulTx, r.Loadtest.TotalTx)
+	}
+	m := r.DBBench.Manifest
+	if m == nil {
+		return fmt.Sprintf("dbbench, %d phases", len(r.DBBench.Results))
+	}
+	return fmt.Sprintf("dbbench %s, %s (%d CPUs %s), seed %d, %s", m.Version, m.Host.Hostname, m.Host.CPUCount,
+		m.Host.CPUModel, m.Seed, m.StartTime.Format(time.RFC3339))
+}
+
+// dbbenchSections charts the throughput, duration, and read latencies of
+// every phase, the phases being matched across runs by their description.
+func dbbenchSections(runs []*run) []section {
+	var phases []string
+	seen := make(map[string]int)
+	for _, r := range runs {
+		for _, tr := range r.DBBench.Results {
+			if _, ok := seen[tr.Description]; !ok {
+				seen[tr.Description] = len(phases)
+				phases = append(phases, tr.Description)
+			}
+		}
+	}
+
+	newBarChart := func(title, unit string, value func(run int, phase int) float64) *barChart {
+		c := &barChart{Title: title, Unit: unit, Groups: phases}
+		for i, r := range runs {
+			s := series{Name: r.Name, Values: make([]float64, len(phases))}
+			for j := range phases {
+				s.Values[j] = value(i, j)
+			}
+			c.Series = append(c.Series, s)
+		}
+		return c
+	}
+	rates := make([][]float64, len(runs))
+	durations := make([][]float64, len(runs))
+	p50s := make([][]float64, len(runs))
+	p99s := make([][]float64, len(runs))
+	hasLatencies := false
+	for i, r := range runs {
+		rates[i], durations[i] = nanSlice(len(phases)), nanSlice(len(phases))
+		p50s[i], p99s[i] = nanSlice(len(phases)), nanSlice(len(phases))
+		for _, tr := range r.DBBench.Results {
+			j := seen[tr.Description]
+			rates[i][j] = tr.OpRate
+			durations[i][j] = tr.TestDuration.Seconds()
+			// The random reads report their latency, the range scans the
+			// setup of their iterators.
+			l := tr.ReadLatency
+			if l == nil {
+				l = tr.IteratorSetup
+			}
+			if l != nil {
+				hasLatencies = true
+				p50s[i][j] = float64(l.P50) / float64(time.Millisecond)
+				p99s[i][j] = float64(l.P99) / float64(time.Millisecond)
+			}
+		}
+	}
+
+	throughput := newBarChart("Throughput per phase", "ops/s", func(i, j int) float64 { return rates[i][j] })
+	duration := newBarChart("Duration per phase", "s", func(i, j int) float64 { return durations[i][j] })
+	sections := []section{
+		{Title: "Throughput", Charts: []template.HTML{throughput.SVG()}, Table: comparisonTable("Phase", "ops/s", throughput)},
+		{Title: "Duration", Charts: []template.HTML{duration.SVG()}, Table: comparisonTable("Phase", "s", duration)},
+	}
+	if hasLatencies {
+		p50 := newBarChart("Latency p50", "ms", func(i, j int) float64 { return p50s[i][j] })
+		p99 := newBarChart("Latency p99", "ms", func(i, j int) float64 { return p99s[i][j] })
+		sections = append(sections, section{Title: "Latency", Charts: []template.HTML{p50.SVG(), p99.SVG()}, Table: comparisonTable("Phase", "ms p99", p99)})
+	}
+	return sections
+}
+
+// loadtestSections charts the overall throughput and latencies of the runs,
+// and overlays their transactions, utilization, and latencies per block.
+func loadtestSections(runs []*run) []section {
+	totals := &barChart{Title: "Throughput", Unit: "tx/s", Groups: []string{"Transactions per second"}}
+	gas := &barChart{Title: "Gas throughput", Unit: "gas/s", Groups: []string{"Gas per second"}}
+	latencies := &barChart{Title: "Transaction latency", Unit: "s", Groups: []string{"Min", "Median", "Max"}}
+	txs := &lineChart{Title: "Transactions per block", XLabel: "Block", Unit: "transactions"}
+	utilization := &lineChart{Title: "Block utilization", XLabel: "Block", Unit: "%"}
+	blockLatencies := &lineChart{Title: "Median latency per block", XLabel: "Block", Unit: "s"}
+
+	for _, r := range runs {
+		lt := r.Loadtest
+		totals.Series = append(totals.Series, series{Name: r.Name, Values: []float64{lt.TransactionsPerSec}})
+		gas.Series = append(gas.Series, series{Name: r.Name, Values: []float64{lt.GasPerSecond}})
+		latencies.Series = append(latencies.Series, series{Name: r.Name, Values: []float64{lt.Latencies.Min, lt.Latencies.Median, lt.Latencies.Max}})
+
+		// The blocks are numbered from the first block of the run so the runs
+		// overlay.
+		tx, util, lat := lineSeries{Name: r.Name}, lineSeries{Name: r.Name}, lineSeries{Name: r.Name}
+		for _, s := range lt.Summaries {
+			x := float64(s.BlockNumber - lt.Summaries[0].BlockNumber)
+			tx.Points = append(tx.Points, point{x, float64(s.NumTx)})
+			util.Points = append(util.Points, point{x, s.Utilization * 100})
+			lat.Points = append(lat.Points, point{x, s.Latencies.Median})
+		}
+		txs.Series = append(txs.Series, tx)
+		utilization.Series = append(utilization.Series, util)
+		blockLatencies.Series = append(blockLatencies.Series, lat)
+	}
+
+	return []section{
+		{Title: "Throughput", Charts: []template.HTML{totals.SVG(), gas.SVG()}, Table: comparisonTable("Metric", "tx/s", totals)},
+		{Title: "Latency", Charts: []template.HTML{latencies.SVG(), blockLatencies.SVG()}, Table: comparisonTable("Latency", "s", latencies)},
+		{Title: "Blocks", Charts: []template.HTML{txs.SVG(), utilization.SVG()}},
+	}
+}
+
+// comparisonTable lists the values of the chart with the change of every run
+// relative to the first one.
+func comparisonTable(name, unit string, c *barChart) *htmlTable {
+	t := &htmlTable{Header: []string{name}}
+	for i, s := range c.Series {
+		t.Header = append(t.Header, fmt.Sprintf("%s (%s)", s.Name, unit))
+		if i > 0 {
+			t.Header = append(t.Header, "Δ "+s.Name)
+		}
+	}
+	for g, group := range c.Groups {
+		row := []string{group}
+		base := c.Series[0].Values[g]
+		for i, s := range c.Series {
+			v := s.Values[g]
+			if math.IsNaN(v) {
+				row = append(row, "-")
+			} else {
+				row = append(row, fmt.Sprintf("%.2f", v))
+			}
+			if i == 0 {
+				continue
+			}
+			if math.IsNaN(v) || math.IsNaN(base) || base == 0 {
+				row = append(row, "-")
+			} else {
+				row = append(row, fmt.Sprintf("%+.1f%%", (v-base)/base*100))
+			}
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+func nanSlice(n int) []float64 {
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = math.NaN()
+	}
+	return s
+}
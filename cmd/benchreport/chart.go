@@ -0,0 +1,207 @@
+package benchreport
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strings"
+)
+
+const (
+	chartWidth   = 860
+	chartHeight  = 320
+	marginLeft   = 70
+	marginRight  = 20
+	marginTop    = 40
+	marginBottom = 60
+	yTicks       = 5
+)
+
+// palette holds the colors of the runs, the same run having the same color in
+// every chart.
+var palette = []string{"#4e79a7", "#f28e2b", "#e15759", "#76b7b2", "#59a14f", "#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac"}
+
+type (
+	// barChart groups a bar per series for every group, e.g. a bar per run
+	// for every benchmark phase.
+	barChart struct {
+		Title  string
+		Unit   string
+		Groups []string
+		Series []series
+	}
+	series struct {
+		Name string
+		// Values has a value per group, NaN when the series has no value for
+		// the group.
+		Values []float64
+	}
+
+	// lineChart overlays a line per series, e.g. a line per run over the
+	// blocks of the load test.
+	lineChart struct {
+		Title  string
+		XLabel string
+		Unit   string
+		Series []lineSeries
+	}
+	lineSeries struct {
+		Name   string
+		Points []point
+	}
+	point struct {
+		X, Y float64
+	}
+)
+
+// SVG renders the chart as an inline SVG element.
+func (c *barChart) SVG() template.HTML {
+	var b strings.Builder
+	maxValue := 0.0
+	for _, s := range c.Series {
+		for _, v := range s.Values {
+			if !math.IsNaN(v) {
+				maxValue = math.Max(maxValue, v)
+			}
+		}
+	}
+	top := niceCeil(maxValue)
+	writeFrame(&b, c.Title, c.Unit, top, seriesNames(c.Series))
+
+	plotWidth := float64(chartWidth - marginLeft - marginRight)
+	plotHeight := float64(chartHeight - marginTop - marginBottom)
+	groupWidth := plotWidth / float64(max(len(c.Groups), 1))
+	barWidth := groupWidth * 0.8 / float64(max(len(c.Series), 1))
+	for g, group := range c.Groups {
+		x0 := marginLeft + float64(g)*groupWidth + groupWidth*0.1
+		for i, s := range c.Series {
+			v := s.Values[g]
+			if math.IsNaN(v) {
+				continue
+			}
+			h := v / top * plotHeight
+			fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %s %s</title></rect>`,
+				x0+float64(i)*barWidth, marginTop+plotHeight-h, barWidth, h, color(i),
+				html.EscapeString(s.Name), formatValue(v), html.EscapeString(c.Unit))
+		}
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle" class="label">%s</text>`,
+			x0+groupWidth*0.4, chartHeight-marginBottom+18, html.EscapeString(truncate(group, int(groupWidth/7))))
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// SVG renders the chart as an inline SVG element.
+func (c *lineChart) SVG() template.HTML {
+	var b strings.Builder
+	maxX, maxY := 0.0, 0.0
+	for _, s := range c.Series {
+		for _, p := range s.Points {
+			maxX = math.Max(maxX, p.X)
+			maxY = math.Max(maxY, p.Y)
+		}
+	}
+	top := niceCeil(maxY)
+	names := make([]string, len(c.Series))
+	for i, s := range c.Series {
+		names[i] = s.Name
+	}
+	writeFrame(&b, c.Title, c.Unit, top, names)
+
+	plotWidth := float64(chartWidth - marginLeft - marginRight)
+	plotHeight := float64(chartHeight - marginTop - marginBottom)
+	if maxX == 0 {
+		maxX = 1
+	}
+	for i, s := range c.Series {
+		coords := make([]string, 0, len(s.Points))
+		for _, p := range s.Points {
+			coords = append(coords, fmt.Sprintf("%.1f,%.1f", marginLeft+p.X/maxX*plotWidth, marginTop+plotHeight-p.Y/top*plotHeight))
+		}
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"><title>%s</title></polyline>`,
+			strings.Join(coords, " "), color(i), html.EscapeString(s.Name))
+	}
+	for i := 0; i <= yTicks; i++ {
+		v := maxX * float64(i) / yTicks
+		fmt.Fprintf(&b, `<text x="%.1f" y="%d" text-anchor="middle" class="label">%s</text>`,
+			marginLeft+float64(i)/yTicks*plotWidth, chartHeight-marginBottom+18, formatValue(v))
+	}
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="middle" class="label">%s</text>`,
+		marginLeft+int(plotWidth)/2, chartHeight-marginBottom+40, html.EscapeString(c.XLabel))
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// writeFrame opens the SVG element and draws the title, the legend, and the y
+// axis with its grid lines.
+func writeFrame(b *strings.Builder, title, unit string, top float64, names []string) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" class="chart">`, chartWidth, chartHeight)
+	fmt.Fprintf(b, `<text x="%d" y="20" class="title">%s</text>`, marginLeft, html.EscapeString(title))
+
+	x := chartWidth - marginRight
+	for i := len(names) - 1; i >= 0; i-- {
+		x -= 7*len(names[i]) + 24
+		fmt.Fprintf(b, `<rect x="%d" y="10" width="12" height="12" fill="%s"/><text x="%d" y="21" class="label">%s</text>`,
+			x, color(i), x+16, html.EscapeString(names[i]))
+	}
+
+	plotHeight := float64(chartHeight - marginTop - marginBottom)
+	for i := 0; i <= yTicks; i++ {
+		y := marginTop + plotHeight - float64(i)/yTicks*plotHeight
+		fmt.Fprintf(b, `<line x1="%d" x2="%d" y1="%.1f" y2="%.1f" class="grid"/>`, marginLeft, chartWidth-marginRight, y, y)
+		fmt.Fprintf(b, `<text x="%d" y="%.1f" text-anchor="end" class="label">%s</text>`,
+			marginLeft-6, y+4, formatValue(top*float64(i)/yTicks))
+	}
+	fmt.Fprintf(b, `<text x="14" y="%d" transform="rotate(-90 14 %d)" text-anchor="middle" class="label">%s</text>`,
+		marginTop+int(plotHeight)/2, marginTop+int(plotHeight)/2, html.EscapeString(unit))
+}
+
+// niceCeil rounds the value up to 1, 2, 2.5, or 5 times a power of ten so the
+// ticks of the y axis are round numbers.
+func niceCeil(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	exp := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if v <= m*exp {
+			return m * exp
+		}
+	}
+	return 10 * exp
+}
+
+// formatValue shortens the value with a metric suffix, e.g. 12.5k.
+func formatValue(v float64) string {
+	abs := math.Abs(v)
+	switch {
+	case abs >= 1e9:
+		return fmt.Sprintf("%.3gG", v/1e9)
+	case abs >= 1e6:
+		return fmt.Sprintf("%.3gM", v/1e6)
+	case abs >= 1e3:
+		return fmt.Sprintf("%.3gk", v/1e3)
+	default:
+		return fmt.Sprintf("%.3g", v)
+	}
+}
+
+func seriesNames(s []series) []string {
+	names := make([]string, len(s))
+	for i := range s {
+		names[i] = s[i].Name
+	}
+	return names
+}
+
+func color(i int) string {
+	return palette[i%len(palette)]
+}
+
+func truncate(s string, n int) string {
+	if n < 4 || len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
@@ -0,0 +1,385 @@
+package blockfetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	maxFetchAttempts = 5
+	retryDelay       = 5 * time.Second
+)
+
+type (
+	rpcBlock struct {
+		Number        hexutil.Uint64    `json:"number"`
+		Hash          ethcommon.Hash    `json:"hash"`
+		ParentHash    ethcommon.Hash    `json:"parentHash"`
+		Timestamp     hexutil.Uint64    `json:"timestamp"`
+		Miner         ethcommon.Address `json:"miner"`
+		GasLimit      hexutil.Uint64    `json:"gasLimit"`
+		GasUsed       hexutil.Uint64    `json:"gasUsed"`
+		BaseFeePerGas *hexutil.Big      `json:"baseFeePerGas"`
+		Size          hexutil.Uint64    `json:"size"`
+		ExtraData     hexutil.Bytes     `json:"extraData"`
+		Transactions  []rpcTransaction  `json:"transactions"`
+	}
+	rpcTransaction struct {
+		Hash                 ethcommon.Hash     `json:"hash"`
+		TransactionIndex     hexutil.Uint64     `json:"transactionIndex"`
+		Type                 hexutil.Uint64     `json:"type"`
+		From                 ethcommon.Address  `json:"from"`
+		To                   *ethcommon.Address `json:"to"`
+		Nonce                hexutil.Uint64     `json:"nonce"`
+		Value                *hexutil.Big       `json:"value"`
+		Gas                  hexutil.Uint64     `json:"gas"`
+		GasPrice             *hexutil.Big       `json:"gasPrice"`
+		MaxFeePerGas         *hexutil.Big       `json:"maxFeePerGas"`
+		MaxPriorityFeePerGas *hexutil.Big       `json:"maxPriorityFeePerGas"`
+		Input                hexutil.Bytes      `json:"input"`
+	}
+	rpcReceipt struct {
+		TransactionHash   ethcommon.Hash     `json:"transactionHash"`
+		Status            *hexutil.Uint64    `json:"status"`
+		GasUsed           hexutil.Uint64     `json:"gasUsed"`
+		EffectiveGasPrice *hexutil.Big       `json:"effectiveGasPrice"`
+		ContractAddress   *ethcommon.Address `json:"contractAddress"`
+		Logs              []rpcLog           `json:"logs"`
+	}
+	rpcLog struct {
+		Address  ethcommon.Address `json:"address"`
+		Topics   []ethcommon.Hash  `json:"topics"`
+		Data     hexutil.Bytes     `json:"data"`
+		LogIndex hexutil.Uint64    `json:"logIndex"`
+	}
+	// txTrace is an element of the debug_traceBlockByNumber result, in the order of the transactions.
+	txTrace struct {
+		Result callFrame `json:"result"`
+		Error  string    `json:"error"`
+	}
+	// callFrame is a frame of the callTracer output.
+	callFrame struct {
+		Type    string             `json:"type"`
+		From    ethcommon.Address  `json:"from"`
+		To      *ethcommon.Address `json:"to"`
+		Value   *hexutil.Big       `json:"value"`
+		Gas     hexutil.Uint64     `json:"gas"`
+		GasUsed hexutil.Uint64     `json:"gasUsed"`
+		Input   hexutil.Bytes      `json:"input"`
+		Output  hexutil.Bytes      `json:"output"`
+		Error   string             `json:"error"`
+		Calls   []callFrame        `json:"calls"`
+	}
+)
+
+// fetchBlocks writes the blocks from the last stored one up to the end, and then follows the head of the
+// chain in tail mode until interrupted.
+func fetchBlocks(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	rpc, err := params.RPCClient.Dial(ctx, *params.RpcUrl)
+	if err != nil {
+		return err
+	}
+	defer rpc.Close()
+	s, err := newSink(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	next := *params.Start
+	last, ok, err := s.LastBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to read the last stored block: %w", err)
+	}
+	if ok && last >= next {
+		log.Info().Uint64("lastBlock", last).Msg("Resuming after the last stored block")
+		next = last + 1
+	}
+
+	for {
+		// The round is skipped while the chain is shorter than the confirmations, since not even the
+		// genesis block is confirmed.
+		target, confirmed := *params.End, true
+		if target == 0 {
+			var head hexutil.Uint64
+			if err = rpc.CallContext(ctx, &head, "eth_blockNumber"); err != nil {
+				return stopped(ctx, err)
+			}
+			if uint64(head) < *params.Confirmations {
+				log.Info().Uint64("head", uint64(head)).Msg("No confirmed block yet")
+				confirmed = false
+			} else {
+				target = uint64(head) - *params.Confirmations
+			}
+		}
+
+		for confirmed && next <= target {
+			to := next + *params.BatchSize - 1
+			if to > target {
+				to = target
+			}
+			if err = fetchAndWrite(ctx, rpc, s, next, to); err != nil {
+				return stopped(ctx, err)
+			}
+			next = to + 1
+		}
+
+		if !*params.Tail {
+			log.Info().Uint64("nextBlock", next).Msg("Backfill done")
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			log.Info().Uint64("nextBlock", next).Msg("Stopped following the head")
+			return nil
+		case <-time.After(*params.PollInterval):
+		}
+	}
+}
+
+// stopped ignores the error caused by an interruption.
+func stopped(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		log.Info().Msg("Interrupted")
+		return nil
+	}
+	return err
+}
+
+// fetchAndWrite writes the range of blocks, retrying the whole range when the fetch or the write fails.
+func fetchAndWrite(ctx context.Context, rpc *ethrpc.Client, s sink, from, to uint64) error {
+	var err error
+	for attempt := 1; attempt <= maxFetchAttempts; attempt++ {
+		var b *batch
+		if b, err = fetchBatch(ctx, rpc, from, to); err == nil {
+			if err = s.Write(ctx, b); err == nil {
+				log.Info().Uint64("start", from).Uint64("end", to).
+					Int("transactions", len(b.transactions)).Int("logs", len(b.logs)).Int("traces", len(b.traces)).
+					Msg("Wrote blocks")
+				return nil
+			}
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		log.Warn().Err(err).Uint64("start", from).Uint64("end", to).Int("attempt", attempt).Msg("Unable to store the blocks")
+		if attempt < maxFetchAttempts {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(retryDelay):
+			}
+		}
+	}
+	return fmt.Errorf("unable to store blocks %d to %d: %w", from, to, err)
+}
+
+// fetchBatch requests the blocks of the range with their receipts, and their traces if enabled.
+func fetchBatch(ctx context.Context, rpc *ethrpc.Client, from, to uint64) (*batch, error) {
+	rawBlocks, err := util.GetBlockRange(ctx, from, to, rpc)
+	if err != nil {
+		return nil, err
+	}
+	blocks := make([]rpcBlock, len(rawBlocks))
+	for i, raw := range rawBlocks {
+		if raw == nil || string(*raw) == "null" {
+			return nil, fmt.Errorf("block %d not found", from+uint64(i))
+		}
+		if err = json.Unmarshal(*raw, &blocks[i]); err != nil {
+			return nil, fmt.Errorf("unable to decode block %d: %w", from+uint64(i), err)
+		}
+	}
+
+	rawReceipts, err := util.GetReceipts(ctx, rawBlocks, rpc, *params.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	receipts := make(map[ethcommon.Hash]*rpcReceipt, len(rawReceipts))
+	for _, raw := range rawReceipts {
+		r := new(rpcReceipt)
+		if err = json.Unmarshal(*raw, r); err != nil {
+			return nil, fmt.Errorf("unable to decode receipt: %w", err)
+		}
+		receipts[r.TransactionHash] = r
+	}
+
+	var traces [][]txTrace
+	if *params.Traces {
+		if traces, err = fetchTraces(ctx, rpc, from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	b := new(batch)
+	for i, block := range blocks {
+		b.addBlock(&block)
+		for _, tx := range block.Transactions {
+			receipt, ok := receipts[tx.Hash]
+			if !ok {
+				return nil, fmt.Errorf("receipt of transaction %v not found", tx.Hash)
+			}
+			b.addTransaction(&block, &tx, receipt)
+		}
+		if traces == nil {
+			continue
+		}
+		if len(traces[i]) != len(block.Transactions) {
+			return nil, fmt.Errorf("got %d traces for the %d transactions of block %d", len(traces[i]), len(block.Transactions), block.Number)
+		}
+		for j, t := range traces[i] {
+			if t.Error != "" {
+				return nil, fmt.Errorf("unable to trace transaction %v: %s", block.Transactions[j].Hash, t.Error)
+			}
+			b.addTrace(&block, &block.Transactions[j], nil, &t.Result)
+		}
+	}
+	return b, nil
+}
+
+// fetchTraces traces the blocks of the range in a single batch.
+func fetchTraces(ctx context.Context, rpc *ethrpc.Client, from, to uint64) ([][]txTrace, error) {
+	traces := make([][]txTrace, to-from+1)
+	elems := make([]ethrpc.BatchElem, len(traces))
+	for i := range elems {
+		elems[i] = ethrpc.BatchElem{
+			Method: "debug_traceBlockByNumber",
+			Args:   []any{hexutil.EncodeUint64(from + uint64(i)), map[string]any{"tracer": "callTracer"}},
+			Result: &traces[i],
+		}
+	}
+	if err := rpc.BatchCallContext(ctx, elems); err != nil {
+		return nil, err
+	}
+	for i, e := range elems {
+		if e.Error != nil {
+			return nil, fmt.Errorf("unable to trace block %d: %w", from+uint64(i), e.Error)
+		}
+	}
+	return traces, nil
+}
+
+func (b *batch) addBlock(block *rpcBlock) {
+	b.blocks = append(b.blocks, row{
+		uint64(block.Number),
+		block.Hash.Hex(),
+		block.ParentHash.Hex(),
+		uint64(block.Timestamp),
+		lowerHex(block.Miner),
+		uint64(block.GasLimit),
+		uint64(block.GasUsed),
+		bigValue(block.BaseFeePerGas),
+		uint64(len(block.Transactions)),
+		uint64(block.Size),
+		block.ExtraData.String(),
+	})
+}
+
+func (b *batch) addTransaction(block *rpcBlock, tx *rpcTransaction, receipt *rpcReceipt) {
+	var status any
+	if receipt.Status != nil {
+		status = uint64(*receipt.Status)
+	}
+	b.transactions = append(b.transactions, row{
+		tx.Hash.Hex(),
+		uint64(block.Number),
+		block.Hash.Hex(),
+		uint64(tx.TransactionIndex),
+		uint64(tx.Type),
+		lowerHex(tx.From),
+		addressValue(tx.To),
+		uint64(tx.Nonce),
+		bigValue(tx.Value),
+		uint64(tx.Gas),
+		bigValue(tx.GasPrice),
+		bigValue(tx.MaxFeePerGas),
+		bigValue(tx.MaxPriorityFeePerGas),
+		tx.Input.String(),
+		status,
+		uint64(receipt.GasUsed),
+		bigValue(receipt.EffectiveGasPrice),
+		addressValue(receipt.ContractAddress),
+	})
+
+	for _, l := range receipt.Logs {
+		topics := make([]any, 4)
+		for i := range topics {
+			if i < len(l.Topics) {
+				topics[i] = l.Topics[i].Hex()
+			}
+		}
+		b.logs = append(b.logs, row{
+			uint64(block.Number),
+			uint64(l.LogIndex),
+			block.Hash.Hex(),
+			tx.Hash.Hex(),
+			uint64(tx.TransactionIndex),
+			lowerHex(l.Address),
+			topics[0], topics[1], topics[2], topics[3],
+			l.Data.String(),
+		})
+	}
+}
+
+// addTrace adds the call and its subcalls, the trace address being the dot separated indexes of the calls
+// from the top call of the transaction, empty for the top call itself.
+func (b *batch) addTrace(block *rpcBlock, tx *rpcTransaction, traceAddress []string, frame *callFrame) {
+	var errMsg any
+	if frame.Error != "" {
+		errMsg = frame.Error
+	}
+	b.traces = append(b.traces, row{
+		uint64(block.Number),
+		uint64(tx.TransactionIndex),
+		strings.Join(traceAddress, "."),
+		tx.Hash.Hex(),
+		frame.Type,
+		lowerHex(frame.From),
+		addressValue(frame.To),
+		bigValue(frame.Value),
+		uint64(frame.Gas),
+		uint64(frame.GasUsed),
+		frame.Input.String(),
+		frame.Output.String(),
+		errMsg,
+	})
+	for i := range frame.Calls {
+		b.addTrace(block, tx, append(traceAddress[:len(traceAddress):len(traceAddress)], strconv.Itoa(i)), &frame.Calls[i])
+	}
+}
+
+// bigValue returns the decimal string of the value, which fits the numeric columns of any size, or nil
+// when it is missing.
+func bigValue(v *hexutil.Big) any {
+	if v == nil {
+		return nil
+	}
+	return v.ToInt().String()
+}
+
+func addressValue(a *ethcommon.Address) any {
+	if a == nil {
+		return nil
+	}
+	return lowerHex(*a)
+}
+
+// lowerHex returns the address in lower case, which is simpler to query than the checksummed form.
+func lowerHex(a ethcommon.Address) string {
+	return strings.ToLower(a.Hex())
+}
@@ -0,0 +1,67 @@
+package blockfetcher
+
+import (
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestAddTrace(t *testing.T) {
+	block := &rpcBlock{Number: 7}
+	tx := &rpcTransaction{Hash: ethcommon.HexToHash("0x01"), TransactionIndex: 2}
+	to := ethcommon.HexToAddress("0xAB")
+	frame := &callFrame{
+		Type:  "CALL",
+		From:  ethcommon.HexToAddress("0xCD"),
+		To:    &to,
+		Value: (*hexutil.Big)(big.NewInt(10)),
+		Calls: []callFrame{
+			{Type: "STATICCALL", To: &to},
+			{Type: "CALL", To: &to, Error: "execution reverted", Calls: []callFrame{
+				{Type: "CREATE"},
+				{Type: "DELEGATECALL", To: &to},
+			}},
+			{Type: "CALL", To: &to},
+		},
+	}
+
+	b := new(batch)
+	b.addTrace(block, tx, nil, frame)
+
+	expected := []struct {
+		traceAddress string
+		callType     string
+	}{
+		{"", "CALL"},
+		{"0", "STATICCALL"},
+		{"1", "CALL"},
+		{"1.0", "CREATE"},
+		{"1.1", "DELEGATECALL"},
+		{"2", "CALL"},
+	}
+	if len(b.traces) != len(expected) {
+		t.Fatalf("expected %d traces, got %d", len(expected), len(b.traces))
+	}
+	for i, e := range expected {
+		r := b.traces[i]
+		if len(r) != len(tracesTable.columns) {
+			t.Fatalf("trace %d: expected %d values, got %d", i, len(tracesTable.columns), len(r))
+		}
+		if r[0] != uint64(7) || r[1] != uint64(2) || r[2] != e.traceAddress || r[4] != e.callType {
+			t.Errorf("trace %d: unexpected row %v", i, r)
+		}
+	}
+
+	top := b.traces[0]
+	if top[5] != "0x00000000000000000000000000000000000000cd" || top[6] != "0x00000000000000000000000000000000000000ab" || top[7] != "10" || top[12] != nil {
+		t.Errorf("unexpected top call %v", top)
+	}
+	if b.traces[2][12] != "execution reverted" {
+		t.Errorf("expected the error of the reverted call, got %v", b.traces[2][12])
+	}
+	if b.traces[3][6] != nil || b.traces[3][7] != nil {
+		t.Errorf("expected the missing address and value to be null, got %v", b.traces[3])
+	}
+}
@@ -0,0 +1,183 @@
+package blockfetcher
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/lib/pq"
+	"golang.org/x/exp/slices"
+)
+
+// postgresSchema creates the tables of the PostgreSQL database. The hashes,
+// addresses and byte strings are 0x prefixed hex, and the amounts in wei are
+// numerics since they don't fit 64 bits.
+const postgresSchema = `
+CREATE TABLE IF NOT EXISTS blocks (
+	number            BIGINT PRIMARY KEY,
+	hash              TEXT NOT NULL,
+	parent_hash       TEXT NOT NULL,
+	timestamp         BIGINT NOT NULL,
+	miner             TEXT NOT NULL,
+	gas_limit         BIGINT NOT NULL,
+	gas_used          BIGINT NOT NULL,
+	base_fee_per_gas  NUMERIC,
+	transaction_count INTEGER NOT NULL,
+	size              BIGINT NOT NULL,
+	extra_data        TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS blocks_hash ON blocks (hash);
+
+CREATE TABLE IF NOT EXISTS transactions (
+	hash                     TEXT PRIMARY KEY,
+	block_number             BIGINT NOT NULL,
+	block_hash               TEXT NOT NULL,
+	transaction_index        INTEGER NOT NULL,
+	type                     SMALLINT NOT NULL,
+	from_address             TEXT NOT NULL,
+	to_address               TEXT,
+	nonce                    BIGINT NOT NULL,
+	value                    NUMERIC,
+	gas                      BIGINT NOT NULL,
+	gas_price                NUMERIC,
+	max_fee_per_gas          NUMERIC,
+	max_priority_fee_per_gas NUMERIC,
+	input                    TEXT NOT NULL,
+	status                   SMALLINT,
+	gas_used                 BIGINT NOT NULL,
+	effective_gas_price      NUMERIC,
+	contract_address         TEXT
+);
+CREATE INDEX IF NOT EXISTS transactions_block_number ON transactions (block_number);
+CREATE INDEX IF NOT EXISTS transactions_from_address ON transactions (from_address);
+CREATE INDEX IF NOT EXISTS transactions_to_address ON transactions (to_address);
+
+CREATE TABLE IF NOT EXISTS logs (
+	block_number      BIGINT NOT NULL,
+	log_index         INTEGER NOT NULL,
+	block_hash        TEXT NOT NULL,
+	transaction_hash  TEXT NOT NULL,
+	transaction_index INTEGER NOT NULL,
+	address           TEXT NOT NULL,
+	topic0            TEXT,
+	topic1            TEXT,
+	topic2            TEXT,
+	topic3            TEXT,
+	data              TEXT NOT NULL,
+	PRIMARY KEY (block_number, log_index)
+);
+CREATE INDEX IF NOT EXISTS logs_address_topic0 ON logs (address, topic0);
+
+CREATE TABLE IF NOT EXISTS traces (
+	block_number      BIGINT NOT NULL,
+	transaction_index INTEGER NOT NULL,
+	trace_address     TEXT NOT NULL,
+	transaction_hash  TEXT NOT NULL,
+	type              TEXT NOT NULL,
+	from_address      TEXT NOT NULL,
+	to_address        TEXT,
+	value             NUMERIC,
+	gas               BIGINT NOT NULL,
+	gas_used          BIGINT NOT NULL,
+	input             TEXT NOT NULL,
+	output            TEXT NOT NULL,
+	error             TEXT,
+	PRIMARY KEY (block_number, transaction_index, trace_address)
+);
+CREATE INDEX IF NOT EXISTS traces_transaction_hash ON traces (transaction_hash);
+`
+
+// postgresMaxParams is the maximum number of parameters of a statement.
+const postgresMaxParams = 65535
+
+// postgresSink upserts the rows of each batch in a single transaction, so a
+// batch is either fully stored or not at all.
+type postgresSink struct {
+	db *sql.DB
+}
+
+// newPostgresSink connects to the database and creates the tables if needed.
+func newPostgresSink(ctx context.Context, dsn string) (*postgresSink, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = db.ExecContext(ctx, postgresSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to create the tables: %w", err)
+	}
+	return &postgresSink{db: db}, nil
+}
+
+func (s *postgresSink) LastBlock(ctx context.Context) (uint64, bool, error) {
+	var last sql.NullInt64
+	if err := s.db.QueryRowContext(ctx, "SELECT MAX(number) FROM blocks").Scan(&last); err != nil {
+		return 0, false, err
+	}
+	return uint64(last.Int64), last.Valid, nil
+}
+
+func (s *postgresSink) Write(ctx context.Context, b *batch) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, t := range b.tables() {
+		// The statements are split to stay under the parameter limit.
+		chunk := postgresMaxParams / len(t.columns)
+		for start := 0; start < len(t.rows); start += chunk {
+			end := start + chunk
+			if end > len(t.rows) {
+				end = len(t.rows)
+			}
+			query, args := t.upsert(t.rows[start:end])
+			if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+				return fmt.Errorf("unable to write the %s: %w", t.name, err)
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *postgresSink) Close() error {
+	return s.db.Close()
+}
+
+// upsert returns the statement inserting the rows in the table, or updating
+// them when their key already exists.
+func (t *table) upsert(rows []row) (string, []any) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "INSERT INTO %s (%s) VALUES ", t.name, strings.Join(t.columns, ", "))
+	args := make([]any, 0, len(rows)*len(t.columns))
+	for i, r := range rows {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for j, v := range r {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			args = append(args, v)
+			fmt.Fprintf(&sb, "$%d", len(args))
+		}
+		sb.WriteString(")")
+	}
+
+	fmt.Fprintf(&sb, " ON CONFLICT (%s) DO UPDATE SET ", strings.Join(t.key, ", "))
+	first := true
+	for _, c := range t.columns {
+		if slices.Contains(t.key, c) {
+			continue
+		}
+		if !first {
+			sb.WriteString(", ")
+		}
+		first = false
+		fmt.Fprintf(&sb, "%s = EXCLUDED.%s", c, c)
+	}
+	return sb.String(), args
+}
@@ -0,0 +1,35 @@
+package blockfetcher
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUpsert(t *testing.T) {
+	tbl := table{
+		name:    "logs",
+		columns: []string{"block_number", "log_index", "data"},
+		key:     []string{"block_number", "log_index"},
+	}
+	query, args := tbl.upsert([]row{{uint64(1), uint64(0), "0x01"}, {uint64(1), uint64(1), nil}})
+
+	expected := "INSERT INTO logs (block_number, log_index, data) VALUES ($1, $2, $3), ($4, $5, $6)" +
+		" ON CONFLICT (block_number, log_index) DO UPDATE SET data = EXCLUDED.data"
+	if query != expected {
+		t.Errorf("unexpected query %q", query)
+	}
+	if !reflect.DeepEqual(args, []any{uint64(1), uint64(0), "0x01", uint64(1), uint64(1), nil}) {
+		t.Errorf("unexpected arguments %v", args)
+	}
+
+	// Every column but the keys is updated, in the order of the columns.
+	query, args = blocksTable.upsert([]row{make(row, len(blocksTable.columns))})
+	expected = "INSERT INTO blocks (number, hash, parent_hash, timestamp, miner, gas_limit, gas_used, base_fee_per_gas, transaction_count, size, extra_data)" +
+		" VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)" +
+		" ON CONFLICT (number) DO UPDATE SET hash = EXCLUDED.hash, parent_hash = EXCLUDED.parent_hash, timestamp = EXCLUDED.timestamp," +
+		" miner = EXCLUDED.miner, gas_limit = EXCLUDED.gas_limit, gas_used = EXCLUDED.gas_used, base_fee_per_gas = EXCLUDED.base_fee_per_gas," +
+		" transaction_count = EXCLUDED.transaction_count, size = EXCLUDED.size, extra_data = EXCLUDED.extra_data"
+	if query != expected || len(args) != len(blocksTable.columns) {
+		t.Errorf("unexpected query %q with %d arguments", query, len(args))
+	}
+}
@@ -0,0 +1,88 @@
+package blockfetcher
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	// sink stores the fetched blocks. Writing a batch again replaces its rows, so a batch interrupted by
+	// a failure can simply be fetched and written again.
+	sink interface {
+		// LastBlock returns the number of the last block stored, and false when there is none.
+		LastBlock(ctx context.Context) (uint64, bool, error)
+		Write(ctx context.Context, b *batch) error
+		Close() error
+	}
+
+	// table describes the columns of a table, in the order of the values of its rows. The rows are
+	// unique by the key columns.
+	table struct {
+		name    string
+		columns []string
+		key     []string
+	}
+	row       []any
+	tableRows struct {
+		table
+		rows []row
+	}
+
+	// batch holds the rows of a range of blocks.
+	batch struct {
+		blocks       []row
+		transactions []row
+		logs         []row
+		traces       []row
+	}
+)
+
+// The blocks are written last, since the last stored block tells where to resume.
+var (
+	transactionsTable = table{
+		name: "transactions",
+		columns: []string{"hash", "block_number", "block_hash", "transaction_index", "type", "from_address", "to_address",
+			"nonce", "value", "gas", "gas_price", "max_fee_per_gas", "max_priority_fee_per_gas", "input", "status",
+			"gas_used", "effective_gas_price", "contract_address"},
+		key: []string{"hash"},
+	}
+	logsTable = table{
+		name: "logs",
+		columns: []string{"block_number", "log_index", "block_hash", "transaction_hash", "transaction_index", "address",
+			"topic0", "topic1", "topic2", "topic3", "data"},
+		key: []string{"block_number", "log_index"},
+	}
+	tracesTable = table{
+		name: "traces",
+		columns: []string{"block_number", "transaction_index", "trace_address", "transaction_hash", "type",
+			"from_address", "to_address", "value", "gas", "gas_used", "input", "output", "error"},
+		key: []string{"block_number", "transaction_index", "trace_address"},
+	}
+	blocksTable = table{
+		name: "blocks",
+		columns: []string{"number", "hash", "parent_hash", "timestamp", "miner", "gas_limit", "gas_used",
+			"base_fee_per_gas", "transaction_count", "size", "extra_data"},
+		key: []string{"number"},
+	}
+)
+
+// tables returns the tables of the batch with their rows, in the order they are written.
+func (b *batch) tables() []tableRows {
+	return []tableRows{
+		{transactionsTable, b.transactions},
+		{logsTable, b.logs},
+		{tracesTable, b.traces},
+		{blocksTable, b.blocks},
+	}
+}
+
+func newSink(ctx context.Context) (sink, error) {
+	switch *params.Sink {
+	case "postgres":
+		return newPostgresSink(ctx, *params.DSN)
+	case "clickhouse":
+		return newClickHouseSink(ctx, *params.DSN)
+	default:
+		return nil, fmt.Errorf("unknown sink %s", *params.Sink)
+	}
+}
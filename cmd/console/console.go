@@ -0,0 +1,172 @@
+package console
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chzyer/readline"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/maticnetwork/polygon-cli/rpcclient"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+type cmdConsoleParams struct {
+	RpcUrl      *string
+	RPCClient   *rpcclient.Flags
+	HistoryFile *string
+	Exec        *[]string
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdConsoleParams
+)
+
+// ConsoleCmd represents the console command.
+var ConsoleCmd = &cobra.Command{
+	Use:   "console",
+	Short: "Explore a JSON-RPC endpoint from an interactive prompt.",
+	Long:  usage,
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return util.ValidateUrl(*params.RpcUrl)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return runConsole(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdConsoleParams)
+	flagSet := ConsoleCmd.Flags()
+
+	p.RpcUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8545", "The RPC endpoint url")
+	p.RPCClient = rpcclient.AddFlags(flagSet)
+	p.HistoryFile = flagSet.String("history-file", "", "The file keeping the history of the prompt across sessions (default is $HOME/.polygon-cli/console_history)")
+	p.Exec = flagSet.StringArrayP("exec", "e", nil, "Run the line instead of prompting, e.g. -e 'eth_blockNumber | dec'. It can be repeated")
+
+	params = *p
+}
+
+func runConsole(ctx context.Context) error {
+	rpc, err := params.RPCClient.Dial(ctx, *params.RpcUrl)
+	if err != nil {
+		return err
+	}
+	defer rpc.Close()
+
+	if len(*params.Exec) > 0 {
+		for _, line := range *params.Exec {
+			if err = evalLine(ctx, rpc, line, os.Stdout); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	historyFile, err := historyPath()
+	if err != nil {
+		return err
+	}
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:            "> ",
+		HistoryFile:       historyFile,
+		HistorySearchFold: true,
+		AutoComplete:      completer{},
+		InterruptPrompt:   "^C",
+		EOFPrompt:         "exit",
+	})
+	if err != nil {
+		return err
+	}
+	defer rl.Close()
+
+	fmt.Fprintf(rl.Stdout(), "Connected to %s, type help for the syntax and exit to leave.\n", *params.RpcUrl)
+	for {
+		line, err := rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		if line == "exit" || line == "quit" {
+			return nil
+		}
+		if err = evalLine(ctx, rpc, line, rl.Stdout()); err != nil {
+			fmt.Fprintln(rl.Stderr(), "Error:", err)
+		}
+	}
+}
+
+// historyPath returns the history file, creating its directory if needed.
+func historyPath() (string, error) {
+	path := *params.HistoryFile
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, ".polygon-cli", "console_history")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// evalLine runs the line and writes its results, one JSON value per line.
+func evalLine(ctx context.Context, rpc *ethrpc.Client, line string, w io.Writer) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return nil
+	}
+	if line == "help" {
+		_, err := fmt.Fprint(w, helpText)
+		return err
+	}
+	values, err := eval(ctx, rpc, line)
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		if err = writeValue(w, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const helpText = `A line calls a method with its arguments, and may pipe the result through filters:
+
+  eth_getBlockByNumber latest false | .transactions | length
+
+The arguments are JSON values, e.g. true, "text" or {"to": "0x..."}, and the
+words which aren't JSON are passed as strings, e.g. latest or 0x12ab. The
+decimal integers are sent as hex quantities, so eth_getBlockByNumber 100 false
+requests the block 0x64.
+
+Filters:
+  .field, .[2], .field[2], .[]  select a field, an element or every element
+  dec                           convert a hex quantity to decimal
+  hex                           convert a decimal number to a hex quantity
+  length                        the number of elements, fields or characters
+  keys                          the sorted fields of an object
+
+The dec and hex filters can also start a line, e.g. dec 0x1bc16d674ec80000.
+Tab completes the methods and filters, the history is kept across sessions,
+and exit or Ctrl-D leaves the console.
+`
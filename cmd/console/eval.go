@@ -0,0 +1,410 @@
+package console
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+type (
+	// filter maps a value to the values passed to the next filter.
+	filter func(v any) ([]any, error)
+	// pathStep is a step of a path expression: a field, an index, or every element when iterate is set.
+	pathStep struct {
+		field   string
+		index   int
+		isIndex bool
+		iterate bool
+	}
+)
+
+var filters = map[string]filter{
+	"dec":    decFilter,
+	"hex":    hexFilter,
+	"length": lengthFilter,
+	"keys":   keysFilter,
+}
+
+// eval runs the line, either a method call or a filter applied to its arguments, followed by the piped
+// filters, and returns the resulting values.
+func eval(ctx context.Context, rpc *ethrpc.Client, line string) ([]any, error) {
+	segments, err := split(line, func(r rune) bool { return r == '|' })
+	if err != nil {
+		return nil, err
+	}
+	words, err := split(segments[0], unicode.IsSpace)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("expected a method before the filters")
+	}
+
+	var values []any
+	if f, ok := filters[words[0]]; ok {
+		for _, w := range words[1:] {
+			out, err := f(parseArg(w, false))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, out...)
+		}
+	} else {
+		args := make([]any, len(words)-1)
+		for i, w := range words[1:] {
+			args[i] = parseArg(w, true)
+		}
+		var raw json.RawMessage
+		if err = rpc.CallContext(ctx, &raw, words[0], args...); err != nil {
+			return nil, err
+		}
+		v, err := decodeJSON(raw)
+		if err != nil {
+			return nil, err
+		}
+		values = []any{v}
+	}
+
+	for _, segment := range segments[1:] {
+		f, err := parseFilter(strings.TrimSpace(segment))
+		if err != nil {
+			return nil, err
+		}
+		var next []any
+		for _, v := range values {
+			out, err := f(v)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, out...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+// split splits the line at the separators which aren't in a JSON string, object or array, dropping the
+// empty parts.
+func split(line string, isSeparator func(rune) bool) ([]string, error) {
+	var parts []string
+	var depth int
+	var inString, escaped bool
+	start := 0
+	for i, r := range line {
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inString = false
+			}
+		case r == '"':
+			inString = true
+		case r == '{' || r == '[':
+			depth++
+		case r == '}' || r == ']':
+			depth--
+		case depth == 0 && isSeparator(r):
+			if part := strings.TrimSpace(line[start:i]); part != "" {
+				parts = append(parts, part)
+			}
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	if inString {
+		return nil, fmt.Errorf("unterminated string")
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced brackets")
+	}
+	if part := strings.TrimSpace(line[start:]); part != "" {
+		parts = append(parts, part)
+	}
+	if len(parts) == 0 {
+		parts = []string{""}
+	}
+	return parts, nil
+}
+
+// parseArg returns the JSON value of the word, or the word itself when it isn't JSON. The decimal integers
+// are converted to hex quantities when quantities is set.
+func parseArg(word string, quantities bool) any {
+	if quantities && isDecimal(word) {
+		n, _ := new(big.Int).SetString(word, 10)
+		return hexutil.EncodeBig(n)
+	}
+	v, err := decodeJSON([]byte(word))
+	if err != nil {
+		return word
+	}
+	return v
+}
+
+func isDecimal(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeJSON decodes a single JSON value, keeping the numbers exact.
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+// writeValue writes the value as indented JSON.
+func writeValue(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func parseFilter(expr string) (filter, error) {
+	if f, ok := filters[expr]; ok {
+		return f, nil
+	}
+	if !strings.HasPrefix(expr, ".") {
+		return nil, fmt.Errorf("unknown filter %q", expr)
+	}
+	steps, err := parsePath(expr)
+	if err != nil {
+		return nil, err
+	}
+	return func(v any) ([]any, error) {
+		return selectPath(v, steps)
+	}, nil
+}
+
+// parsePath parses the path expressions like .result.logs[0].topics or .[].hash.
+func parsePath(expr string) ([]pathStep, error) {
+	var steps []pathStep
+	for i := 1; i < len(expr); {
+		switch expr[i] {
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated index in %s", expr)
+			}
+			inner := expr[i+1 : i+end]
+			if inner == "" {
+				steps = append(steps, pathStep{iterate: true})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index %q in %s", inner, expr)
+				}
+				steps = append(steps, pathStep{index: index, isIndex: true})
+			}
+			i += end + 1
+		case '.':
+			if i+1 == len(expr) || expr[i+1] == '.' {
+				return nil, fmt.Errorf("expected a field after the dot in %s", expr)
+			}
+			i++
+		default:
+			end := i
+			for end < len(expr) && (expr[end] == '_' || unicode.IsLetter(rune(expr[end])) || unicode.IsDigit(rune(expr[end]))) {
+				end++
+			}
+			if end == i {
+				return nil, fmt.Errorf("unexpected %q in %s", expr[i], expr)
+			}
+			steps = append(steps, pathStep{field: expr[i:end]})
+			i = end
+		}
+	}
+	return steps, nil
+}
+
+// selectPath returns the values selected by the steps. Like jq, a missing field or index selects null.
+func selectPath(v any, steps []pathStep) ([]any, error) {
+	if len(steps) == 0 {
+		return []any{v}, nil
+	}
+	step := steps[0]
+	var selected []any
+	switch t := v.(type) {
+	case nil:
+		if step.iterate {
+			return nil, fmt.Errorf("cannot iterate over null")
+		}
+		selected = []any{nil}
+	case map[string]any:
+		switch {
+		case step.iterate:
+			keys := sortedKeys(t)
+			for _, k := range keys {
+				selected = append(selected, t[k])
+			}
+		case step.isIndex:
+			return nil, fmt.Errorf("cannot index an object with %d", step.index)
+		default:
+			selected = []any{t[step.field]}
+		}
+	case []any:
+		switch {
+		case step.iterate:
+			selected = t
+		case step.isIndex:
+			index := step.index
+			if index < 0 {
+				index += len(t)
+			}
+			if index < 0 || index >= len(t) {
+				selected = []any{nil}
+			} else {
+				selected = []any{t[index]}
+			}
+		default:
+			return nil, fmt.Errorf("cannot select the field %s of an array", step.field)
+		}
+	default:
+		return nil, fmt.Errorf("cannot select in %s", typeName(v))
+	}
+
+	var out []any
+	for _, s := range selected {
+		values, err := selectPath(s, steps[1:])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, values...)
+	}
+	return out, nil
+}
+
+// decFilter converts a hex quantity to a decimal number, and the elements of an array.
+func decFilter(v any) ([]any, error) {
+	switch t := v.(type) {
+	case json.Number:
+		return []any{t}, nil
+	case string:
+		if !strings.HasPrefix(t, "0x") && !strings.HasPrefix(t, "0X") {
+			return nil, fmt.Errorf("%q is not a hex number", t)
+		}
+		n, ok := new(big.Int).SetString(t[2:], 16)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a hex number", t)
+		}
+		return []any{json.Number(n.String())}, nil
+	case []any:
+		return mapArray(t, decFilter)
+	default:
+		return nil, fmt.Errorf("cannot convert %s to decimal", typeName(v))
+	}
+}
+
+// hexFilter converts a decimal number to a hex quantity, and the elements of an array.
+func hexFilter(v any) ([]any, error) {
+	switch t := v.(type) {
+	case json.Number, string:
+		s := fmt.Sprint(t)
+		if strings.HasPrefix(s, "0x") {
+			return []any{s}, nil
+		}
+		n, ok := new(big.Int).SetString(s, 10)
+		if !ok || n.Sign() < 0 {
+			return nil, fmt.Errorf("%q is not a positive integer", s)
+		}
+		return []any{hexutil.EncodeBig(n)}, nil
+	case []any:
+		return mapArray(t, hexFilter)
+	default:
+		return nil, fmt.Errorf("cannot convert %s to hex", typeName(v))
+	}
+}
+
+func lengthFilter(v any) ([]any, error) {
+	switch t := v.(type) {
+	case nil:
+		return []any{0}, nil
+	case string:
+		return []any{utf8.RuneCountInString(t)}, nil
+	case []any:
+		return []any{len(t)}, nil
+	case map[string]any:
+		return []any{len(t)}, nil
+	default:
+		return nil, fmt.Errorf("%s has no length", typeName(v))
+	}
+}
+
+func keysFilter(v any) ([]any, error) {
+	t, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s has no keys", typeName(v))
+	}
+	keys := []any{}
+	for _, k := range sortedKeys(t) {
+		keys = append(keys, k)
+	}
+	return []any{keys}, nil
+}
+
+// mapArray applies the filter to the elements, keeping them in an array.
+func mapArray(values []any, f filter) ([]any, error) {
+	out := make([]any, 0, len(values))
+	for _, v := range values {
+		converted, err := f(v)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, converted...)
+	}
+	return []any{out}, nil
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case json.Number:
+		return "a number"
+	case string:
+		return "a string"
+	case []any:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
@@ -0,0 +1,84 @@
+package console
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// testEth emulates a few methods of the eth namespace.
+type testEth struct{}
+
+func (testEth) BlockNumber() hexutil.Uint64 {
+	return 0x64
+}
+
+func (testEth) GetBlockByNumber(number string, full bool) map[string]any {
+	return map[string]any{
+		"number":       number,
+		"gasUsed":      "0x5208",
+		"transactions": []map[string]any{{"hash": "0x01", "value": "0xde0b6b3a7640000"}, {"hash": "0x02", "value": "0x0"}},
+	}
+}
+
+func TestEvalLine(t *testing.T) {
+	server := ethrpc.NewServer()
+	if err := server.RegisterName("eth", testEth{}); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	rpc, err := ethrpc.Dial(httpServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rpc.Close()
+
+	for _, tc := range []struct {
+		line, expected string
+	}{
+		{"eth_blockNumber | dec", "100\n"},
+		{"eth_getBlockByNumber 100 false | .number", "\"0x64\"\n"},
+		{"eth_getBlockByNumber latest false | .transactions[].value | dec", "1000000000000000000\n0\n"},
+		{"eth_getBlockByNumber latest false | .transactions[-1].hash", "\"0x02\"\n"},
+		{"eth_getBlockByNumber latest false | .transactions | length", "2\n"},
+		{"eth_getBlockByNumber latest false | .missing", "null\n"},
+		{"eth_getBlockByNumber latest false | keys", "[\n  \"gasUsed\",\n  \"number\",\n  \"transactions\"\n]\n"},
+		{"hex 21000 [1, 2]", "\"0x5208\"\n[\n  \"0x1\",\n  \"0x2\"\n]\n"},
+		{"dec 0x1bc16d674ec80000", "2000000000000000000\n"},
+	} {
+		var out bytes.Buffer
+		if err = evalLine(context.Background(), rpc, tc.line, &out); err != nil {
+			t.Fatalf("%s: %v", tc.line, err)
+		}
+		if out.String() != tc.expected {
+			t.Errorf("%s: got %q, expected %q", tc.line, out.String(), tc.expected)
+		}
+	}
+
+	for _, line := range []string{
+		"eth_blockNumber | .field",
+		"eth_blockNumber | unknown",
+		"eth_getBlockByNumber latest false | .transactions.hash",
+		"eth_getBlockByNumber {\"unterminated\" false",
+		"dec latest",
+	} {
+		if err = evalLine(context.Background(), rpc, line, &bytes.Buffer{}); err == nil {
+			t.Errorf("%s: expected an error", line)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	words, err := split(`eth_call {"to": "0x01", "data": "a b|c"} latest`, func(r rune) bool { return r == ' ' })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(words) != 3 || words[1] != `{"to": "0x01", "data": "a b|c"}` {
+		t.Fatalf("unexpected words %q", words)
+	}
+}
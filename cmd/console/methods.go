@@ -0,0 +1,105 @@
+package console
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// methods are the JSON-RPC methods completed by the prompt. Any other method can still be called.
+var methods = []string{
+	"admin_nodeInfo",
+	"admin_peers",
+	"debug_getBadBlocks",
+	"debug_getRawBlock",
+	"debug_getRawHeader",
+	"debug_getRawReceipts",
+	"debug_getRawTransaction",
+	"debug_traceBlockByHash",
+	"debug_traceBlockByNumber",
+	"debug_traceCall",
+	"debug_traceTransaction",
+	"eth_accounts",
+	"eth_blobBaseFee",
+	"eth_blockNumber",
+	"eth_call",
+	"eth_chainId",
+	"eth_coinbase",
+	"eth_createAccessList",
+	"eth_estimateGas",
+	"eth_feeHistory",
+	"eth_gasPrice",
+	"eth_getBalance",
+	"eth_getBlockByHash",
+	"eth_getBlockByNumber",
+	"eth_getBlockReceipts",
+	"eth_getBlockTransactionCountByHash",
+	"eth_getBlockTransactionCountByNumber",
+	"eth_getCode",
+	"eth_getFilterChanges",
+	"eth_getFilterLogs",
+	"eth_getLogs",
+	"eth_getProof",
+	"eth_getStorageAt",
+	"eth_getTransactionByBlockHashAndIndex",
+	"eth_getTransactionByBlockNumberAndIndex",
+	"eth_getTransactionByHash",
+	"eth_getTransactionCount",
+	"eth_getTransactionReceipt",
+	"eth_getUncleByBlockHashAndIndex",
+	"eth_getUncleByBlockNumberAndIndex",
+	"eth_getUncleCountByBlockHash",
+	"eth_getUncleCountByBlockNumber",
+	"eth_maxPriorityFeePerGas",
+	"eth_newBlockFilter",
+	"eth_newFilter",
+	"eth_newPendingTransactionFilter",
+	"eth_sendRawTransaction",
+	"eth_syncing",
+	"eth_uninstallFilter",
+	"net_listening",
+	"net_peerCount",
+	"net_version",
+	"rpc_modules",
+	"txpool_content",
+	"txpool_inspect",
+	"txpool_status",
+	"web3_clientVersion",
+	"web3_sha3",
+}
+
+// completer completes the method or the filter being typed: the methods and the filters starting a line,
+// and the filters after a pipe.
+type completer struct{}
+
+func (completer) Do(line []rune, pos int) ([][]rune, int) {
+	before := string(line[:pos])
+	segment := before
+	first := true
+	if i := strings.LastIndexByte(before, '|'); i >= 0 {
+		segment, first = before[i+1:], false
+	}
+	word := strings.TrimLeftFunc(segment, unicode.IsSpace)
+	// Only the first word of the segment is completed.
+	if strings.ContainsFunc(word, unicode.IsSpace) {
+		return nil, 0
+	}
+
+	candidates := make([]string, 0, len(filters)+len(methods))
+	for name := range filters {
+		candidates = append(candidates, name)
+	}
+	sort.Strings(candidates)
+	if first {
+		candidates = append(candidates, methods...)
+		candidates = append(candidates, "help", "exit")
+	}
+
+	var suffixes [][]rune
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) {
+			suffixes = append(suffixes, []rune(c[len(word):]+" "))
+		}
+	}
+	return suffixes, len([]rune(word))
+}
@@ -0,0 +1,90 @@
+package contract
+
+import (
+	_ "embed"
+	"os"
+
+	"github.com/maticnetwork/polygon-cli/rpcclient"
+	"github.com/maticnetwork/polygon-cli/signer"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// The default private key used to send transactions.
+const defaultPrivateKey = "0x42b6e34dc21598a807dc19d7784c71b2a7a01f6480dc6f58258f78e539f1a1fa"
+
+type cmdDeployParams struct {
+	RpcUrl     *string
+	RPCClient  *rpcclient.Flags
+	PrivateKey *string
+	Signer     *signer.Flags
+
+	ABIFile *string
+}
+
+var (
+	//go:embed usage.md
+	usage string
+	//go:embed listCmdUsage.md
+	listCmdUsage string
+	//go:embed deployCmdUsage.md
+	deployCmdUsage string
+
+	params cmdDeployParams
+)
+
+// ContractCmd represents the contract command.
+var ContractCmd = &cobra.Command{
+	Use:   "contract",
+	Short: "Deploy the embedded test contracts, to have known targets for load tests and fuzzing.",
+	Long:  usage,
+	Args:  cobra.NoArgs,
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the embedded test contracts and their functions.",
+	Long:  listCmdUsage,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := listTestContracts()
+		if err != nil {
+			return err
+		}
+		return util.NewOutputWriter(os.Stdout).Write(list)
+	},
+}
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy <contract>",
+	Short: "Deploy an embedded test contract and print its address and ABI.",
+	Long:  deployCmdUsage,
+	Args:  cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return util.ValidateUrl(*params.RpcUrl)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := findTestContract(args[0])
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
+		return deploy(cmd.Context(), c)
+	},
+}
+
+func init() {
+	p := new(cmdDeployParams)
+	flagSet := deployCmd.Flags()
+
+	p.RpcUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8545", "The RPC endpoint url")
+	p.RPCClient = rpcclient.AddFlags(flagSet)
+	p.PrivateKey = flagSet.String("private-key", defaultPrivateKey, "The hex encoded private key that we'll use to send the deployment transaction")
+	p.Signer = signer.AddFlags(flagSet)
+	p.ABIFile = flagSet.String("abi-file", "", "Also write the JSON ABI of the contract to this file, e.g. for loadtest --abi-file")
+
+	params = *p
+
+	ContractCmd.AddCommand(listCmd)
+	ContractCmd.AddCommand(deployCmd)
+}
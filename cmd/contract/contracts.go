@@ -0,0 +1,101 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/bindings/tester"
+	"github.com/maticnetwork/polygon-cli/bindings/tokens"
+)
+
+type (
+	// testContract is a pre-compiled contract embedded through its bindings,
+	// deployed with the constructor arguments.
+	testContract struct {
+		name        string
+		description string
+		metaData    *bind.MetaData
+		args        []any
+	}
+
+	// TestContract describes a test contract and the signatures of its
+	// functions.
+	TestContract struct {
+		Name        string
+		Description string
+		Functions   []string
+	}
+	TestContracts []TestContract
+)
+
+var testContracts = []testContract{
+	{"erc20", "ERC20 token minting 1,000,000 MTK to the deployer, and mint(amount) to the caller", tokens.ERC20MetaData, nil},
+	{"erc721", "ERC721 token minting 100 MNFT to the deployer, and mintBatch(to, amount)", tokens.ERC721MetaData, nil},
+	{"storage-stress", "fill(count) writes count new storage slots, slots() returns the number written", tester.StorageStressMetaData, nil},
+	{"compute-stress", "hash(rounds) chains the number of rounds of keccak256", tester.ComputeStressMetaData, nil},
+	{"reverter", "Every call reverts with an error message, fail() included", tester.ReverterMetaData, nil},
+	// The name is the one expected by rpcfuzz.
+	{"conformance", "The contract whose state rpcfuzz validates with --contract-address", tester.ConformanceTesterMetaData, []any{"ConformanceTesterContractName"}},
+}
+
+func findTestContract(name string) (*testContract, error) {
+	names := make([]string, len(testContracts))
+	for i := range testContracts {
+		if testContracts[i].name == name {
+			return &testContracts[i], nil
+		}
+		names[i] = testContracts[i].name
+	}
+	return nil, fmt.Errorf("unknown contract %s, the contracts are: %s", name, strings.Join(names, ", "))
+}
+
+// compactABI returns the ABI of the contract on a single line.
+func (c *testContract) compactABI() (json.RawMessage, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(c.metaData.ABI)); err != nil {
+		return nil, err
+	}
+	return compact.Bytes(), nil
+}
+
+func listTestContracts() (TestContracts, error) {
+	list := make(TestContracts, 0, len(testContracts))
+	for _, c := range testContracts {
+		parsed, err := c.metaData.GetAbi()
+		if err != nil {
+			return nil, fmt.Errorf("invalid ABI of %s: %w", c.name, err)
+		}
+		list = append(list, TestContract{Name: c.name, Description: c.description, Functions: functionSignatures(parsed)})
+	}
+	return list, nil
+}
+
+// functionSignatures returns the signatures of the functions of the ABI,
+// sorted by name.
+func functionSignatures(parsed *abi.ABI) []string {
+	signatures := make([]string, 0, len(parsed.Methods))
+	for _, m := range parsed.Methods {
+		signatures = append(signatures, m.Sig)
+	}
+	sort.Strings(signatures)
+	return signatures
+}
+
+// WriteText renders the contracts as a table.
+func (l TestContracts) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Name", "Description", "Functions"})
+	for _, c := range l {
+		t.AppendRow(table.Row{c.Name, c.Description, strings.Join(c.Functions, "\n")})
+	}
+	t.Render()
+	return nil
+}
@@ -0,0 +1,101 @@
+package contract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/signer"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+// DeployedContract is the result of a deployment.
+type DeployedContract struct {
+	Name        string
+	Address     common.Address
+	TxHash      common.Hash
+	BlockNumber uint64
+	GasUsed     uint64
+	ABI         json.RawMessage
+}
+
+func deploy(ctx context.Context, c *testContract) error {
+	rpc, err := params.RPCClient.Dial(ctx, *params.RpcUrl)
+	if err != nil {
+		return err
+	}
+	client := ethclient.NewClient(rpc)
+	defer client.Close()
+
+	s, err := params.Signer.New(ctx, *params.PrivateKey)
+	if err != nil {
+		return err
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	tops, err := signer.TransactOpts(ctx, s, chainID)
+	if err != nil {
+		return err
+	}
+	parsed, err := c.metaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	compact, err := c.compactABI()
+	if err != nil {
+		return err
+	}
+
+	address, tx, _, err := bind.DeployContract(tops, *parsed, common.FromHex(c.metaData.Bin), client, c.args...)
+	if err != nil {
+		return fmt.Errorf("unable to deploy %s: %w", c.name, err)
+	}
+	log.Info().Str("contract", c.name).Stringer("txHash", tx.Hash()).Msg("Waiting for the deployment to be mined")
+	receipt, err := bind.WaitMined(ctx, client, tx)
+	if err != nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("the deployment of %s failed in transaction %s", c.name, tx.Hash())
+	}
+
+	if *params.ABIFile != "" {
+		if err = os.WriteFile(*params.ABIFile, []byte(c.metaData.ABI), 0644); err != nil {
+			return err
+		}
+	}
+	return util.NewOutputWriter(os.Stdout).Write(DeployedContract{
+		Name:        c.name,
+		Address:     address,
+		TxHash:      tx.Hash(),
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		GasUsed:     receipt.GasUsed,
+		ABI:         compact,
+	})
+}
+
+// WriteText renders the deployment as a table, followed by the ABI.
+func (d DeployedContract) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendRows([]table.Row{
+		{"Contract", d.Name},
+		{"Address", d.Address},
+		{"Transaction", d.TxHash},
+		{"Block", d.BlockNumber},
+		{"Gas Used", d.GasUsed},
+	})
+	t.Render()
+	_, err := fmt.Fprintf(w, "ABI: %s\n", d.ABI)
+	return err
+}
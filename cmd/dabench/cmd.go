@@ -0,0 +1,118 @@
+package dabench
+
+import (
+	"time"
+
+	_ "embed"
+
+	"github.com/maticnetwork/polygon-cli/signer"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// The default private key used to send the blob transactions.
+const defaultPrivateKey = "0x42b6e34dc21598a807dc19d7784c71b2a7a01f6480dc6f58258f78e539f1a1fa"
+
+const (
+	backendAvail   = "avail"
+	backendJSONRPC = "jsonrpc"
+	backendBlob    = "blob"
+)
+
+type cmdDABenchParams struct {
+	RPCUrl       *string
+	Backend      *string
+	PayloadSizes *[]int
+	Count        *uint64
+	Rate         *float64
+	Concurrency  *uint64
+	Seed         *int64
+	Timeout      *time.Duration
+
+	// Generic JSON-RPC parameters.
+	Method       *string
+	MethodParams *string
+
+	// Blob transaction parameters.
+	PrivateKey *string
+	Signer     *signer.Flags
+	BlobFeeCap *uint64
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdDABenchParams
+)
+
+// DABenchCmd represents the dabench command.
+var DABenchCmd = &cobra.Command{
+	Use:     "dabench",
+	Aliases: []string{"da"},
+	Short:   "Benchmark the submission of payloads to a data availability layer.",
+	Long:    usage,
+	Args:    cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return runDABench(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdDABenchParams)
+	flagSet := DABenchCmd.Flags()
+
+	p.RPCUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8545", "The url of the endpoint the payloads are submitted to")
+	p.Backend = flagSet.StringP("backend", "b", backendBlob, "How the payloads are submitted: avail (Avail light client API), jsonrpc (any JSON-RPC method) or blob (EIP-4844 transactions)")
+	p.PayloadSizes = flagSet.IntSlice("payload-sizes", []int{1024, 32768, maxBlobPayload}, "The sizes of the payloads in bytes, each one benchmarked in its own phase")
+	p.Count = flagSet.Uint64P("count", "n", 20, "The number of payloads submitted for each size")
+	p.Rate = flagSet.Float64("rate", 1, "The target number of submissions per second. Give a number less than or equal to zero to remove the limit")
+	p.Concurrency = flagSet.Uint64P("concurrency", "c", 1, "The number of submissions in flight at once")
+	p.Seed = flagSet.Int64("seed", 123456, "The seed of the random payloads")
+	p.Timeout = flagSet.Duration("inclusion-timeout", 2*time.Minute, "How long to wait for the inclusion of a submission before counting it as failed")
+
+	p.Method = flagSet.String("method", "", "With --backend jsonrpc, the method submitting a payload")
+	p.MethodParams = flagSet.String("method-params", `["{{payload}}"]`, `With --backend jsonrpc, the JSON params of the method where "{{payload}}" is replaced by the hex encoded payload and "{{payloadBase64}}" by the base64 encoded payload`)
+
+	p.PrivateKey = flagSet.String("private-key", defaultPrivateKey, "With --backend blob, the hex encoded private key sending the blob transactions")
+	p.Signer = signer.AddFlags(flagSet)
+	p.BlobFeeCap = flagSet.Uint64("blob-fee-cap", 100, "With --backend blob, the maximum blob fee per blob gas in Gwei")
+
+	params = *p
+}
+
+func checkFlags() error {
+	if err := util.ValidateUrl(*params.RPCUrl); err != nil {
+		return err
+	}
+	switch *params.Backend {
+	case backendAvail, backendBlob:
+	case backendJSONRPC:
+		if *params.Method == "" {
+			return util.Errorf(util.ValidationError, "the jsonrpc backend needs the --method submitting the payloads")
+		}
+	default:
+		return util.Errorf(util.ValidationError, "unknown backend %q, expected avail, jsonrpc or blob", *params.Backend)
+	}
+	if len(*params.PayloadSizes) == 0 {
+		return util.Errorf(util.ValidationError, "expected at least one payload size")
+	}
+	for _, size := range *params.PayloadSizes {
+		if size <= 0 {
+			return util.Errorf(util.ValidationError, "the payload sizes must be positive. Given: %d", size)
+		}
+		if *params.Backend == backendBlob && size > maxBlobPayload*maxBlobsPerTx {
+			return util.Errorf(util.ValidationError, "a blob transaction carries at most %d bytes. Given: %d", maxBlobPayload*maxBlobsPerTx, size)
+		}
+	}
+	if *params.Count == 0 {
+		return util.Errorf(util.ValidationError, "the number of submissions is set to zero")
+	}
+	if *params.Concurrency == 0 {
+		return util.Errorf(util.ValidationError, "the concurrency is set to zero")
+	}
+	return nil
+}
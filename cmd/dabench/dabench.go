@@ -0,0 +1,196 @@
+package dabench
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/cmd/dbbench"
+	"github.com/maticnetwork/polygon-cli/progress"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
+)
+
+type (
+	// Result is the result of the submissions of a payload size. The op count and rate of the test result
+	// are the ones of the accepted submissions.
+	Result struct {
+		dbbench.TestResult
+		PayloadSize int
+		Failures    uint64
+		// Bytes is the number of bytes accepted and ByteRate their rate over the test duration.
+		Bytes    uint64
+		ByteRate float64
+		// AcceptLatency is the time for the endpoint to accept each submission.
+		AcceptLatency *dbbench.LatencySummary `json:",omitempty"`
+		// Included is the number of submissions observed in a block, and InclusionLatency the time from
+		// their submission, with the backends following the submissions.
+		Included         uint64
+		InclusionLatency *dbbench.LatencySummary `json:",omitempty"`
+		// Cost is the cost of the included submissions in wei, with the backends reporting it, and
+		// CostPerByte the cost of a byte of their payloads.
+		Cost        *big.Int `json:",omitempty"`
+		CostPerByte float64  `json:",omitempty"`
+	}
+	Results []Result
+)
+
+// phases numbers the progress reports of the payload sizes.
+var phases *progress.Phases
+
+func runDABench(ctx context.Context) error {
+	sub, err := newSubmitter(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to set up the submissions")
+		return err
+	}
+	log.Info().Str("backend", *params.Backend).Str("url", *params.RPCUrl).Msg("Starting data availability benchmark")
+
+	rng := rand.New(rand.NewSource(*params.Seed))
+	phases = progress.NewPhases("dabench", len(*params.PayloadSizes))
+	results := make(Results, 0, len(*params.PayloadSizes))
+	for _, size := range *params.PayloadSizes {
+		results = append(results, runPhase(ctx, sub, size, rng))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return util.NewOutputWriter(os.Stdout).Write(results)
+}
+
+// runPhase submits --count random payloads of the size at the target rate, then waits for their inclusion.
+func runPhase(ctx context.Context, sub submitter, size int, rng *rand.Rand) Result {
+	var limiter *rate.Limiter
+	if *params.Rate > 0 {
+		limiter = rate.NewLimiter(rate.Limit(*params.Rate), 1)
+	}
+	bar := phases.Next(fmt.Sprintf("%d bytes", size), int64(*params.Count), "sub")
+
+	var (
+		mu                 sync.Mutex
+		accept, inclusion  []time.Duration
+		failures, included uint64
+		cost               *big.Int
+		end                time.Time
+	)
+	fail := func(err error, msg string) {
+		log.Error().Err(err).Int("size", size).Msg(msg)
+		mu.Lock()
+		failures++
+		mu.Unlock()
+	}
+
+	sem := make(chan struct{}, *params.Concurrency)
+	var submitting, waiting sync.WaitGroup
+	start := time.Now()
+	for i := uint64(0); i < *params.Count; i++ {
+		payload := make([]byte, size)
+		rng.Read(payload)
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				break
+			}
+		}
+		sem <- struct{}{}
+		submitting.Add(1)
+		go func() {
+			defer submitting.Done()
+			s, err := sub.Submit(ctx, payload)
+			acceptedAt := time.Now()
+			<-sem
+			bar.Add(1)
+			if err != nil {
+				fail(err, "Unable to submit the payload")
+				return
+			}
+			log.Debug().Str("id", s.ID).Dur("latency", acceptedAt.Sub(s.SentAt)).Msg("Submission accepted")
+			mu.Lock()
+			accept = append(accept, acceptedAt.Sub(s.SentAt))
+			if acceptedAt.After(end) {
+				end = acceptedAt
+			}
+			mu.Unlock()
+
+			// The inclusions are awaited outside of the concurrency limit, which bounds the submissions.
+			waiting.Add(1)
+			go func() {
+				defer waiting.Done()
+				waitCtx, cancel := context.WithTimeout(ctx, *params.Timeout)
+				defer cancel()
+				followed, err := sub.Wait(waitCtx, s)
+				if !followed {
+					return
+				}
+				if err != nil {
+					fail(err, "The submission wasn't included")
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				included++
+				inclusion = append(inclusion, time.Since(s.SentAt))
+				if s.Cost != nil {
+					if cost == nil {
+						cost = new(big.Int)
+					}
+					cost.Add(cost, s.Cost)
+				}
+			}()
+		}()
+	}
+	submitting.Wait()
+	bar.Finish()
+	if end.IsZero() {
+		end = time.Now()
+	}
+	waiting.Wait()
+
+	accepted := uint64(len(accept))
+	r := Result{
+		TestResult:       *dbbench.NewTestResult(start, end, fmt.Sprintf("submit %d bytes", size), accepted),
+		PayloadSize:      size,
+		Failures:         failures,
+		Bytes:            accepted * uint64(size),
+		AcceptLatency:    dbbench.NewLatencySummary(accept),
+		Included:         included,
+		InclusionLatency: dbbench.NewLatencySummary(inclusion),
+		Cost:             cost,
+	}
+	r.ByteRate = float64(r.Bytes) / r.TestDuration.Seconds()
+	if cost != nil && included > 0 {
+		r.CostPerByte, _ = new(big.Float).Quo(new(big.Float).SetInt(cost), big.NewFloat(float64(included*uint64(size)))).Float64()
+	}
+	return r
+}
+
+// WriteText renders the results as a table.
+func (rs Results) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Description", "Start Time", "Duration", "Op Count", "Op Rate", "Throughput", "Failures", "Accept p50/p99", "Included", "Inclusion p50/p99", "Cost"})
+	for _, r := range rs {
+		acceptLatency, inclusionLatency, cost := "", "", ""
+		if l := r.AcceptLatency; l != nil {
+			acceptLatency = fmt.Sprintf("%s / %s", l.P50, l.P99)
+		}
+		if l := r.InclusionLatency; l != nil {
+			inclusionLatency = fmt.Sprintf("%s / %s", l.P50, l.P99)
+		}
+		if r.Cost != nil {
+			cost = fmt.Sprintf("%s wei (%.2f wei/B)", r.Cost, r.CostPerByte)
+		}
+		t.AppendRow(table.Row{
+			r.Description, r.StartTime.Format(time.RFC3339), r.TestDuration, r.OpCount, fmt.Sprintf("%.2f", r.OpRate),
+			fmt.Sprintf("%.2f KiB/s", r.ByteRate/1024), r.Failures, acceptLatency, r.Included, inclusionLatency, cost,
+		})
+	}
+	t.Render()
+	return nil
+}
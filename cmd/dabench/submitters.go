@@ -0,0 +1,275 @@
+package dabench
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/ethclient"
+	ethparams "github.com/ethereum/go-ethereum/params"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+	"github.com/maticnetwork/polygon-cli/signer"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// maxBlobPayload is the number of bytes carried by a blob, 31 bytes per field element so each element
+	// stays below the BLS modulus.
+	maxBlobPayload = ethparams.BlobTxFieldElementsPerBlob * (ethparams.BlobTxBytesPerFieldElement - 1)
+	// maxBlobsPerTx is the number of blobs filling a block.
+	maxBlobsPerTx = ethparams.MaxBlobGasPerBlock / ethparams.BlobTxBlobGasPerBlob
+
+	receiptPollInterval = time.Second
+)
+
+type (
+	// submitter submits the payloads to a data availability layer.
+	submitter interface {
+		// Submit sends the payload and returns once the endpoint accepted it.
+		Submit(ctx context.Context, payload []byte) (*submission, error)
+		// Wait blocks until the submission is included, and sets its cost when the backend reports it. It
+		// returns false when the backend can't follow the submissions once accepted.
+		Wait(ctx context.Context, s *submission) (bool, error)
+	}
+	submission struct {
+		ID string
+		// SentAt is when the payload was sent, once it was encoded and signed.
+		SentAt time.Time
+		Cost   *big.Int
+		hash   ethcommon.Hash
+	}
+)
+
+func newSubmitter(ctx context.Context) (submitter, error) {
+	switch *params.Backend {
+	case backendAvail:
+		return &availSubmitter{url: strings.TrimSuffix(*params.RPCUrl, "/") + "/v2/submit", client: &http.Client{Timeout: *params.Timeout}}, nil
+	case backendJSONRPC:
+		c, err := ethrpc.DialContext(ctx, *params.RPCUrl)
+		if err != nil {
+			return nil, util.NewNetworkError(err)
+		}
+		return &jsonRPCSubmitter{c: c, method: *params.Method, params: *params.MethodParams}, nil
+	default:
+		return newBlobSubmitter(ctx)
+	}
+}
+
+// availSubmitter posts the payloads to the submit endpoint of an Avail light client, which answers once the
+// data submission is included in a block.
+type availSubmitter struct {
+	url    string
+	client *http.Client
+}
+
+func (a *availSubmitter) Submit(ctx context.Context, payload []byte) (*submission, error) {
+	body, err := json.Marshal(map[string]string{"data": base64.StdEncoding.EncodeToString(payload)})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	s := &submission{SentAt: time.Now()}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the light client returned %s", resp.Status)
+	}
+	var result struct {
+		BlockNumber uint64 `json:"block_number"`
+		Hash        string `json:"hash"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	s.ID = fmt.Sprintf("%s@%d", result.Hash, result.BlockNumber)
+	return s, nil
+}
+
+func (a *availSubmitter) Wait(ctx context.Context, s *submission) (bool, error) {
+	return true, nil
+}
+
+// jsonRPCSubmitter calls a JSON-RPC method with the payload, which can't be followed once accepted.
+type jsonRPCSubmitter struct {
+	c      *ethrpc.Client
+	method string
+	params string
+}
+
+func (j *jsonRPCSubmitter) Submit(ctx context.Context, payload []byte) (*submission, error) {
+	replacer := strings.NewReplacer("{{payload}}", hexutil.Encode(payload), "{{payloadBase64}}", base64.StdEncoding.EncodeToString(payload))
+	var args []any
+	if err := json.Unmarshal([]byte(replacer.Replace(j.params)), &args); err != nil {
+		return nil, fmt.Errorf("the method params must be a JSON array: %w", err)
+	}
+
+	s := &submission{SentAt: time.Now()}
+	var result json.RawMessage
+	if err := j.c.CallContext(ctx, &result, j.method, args...); err != nil {
+		return nil, err
+	}
+	s.ID = string(result)
+	return s, nil
+}
+
+func (j *jsonRPCSubmitter) Wait(ctx context.Context, s *submission) (bool, error) {
+	return false, nil
+}
+
+// blobSubmitter sends the payloads in the blobs of EIP-4844 transactions to the sender itself. The
+// transactions are sent in the order of their nonce since the blob pools reject nonce gaps, so the signing
+// and the sending are serialized by mu whatever the --concurrency. The encoding of the blobs, their proofs
+// and the wait for the inclusions still run concurrently.
+type blobSubmitter struct {
+	c          *ethclient.Client
+	s          signer.Signer
+	chainID    *big.Int
+	blobFeeCap *uint256.Int
+
+	mu    sync.Mutex
+	nonce uint64
+}
+
+func newBlobSubmitter(ctx context.Context) (*blobSubmitter, error) {
+	c, err := ethclient.DialContext(ctx, *params.RPCUrl)
+	if err != nil {
+		return nil, util.NewNetworkError(err)
+	}
+	s, err := params.Signer.New(ctx, *params.PrivateKey)
+	if err != nil {
+		return nil, util.NewError(util.AuthError, err)
+	}
+	b := &blobSubmitter{c: c, s: s}
+	if b.chainID, err = c.ChainID(ctx); err != nil {
+		return nil, util.NewNetworkError(err)
+	}
+	if b.nonce, err = c.PendingNonceAt(ctx, s.Address()); err != nil {
+		return nil, util.NewNetworkError(err)
+	}
+	b.blobFeeCap = new(uint256.Int).Mul(uint256.NewInt(*params.BlobFeeCap), uint256.NewInt(ethparams.GWei))
+	return b, nil
+}
+
+func (b *blobSubmitter) Submit(ctx context.Context, payload []byte) (*submission, error) {
+	sidecar, hashes, err := encodeBlobs(payload)
+	if err != nil {
+		return nil, err
+	}
+	head, err := b.c.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if head.BaseFee == nil {
+		return nil, errors.New("the chain doesn't support EIP-1559 fees")
+	}
+	tip, err := b.c.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	feeCap := new(big.Int).Add(new(big.Int).Mul(head.BaseFee, big.NewInt(2)), tip)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	to := b.s.Address()
+	tx := types.NewTx(&types.BlobTx{
+		ChainID:    uint256.MustFromBig(b.chainID),
+		Nonce:      b.nonce,
+		GasTipCap:  uint256.MustFromBig(tip),
+		GasFeeCap:  uint256.MustFromBig(feeCap),
+		Gas:        ethparams.TxGas,
+		To:         to,
+		Value:      new(uint256.Int),
+		BlobFeeCap: b.blobFeeCap,
+		BlobHashes: hashes,
+		Sidecar:    sidecar,
+	})
+	stx, err := b.s.SignTx(ctx, tx, types.LatestSignerForChainID(b.chainID))
+	if err != nil {
+		return nil, err
+	}
+	s := &submission{ID: stx.Hash().Hex(), SentAt: time.Now(), hash: stx.Hash()}
+	if err = b.c.SendTransaction(ctx, stx); err != nil {
+		// The transaction may have reached the pool anyway, e.g. after a timeout, so the next nonce is
+		// read again instead of reused.
+		if nonce, nErr := b.c.PendingNonceAt(ctx, to); nErr == nil {
+			b.nonce = nonce
+		} else {
+			log.Warn().Err(nErr).Msg("Unable to read the nonce again after the send failure")
+		}
+		return nil, err
+	}
+	b.nonce++
+	return s, nil
+}
+
+func (b *blobSubmitter) Wait(ctx context.Context, s *submission) (bool, error) {
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		receipt, err := b.c.TransactionReceipt(ctx, s.hash)
+		if err == nil {
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return true, fmt.Errorf("the transaction %s reverted", s.hash)
+			}
+			s.Cost = new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+			if receipt.BlobGasPrice != nil {
+				s.Cost.Add(s.Cost, new(big.Int).Mul(new(big.Int).SetUint64(receipt.BlobGasUsed), receipt.BlobGasPrice))
+			}
+			return true, nil
+		}
+		select {
+		case <-ctx.Done():
+			return true, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// encodeBlobs spreads the payload over as many blobs as needed.
+func encodeBlobs(payload []byte) (*types.BlobTxSidecar, []ethcommon.Hash, error) {
+	sidecar := new(types.BlobTxSidecar)
+	var hashes []ethcommon.Hash
+	for start := 0; start < len(payload); start += maxBlobPayload {
+		var blob kzg4844.Blob
+		chunk := payload[start:min(start+maxBlobPayload, len(payload))]
+		for i := 0; i*31 < len(chunk); i++ {
+			copy(blob[i*32+1:(i+1)*32], chunk[i*31:])
+		}
+		commitment, err := kzg4844.BlobToCommitment(blob)
+		if err != nil {
+			return nil, nil, err
+		}
+		proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+		if err != nil {
+			return nil, nil, err
+		}
+		sidecar.Blobs = append(sidecar.Blobs, blob)
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+		hashes = append(hashes, kzg4844.CalcBlobHashV1(sha256.New(), &commitment))
+	}
+	return sidecar, hashes, nil
+}
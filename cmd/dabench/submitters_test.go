@@ -0,0 +1,66 @@
+package dabench
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEncodeBlobs(t *testing.T) {
+	payload := make([]byte, maxBlobPayload+100)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+	sidecar, hashes, err := encodeBlobs(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sidecar.Blobs) != 2 || len(hashes) != 2 {
+		t.Fatalf("expected 2 blobs, got %d", len(sidecar.Blobs))
+	}
+
+	var decoded []byte
+	for _, blob := range sidecar.Blobs {
+		for i := 0; i < len(blob); i += 32 {
+			if blob[i] != 0 {
+				t.Fatalf("the field element %d exceeds the modulus", i/32)
+			}
+			decoded = append(decoded, blob[i+1:i+32]...)
+		}
+	}
+	if !bytes.Equal(decoded[:len(payload)], payload) {
+		t.Fatal("the blobs don't hold the payload")
+	}
+}
+
+func TestAvailSubmit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Data string }
+		if r.URL.Path != "/v2/submit" || json.NewDecoder(r.Body).Decode(&body) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if data, _ := base64.StdEncoding.DecodeString(body.Data); string(data) != "payload" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"block_number":12,"block_hash":"0x01","hash":"0x02","index":0}`))
+	}))
+	defer server.Close()
+
+	a := &availSubmitter{url: server.URL + "/v2/submit", client: server.Client()}
+	s, err := a.Submit(context.Background(), []byte("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ID != "0x02@12" {
+		t.Fatalf("unexpected submission %s", s.ID)
+	}
+	if _, err = a.Submit(context.Background(), []byte("other")); err == nil {
+		t.Fatal("expected the rejected submission to fail")
+	}
+}
@@ -0,0 +1,76 @@
+package dbbench
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
+)
+
+// backgroundWritePass distinguishes the background writes from the written entries and the range scan keys.
+const backgroundWritePass = 1 << 33
+
+type BackgroundWriteResult struct {
+	// TargetRate is the --background-writes-rate, which isn't reached when the database can't keep up.
+	TargetRate float64
+	OpCount    uint64
+	OpRate     float64
+}
+
+// backgroundWriter writes new entries at a steady rate during the read phases. The entries follow the ones
+// of the write phases, so the database keeps growing and compacting like a node ingesting blocks.
+type backgroundWriter struct {
+	db   KeyValueDB
+	rate float64
+	next uint64
+}
+
+func newBackgroundWriter(db KeyValueDB, writesPerSecond float64) *backgroundWriter {
+	return &backgroundWriter{db: db, rate: writesPerSecond, next: *writeLimit}
+}
+
+// start writes entries until the returned function is called, which waits for the last write and returns
+// the result of the phase, or the error which stopped the writes. It does nothing without a rate.
+func (b *backgroundWriter) start(ctx context.Context) func() (*BackgroundWriteResult, error) {
+	if b.rate <= 0 {
+		return func() (*BackgroundWriteResult, error) { return nil, nil }
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	limiter := rate.NewLimiter(rate.Limit(b.rate), 1)
+	first := b.next
+	start := time.Now()
+	var wg sync.WaitGroup
+	var err error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for limiter.Wait(ctx) == nil {
+			r := newEntryRand(*seed, backgroundWritePass, b.next)
+			k, v := makeKV(r, b.next, sizeDistribution.GetSizeSample(r), *sequentialWrites)
+			if err = b.db.Put(k, v); err != nil {
+				err = fmt.Errorf("unable to put value in the background: %w", err)
+				return
+			}
+			b.next += 1
+		}
+	}()
+
+	return func() (*BackgroundWriteResult, error) {
+		cancel()
+		wg.Wait()
+		if err != nil {
+			return nil, err
+		}
+		opCount := b.next - first
+		res := &BackgroundWriteResult{
+			TargetRate: b.rate,
+			OpCount:    opCount,
+			OpRate:     float64(opCount) / time.Since(start).Seconds(),
+		}
+		log.Info().Uint64("opCount", res.OpCount).Float64("opRate", res.OpRate).Msg("Background writes")
+		return res, nil
+	}
+}
@@ -0,0 +1,157 @@
+package dbbench
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/maticnetwork/polygon-cli/progress"
+	"github.com/rs/zerolog/log"
+)
+
+// fsRecord locates the last record written for an entry in a baseline file.
+type fsRecord struct {
+	offset int64
+	size   int
+}
+
+// runFSWriteBaseline runs the write and random read workload of the database against flat files in --db-path,
+// to measure the ceiling of the disk: the entries are appended to a file with a write per entry and a single
+// fsync at the end of each pass, then --fsync-write-limit entries to another file with an fsync after every
+// write, and finally read back from random offsets of the first file. The files are removed afterwards.
+func runFSWriteBaseline() ([]*TestResult, error) {
+	if err := os.MkdirAll(*dbPath, 0755); err != nil {
+		return nil, err
+	}
+	trs := make([]*TestResult, 0)
+	appendPath := filepath.Join(*dbPath, "baseline-append.dat")
+	syncPath := filepath.Join(*dbPath, "baseline-fsync.dat")
+	defer os.Remove(appendPath)
+	defer os.Remove(syncPath)
+
+	f, err := os.OpenFile(appendPath, os.O_CREATE|os.O_TRUNC|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var records []fsRecord
+	var offset int64
+	for pass := uint64(0); pass <= *overwriteCount; pass++ {
+		desc := "fswrite append"
+		if pass > 0 {
+			desc = fmt.Sprintf("fswrite append overwrite %d", pass-1)
+		}
+		start := startPhase()
+		if records, offset, err = appendEntries(f, offset, pass, *writeLimit, false); err != nil {
+			return nil, err
+		}
+		trs = append(trs, NewTestResult(start, time.Now(), desc, *writeLimit))
+	}
+
+	sf, err := os.OpenFile(syncPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer sf.Close()
+	start := startPhase()
+	if _, _, err = appendEntries(sf, 0, 0, *fsyncWriteLimit, true); err != nil {
+		return nil, err
+	}
+	trs = append(trs, NewTestResult(start, time.Now(), "fswrite fsync per write", *fsyncWriteLimit))
+
+	start = startPhase()
+	latency, err := readRecords(f, records, *readLimit)
+	if err != nil {
+		return nil, err
+	}
+	tr := NewTestResult(start, time.Now(), "fswrite random read", *readLimit)
+	tr.ReadLatency = latency
+	trs = append(trs, tr)
+	return trs, nil
+}
+
+// appendEntries appends the first limit entries of the pass to the file from the offset, with a write per entry,
+// and syncs the file after every write or once at the end. It returns the records of the entries and the end offset.
+func appendEntries(f *os.File, offset int64, pass, limit uint64, syncEach bool) ([]fsRecord, int64, error) {
+	bar := progress.New("Writing flat file", int64(limit), "iop")
+	records := make([]fsRecord, limit)
+	for i := uint64(0); i < limit; i++ {
+		r := newEntryRand(*seed, pass, i)
+		k, v := makeKV(r, i, sizeDistribution.GetSizeSample(r), *sequentialWrites)
+		n, err := f.Write(append(k, v...))
+		if err != nil {
+			return nil, 0, err
+		}
+		if syncEach {
+			if err = f.Sync(); err != nil {
+				return nil, 0, err
+			}
+		}
+		records[i] = fsRecord{offset: offset, size: n}
+		offset += int64(n)
+		bar.Add(1)
+		countOps(1)
+	}
+	bar.Finish()
+	if !syncEach {
+		if err := f.Sync(); err != nil {
+			return nil, 0, err
+		}
+	}
+	return records, offset, nil
+}
+
+// readRecords reads limit random records with the degree of parallelism and returns the latency of the reads.
+func readRecords(f *os.File, records []fsRecord, limit uint64) (*LatencySummary, error) {
+	if len(records) == 0 {
+		return nil, nil
+	}
+	pb := progress.New("flat file random reads", int64(limit), "iop")
+	workers := parallelism()
+	latencies := make([][]time.Duration, workers)
+	var next atomic.Uint64
+	var firstErr atomic.Pointer[error]
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(*seed + int64(w)))
+			buf := make([]byte, 0)
+			for next.Add(1) <= limit {
+				rec := records[r.Intn(len(records))]
+				if cap(buf) < rec.size {
+					buf = make([]byte, rec.size)
+				}
+				start := time.Now()
+				_, err := f.ReadAt(buf[:rec.size], rec.offset)
+				latencies[w] = append(latencies[w], time.Since(start))
+				if err != nil {
+					firstErr.CompareAndSwap(nil, &err)
+					return
+				}
+				pb.Add(1)
+				countOps(1)
+			}
+		}(w)
+	}
+	wg.Wait()
+	pb.Finish()
+	if err := firstErr.Load(); err != nil {
+		return nil, *err
+	}
+
+	all := make([]time.Duration, 0, limit)
+	for _, l := range latencies {
+		all = append(all, l...)
+	}
+	latency := NewLatencySummary(all)
+	if latency != nil {
+		log.Info().Dur("p50", latency.P50).Dur("p99", latency.P99).Dur("max", latency.Max).Msg("Flat file random read latency")
+	}
+	return latency, nil
+}
@@ -0,0 +1,38 @@
+package dbbench
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sys/unix"
+)
+
+func openDirect(name string) (*os.File, error) {
+	return os.OpenFile(name, os.O_RDONLY|syscall.O_DIRECT, 0)
+}
+
+// dropPageCache evicts the database from the page cache. Dropping the whole
+// page cache needs root, otherwise the pages of the database files are
+// evicted one file at a time.
+func dropPageCache(path string) error {
+	unix.Sync()
+	err := os.WriteFile("/proc/sys/vm/drop_caches", []byte("1"), 0)
+	if err == nil {
+		return nil
+	}
+	log.Debug().Err(err).Msg("Unable to drop the page cache, evicting the database files instead")
+	return filepath.WalkDir(path, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return unix.Fadvise(int(f.Fd()), 0, 0, unix.FADV_DONTNEED)
+	})
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package dbbench
+
+import (
+	"errors"
+	"os"
+)
+
+var errCacheControl = errors.New("the page cache control is only supported on linux")
+
+func openDirect(name string) (*os.File, error) {
+	return nil, errCacheControl
+}
+
+func dropPageCache(path string) error {
+	return errCacheControl
+}
@@ -6,23 +6,29 @@ import (
 	_ "embed"
 	"encoding/binary"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/bits"
 	"math/rand"
 	"os"
 	"regexp"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/progress"
 	"github.com/rs/zerolog/log"
-	progressbar "github.com/schollz/progressbar/v3"
 	"github.com/spf13/cobra"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
+
+	"github.com/maticnetwork/polygon-cli/cmd/benchreport"
+	"github.com/maticnetwork/polygon-cli/util"
 )
 
 var (
@@ -52,6 +58,23 @@ var (
 	dbPath                 *string
 	fullScan               *bool
 	dbMode                 *string
+	seed                   *int64
+	rangeScans             *uint64
+	rangeScanSpan          *uint64
+	rangeScanIterators     *int
+	dropCaches             *bool
+	directIO               *bool
+	shards                 *int
+	backgroundWritesRate   *float64
+	restoreFrom            *string
+	restartPhases          *bool
+	crashWrites            *uint64
+	crashWriter            *bool
+	baseline               *string
+	fsyncWriteLimit        *uint64
+	warmupOps              *uint64
+	steadyState            *bool
+	steadyStateTolerance   *float64
 )
 
 const (
@@ -95,6 +118,25 @@ type (
 		OpCount      uint64
 		OpRate       float64
 		ValueDist    []uint64
+		// Workers are the results of each worker of the sequential reads.
+		Workers []WorkerResult `json:",omitempty"`
+		// IteratorSetup is the latency to open an iterator and seek to the start key of the range scans.
+		IteratorSetup *LatencySummary `json:",omitempty"`
+		// Shards are the results of each shard with --shards.
+		Shards []ShardResult `json:",omitempty"`
+		// ReadLatency is the latency of each random read.
+		ReadLatency *LatencySummary `json:",omitempty"`
+		// BackgroundWrites are the writes made during the phase with --background-writes-rate.
+		BackgroundWrites *BackgroundWriteResult `json:",omitempty"`
+		// SteadyState excludes the warmup of the phase with --warmup-ops or --steady-state.
+		SteadyState *SteadyStateResult `json:",omitempty"`
+	}
+	TestResults  []*TestResult
+	WorkerResult struct {
+		Worker       int
+		TestDuration time.Duration
+		OpCount      uint64
+		OpRate       float64
 	}
 	RandomKeySeeker struct {
 		db            KeyValueDB
@@ -130,39 +172,64 @@ func NewTestResult(startTime, endTime time.Time, desc string, opCount uint64) *T
 	tr.Description = desc
 	tr.OpCount = opCount
 	tr.OpRate = float64(opCount) / tr.TestDuration.Seconds()
+	tr.SteadyState = stopMeter(endTime, opCount)
 
 	log.Info().Dur("testDuration", tr.TestDuration).Str("desc", tr.Description).Msg("Recorded result")
 	log.Debug().Interface("result", tr).Msg("Recorded result")
 	return tr
 }
 
+func NewWorkerResult(worker int, startTime, endTime time.Time, opCount uint64) WorkerResult {
+	d := endTime.Sub(startTime)
+	return WorkerResult{
+		Worker:       worker,
+		TestDuration: d,
+		OpCount:      opCount,
+		OpRate:       float64(opCount) / d.Seconds(),
+	}
+}
+
 var DBBenchCmd = &cobra.Command{
 	Use:   "dbbench [flags]",
 	Short: "Perform a level/pebble db benchmark",
 	Long:  usage,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		log.Info().Msg("Starting db test")
-		var kvdb KeyValueDB
-		var err error
-		switch *dbMode {
-		case "leveldb":
-			kvdb, err = NewWrappedLevelDB()
+		if *crashWriter {
+			return runCrashWriter(context.Background())
+		}
+		log.Info().Int64("seed", *seed).Msg("Starting db test")
+		randSrc = rand.New(rand.NewSource(*seed))
+		manifest := NewRunManifest(cmd, *seed)
+		if *restoreFrom != "" {
+			snapshot, err := restoreSnapshot(*restoreFrom, *dbPath)
 			if err != nil {
 				return err
 			}
-		case "pebbledb":
-			kvdb, err = NewWrappedPebbleDB()
+			manifest.Snapshot = snapshot
+		}
+		if *baseline != "" {
+			trs, err := runFSWriteBaseline()
 			if err != nil {
 				return err
 			}
-		default:
-			return fmt.Errorf("the mode %s is not recognized", *dbMode)
+			return printSummary(manifest, trs)
+		}
+
+		kvdb, err := openDB()
+		if err != nil {
+			return err
 		}
 
 		ctx := context.Background()
 
 		var start time.Time
 		trs := make([]*TestResult, 0)
+		record := func(tr *TestResult) {
+			if sharded, ok := kvdb.(*ShardedDB); ok {
+				tr.Shards = sharded.Results(tr.TestDuration)
+			}
+			trs = append(trs, tr)
+		}
 
 		sequentialWritesDesc := "random"
 		if *sequentialWrites {
@@ -174,39 +241,90 @@ var DBBenchCmd = &cobra.Command{
 		}
 
 		if *fullScan {
-			start = time.Now()
-			opCount, valueDist := runFullScan(ctx, kvdb)
+			start = startPhase()
+			opCount, valueDist, err := runFullScan(ctx, kvdb)
+			if err != nil {
+				return err
+			}
 			tr := NewTestResult(start, time.Now(), "full scan", opCount)
 			tr.ValueDist = valueDist
-			trs = append(trs, tr)
-			return printSummary(trs)
+			record(tr)
+			return printSummary(manifest, trs)
 		}
 
 		// in no write mode, we assume the database as already been populated in a previous run or we're using some other database
 		if !*readOnly {
-			start = time.Now()
-			writeData(ctx, kvdb, 0, *writeLimit, *sequentialWrites)
-			trs = append(trs, NewTestResult(start, time.Now(), fmt.Sprintf("initial %s write", sequentialWritesDesc), *writeLimit))
+			start = startPhase()
+			if err = writeData(ctx, kvdb, 0, *writeLimit, 0, *sequentialWrites); err != nil {
+				return err
+			}
+			record(NewTestResult(start, time.Now(), fmt.Sprintf("initial %s write", sequentialWritesDesc), *writeLimit))
 
 			for i := 0; i < int(*overwriteCount); i += 1 {
-				start = time.Now()
-				writeData(ctx, kvdb, 0, *writeLimit, *sequentialWrites)
-				trs = append(trs, NewTestResult(start, time.Now(), fmt.Sprintf("%s overwrite %d", sequentialWritesDesc, i), *writeLimit))
+				start = startPhase()
+				if err = writeData(ctx, kvdb, 0, *writeLimit, uint64(i+1), *sequentialWrites); err != nil {
+					return err
+				}
+				record(NewTestResult(start, time.Now(), fmt.Sprintf("%s overwrite %d", sequentialWritesDesc, i), *writeLimit))
 			}
 
-			start = time.Now()
-			runFullCompact(ctx, kvdb)
-			trs = append(trs, NewTestResult(start, time.Now(), "compaction", 1))
+			start = startPhase()
+			if err = runFullCompact(ctx, kvdb); err != nil {
+				return err
+			}
+			record(NewTestResult(start, time.Now(), "compaction", 1))
 		}
 
+		// the read phases run alongside the background writes, if any
+		bw := newBackgroundWriter(kvdb, *backgroundWritesRate)
 		if *sequentialReads {
-			start = time.Now()
-			readSeq(ctx, kvdb, *readLimit)
-			trs = append(trs, NewTestResult(start, time.Now(), fmt.Sprintf("%s read", sequentialReadsDesc), *readLimit))
+			start = startPhase()
+			stop := bw.start(ctx)
+			workers, err := readSeq(ctx, kvdb, *readLimit)
+			bwResult, bwErr := stop()
+			if err = errors.Join(err, bwErr); err != nil {
+				return err
+			}
+			var opCount uint64
+			for _, w := range workers {
+				opCount += w.OpCount
+			}
+			tr := NewTestResult(start, time.Now(), fmt.Sprintf("%s read", sequentialReadsDesc), opCount)
+			tr.Workers = workers
+			tr.BackgroundWrites = bwResult
+			record(tr)
 		} else {
-			start = time.Now()
-			readRandom(ctx, kvdb, *readLimit)
-			trs = append(trs, NewTestResult(start, time.Now(), fmt.Sprintf("%s read", sequentialWritesDesc), *readLimit))
+			start = startPhase()
+			stop := bw.start(ctx)
+			latency, err := readRandom(ctx, kvdb, *readLimit)
+			bwResult, bwErr := stop()
+			if err = errors.Join(err, bwErr); err != nil {
+				return err
+			}
+			tr := NewTestResult(start, time.Now(), fmt.Sprintf("%s read", sequentialWritesDesc), *readLimit)
+			tr.ReadLatency = latency
+			tr.BackgroundWrites = bwResult
+			record(tr)
+		}
+
+		if *rangeScans > 0 {
+			start = startPhase()
+			stop := bw.start(ctx)
+			keyCount, setup, err := runRangeScans(ctx, kvdb, *rangeScans, *rangeScanSpan, *rangeScanIterators)
+			bwResult, bwErr := stop()
+			if err = errors.Join(err, bwErr); err != nil {
+				return err
+			}
+			tr := NewTestResult(start, time.Now(), rangeScanDesc(), keyCount)
+			tr.IteratorSetup = setup
+			tr.BackgroundWrites = bwResult
+			record(tr)
+		}
+
+		if *restartPhases {
+			if kvdb, err = runRestartPhases(ctx, kvdb, record); err != nil {
+				return err
+			}
 		}
 
 		log.Info().Msg("Close DB")
@@ -215,7 +333,7 @@ var DBBenchCmd = &cobra.Command{
 			log.Error().Err(err).Msg("Error while closing db")
 		}
 
-		return printSummary(trs)
+		return printSummary(manifest, trs)
 	},
 	Args: func(cmd *cobra.Command, args []string) error {
 		var err error
@@ -223,6 +341,33 @@ var DBBenchCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		if *rangeScans > 0 && (*rangeScanSpan == 0 || *rangeScanIterators < 1) {
+			return fmt.Errorf("the range scans need a span and at least one iterator")
+		}
+		if *shards < 1 {
+			return fmt.Errorf("the number of shards must be at least 1")
+		}
+		if (*dropCaches || *directIO) && runtime.GOOS != "linux" {
+			return fmt.Errorf("--drop-caches and --direct-io are only supported on linux")
+		}
+		if *backgroundWritesRate < 0 {
+			return fmt.Errorf("the background writes rate can't be negative")
+		}
+		if *backgroundWritesRate > 0 && (*readOnly || *fullScan) {
+			return fmt.Errorf("--background-writes-rate can't be used with a read only database")
+		}
+		if *baseline != "" && *baseline != "fswrite" {
+			return fmt.Errorf("the baseline %s is not recognized", *baseline)
+		}
+		if *baseline != "" && (*readOnly || *fullScan || *restoreFrom != "") {
+			return fmt.Errorf("--baseline writes its own files and can't be used with --read-only, --full-scan-mode or --restore-from")
+		}
+		if *restartPhases && *fullScan {
+			return fmt.Errorf("--restart-phases can't be used with --full-scan-mode")
+		}
+		if *steadyStateTolerance <= 0 {
+			return fmt.Errorf("the steady state tolerance must be positive")
+		}
 		if *keySize > 64 {
 			return fmt.Errorf(" max supported key size is 64 bytes. %d is too big", *keySize)
 		}
@@ -230,23 +375,115 @@ var DBBenchCmd = &cobra.Command{
 	},
 }
 
-func printSummary(trs []*TestResult) error {
-	jsonResults, err := json.Marshal(trs)
-	if err != nil {
-		return err
+// openDB opens the database at --db-path, split into shards with --shards.
+func openDB() (KeyValueDB, error) {
+	open := func(path string) (KeyValueDB, error) {
+		switch *dbMode {
+		case "leveldb":
+			return NewWrappedLevelDB(path)
+		case "pebbledb":
+			return NewWrappedPebbleDB(path)
+		default:
+			return nil, fmt.Errorf("the mode %s is not recognized", *dbMode)
+		}
+	}
+	if *shards > 1 {
+		return NewShardedDB(*shards, open)
+	}
+	return open(*dbPath)
+}
+
+// startPhase returns the start time of a phase, evicting the database from
+// the page cache first with --drop-caches so the phase starts cache-cold.
+// The operations of the phase are metered with --warmup-ops and --steady-state.
+func startPhase() time.Time {
+	if *dropCaches {
+		if err := dropPageCache(*dbPath); err != nil {
+			log.Warn().Err(err).Msg("Unable to drop the page cache")
+		}
+	}
+	start := time.Now()
+	if *warmupOps > 0 || *steadyState {
+		startMeter(start)
+	}
+	return start
+}
+
+func printSummary(manifest *RunManifest, trs []*TestResult) error {
+	// The summary was printed as JSON before the --output flag existed.
+	return util.NewOutputWriterWithDefault(os.Stdout, util.JSONOutput).Write(BenchmarkReport{Manifest: manifest, Results: trs})
+}
+
+// WriteText renders the results as a table.
+func (trs TestResults) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Description", "Start Time", "Duration", "Op Count", "Op Rate", "Latency p50/p99"})
+	for _, tr := range trs {
+		// the latency is the one of the random reads or the iterator setup of the range scans
+		latency := ""
+		if l := tr.ReadLatency; l != nil {
+			latency = fmt.Sprintf("%s / %s", l.P50, l.P99)
+		} else if l := tr.IteratorSetup; l != nil {
+			latency = fmt.Sprintf("%s / %s", l.P50, l.P99)
+		}
+		t.AppendRow(table.Row{tr.Description, tr.StartTime.Format(time.RFC3339), tr.TestDuration, tr.OpCount, fmt.Sprintf("%.2f", tr.OpRate), latency})
+		if bw := tr.BackgroundWrites; bw != nil {
+			t.AppendRow(table.Row{"  background writes", "", "", bw.OpCount, fmt.Sprintf("%.2f", bw.OpRate), ""})
+		}
+		for _, w := range tr.Workers {
+			t.AppendRow(table.Row{fmt.Sprintf("  worker %d", w.Worker), "", w.TestDuration, w.OpCount, fmt.Sprintf("%.2f", w.OpRate), ""})
+		}
+		for _, sr := range tr.Shards {
+			t.AppendRow(table.Row{fmt.Sprintf("  shard %d", sr.Shard), "", "", sr.OpCount, fmt.Sprintf("%.2f", sr.OpRate), ""})
+		}
+		if ss := tr.SteadyState; ss != nil {
+			desc := "  steady state"
+			if !ss.Detected {
+				desc = "  after warmup"
+			}
+			t.AppendRow(table.Row{"  warmup", "", ss.WarmupDuration, ss.WarmupOpCount, "", ""})
+			t.AppendRow(table.Row{desc, "", ss.TestDuration, ss.OpCount, fmt.Sprintf("%.2f", ss.OpRate), ""})
+		}
 	}
-	fmt.Println(string(jsonResults))
+	t.Render()
 	return nil
 }
 
-func runFullCompact(ctx context.Context, db KeyValueDB) {
-	err := db.Compact()
-	if err != nil {
-		log.Fatal().Err(err).Msg("Error compacting data")
+// parallelism returns the number of concurrent operations, which is enough to
+// keep the worker pool of every shard busy with --shards.
+func parallelism() int {
+	return max(int(*degreeOfParallelism), 1) * *shards
+}
+
+// workerError keeps the first error of the concurrent workers of a phase, which stop once it's set.
+type workerError struct {
+	mu  sync.Mutex
+	err error
+}
+
+func (e *workerError) set(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+func (e *workerError) get() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+func runFullCompact(ctx context.Context, db KeyValueDB) error {
+	if err := db.Compact(); err != nil {
+		return fmt.Errorf("unable to compact the data: %w", err)
 	}
+	return nil
 }
-func runFullScan(ctx context.Context, db KeyValueDB) (uint64, []uint64) {
-	pool := make(chan bool, *degreeOfParallelism)
+func runFullScan(ctx context.Context, db KeyValueDB) (uint64, []uint64, error) {
+	pool := make(chan bool, parallelism())
 	var wg sync.WaitGroup
 	// 32 should be safe here. That would correspond to a single value that's 4.2 GB
 	buckets := make([]uint64, 32)
@@ -258,6 +495,7 @@ func runFullScan(ctx context.Context, db KeyValueDB) (uint64, []uint64) {
 		wg.Add(1)
 		go func(i iterator.Iterator) {
 			opCount += 1
+			countOps(1)
 			k := i.Key()
 			v := i.Value()
 
@@ -280,12 +518,11 @@ func runFullScan(ctx context.Context, db KeyValueDB) (uint64, []uint64) {
 	}
 	iter.Release()
 	err := iter.Error()
+	wg.Wait()
 	if err != nil {
-		log.Fatal().Err(err).Msg("Error running full scan")
+		return 0, nil, fmt.Errorf("unable to run the full scan: %w", err)
 	}
 
-	wg.Wait()
-
 	for k, v := range buckets {
 		if v == 0 {
 			continue
@@ -301,76 +538,127 @@ func runFullScan(ctx context.Context, db KeyValueDB) (uint64, []uint64) {
 			Float64("end", end).
 			Uint64("count", v).Msg("Buckets")
 	}
-	return opCount, buckets
+	return opCount, buckets, nil
 }
-func writeData(ctx context.Context, db KeyValueDB, startIndex, writeLimit uint64, sequential bool) {
+
+// writeData writes the entries from startIndex. The content of every entry only depends on the seed, its index
+// and the pass, so runs with the same seed write the same data regardless of the goroutine scheduling.
+func writeData(ctx context.Context, db KeyValueDB, startIndex, writeLimit, pass uint64, sequential bool) error {
 	var i uint64 = startIndex
 	var wg sync.WaitGroup
-	pool := make(chan bool, *degreeOfParallelism)
-	bar := getNewProgressBar(int64(writeLimit), "Writing data")
+	var werr workerError
+	pool := make(chan bool, parallelism())
+	bar := progress.New("Writing data", int64(writeLimit), "iop")
 	lim := writeLimit + startIndex
-	for ; i < lim; i = i + 1 {
+	for ; i < lim && werr.get() == nil; i = i + 1 {
 		pool <- true
 		wg.Add(1)
 		go func(i uint64) {
-			_ = bar.Add(1)
-			k, v := makeKV(i, sizeDistribution.GetSizeSample(), sequential)
+			bar.Add(1)
+			countOps(1)
+			r := newEntryRand(*seed, pass, i)
+			k, v := makeKV(r, i, sizeDistribution.GetSizeSample(r), sequential)
 			err := db.Put(k, v)
 			if err != nil {
-				log.Fatal().Err(err).Msg("Failed to put value")
+				werr.set(fmt.Errorf("unable to put value: %w", err))
 			}
 			wg.Done()
 			<-pool
 		}(i)
 	}
 	wg.Wait()
-	_ = bar.Finish()
+	bar.Finish()
+	return werr.get()
 }
 
-func readSeq(ctx context.Context, db KeyValueDB, limit uint64) {
-	pb := getNewProgressBar(int64(limit), "sequential reads")
-	var rCount uint64 = 0
-	pool := make(chan bool, *degreeOfParallelism)
+// readSeq reads limit entries sequentially with a worker per degree of parallelism. Each worker owns an iterator
+// starting at its own shard of the keyspace and reads its share of the entries, wrapping around to the first key
+// at the end of the database.
+func readSeq(ctx context.Context, db KeyValueDB, limit uint64) ([]WorkerResult, error) {
+	pb := progress.New("sequential reads", int64(limit), "iop")
+	workers := uint64(*degreeOfParallelism)
+	if workers == 0 {
+		workers = 1
+	}
+	results := make([]WorkerResult, workers)
 	var wg sync.WaitGroup
-benchLoop:
-	for {
-		iter := db.NewIterator()
-		for iter.Next() {
-			rCount += 1
-			_ = pb.Add(1)
-			pool <- true
-			wg.Add(1)
-			go func(i iterator.Iterator) {
-				_ = i.Key()
-				_ = i.Value()
-				wg.Done()
-				<-pool
-			}(iter)
+	var werr workerError
+	for w := uint64(0); w < workers; w++ {
+		// the remainder is spread over the first workers
+		count := limit / workers
+		if w < limit%workers {
+			count += 1
+		}
+		wg.Add(1)
+		go func(w, count uint64) {
+			defer wg.Done()
+			start := time.Now()
+			n, err := readShard(db, shardStartKey(w, workers), count, pb)
+			if err != nil {
+				werr.set(err)
+			}
+			results[w] = NewWorkerResult(int(w), start, time.Now(), n)
+		}(w, count)
+	}
+	wg.Wait()
+	pb.Finish()
+	if err := werr.get(); err != nil {
+		return nil, err
+	}
 
-			if rCount >= limit {
-				iter.Release()
-				break benchLoop
+	for _, r := range results {
+		log.Debug().Int("worker", r.Worker).Uint64("opCount", r.OpCount).Float64("opRate", r.OpRate).Msg("Sequential read worker")
+	}
+	return results, nil
+}
+
+// readShard reads count entries from the start key with its own iterator and returns the number of entries read.
+func readShard(db KeyValueDB, startKey []byte, count uint64, pb *progress.Bar) (uint64, error) {
+	iter := db.NewIterator()
+	defer iter.Release()
+	var rCount uint64 = 0
+	exists := iter.Seek(startKey)
+	for rCount < count {
+		if !exists {
+			if err := iter.Error(); err != nil {
+				return rCount, fmt.Errorf("unable to read sequentially: %w", err)
+			}
+			// wrap around to the beginning, unless the database is empty
+			if exists = iter.First(); !exists {
+				log.Error().Msg("No entry to read sequentially")
+				break
 			}
 		}
-		iter.Release()
-		err := iter.Error()
-		if err != nil {
-			log.Fatal().Err(err).Msg("Error reading sequentially")
-		}
+		_ = iter.Key()
+		_ = iter.Value()
+		rCount += 1
+		pb.Add(1)
+		countOps(1)
+		exists = iter.Next()
 	}
-	wg.Wait()
-	_ = pb.Finish()
+	return rCount, nil
+}
+
+// shardStartKey returns the first key of the shard when the keyspace is split evenly by its first two bytes.
+func shardStartKey(shard, shards uint64) []byte {
+	key := make([]byte, 2)
+	binary.BigEndian.PutUint16(key, uint16(shard*math.MaxUint16/shards))
+	return key
 }
-func readRandom(ctx context.Context, db KeyValueDB, limit uint64) {
-	pb := getNewProgressBar(int64(limit), "random reads")
+
+// readRandom reads limit random keys and returns the latency of the reads.
+func readRandom(ctx context.Context, db KeyValueDB, limit uint64) (*LatencySummary, error) {
+	pb := progress.New("random reads", int64(limit), "iop")
 	var rCount uint64 = 0
-	pool := make(chan bool, *degreeOfParallelism)
+	pool := make(chan bool, parallelism())
 	var wg sync.WaitGroup
+	var werr workerError
 	rks := NewRandomKeySeeker(db)
 	defer rks.iterator.Release()
 
 	var rCountLock sync.Mutex
 	var keyLock sync.Mutex
+	latencies := make([]time.Duration, 0, limit)
 benchLoop:
 	for {
 		for {
@@ -380,13 +668,23 @@ benchLoop:
 				rCountLock.Lock()
 				rCount += 1
 				rCountLock.Unlock()
-				_ = pb.Add(1)
+				pb.Add(1)
+				countOps(1)
 
 				// It's not entirely obvious WHY this is needed, but without it, there are issues with the way that
 				// pebble db manages it's iterators and internal state. Level db works fine though.
 				keyLock.Lock()
-				tmpKey := rks.Key()
-				_, err := db.Get(tmpKey)
+				tmpKey, err := rks.Key()
+				if err != nil {
+					keyLock.Unlock()
+					werr.set(err)
+					wg.Done()
+					<-pool
+					return
+				}
+				start := time.Now()
+				_, err = db.Get(tmpKey)
+				latencies = append(latencies, time.Since(start))
 				keyLock.Unlock()
 				if err != nil {
 					log.Error().Str("key", hex.EncodeToString(tmpKey)).Err(err).Msg("db random read error")
@@ -395,7 +693,7 @@ benchLoop:
 				<-pool
 			}()
 			rCountLock.Lock()
-			if rCount >= limit {
+			if rCount >= limit || werr.get() != nil {
 				rCountLock.Unlock()
 				break benchLoop
 			}
@@ -404,7 +702,16 @@ benchLoop:
 		}
 	}
 	wg.Wait()
-	_ = pb.Finish()
+	pb.Finish()
+	if err := werr.get(); err != nil {
+		return nil, err
+	}
+
+	latency := NewLatencySummary(latencies)
+	if latency != nil {
+		log.Info().Dur("p50", latency.P50).Dur("p99", latency.P99).Dur("max", latency.Max).Msg("Random read latency")
+	}
+	return latency, nil
 }
 
 func NewRandomKeySeeker(db KeyValueDB) *RandomKeySeeker {
@@ -414,7 +721,7 @@ func NewRandomKeySeeker(db KeyValueDB) *RandomKeySeeker {
 	rks.firstKey = rks.iterator.Key()
 	return rks
 }
-func (r *RandomKeySeeker) Key() []byte {
+func (r *RandomKeySeeker) Key() ([]byte, error) {
 	seekKey := make([]byte, 8)
 	randSrcMutex.Lock()
 	randSrc.Read(seekKey)
@@ -423,6 +730,7 @@ func (r *RandomKeySeeker) Key() []byte {
 	log.Trace().Str("seekKey", hex.EncodeToString(seekKey)).Msg("Searching for key")
 
 	r.iteratorMutex.Lock()
+	defer r.iteratorMutex.Unlock()
 
 	// first try to just get a random key
 	exists := r.iterator.Seek(seekKey)
@@ -444,47 +752,20 @@ func (r *RandomKeySeeker) Key() []byte {
 
 	// if after trying to all these ways to find a valid key... something must be very wrong
 	if !exists {
-		log.Fatal().Msg("Unable to select random key!?")
+		if err := r.iterator.Error(); err != nil {
+			return nil, fmt.Errorf("unable to select a random key: %w", err)
+		}
+		return nil, errors.New("unable to select a random key, the database is empty")
 	}
 	if err := r.iterator.Error(); err != nil {
 		log.Error().Err(err).Msg("Issue getting random key")
 	}
 	resultKey := r.iterator.Key()
-	r.iteratorMutex.Unlock()
 	log.Trace().Str("seekKey", hex.EncodeToString(seekKey)).Str("resultKey", hex.EncodeToString(resultKey)).Msg("Found random key")
-	return resultKey
-}
-
-func getNewProgressBar(max int64, description string) *progressbar.ProgressBar {
-	pb := progressbar.NewOptions64(max,
-		progressbar.OptionEnableColorCodes(false),
-		progressbar.OptionSetDescription(description),
-		progressbar.OptionSetElapsedTime(true),
-		progressbar.OptionSetItsString("iop"),
-		progressbar.OptionSetRenderBlankState(true),
-		progressbar.OptionShowCount(),
-		progressbar.OptionShowIts(),
-		progressbar.OptionShowElapsedTimeOnFinish(),
-		progressbar.OptionUseANSICodes(true),
-		progressbar.OptionThrottle(1*time.Second),
-		progressbar.OptionSetWriter(os.Stderr),
-		progressbar.OptionOnCompletion(func() {
-			_, _ = fmt.Fprintln(os.Stderr)
-		}),
-		progressbar.OptionSetTheme(progressbar.Theme{
-			Saucer:        "=",
-			SaucerHead:    ">",
-			SaucerPadding: " ",
-			BarStart:      "[",
-			BarEnd:        "]",
-		}),
-		progressbar.OptionSetWidth(10),
-		progressbar.OptionFullWidth(),
-	)
-	return pb
+	return resultKey, nil
 }
 
-func makeKV(seed, valueSize uint64, sequential bool) ([]byte, []byte) {
+func makeKV(r *rand.Rand, seed, valueSize uint64, sequential bool) ([]byte, []byte) {
 	tmpKey := make([]byte, *keySize)
 	binary.LittleEndian.PutUint64(tmpKey, seed)
 	hashedKey := sha512.Sum512(tmpKey)
@@ -499,9 +780,7 @@ func makeKV(seed, valueSize uint64, sequential bool) ([]byte, []byte) {
 	tmpValue := make([]byte, valueSize)
 	if !*writeZero {
 		// Assuming we're not in zero mode, we'll fill the data with random data
-		randSrcMutex.Lock()
-		randSrc.Read(tmpValue)
-		randSrcMutex.Unlock()
+		r.Read(tmpValue)
 	}
 	return tmpKey, tmpValue
 }
@@ -543,10 +822,8 @@ func NewIODistribution(ranges []IORange) (*IODistribution, error) {
 }
 
 // GetSizeSample will return an IO size in accordance with the probability distribution
-func (i *IODistribution) GetSizeSample() uint64 {
-	randSrcMutex.Lock()
-	randFreq := randSrc.Intn(i.totalFrequency)
-	randSrcMutex.Unlock()
+func (i *IODistribution) GetSizeSample(r *rand.Rand) uint64 {
+	randFreq := r.Intn(i.totalFrequency)
 
 	log.Trace().Int("randFreq", randFreq).Int("totalFreq", i.totalFrequency).Msg("Getting Size Sample")
 	var selectedRange *IORange
@@ -563,9 +840,7 @@ func (i *IODistribution) GetSizeSample() uint64 {
 		return 0 // lint
 	}
 	randRange := selectedRange.EndRange - selectedRange.StartRange
-	randSrcMutex.Lock()
-	randSize := randSrc.Intn(randRange + 1)
-	randSrcMutex.Unlock()
+	randSize := r.Intn(randRange + 1)
 	return uint64(randSize + selectedRange.StartRange)
 }
 
@@ -633,6 +908,46 @@ func init() {
 	dbPath = flagSet.String("db-path", "_benchmark_db", "the path of the database that we'll use for testing")
 	fullScan = flagSet.Bool("full-scan-mode", false, "if true, the application will scan the full database as fast as possible and print a summary")
 	dbMode = flagSet.String("db-mode", "leveldb", "The mode to use: leveldb or pebbledb")
+	rangeScans = flagSet.Uint64("range-scans", 0, "the number of range scans from random keys to run after the reads, 0 to skip them")
+	rangeScanSpan = flagSet.Uint64("range-scan-span", 1000, "the number of keys read by each range scan")
+	rangeScanIterators = flagSet.Int("range-scan-iterators", 4, "the number of iterators scanning concurrently")
+	dropCaches = flagSet.Bool("drop-caches", false, "drop the OS page cache before each phase so it starts cache-cold, the whole cache as root and the database files otherwise")
+	directIO = flagSet.Bool("direct-io", false, "read the table files with O_DIRECT, bypassing the OS page cache. The writes are still buffered")
+	shards = flagSet.Int("shards", 1, "the number of independent databases the keys are hash-partitioned across, each with its own pool of --degree-of-parallelism workers")
+	backgroundWritesRate = flagSet.Float64("background-writes-rate", 0, "the number of writes per second made in the background of the read phases, 0 to read a quiescent database")
+	restoreFrom = flagSet.String("restore-from", "", "the snapshot created by the snapshot command to restore in --db-path before the run, usually with --read-only")
+	restartPhases = flagSet.Bool("restart-phases", false, "measure the time to close and reopen the database, and to recover it after a crash, at the end of the run")
+	crashWrites = flagSet.Uint64("crash-writes", 100000, "the number of entries written by the process killed before the crash recovery phase of --restart-phases, 0 to skip the phase")
+	crashWriter = flagSet.Bool("crash-writer", false, "")
+	_ = flagSet.MarkHidden("crash-writer")
+	baseline = flagSet.String("baseline", "", "run the write and read workload against flat files instead of the database to measure the ceiling of the disk: fswrite")
+	fsyncWriteLimit = flagSet.Uint64("fsync-write-limit", 10000, "the number of entries written with an fsync after each one by --baseline fswrite")
+	seed = flagSet.Int64("seed", 1, "The seed of the random data and keys, runs with the same seed and flags use identical workloads")
+	warmupOps = flagSet.Uint64("warmup-ops", 0, "the number of operations at the start of each phase reported separately as its warmup")
+	steadyState = flagSet.Bool("steady-state", false, "extend the warmup of each phase until its op rate settles, and report the rest of the phase as its steady state")
+	steadyStateTolerance = flagSet.Float64("steady-state-tolerance", 0.1, "the coefficient of variation of the op rate over one second below which a phase is steady")
+
+	DBBenchCmd.AddCommand(benchreport.NewReportCmd())
+	DBBenchCmd.AddCommand(snapshotCmd)
+}
 
-	randSrc = rand.New(rand.NewSource(1))
+// splitMix64 is a small rand.Source64 which is cheap enough to seed for every entry.
+type splitMix64 uint64
+
+func (s *splitMix64) Uint64() uint64 {
+	*s += 0x9e3779b97f4a7c15
+	z := uint64(*s)
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+func (s *splitMix64) Int63() int64    { return int64(s.Uint64() >> 1) }
+func (s *splitMix64) Seed(seed int64) { *s = splitMix64(seed) }
+
+// newEntryRand returns the random source of the entry at the index for the write pass.
+func newEntryRand(seed int64, pass, index uint64) *rand.Rand {
+	s := splitMix64(uint64(seed))
+	s.Seed(int64(s.Uint64() ^ pass))
+	s.Seed(int64(s.Uint64() ^ index))
+	return rand.New(&s)
 }
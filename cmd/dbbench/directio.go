@@ -0,0 +1,157 @@
+package dbbench
+
+import (
+	"io"
+	"os"
+	"strings"
+	"unsafe"
+
+	"github.com/cockroachdb/pebble/vfs"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+// directIOAlignment is the alignment of the offsets, lengths and buffers of
+// the O_DIRECT reads, which is a multiple of the logical block size of the
+// usual devices.
+const directIOAlignment = 4096
+
+type (
+	// directReader reads a file opened with O_DIRECT, bypassing the page
+	// cache. The reads of the databases are unaligned, so they go through an
+	// aligned buffer.
+	directReader struct {
+		file *os.File
+		// closer closes the file the database opened in the first place.
+		closer io.Closer
+		offset int64
+	}
+
+	// directStorage is a leveldb storage reading the tables with O_DIRECT.
+	directStorage struct {
+		storage.Storage
+	}
+
+	// directFS is a pebble file system reading the tables with O_DIRECT.
+	directFS struct {
+		vfs.FS
+	}
+	directFile struct {
+		vfs.File
+		direct *directReader
+	}
+)
+
+func newDirectReader(name string, closer io.Closer) (*directReader, error) {
+	f, err := openDirect(name)
+	if err != nil {
+		return nil, err
+	}
+	return &directReader{file: f, closer: closer}, nil
+}
+
+func (d *directReader) ReadAt(p []byte, off int64) (int, error) {
+	start := off &^ (directIOAlignment - 1)
+	end := (off + int64(len(p)) + directIOAlignment - 1) &^ (directIOAlignment - 1)
+	buf := alignedBuffer(int(end - start))
+	n, err := d.file.ReadAt(buf, start)
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	skip := int(off - start)
+	if n <= skip {
+		return 0, io.EOF
+	}
+	copied := copy(p, buf[skip:n])
+	if copied < len(p) {
+		return copied, io.EOF
+	}
+	return copied, nil
+}
+
+func (d *directReader) Read(p []byte) (int, error) {
+	n, err := d.ReadAt(p, d.offset)
+	d.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (d *directReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		d.offset = offset
+	case io.SeekCurrent:
+		d.offset += offset
+	case io.SeekEnd:
+		info, err := d.file.Stat()
+		if err != nil {
+			return 0, err
+		}
+		d.offset = info.Size() + offset
+	}
+	return d.offset, nil
+}
+
+func (d *directReader) Close() error {
+	err := d.file.Close()
+	if cerr := d.closer.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// alignedBuffer returns a buffer of the size whose address is aligned for
+// O_DIRECT.
+func alignedBuffer(size int) []byte {
+	buf := make([]byte, size+directIOAlignment)
+	shift := 0
+	if rem := int(uintptr(unsafe.Pointer(&buf[0])) & (directIOAlignment - 1)); rem != 0 {
+		shift = directIOAlignment - rem
+	}
+	return buf[shift : shift+size]
+}
+
+func (s directStorage) Open(fd storage.FileDesc) (storage.Reader, error) {
+	r, err := s.Storage.Open(fd)
+	if err != nil || fd.Type != storage.TypeTable {
+		return r, err
+	}
+	// The file storage returns the os.File it opened, which gives the name of
+	// the table, either in the current or in the legacy format.
+	named, ok := r.(interface{ Name() string })
+	if !ok {
+		return r, nil
+	}
+	d, err := newDirectReader(named.Name(), r)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func (fs directFS) Open(name string, opts ...vfs.OpenOption) (vfs.File, error) {
+	f, err := fs.FS.Open(name, opts...)
+	if err != nil || !strings.HasSuffix(name, ".sst") {
+		return f, err
+	}
+	d, err := newDirectReader(name, f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &directFile{File: f, direct: d}, nil
+}
+
+func (f *directFile) ReadAt(p []byte, off int64) (int, error) {
+	return f.direct.ReadAt(p, off)
+}
+
+func (f *directFile) Read(p []byte) (int, error) {
+	return f.direct.Read(p)
+}
+
+func (f *directFile) Close() error {
+	return f.direct.Close()
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/filter"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
 	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
@@ -13,11 +14,13 @@ type (
 		ro     *opt.ReadOptions
 		wo     *opt.WriteOptions
 		handle *leveldb.DB
+		// stor is the storage of the database opened with --direct-io.
+		stor storage.Storage
 	}
 )
 
-func NewWrappedLevelDB() (*LevelDBWrapper, error) {
-	db, err := leveldb.OpenFile(*dbPath, &opt.Options{
+func NewWrappedLevelDB(path string) (*LevelDBWrapper, error) {
+	o := &opt.Options{
 		Filter:                 filter.NewBloomFilter(10),
 		DisableSeeksCompaction: true,
 		OpenFilesCacheCapacity: *openFilesCacheCapacity,
@@ -25,8 +28,21 @@ func NewWrappedLevelDB() (*LevelDBWrapper, error) {
 		WriteBuffer:            *cacheSize / 4 * opt.MiB,
 		// if we've disabled writes, or we're doing a full scan, we should open the database in read only mode
 		ReadOnly: *readOnly || *fullScan,
-	})
-	if err != nil {
+	}
+	var (
+		db   *leveldb.DB
+		stor storage.Storage
+		err  error
+	)
+	if *directIO {
+		if stor, err = storage.OpenFile(path, o.ReadOnly); err != nil {
+			return nil, err
+		}
+		if db, err = leveldb.Open(directStorage{stor}, o); err != nil {
+			stor.Close()
+			return nil, err
+		}
+	} else if db, err = leveldb.OpenFile(path, o); err != nil {
 		return nil, err
 	}
 
@@ -47,12 +63,19 @@ func NewWrappedLevelDB() (*LevelDBWrapper, error) {
 	}
 	wrapper := new(LevelDBWrapper)
 	wrapper.handle = db
+	wrapper.stor = stor
 	wrapper.wo = wo
 	wrapper.ro = ro
 	return wrapper, nil
 }
 func (l *LevelDBWrapper) Close() error {
-	return l.handle.Close()
+	err := l.handle.Close()
+	if l.stor != nil {
+		if serr := l.stor.Close(); err == nil {
+			err = serr
+		}
+	}
+	return err
 }
 func (l *LevelDBWrapper) Compact() error {
 	return l.handle.CompactRange(util.Range{Start: nil, Limit: nil})
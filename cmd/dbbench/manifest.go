@@ -0,0 +1,162 @@
+package dbbench
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/maticnetwork/polygon-cli/cmd/version"
+)
+
+type (
+	// RunManifest records everything needed to trace results back to the run
+	// that produced them and to replay the same workload elsewhere.
+	RunManifest struct {
+		StartTime time.Time
+		Seed      int64
+		Version   string
+		Commit    string
+		Flags     map[string]string
+		Host      HostInfo
+		// Snapshot is the snapshot the database was restored from with --restore-from.
+		Snapshot *SnapshotManifest `json:",omitempty"`
+	}
+	HostInfo struct {
+		Hostname    string
+		OS          string
+		Arch        string
+		Kernel      string `json:",omitempty"`
+		CPUModel    string `json:",omitempty"`
+		CPUCount    int
+		MemoryBytes uint64 `json:",omitempty"`
+		Filesystem  string `json:",omitempty"`
+		GoVersion   string
+	}
+	// BenchmarkReport is the output of a benchmark run.
+	BenchmarkReport struct {
+		Manifest *RunManifest
+		Results  TestResults
+	}
+)
+
+// NewRunManifest captures the effective flag values of the command and the
+// host details.
+func NewRunManifest(cmd *cobra.Command, seed int64) *RunManifest {
+	m := new(RunManifest)
+	m.StartTime = time.Now()
+	m.Seed = seed
+	info := version.GetInfo()
+	m.Version = info.Version
+	m.Commit = info.Commit
+	m.Flags = make(map[string]string)
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if f.Name == "help" {
+			return
+		}
+		m.Flags[f.Name] = f.Value.String()
+	})
+	m.Host = getHostInfo()
+	return m
+}
+
+// getHostInfo collects the host details. The CPU, memory, kernel and
+// filesystem are read from /proc and are left empty when it's not available.
+func getHostInfo() HostInfo {
+	h := HostInfo{
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		CPUCount:  runtime.NumCPU(),
+		GoVersion: runtime.Version(),
+	}
+	h.Hostname, _ = os.Hostname()
+	if kernel, err := os.ReadFile("/proc/sys/kernel/osrelease"); err == nil {
+		h.Kernel = strings.TrimSpace(string(kernel))
+	}
+	h.CPUModel = readProcField("/proc/cpuinfo", "model name")
+	if mem := strings.TrimSuffix(readProcField("/proc/meminfo", "MemTotal"), " kB"); mem != "" {
+		if kb, err := strconv.ParseUint(mem, 10, 64); err == nil {
+			h.MemoryBytes = kb * 1024
+		}
+	}
+	h.Filesystem = getFilesystem(*dbPath)
+	return h
+}
+
+// readProcField returns the value of the first "key: value" line with the key.
+func readProcField(path, key string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		k, v, found := strings.Cut(scanner.Text(), ":")
+		if found && strings.TrimSpace(k) == key {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// getFilesystem returns the type of the filesystem holding the path, based on
+// the longest mount point containing it.
+func getFilesystem(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+	f, err := os.Open("/proc/self/mounts")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	var mountPoint, fsType string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		mp := fields[1]
+		if !strings.HasPrefix(abs, mp) || len(mp) < len(mountPoint) {
+			continue
+		}
+		if mp != "/" && abs != mp && !strings.HasPrefix(abs, mp+"/") {
+			continue
+		}
+		mountPoint, fsType = mp, fields[2]
+	}
+	return fsType
+}
+
+// WriteText renders the manifest followed by the results table.
+func (r BenchmarkReport) WriteText(w io.Writer) error {
+	if r.Manifest != nil {
+		m := r.Manifest
+		t := table.NewWriter()
+		t.SetOutputMirror(w)
+		t.AppendRow(table.Row{"Start Time", m.StartTime.Format(time.RFC3339)})
+		t.AppendRow(table.Row{"Seed", m.Seed})
+		t.AppendRow(table.Row{"Version", fmt.Sprintf("%s (%s)", m.Version, m.Commit)})
+		t.AppendRow(table.Row{"Host", fmt.Sprintf("%s %s/%s %s", m.Host.Hostname, m.Host.OS, m.Host.Arch, m.Host.Kernel)})
+		t.AppendRow(table.Row{"CPU", fmt.Sprintf("%d x %s", m.Host.CPUCount, m.Host.CPUModel)})
+		t.AppendRow(table.Row{"Memory", fmt.Sprintf("%d MiB", m.Host.MemoryBytes/1024/1024)})
+		t.AppendRow(table.Row{"Filesystem", m.Host.Filesystem})
+		if m.Snapshot != nil {
+			t.AppendRow(table.Row{"Snapshot", m.Snapshot.ContentHash})
+		}
+		t.Render()
+	}
+	return r.Results.WriteText(w)
+}
@@ -3,6 +3,7 @@ package dbbench
 import (
 	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/bloom"
+	"github.com/cockroachdb/pebble/vfs"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/util"
 	"runtime"
@@ -21,7 +22,7 @@ type (
 	}
 )
 
-func NewWrappedPebbleDB() (*PebbleDBWrapper, error) {
+func NewWrappedPebbleDB(path string) (*PebbleDBWrapper, error) {
 	memTableLimit := 2
 	memTableSize := *cacheSize * 1024 * 1024 / 2 / memTableLimit
 	opt := &pebble.Options{
@@ -40,7 +41,10 @@ func NewWrappedPebbleDB() (*PebbleDBWrapper, error) {
 		},
 		ReadOnly: *readOnly || *fullScan,
 	}
-	p, err := pebble.Open(*dbPath, opt)
+	if *directIO {
+		opt.FS = directFS{vfs.Default}
+	}
+	p, err := pebble.Open(path, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,111 @@
+package dbbench
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/maticnetwork/polygon-cli/progress"
+	"github.com/rs/zerolog/log"
+)
+
+// rangeScanPass distinguishes the random start keys of the scans from the written entries.
+const rangeScanPass = 1 << 32
+
+type LatencySummary struct {
+	Min  time.Duration
+	Mean time.Duration
+	P50  time.Duration
+	P99  time.Duration
+	Max  time.Duration
+}
+
+// NewLatencySummary summarizes the latencies, which are sorted in place.
+func NewLatencySummary(latencies []time.Duration) *LatencySummary {
+	if len(latencies) == 0 {
+		return nil
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	var total time.Duration
+	for _, l := range latencies {
+		total += l
+	}
+	return &LatencySummary{
+		Min:  latencies[0],
+		Mean: total / time.Duration(len(latencies)),
+		P50:  latencies[len(latencies)/2],
+		P99:  latencies[len(latencies)*99/100],
+		Max:  latencies[len(latencies)-1],
+	}
+}
+
+// runRangeScans runs scans of span keys from random start keys with the given number of concurrent iterators.
+// Every scan opens its own iterator, and the time to open it and seek to the start key is recorded as the
+// setup latency. It returns the number of keys read.
+func runRangeScans(ctx context.Context, db KeyValueDB, scans, span uint64, iterators int) (uint64, *LatencySummary, error) {
+	pb := progress.New("range scans", int64(scans), "iop")
+	var next, keyCount atomic.Uint64
+	latencies := make([][]time.Duration, iterators)
+	var wg sync.WaitGroup
+	var werr workerError
+	for w := 0; w < iterators; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for {
+				scan := next.Add(1) - 1
+				if scan >= scans || werr.get() != nil {
+					return
+				}
+				startKey := make([]byte, 8)
+				newEntryRand(*seed, rangeScanPass, scan).Read(startKey)
+
+				start := time.Now()
+				iter := db.NewIterator()
+				exists := iter.Seek(startKey)
+				if !exists {
+					// past the last key, wrap around to the beginning
+					exists = iter.First()
+				}
+				latencies[w] = append(latencies[w], time.Since(start))
+
+				var n uint64
+				for ; exists && n < span; exists = iter.Next() {
+					_ = iter.Key()
+					_ = iter.Value()
+					n++
+				}
+				iter.Release()
+				if err := iter.Error(); err != nil {
+					werr.set(fmt.Errorf("unable to run the range scan: %w", err))
+					return
+				}
+				keyCount.Add(n)
+				countOps(n)
+				pb.Add(1)
+			}
+		}(w)
+	}
+	wg.Wait()
+	pb.Finish()
+	if err := werr.get(); err != nil {
+		return 0, nil, err
+	}
+
+	all := make([]time.Duration, 0, scans)
+	for _, l := range latencies {
+		all = append(all, l...)
+	}
+	setup := NewLatencySummary(all)
+	if setup != nil {
+		log.Info().Dur("p50", setup.P50).Dur("p99", setup.P99).Dur("max", setup.Max).Msg("Iterator setup latency")
+	}
+	return keyCount.Load(), setup, nil
+}
+
+func rangeScanDesc() string {
+	return fmt.Sprintf("range scan (span %d, %d iterators)", *rangeScanSpan, *rangeScanIterators)
+}
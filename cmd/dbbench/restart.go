@@ -0,0 +1,89 @@
+package dbbench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// runRestartPhases measures the costs dominating the restart of a node: closing the database cleanly, opening
+// it again, and recovering its journal after the process writing to it was killed. It returns the database
+// opened after the last phase.
+func runRestartPhases(ctx context.Context, db KeyValueDB, record func(*TestResult)) (KeyValueDB, error) {
+	start := startPhase()
+	if err := db.Close(); err != nil {
+		return nil, fmt.Errorf("unable to close the database: %w", err)
+	}
+	record(NewTestResult(start, time.Now(), "clean close", 1))
+
+	start = startPhase()
+	db, err := openDB()
+	if err != nil {
+		return nil, fmt.Errorf("unable to reopen the database: %w", err)
+	}
+	record(NewTestResult(start, time.Now(), "clean open", 1))
+
+	if *readOnly || *crashWrites == 0 {
+		return db, nil
+	}
+	// The crashing process needs the lock of the database.
+	if err = db.Close(); err != nil {
+		return nil, fmt.Errorf("unable to close the database: %w", err)
+	}
+	if err = crash(ctx); err != nil {
+		return nil, err
+	}
+	start = startPhase()
+	if db, err = openDB(); err != nil {
+		return nil, fmt.Errorf("unable to recover the database: %w", err)
+	}
+	// The op count is the number of entries written by the crashed process, the ones which weren't flushed to a
+	// table yet are replayed from the journal.
+	record(NewTestResult(start, time.Now(), "crash recovery", *crashWrites))
+	return db, nil
+}
+
+// crash runs the same command with --crash-writer and waits until it kills itself, leaving the entries it
+// wrote in the journal of the database.
+func crash(ctx context.Context) error {
+	log.Info().Uint64("crashWrites", *crashWrites).Msg("Writing to the database until the crash")
+	cmd := exec.CommandContext(ctx, os.Args[0], append(os.Args[1:], "--crash-writer")...)
+	// The standard output is kept for the summary.
+	cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != -1 {
+		return fmt.Errorf("the crash writer didn't crash: %v", err)
+	}
+	return nil
+}
+
+// crashWriteIndex is the index of the first entry of the crash writer. The background writer grows from the end
+// of the written entries for as long as the run lasts, so the crash writer starts far away from both to write
+// new keys.
+const crashWriteIndex = 1 << 62
+
+// runCrashWriter writes --crash-writes new entries, in a key range of their own, and kills the process without
+// closing the database.
+func runCrashWriter(ctx context.Context) error {
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+	if err = writeData(ctx, db, crashWriteIndex, *crashWrites, 0, *sequentialWrites); err != nil {
+		return err
+	}
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		return err
+	}
+	if err = p.Kill(); err != nil {
+		return err
+	}
+	select {}
+}
@@ -0,0 +1,146 @@
+package dbbench
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+type (
+	// ShardedDB hash-partitions the keys across independent databases. Each
+	// shard has its own pool of --degree-of-parallelism workers, and counts
+	// the entries read and written since the last phase.
+	ShardedDB struct {
+		shards []KeyValueDB
+		pools  []chan struct{}
+		ops    []atomic.Uint64
+	}
+	ShardResult struct {
+		Shard   int
+		OpCount uint64
+		OpRate  float64
+	}
+	// shardCountingIterator merges the iterators of the shards and counts
+	// the values read in the shard of their key.
+	shardCountingIterator struct {
+		iterator.Iterator
+		db *ShardedDB
+	}
+)
+
+// NewShardedDB opens the shards in subfolders of the database path.
+func NewShardedDB(count int, open func(path string) (KeyValueDB, error)) (*ShardedDB, error) {
+	s := &ShardedDB{
+		shards: make([]KeyValueDB, count),
+		pools:  make([]chan struct{}, count),
+		ops:    make([]atomic.Uint64, count),
+	}
+	for i := range s.shards {
+		db, err := open(filepath.Join(*dbPath, fmt.Sprintf("shard-%d", i)))
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("unable to open the shard %d: %w", i, err)
+		}
+		s.shards[i] = db
+		s.pools[i] = make(chan struct{}, max(int(*degreeOfParallelism), 1))
+	}
+	return s, nil
+}
+
+func (s *ShardedDB) shardOf(key []byte) int {
+	h := fnv.New64a()
+	_, _ = h.Write(key)
+	return int(h.Sum64() % uint64(len(s.shards)))
+}
+
+// do runs the operation on the shard of the key once one of its workers is available.
+func (s *ShardedDB) do(key []byte, op func(db KeyValueDB) error) error {
+	i := s.shardOf(key)
+	s.pools[i] <- struct{}{}
+	defer func() { <-s.pools[i] }()
+	s.ops[i].Add(1)
+	return op(s.shards[i])
+}
+
+func (s *ShardedDB) Get(key []byte) (value []byte, err error) {
+	err = s.do(key, func(db KeyValueDB) error {
+		value, err = db.Get(key)
+		return err
+	})
+	return value, err
+}
+
+func (s *ShardedDB) Put(key []byte, value []byte) error {
+	return s.do(key, func(db KeyValueDB) error {
+		return db.Put(key, value)
+	})
+}
+
+func (s *ShardedDB) NewIterator() iterator.Iterator {
+	iters := make([]iterator.Iterator, len(s.shards))
+	for i, db := range s.shards {
+		iters[i] = db.NewIterator()
+	}
+	return &shardCountingIterator{Iterator: iterator.NewMergedIterator(iters, comparer.DefaultComparer, true), db: s}
+}
+
+func (it *shardCountingIterator) Value() []byte {
+	it.db.ops[it.db.shardOf(it.Key())].Add(1)
+	return it.Iterator.Value()
+}
+
+// Compact compacts the shards concurrently.
+func (s *ShardedDB) Compact() error {
+	errs := make([]error, len(s.shards))
+	var wg sync.WaitGroup
+	for i, db := range s.shards {
+		wg.Add(1)
+		go func(i int, db KeyValueDB) {
+			defer wg.Done()
+			errs[i] = db.Compact()
+		}(i, db)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ShardedDB) Close() error {
+	var err error
+	for _, db := range s.shards {
+		if db == nil {
+			continue
+		}
+		if cerr := db.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Results returns the throughput of each shard over the phase and resets the
+// counts for the next phase. There are no results for the phases without
+// reads or writes, e.g. the compaction.
+func (s *ShardedDB) Results(d time.Duration) []ShardResult {
+	results := make([]ShardResult, len(s.shards))
+	var total uint64
+	for i := range s.shards {
+		n := s.ops[i].Swap(0)
+		total += n
+		results[i] = ShardResult{Shard: i, OpCount: n, OpRate: float64(n) / d.Seconds()}
+	}
+	if total == 0 {
+		return nil
+	}
+	return results
+}
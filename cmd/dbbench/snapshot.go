@@ -0,0 +1,293 @@
+package dbbench
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// snapshotManifestName is the file holding the manifest of a snapshot, the
+// last entry of the archive. It is kept in the restored database to mark the
+// directory as safe to replace by the next restore.
+const snapshotManifestName = "polycli-snapshot.json"
+
+// SnapshotManifest describes the database of a snapshot. The content hash
+// only depends on the paths and contents of the files, not on their order in
+// the archive.
+type SnapshotManifest struct {
+	CreatedAt   time.Time
+	DBMode      string
+	Files       int
+	Bytes       int64
+	ContentHash string
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot file",
+	Short: "Archive the benchmark database so it can be restored with --restore-from.",
+	Long: `Archive the --db-path directory in a tar file, compressed with gzip when the file ends with .gz,
+along with a content hash verified on restore. The database must not be in use.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		m, err := createSnapshot(*dbPath, args[0])
+		if err != nil {
+			return err
+		}
+		log.Info().Str("file", args[0]).Int("files", m.Files).Int64("bytes", m.Bytes).Str("contentHash", m.ContentHash).Msg("Created snapshot")
+		return nil
+	},
+}
+
+// contentHasher hashes every file separately so the content hash doesn't
+// depend on the order of the files.
+type contentHasher map[string][]byte
+
+func (h contentHasher) add(name string, r io.Reader) (int64, error) {
+	sum := sha256.New()
+	n, err := io.Copy(sum, r)
+	if err != nil {
+		return n, err
+	}
+	h[name] = sum.Sum(nil)
+	return n, nil
+}
+
+func (h contentHasher) sum() string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sum := sha256.New()
+	for _, name := range names {
+		_, _ = fmt.Fprintf(sum, "%s\x00%x\n", name, h[name])
+	}
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+func createSnapshot(dir, file string) (m *SnapshotManifest, err error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	var w io.Writer = f
+	if strings.HasSuffix(file, ".gz") {
+		gz := gzip.NewWriter(f)
+		defer func() {
+			if closeErr := gz.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+	defer func() {
+		if closeErr := tw.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	m = &SnapshotManifest{CreatedAt: time.Now(), DBMode: *dbMode}
+	hasher := make(contentHasher)
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil || name == "." || name == snapshotManifestName {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return fmt.Errorf("%s is neither a file nor a directory", path)
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(name)
+		if err = tw.WriteHeader(hdr); err != nil || info.IsDir() {
+			return err
+		}
+
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		n, err := hasher.add(hdr.Name, io.TeeReader(src, tw))
+		m.Files += 1
+		m.Bytes += n
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if m.Files == 0 {
+		return nil, fmt.Errorf("there is no database in %s", dir)
+	}
+
+	m.ContentHash = hasher.sum()
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	hdr := &tar.Header{Name: snapshotManifestName, Mode: 0644, Size: int64(len(data)), ModTime: m.CreatedAt}
+	if err = tw.WriteHeader(hdr); err != nil {
+		return nil, err
+	}
+	if _, err = tw.Write(data); err != nil {
+		return nil, err
+	}
+	// The database is backed up by the snapshot, so it can be replaced by a
+	// restore.
+	if err = os.WriteFile(filepath.Join(dir, snapshotManifestName), data, 0644); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// restoreSnapshot replaces the database in dir with the snapshot. The
+// snapshot is extracted next to it and only replaces it once its content hash
+// is verified. To avoid deleting data by mistake, dir must not exist, be
+// empty, or hold a database which was snapshotted or restored.
+func restoreSnapshot(file, dir string) (*SnapshotManifest, error) {
+	if err := checkReplaceable(dir); err != nil {
+		return nil, err
+	}
+	tmp := filepath.Clean(dir) + ".restoring"
+	if err := os.RemoveAll(tmp); err != nil {
+		return nil, err
+	}
+	m, err := extractSnapshot(file, tmp)
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		return nil, err
+	}
+	if err = os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err = os.Rename(tmp, dir); err != nil {
+		return nil, err
+	}
+	log.Info().Str("file", file).Str("dbPath", dir).Int("files", m.Files).Str("contentHash", m.ContentHash).Msg("Restored snapshot")
+	return m, nil
+}
+
+func checkReplaceable(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && len(entries) == 0) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(filepath.Join(dir, snapshotManifestName)); err != nil {
+		return fmt.Errorf("refusing to replace %s which was neither snapshotted nor restored, remove it first", dir)
+	}
+	return nil
+}
+
+// extractSnapshot extracts the snapshot into dir and verifies its content
+// hash against its manifest.
+func extractSnapshot(file, dir string) (*SnapshotManifest, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(file, ".gz") {
+		gz, gzErr := gzip.NewReader(f)
+		if gzErr != nil {
+			return nil, gzErr
+		}
+		defer gz.Close()
+		r = gz
+	}
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var m *SnapshotManifest
+	var manifestData []byte
+	hasher := make(contentHasher)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsLocal(hdr.Name) {
+			return nil, fmt.Errorf("invalid path %s in the snapshot", hdr.Name)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		switch {
+		case hdr.Typeflag == tar.TypeDir:
+			err = os.MkdirAll(path, 0755)
+		case hdr.Typeflag != tar.TypeReg:
+			err = fmt.Errorf("unexpected entry %s in the snapshot", hdr.Name)
+		case hdr.Name == snapshotManifestName:
+			if manifestData, err = io.ReadAll(tr); err == nil {
+				err = os.WriteFile(path, manifestData, 0644)
+			}
+		default:
+			err = extractFile(path, hdr.Name, tr, hasher)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if manifestData == nil {
+		return nil, fmt.Errorf("%s isn't a snapshot, it has no manifest", file)
+	}
+	if err = json.Unmarshal(manifestData, &m); err != nil {
+		return nil, fmt.Errorf("unable to parse the manifest of the snapshot: %w", err)
+	}
+	if sum := hasher.sum(); sum != m.ContentHash {
+		return nil, fmt.Errorf("the content hash of the snapshot is %s instead of %s", sum, m.ContentHash)
+	}
+	return m, nil
+}
+
+func extractFile(path, name string, r io.Reader, hasher contentHasher) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = hasher.add(name, io.TeeReader(r, f)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
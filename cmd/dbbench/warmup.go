@@ -0,0 +1,174 @@
+package dbbench
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// meterInterval is the period of the samples of the op count used to detect the steady state.
+	meterInterval = 100 * time.Millisecond
+	// steadyStateWindow is the number of consecutive intervals whose op rates must agree within
+	// --steady-state-tolerance for the phase to be steady.
+	steadyStateWindow = 10
+)
+
+// SteadyStateResult splits a phase into its warmup, the first --warmup-ops operations and the ramp up to
+// the steady state with --steady-state, and the rest of the phase.
+type SteadyStateResult struct {
+	WarmupDuration time.Duration
+	WarmupOpCount  uint64
+	// Detected is false when the rate never settled, the numbers being the ones after the warmup ops.
+	Detected     bool `json:",omitempty"`
+	TestDuration time.Duration
+	OpCount      uint64
+	OpRate       float64
+}
+
+type (
+	// opMeter counts the operations of a phase and samples the count to time the end of the warmup.
+	opMeter struct {
+		start     time.Time
+		ops       atomic.Uint64
+		warmupEnd atomic.Pointer[meterSample]
+		samples   []meterSample
+		stop      chan struct{}
+		wg        sync.WaitGroup
+	}
+	meterSample struct {
+		elapsed time.Duration
+		ops     uint64
+	}
+)
+
+// meter is the meter of the running phase, nil without --warmup-ops and --steady-state.
+var meter atomic.Pointer[opMeter]
+
+// startMeter starts the meter of a phase starting at the given time.
+func startMeter(start time.Time) {
+	m := &opMeter{start: start, stop: make(chan struct{})}
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(meterInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.stop:
+				return
+			case now := <-ticker.C:
+				m.samples = append(m.samples, meterSample{elapsed: now.Sub(m.start), ops: m.ops.Load()})
+			}
+		}
+	}()
+	if old := meter.Swap(m); old != nil {
+		old.finish(time.Now())
+	}
+}
+
+// countOps adds the operations to the meter of the running phase.
+func countOps(n uint64) {
+	m := meter.Load()
+	if m == nil {
+		return
+	}
+	total := m.ops.Add(n)
+	if *warmupOps > 0 && total >= *warmupOps && total-n < *warmupOps {
+		m.warmupEnd.Store(&meterSample{elapsed: time.Since(m.start), ops: total})
+	}
+}
+
+// finish stops the sampling and records the last sample at the end of the phase.
+func (m *opMeter) finish(end time.Time) {
+	close(m.stop)
+	m.wg.Wait()
+	m.samples = append(m.samples, meterSample{elapsed: end.Sub(m.start), ops: m.ops.Load()})
+}
+
+// stopMeter stops the meter of the phase ending at the given time and returns its steady state, or nil when
+// nothing was counted or when the phase is a single operation, e.g. the compaction or the reopening of the
+// database, which has no warmup to split.
+func stopMeter(end time.Time, opCount uint64) *SteadyStateResult {
+	m := meter.Swap(nil)
+	if m == nil {
+		return nil
+	}
+	m.finish(end)
+	total := m.ops.Load()
+	if total == 0 || opCount <= 1 {
+		return nil
+	}
+
+	steady := meterSample{}
+	if *warmupOps > 0 {
+		w := m.warmupEnd.Load()
+		if w == nil || w.ops >= total {
+			log.Warn().Uint64("warmupOps", *warmupOps).Uint64("opCount", total).Msg("The warmup lasted the whole phase")
+			return nil
+		}
+		steady = *w
+	}
+	detected := false
+	if *steadyState {
+		steady, detected = m.detectSteadyState(steady)
+	}
+
+	last := m.samples[len(m.samples)-1]
+	res := &SteadyStateResult{
+		WarmupDuration: steady.elapsed,
+		WarmupOpCount:  steady.ops,
+		Detected:       detected,
+		TestDuration:   last.elapsed - steady.elapsed,
+		OpCount:        last.ops - steady.ops,
+	}
+	res.OpRate = float64(res.OpCount) / res.TestDuration.Seconds()
+	log.Info().Dur("warmupDuration", res.WarmupDuration).Bool("detected", res.Detected).Float64("opRate", res.OpRate).Msg("Steady state")
+	return res
+}
+
+// detectSteadyState returns the first sample after the warmup from which the rates of the next
+// steadyStateWindow intervals have a coefficient of variation within the tolerance, or the end of the warmup
+// when the rate never settles.
+func (m *opMeter) detectSteadyState(warmup meterSample) (meterSample, bool) {
+	samples := []meterSample{warmup}
+	for _, s := range m.samples {
+		if s.elapsed > warmup.elapsed {
+			samples = append(samples, s)
+		}
+	}
+	// the last interval is cut short by the end of the phase
+	if len(samples) > 1 {
+		samples = samples[:len(samples)-1]
+	}
+	rates := make([]float64, 0, len(samples))
+	for i := 1; i < len(samples); i++ {
+		rates = append(rates, float64(samples[i].ops-samples[i-1].ops)/(samples[i].elapsed-samples[i-1].elapsed).Seconds())
+	}
+	for i := 0; i+steadyStateWindow <= len(rates); i++ {
+		if variation(rates[i:i+steadyStateWindow]) <= *steadyStateTolerance {
+			return samples[i], true
+		}
+	}
+	return warmup, false
+}
+
+// variation returns the coefficient of variation of the values, their standard deviation over their mean.
+func variation(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	if mean == 0 {
+		return math.Inf(1)
+	}
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(squares/float64(len(values))) / mean
+}
@@ -0,0 +1,78 @@
+package dbbench
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestVariation(t *testing.T) {
+	for name, tc := range map[string]struct {
+		values   []float64
+		expected float64
+	}{
+		"constant": {[]float64{5, 5, 5}, 0},
+		"spread":   {[]float64{8, 12}, 0.2},
+		"zero":     {[]float64{0, 0}, math.Inf(1)},
+	} {
+		if v := variation(tc.values); v != tc.expected && math.Abs(v-tc.expected) > 1e-9 {
+			t.Errorf("%s: expected %f, got %f", name, tc.expected, v)
+		}
+	}
+}
+
+// meterWithRates returns a meter whose samples are taken every meterInterval at the given op rates, followed by
+// a last sample cut short by the end of the phase.
+func meterWithRates(rates ...float64) *opMeter {
+	m := new(opMeter)
+	var s meterSample
+	for _, r := range rates {
+		s.elapsed += meterInterval
+		s.ops += uint64(r * meterInterval.Seconds())
+		m.samples = append(m.samples, s)
+	}
+	m.samples = append(m.samples, meterSample{elapsed: s.elapsed + meterInterval/2, ops: s.ops + 1})
+	return m
+}
+
+func TestDetectSteadyState(t *testing.T) {
+	previous := *steadyStateTolerance
+	*steadyStateTolerance = 0.1
+	defer func() { *steadyStateTolerance = previous }()
+
+	ramp := []float64{100, 200, 300, 400}
+	steady := []float64{1000, 1050, 980, 1000, 1020, 990, 1000, 1010, 1000, 990}
+	noisy := []float64{1000, 200, 1500, 100, 1200, 300, 1000, 200, 1500, 100, 900}
+
+	for name, tc := range map[string]struct {
+		m        *opMeter
+		warmup   meterSample
+		detected bool
+		elapsed  time.Duration
+	}{
+		"steady from the start": {m: meterWithRates(steady...), detected: true},
+		"after the ramp up": {
+			m:        meterWithRates(append(ramp, steady...)...),
+			detected: true,
+			elapsed:  4 * meterInterval,
+		},
+		"after the warmup ops": {
+			m:        meterWithRates(append(ramp, steady...)...),
+			warmup:   meterSample{elapsed: 2 * meterInterval, ops: 40},
+			detected: true,
+			elapsed:  4 * meterInterval,
+		},
+		"never settles": {
+			m:      meterWithRates(noisy...),
+			warmup: meterSample{elapsed: meterInterval, ops: 100},
+			// the end of the warmup is kept
+			elapsed: meterInterval,
+		},
+		"too short": {m: meterWithRates(steady[:steadyStateWindow-1]...)},
+	} {
+		s, detected := tc.m.detectSteadyState(tc.warmup)
+		if detected != tc.detected || s.elapsed != tc.elapsed {
+			t.Errorf("%s: expected the steady state from %s (%t), got %s (%t)", name, tc.elapsed, tc.detected, s.elapsed, detected)
+		}
+	}
+}
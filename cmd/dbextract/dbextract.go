@@ -0,0 +1,324 @@
+package dbextract
+
+import (
+	"bufio"
+	"compress/gzip"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// exportMagic identifies the export files.
+	exportMagic   = "polycli-dbextract"
+	exportVersion = 1
+)
+
+var (
+	//go:embed usage.md
+	usage string
+
+	// prefixPresets are the key prefixes of the geth/bor chaindata schema.
+	prefixPresets = map[string][]string{
+		"headers":      {"h", "H"},
+		"bodies":       {"b"},
+		"receipts":     {"r"},
+		"tx-lookup":    {"l"},
+		"code":         {"c"},
+		"snapshot":     {"a", "o"},
+		"account-trie": {"A"},
+		"storage-trie": {"O"},
+		"state":        {"A", "O", "c"},
+		"preimages":    {"secure-key-"},
+	}
+)
+
+type (
+	dbextractParams struct {
+		DBPath  *string
+		DBMode  *string
+		Cache   *int
+		Handles *int
+
+		File     *string
+		Prefixes *[]string
+		HashTrie *bool
+		Limit    *uint64
+		Force    *bool
+	}
+	// exportHeader is the first RLP item of an export file.
+	exportHeader struct {
+		Magic    string
+		Version  uint64
+		Prefixes [][]byte
+		HashTrie bool
+		Time     uint64
+	}
+	exportEntry struct {
+		Key   []byte
+		Value []byte
+	}
+)
+
+var params dbextractParams
+
+var DBExtractCmd = &cobra.Command{
+	Use:   "dbextract",
+	Short: "Export and import key/value pairs of a chaindata database.",
+	Long:  usage,
+	Args:  cobra.NoArgs,
+}
+
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the entries matching the prefixes to a file.",
+	Long:  "Export the entries of the database whose key matches one of the prefixes to a portable file. The file is gzip compressed when its name ends with .gz.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		prefixes, err := parsePrefixes(*params.Prefixes)
+		if err != nil {
+			return err
+		}
+		if len(prefixes) == 0 && !*params.HashTrie {
+			return errors.New("at least one prefix or --hash-trie is required")
+		}
+		db, err := openDB(true)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		cmd.SilenceUsage = true
+		return exportEntries(db, prefixes)
+	},
+}
+
+var ImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import the entries of an export file into a database.",
+	Long:  "Import the entries of an export file into a database, which is created when it doesn't exist. A database holding entries is only written with --force.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := openDB(false)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		// The flags are fine past this point, so the usage isn't relevant to the errors.
+		cmd.SilenceUsage = true
+		return importEntries(db)
+	},
+}
+
+// openDB opens the database in the format given by the db mode.
+func openDB(readonly bool) (ethdb.KeyValueStore, error) {
+	log.Info().Str("path", *params.DBPath).Str("mode", *params.DBMode).Bool("readonly", readonly).Msg("Opening database")
+	switch *params.DBMode {
+	case "leveldb":
+		return leveldb.New(*params.DBPath, *params.Cache, *params.Handles, "", readonly)
+	case "pebbledb":
+		return pebble.New(*params.DBPath, *params.Cache, *params.Handles, "", readonly, false)
+	default:
+		return nil, fmt.Errorf("the mode %s is not recognized", *params.DBMode)
+	}
+}
+
+// parsePrefixes resolves the preset names and hex encoded prefixes. Nested prefixes are dropped so no entry is
+// exported twice.
+func parsePrefixes(values []string) ([][]byte, error) {
+	unique := make(map[string]struct{})
+	for _, v := range values {
+		if preset, ok := prefixPresets[strings.ToLower(v)]; ok {
+			for _, p := range preset {
+				unique[p] = struct{}{}
+			}
+			continue
+		}
+		p, err := hexutil.Decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("the prefix %s is neither a preset nor 0x prefixed hex: %w", v, err)
+		}
+		unique[string(p)] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(unique))
+	for p := range unique {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+	prefixes := make([][]byte, 0, len(sorted))
+	for _, p := range sorted {
+		if len(prefixes) > 0 && strings.HasPrefix(p, string(prefixes[len(prefixes)-1])) {
+			continue
+		}
+		prefixes = append(prefixes, []byte(p))
+	}
+	return prefixes, nil
+}
+
+func exportEntries(db ethdb.KeyValueStore, prefixes [][]byte) error {
+	f, err := os.Create(*params.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	bw := bufio.NewWriter(f)
+	var w io.Writer = bw
+	if strings.HasSuffix(*params.File, ".gz") {
+		w = gzip.NewWriter(bw)
+	}
+
+	header := exportHeader{Magic: exportMagic, Version: exportVersion, Prefixes: prefixes, HashTrie: *params.HashTrie, Time: uint64(time.Now().Unix())}
+	if err = rlp.Encode(w, &header); err != nil {
+		return err
+	}
+
+	var count uint64
+	write := func(it ethdb.Iterator, keep func([]byte) bool) error {
+		defer it.Release()
+		for it.Next() {
+			if *params.Limit > 0 && count >= *params.Limit {
+				return nil
+			}
+			if keep != nil && !keep(it.Key()) {
+				continue
+			}
+			if err := rlp.Encode(w, &exportEntry{Key: it.Key(), Value: it.Value()}); err != nil {
+				return err
+			}
+			count++
+			if count%1000000 == 0 {
+				log.Info().Uint64("count", count).Str("currentKey", hexutil.Encode(it.Key())).Msg("Exporting entries")
+			}
+		}
+		return it.Error()
+	}
+	for _, p := range prefixes {
+		log.Info().Str("prefix", hexutil.Encode(p)).Msg("Exporting prefix")
+		if err = write(db.NewIterator(p, nil), nil); err != nil {
+			return err
+		}
+	}
+	if *params.HashTrie {
+		// The hash based trie nodes are keyed by their bare 32 bytes hash, so the whole database is scanned.
+		log.Info().Msg("Exporting hash based trie nodes")
+		if err = write(db.NewIterator(nil, nil), func(k []byte) bool { return len(k) == 32 }); err != nil {
+			return err
+		}
+	}
+
+	if gw, ok := w.(*gzip.Writer); ok {
+		if err = gw.Close(); err != nil {
+			return err
+		}
+	}
+	if err = bw.Flush(); err != nil {
+		return err
+	}
+	log.Info().Uint64("count", count).Str("file", *params.File).Msg("Export done")
+	return nil
+}
+
+func importEntries(db ethdb.KeyValueStore) error {
+	if !*params.Force {
+		it := db.NewIterator(nil, nil)
+		notEmpty := it.Next()
+		it.Release()
+		if notEmpty {
+			return errors.New("the database isn't empty, use --force to import anyway")
+		}
+	}
+
+	f, err := os.Open(*params.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var r io.Reader = bufio.NewReader(f)
+	if strings.HasSuffix(*params.File, ".gz") {
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		r = gr
+	}
+	stream := rlp.NewStream(r, 0)
+
+	var header exportHeader
+	if err = stream.Decode(&header); err != nil {
+		return fmt.Errorf("unable to read the export header: %w", err)
+	}
+	if header.Magic != exportMagic {
+		return errors.New("the file isn't a dbextract export")
+	}
+	if header.Version != exportVersion {
+		return fmt.Errorf("the export version %d is not supported", header.Version)
+	}
+	log.Info().Time("exportTime", time.Unix(int64(header.Time), 0)).Int("prefixes", len(header.Prefixes)).Bool("hashTrie", header.HashTrie).Msg("Importing export")
+
+	batch := db.NewBatch()
+	var count uint64
+	for {
+		var e exportEntry
+		if err = stream.Decode(&e); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("unable to read entry %d: %w", count, err)
+		}
+		if err = batch.Put(e.Key, e.Value); err != nil {
+			return err
+		}
+		count++
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err = batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+		if count%1000000 == 0 {
+			log.Info().Uint64("count", count).Msg("Importing entries")
+		}
+	}
+	if err = batch.Write(); err != nil {
+		return err
+	}
+	log.Info().Uint64("count", count).Str("path", *params.DBPath).Msg("Import done")
+	return nil
+}
+
+func init() {
+	DBExtractCmd.AddCommand(ExportCmd)
+	DBExtractCmd.AddCommand(ImportCmd)
+
+	flagSet := DBExtractCmd.PersistentFlags()
+	params.DBPath = flagSet.String("db-path", "", "The path of the chaindata database")
+	params.DBMode = flagSet.String("db-mode", "leveldb", "The format of the database: leveldb or pebbledb")
+	params.Cache = flagSet.Int("cache-size", 512, "The number of megabytes to use as cache")
+	params.Handles = flagSet.Int("handles", 500, "The number of open files")
+	params.File = flagSet.StringP("file", "f", "", "The export file, gzip compressed when its name ends with .gz")
+	_ = DBExtractCmd.MarkPersistentFlagRequired("db-path")
+	_ = DBExtractCmd.MarkPersistentFlagRequired("file")
+
+	presets := make([]string, 0, len(prefixPresets))
+	for name := range prefixPresets {
+		presets = append(presets, name)
+	}
+	sort.Strings(presets)
+	params.Prefixes = ExportCmd.Flags().StringSliceP("prefix", "p", nil, fmt.Sprintf("The key prefixes to export, either 0x prefixed hex or one of the presets [%s]", strings.Join(presets, ", ")))
+	params.HashTrie = ExportCmd.Flags().Bool("hash-trie", false, "Also export the hash based trie nodes, whose keys are bare 32 bytes hashes")
+	params.Limit = ExportCmd.Flags().Uint64("limit", 0, "The maximum number of entries to export, 0 for no limit")
+	params.Force = ImportCmd.Flags().Bool("force", false, "Import into a database which already holds entries")
+}
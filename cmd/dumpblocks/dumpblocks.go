@@ -12,8 +12,9 @@ import (
 
 	_ "embed"
 
-	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/maticnetwork/polygon-cli/progress"
 	"github.com/maticnetwork/polygon-cli/proto/gen/pb"
+	"github.com/maticnetwork/polygon-cli/rpcclient"
 	"github.com/maticnetwork/polygon-cli/rpctypes"
 	"github.com/maticnetwork/polygon-cli/util"
 	"github.com/rs/zerolog/log"
@@ -35,11 +36,17 @@ type (
 		Filename           string
 		Mode               string
 		FilterStr          string
+		BlockFields        []string
+		ReceiptFields      []string
+		RPCClient          *rpcclient.Flags
 		filter             Filter
+		blockFields        fieldTree
+		receiptFields      fieldTree
 	}
 	Filter struct {
-		To   []string `json:"to"`
-		From []string `json:"from"`
+		To     []string `json:"to"`
+		From   []string `json:"from"`
+		Topics []string `json:"topics"`
 	}
 )
 
@@ -59,7 +66,7 @@ var DumpblocksCmd = &cobra.Command{
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
-		ec, err := ethrpc.DialContext(ctx, inputDumpblocks.RpcUrl)
+		ec, err := inputDumpblocks.RPCClient.Dial(ctx, inputDumpblocks.RpcUrl)
 		if err != nil {
 			return err
 		}
@@ -69,6 +76,7 @@ var DumpblocksCmd = &cobra.Command{
 		var pool = make(chan bool, inputDumpblocks.Threads)
 		start := inputDumpblocks.Start
 		end := inputDumpblocks.End
+		bar := progress.New("dumping blocks", int64(end-start), "block")
 
 		for start < end {
 			rangeStart := start
@@ -80,11 +88,12 @@ var DumpblocksCmd = &cobra.Command{
 
 			pool <- true
 			wg.Add(1)
-			log.Info().Uint64("start", rangeStart).Uint64("end", rangeEnd).Msg("Getting range")
+			log.Debug().Uint64("start", rangeStart).Uint64("end", rangeEnd).Msg("Getting range")
 			go func() {
 				defer wg.Done()
+				defer bar.Add(int64(rangeEnd - rangeStart))
+				failCount := 0
 				for {
-					failCount := 0
 					blocks, err := util.GetBlockRange(ctx, rangeStart, rangeEnd, ec)
 					if err != nil {
 						failCount = failCount + 1
@@ -98,16 +107,10 @@ var DumpblocksCmd = &cobra.Command{
 
 					blocks = filterBlocks(blocks)
 
-					if inputDumpblocks.ShouldDumpBlocks {
-						err = writeResponses(blocks, "block")
-						if err != nil {
-							log.Error().Err(err).Msg("Error writing blocks")
-						}
-					}
-
-					if inputDumpblocks.ShouldDumpReceipts {
-						failCount = 0
-						receipts, err := util.GetReceipts(ctx, blocks, ec, inputDumpblocks.BatchSize)
+					// The receipts are needed to filter the blocks by topics.
+					var receipts []*json.RawMessage
+					if inputDumpblocks.ShouldDumpReceipts || len(inputDumpblocks.filter.Topics) > 0 {
+						receipts, err = util.GetReceipts(ctx, blocks, ec, inputDumpblocks.BatchSize)
 						if err != nil {
 							failCount = failCount + 1
 							if failCount > 5 {
@@ -117,8 +120,18 @@ var DumpblocksCmd = &cobra.Command{
 							time.Sleep(5 * time.Second)
 							continue
 						}
+						receipts, blocks = filterReceipts(receipts, blocks)
+					}
 
-						err = writeResponses(receipts, "transaction")
+					if inputDumpblocks.ShouldDumpBlocks {
+						err = writeResponses(project(blocks, inputDumpblocks.blockFields), "block")
+						if err != nil {
+							log.Error().Err(err).Msg("Error writing blocks")
+						}
+					}
+
+					if inputDumpblocks.ShouldDumpReceipts {
+						err = writeResponses(project(receipts, inputDumpblocks.receiptFields), "transaction")
 						if err != nil {
 							log.Error().Err(err).Msg("Error writing receipts")
 						}
@@ -133,6 +146,7 @@ var DumpblocksCmd = &cobra.Command{
 
 		log.Info().Msg("Finished requesting data starting to wait")
 		wg.Wait()
+		bar.Finish()
 		log.Info().Msg("Done")
 
 		return nil
@@ -178,6 +192,15 @@ var DumpblocksCmd = &cobra.Command{
 		for i := 0; i < len(inputDumpblocks.filter.From); i++ {
 			inputDumpblocks.filter.From[i] = strings.ToLower(inputDumpblocks.filter.From[i])
 		}
+		for i := 0; i < len(inputDumpblocks.filter.Topics); i++ {
+			inputDumpblocks.filter.Topics[i] = strings.ToLower(inputDumpblocks.filter.Topics[i])
+		}
+
+		if (len(inputDumpblocks.BlockFields) > 0 || len(inputDumpblocks.ReceiptFields) > 0) && inputDumpblocks.Mode != "json" {
+			return fmt.Errorf("the fields can only be selected in the json mode")
+		}
+		inputDumpblocks.blockFields = newFieldTree(inputDumpblocks.BlockFields)
+		inputDumpblocks.receiptFields = newFieldTree(inputDumpblocks.ReceiptFields)
 
 		return nil
 	},
@@ -191,7 +214,10 @@ func init() {
 	DumpblocksCmd.PersistentFlags().StringVarP(&inputDumpblocks.Filename, "filename", "f", "", "where to write the output to (default stdout)")
 	DumpblocksCmd.PersistentFlags().StringVarP(&inputDumpblocks.Mode, "mode", "m", "json", "the output format [json, proto]")
 	DumpblocksCmd.PersistentFlags().Uint64VarP(&inputDumpblocks.BatchSize, "batch-size", "b", 150, "the batch size. Realistically, this probably shouldn't be bigger than 999. Most providers seem to cap at 1000.")
-	DumpblocksCmd.PersistentFlags().StringVarP(&inputDumpblocks.FilterStr, "filter", "F", "{}", "filter output based on tx to and from, not setting a filter means all are allowed")
+	DumpblocksCmd.PersistentFlags().StringVarP(&inputDumpblocks.FilterStr, "filter", "F", "{}", "filter output based on tx to and from and receipt log topics, not setting a filter means all are allowed")
+	DumpblocksCmd.PersistentFlags().StringSliceVar(&inputDumpblocks.BlockFields, "fields", nil, "the block fields to output, nested fields being dot separated, e.g. number,hash,transactions.to (default all)")
+	DumpblocksCmd.PersistentFlags().StringSliceVar(&inputDumpblocks.ReceiptFields, "receipt-fields", nil, "the receipt fields to output, nested fields being dot separated, e.g. transactionHash,logs.topics (default all)")
+	inputDumpblocks.RPCClient = rpcclient.AddFlags(DumpblocksCmd.PersistentFlags())
 }
 
 func checkFlags() error {
@@ -315,9 +341,61 @@ func filterBlocks(blocks []*json.RawMessage) []*json.RawMessage {
 			if (len(inputDumpblocks.filter.To) > 0 && slices.Contains(inputDumpblocks.filter.To, strings.ToLower(string(tx.To)))) ||
 				(len(inputDumpblocks.filter.From) > 0 && slices.Contains(inputDumpblocks.filter.From, strings.ToLower(string(tx.From)))) {
 				filtered = append(filtered, msg)
+				break
 			}
 		}
 	}
 
 	return filtered
 }
+
+// filterReceipts will filter the receipts having a log with a matching topic,
+// and the blocks of these receipts. If the topics are an empty slice, then it
+// will match all.
+func filterReceipts(receipts, blocks []*json.RawMessage) ([]*json.RawMessage, []*json.RawMessage) {
+	if len(inputDumpblocks.filter.Topics) == 0 {
+		return receipts, blocks
+	}
+
+	filtered := []*json.RawMessage{}
+	blockNumbers := make(map[string]struct{})
+	for _, msg := range receipts {
+		var receipt rpctypes.RawTxReceipt
+		if err := json.Unmarshal(*msg, &receipt); err != nil {
+			log.Error().Bytes("receipt", *msg).Msg("Unable to unmarshal receipt")
+			continue
+		}
+		if hasTopic(receipt.Logs, inputDumpblocks.filter.Topics) {
+			filtered = append(filtered, msg)
+			blockNumbers[strings.ToLower(string(receipt.BlockNumber))] = struct{}{}
+		}
+	}
+
+	filteredBlocks := []*json.RawMessage{}
+	for _, msg := range blocks {
+		var block struct {
+			Number rpctypes.RawQuantityResponse `json:"number"`
+		}
+		if err := json.Unmarshal(*msg, &block); err != nil {
+			log.Error().Bytes("block", *msg).Msg("Unable to unmarshal block")
+			continue
+		}
+		if _, ok := blockNumbers[strings.ToLower(string(block.Number))]; ok {
+			filteredBlocks = append(filteredBlocks, msg)
+		}
+	}
+
+	return filtered, filteredBlocks
+}
+
+// hasTopic returns whether one of the logs has one of the topics, at any position.
+func hasTopic(logs []rpctypes.RawTxLogs, topics []string) bool {
+	for _, l := range logs {
+		for _, t := range l.Topics {
+			if slices.Contains(topics, strings.ToLower(string(t))) {
+				return true
+			}
+		}
+	}
+	return false
+}
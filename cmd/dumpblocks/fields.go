@@ -0,0 +1,84 @@
+package dumpblocks
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// fieldTree is the set of fields kept by the projection. A field maps to its
+// nested fields, an empty tree keeping the whole value.
+type fieldTree map[string]fieldTree
+
+// newFieldTree parses the dot separated fields, e.g. `transactions.to`.
+func newFieldTree(fields []string) fieldTree {
+	tree := fieldTree{}
+	for _, field := range fields {
+		node := tree
+		for _, name := range strings.Split(strings.TrimSpace(field), ".") {
+			if name == "" {
+				continue
+			}
+			if node[name] == nil {
+				node[name] = fieldTree{}
+			}
+			node = node[name]
+		}
+	}
+	return tree
+}
+
+// project keeps the fields of the tree in each message. The arrays are
+// projected element by element, e.g. `transactions.to` keeps the recipient of
+// every transaction.
+func project(msgs []*json.RawMessage, tree fieldTree) []*json.RawMessage {
+	if len(tree) == 0 {
+		return msgs
+	}
+
+	projected := make([]*json.RawMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		dec := json.NewDecoder(bytes.NewReader(*msg))
+		// Keep the numbers as they are.
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			log.Error().Bytes("msg", *msg).Msg("Unable to unmarshal message")
+			continue
+		}
+		out, err := json.Marshal(tree.project(v))
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to marshal projected message")
+			continue
+		}
+		raw := json.RawMessage(out)
+		projected = append(projected, &raw)
+	}
+	return projected
+}
+
+func (tree fieldTree) project(v any) any {
+	if len(tree) == 0 {
+		return v
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(tree))
+		for name, subtree := range tree {
+			if field, ok := v[name]; ok {
+				out[name] = subtree.project(field)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = tree.project(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
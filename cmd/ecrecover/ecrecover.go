@@ -27,7 +27,7 @@ var (
 
 var EcRecoverCmd = &cobra.Command{
 	Use:   "ecrecover",
-	Short: "Recovers and returns the public key of the signature",
+	Short: "Recover the signer of a block, message, typed data or transaction",
 	Long:  usage,
 	Args:  cobra.NoArgs,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
@@ -99,9 +99,9 @@ var EcRecoverCmd = &cobra.Command{
 }
 
 func init() {
-	EcRecoverCmd.PersistentFlags().StringVarP(&rpcUrl, "rpc-url", "r", "", "The RPC endpoint url")
-	EcRecoverCmd.PersistentFlags().Uint64VarP(&blockNumber, "block-number", "b", 0, "Block number to check the extra data for (default: latest)")
-	EcRecoverCmd.PersistentFlags().StringVarP(&filePath, "file", "f", "", "Path to a file containing block information in JSON format")
+	EcRecoverCmd.Flags().StringVarP(&rpcUrl, "rpc-url", "r", "", "The RPC endpoint url")
+	EcRecoverCmd.Flags().Uint64VarP(&blockNumber, "block-number", "b", 0, "Block number to check the extra data for (default: latest)")
+	EcRecoverCmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to a file containing block information in JSON format")
 }
 
 func checkFlags() (err error) {
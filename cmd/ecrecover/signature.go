@@ -0,0 +1,231 @@
+package ecrecover
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+type (
+	signatureFlags struct {
+		Message   string
+		Signature string
+		Address   string
+		File      string
+	}
+	// recovered is the signer recovered from a signature, and whether it's
+	// the expected address when one was given.
+	recovered struct {
+		Signer ethcommon.Address
+		Hash   ethcommon.Hash
+		// DomainSeparator is only set for typed data.
+		DomainSeparator *ethcommon.Hash `json:",omitempty"`
+		// ChainID is only set for transactions.
+		ChainID  *hexutil.Big       `json:",omitempty"`
+		Expected *ethcommon.Address `json:",omitempty"`
+		Valid    *bool              `json:",omitempty"`
+	}
+)
+
+var (
+	messageParams   signatureFlags
+	typedDataParams signatureFlags
+	txParams        signatureFlags
+)
+
+var messageCmd = &cobra.Command{
+	Use:   "message",
+	Short: "Recover the signer of an EIP-191 personal message",
+	Long:  "Recover the address which signed the EIP-191 personal message, as signed by `personal_sign`. A 0x prefixed message is decoded from hex. With --address, the signature is verified against the address.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		msg := []byte(messageParams.Message)
+		if strings.HasPrefix(messageParams.Message, "0x") {
+			var err error
+			if msg, err = hexutil.Decode(messageParams.Message); err != nil {
+				return fmt.Errorf("unable to decode the message: %w", err)
+			}
+		}
+		return recoverSigner(accounts.TextHash(msg), messageParams, nil)
+	},
+}
+
+var typedDataCmd = &cobra.Command{
+	Use:   "typed-data",
+	Short: "Recover the signer of EIP-712 typed data",
+	Long:  "Recover the address which signed the EIP-712 typed data, as signed by `eth_signTypedData_v4`. The typed data JSON holds the `types`, `primaryType`, `domain` and `message`, and is read from --file or stdin. With --address, the signature is verified against the address.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		data, err := readInput(typedDataParams.File)
+		if err != nil {
+			return err
+		}
+		var typedData apitypes.TypedData
+		if err = json.Unmarshal(data, &typedData); err != nil {
+			return fmt.Errorf("unable to parse the typed data: %w", err)
+		}
+		hash, _, err := apitypes.TypedDataAndHash(typedData)
+		if err != nil {
+			return fmt.Errorf("unable to hash the typed data: %w", err)
+		}
+		domainSeparator, err := typedData.HashStruct("EIP712Domain", typedData.Domain.Map())
+		if err != nil {
+			return fmt.Errorf("unable to hash the domain: %w", err)
+		}
+		return recoverSigner(hash, typedDataParams, func(r *recovered) {
+			separator := ethcommon.BytesToHash(domainSeparator)
+			r.DomainSeparator = &separator
+		})
+	},
+}
+
+var txCmd = &cobra.Command{
+	Use:   "tx [raw transaction]",
+	Short: "Recover the sender of a signed raw transaction",
+	Long:  "Recover the sender of the hex encoded signed transaction, as sent with `eth_sendRawTransaction`. The transaction is read from the argument, --file or stdin. With --address, the sender is verified against the address.",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		var raw string
+		if len(args) > 0 {
+			raw = args[0]
+		} else {
+			data, err := readInput(txParams.File)
+			if err != nil {
+				return err
+			}
+			raw = string(data)
+		}
+		rawTx, err := hexutil.Decode(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("unable to decode the transaction: %w", err)
+		}
+		tx := new(types.Transaction)
+		if err = tx.UnmarshalBinary(rawTx); err != nil {
+			return fmt.Errorf("unable to parse the transaction: %w", err)
+		}
+
+		// The legacy transactions signed before EIP-155 have no chain id.
+		var signer types.Signer = types.HomesteadSigner{}
+		if tx.Protected() {
+			signer = types.LatestSignerForChainID(tx.ChainId())
+		}
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			return fmt.Errorf("unable to recover the sender: %w", err)
+		}
+		r := &recovered{Signer: sender, Hash: tx.Hash()}
+		if tx.Protected() {
+			r.ChainID = (*hexutil.Big)(tx.ChainId())
+		}
+		return writeRecovered(r, txParams.Address)
+	},
+}
+
+func init() {
+	for _, c := range []struct {
+		cmd    *cobra.Command
+		params *signatureFlags
+	}{
+		{messageCmd, &messageParams},
+		{typedDataCmd, &typedDataParams},
+		{txCmd, &txParams},
+	} {
+		flagSet := c.cmd.Flags()
+		if c.cmd != txCmd {
+			flagSet.StringVarP(&c.params.Signature, "signature", "s", "", "The hex encoded 65 bytes [R || S || V] signature")
+			_ = c.cmd.MarkFlagRequired("signature")
+		}
+		flagSet.StringVarP(&c.params.Address, "address", "a", "", "Verify that the signer is this address")
+		EcRecoverCmd.AddCommand(c.cmd)
+	}
+
+	messageCmd.Flags().StringVarP(&messageParams.Message, "message", "m", "", "The signed message, decoded from hex if 0x prefixed")
+	_ = messageCmd.MarkFlagRequired("message")
+	typedDataCmd.Flags().StringVarP(&typedDataParams.File, "file", "f", "", "Path to a file containing the typed data JSON (default: stdin)")
+	txCmd.Flags().StringVarP(&txParams.File, "file", "f", "", "Path to a file containing the raw transaction (default: stdin)")
+}
+
+// recoverSigner recovers the signer of the hash from the signature flag.
+func recoverSigner(hash []byte, params signatureFlags, decorate func(*recovered)) error {
+	sig, err := hexutil.Decode(params.Signature)
+	if err != nil {
+		return fmt.Errorf("unable to decode the signature: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("the signature must be %d bytes long, got %d", crypto.SignatureLength, len(sig))
+	}
+	// The wallets return V as 27 or 28, crypto expects the recovery id.
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return fmt.Errorf("unable to recover the signer: %w", err)
+	}
+
+	r := &recovered{Signer: crypto.PubkeyToAddress(*pub), Hash: ethcommon.BytesToHash(hash)}
+	if decorate != nil {
+		decorate(r)
+	}
+	return writeRecovered(r, params.Address)
+}
+
+// writeRecovered writes the recovered signer, and fails when it isn't the
+// expected address.
+func writeRecovered(r *recovered, expected string) error {
+	if expected != "" {
+		if !ethcommon.IsHexAddress(expected) {
+			return fmt.Errorf("invalid address %s", expected)
+		}
+		address := ethcommon.HexToAddress(expected)
+		valid := address == r.Signer
+		r.Expected, r.Valid = &address, &valid
+	}
+	if err := util.NewOutputWriter(os.Stdout).Write(r); err != nil {
+		return err
+	}
+	if r.Valid != nil && !*r.Valid {
+		return fmt.Errorf("the signer %s is not %s", r.Signer, r.Expected)
+	}
+	return nil
+}
+
+func readInput(filePath string) ([]byte, error) {
+	if filePath != "" {
+		return os.ReadFile(filePath)
+	}
+	return io.ReadAll(os.Stdin)
+}
+
+func (r *recovered) WriteText(w io.Writer) error {
+	lines := []string{fmt.Sprintf("Signer: %s", r.Signer), fmt.Sprintf("Hash: %s", r.Hash)}
+	if r.DomainSeparator != nil {
+		lines = append(lines, fmt.Sprintf("Domain separator: %s", r.DomainSeparator))
+	}
+	if r.ChainID != nil {
+		lines = append(lines, fmt.Sprintf("Chain ID: %s", r.ChainID.ToInt()))
+	}
+	if r.Valid != nil {
+		status := "valid"
+		if !*r.Valid {
+			status = "invalid"
+		}
+		lines = append(lines, fmt.Sprintf("Signature of %s: %s", r.Expected, status))
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
@@ -0,0 +1,153 @@
+package enr
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/forkid"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/spf13/cobra"
+)
+
+type encodeParams struct {
+	PrivateKey  *string
+	KeyFile     *string
+	IP          *string
+	TCP         *int
+	UDP         *int
+	Seq         *uint64
+	EthForkHash *string
+	EthForkNext *uint64
+	Eth2        *string
+	Entries     *[]string
+}
+
+var encodeInput encodeParams
+
+var EncodeCmd = &cobra.Command{
+	Use:   "encode",
+	Short: "Create and sign a new ENR.",
+	Long:  "Create a new ENR from the given values and sign it with the node key. A new key is generated and output when none is given.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, generated, err := getNodeKey()
+		if err != nil {
+			return err
+		}
+		r, err := buildRecord()
+		if err != nil {
+			return err
+		}
+		if err = enode.SignV4(r, key); err != nil {
+			return fmt.Errorf("unable to sign the record: %w", err)
+		}
+		node, err := enode.New(enode.ValidSchemes, r)
+		if err != nil {
+			return err
+		}
+
+		out := make(map[string]string, 0)
+		out["enr"] = node.String()
+		out["enode"] = node.URLv4()
+		out["id"] = node.ID().String()
+		if generated {
+			out["privateKey"] = hex.EncodeToString(crypto.FromECDSA(key))
+		}
+		jsonOut, err := json.Marshal(out)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(jsonOut))
+		return nil
+	},
+}
+
+// getNodeKey returns the node key given by flag, or a new one.
+func getNodeKey() (key *ecdsa.PrivateKey, generated bool, err error) {
+	switch {
+	case *encodeInput.PrivateKey != "":
+		key, err = crypto.HexToECDSA(strings.TrimPrefix(*encodeInput.PrivateKey, "0x"))
+	case *encodeInput.KeyFile != "":
+		key, err = crypto.LoadECDSA(*encodeInput.KeyFile)
+	default:
+		key, err = crypto.GenerateKey()
+		generated = true
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to load the node key: %w", err)
+	}
+	return key, generated, nil
+}
+
+// buildRecord returns the unsigned record holding the values given by flag.
+func buildRecord() (*enr.Record, error) {
+	var r enr.Record
+	r.SetSeq(*encodeInput.Seq)
+
+	if *encodeInput.IP != "" {
+		ip := net.ParseIP(*encodeInput.IP)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %s", *encodeInput.IP)
+		}
+		r.Set(enr.IP(ip))
+	}
+	if *encodeInput.TCP != 0 {
+		r.Set(enr.TCP(*encodeInput.TCP))
+	}
+	if *encodeInput.UDP != 0 {
+		r.Set(enr.UDP(*encodeInput.UDP))
+	}
+
+	if *encodeInput.EthForkHash != "" {
+		hash, err := hexutil.Decode(*encodeInput.EthForkHash)
+		if err != nil || len(hash) != 4 {
+			return nil, fmt.Errorf("the eth fork hash must be 4 hex encoded bytes")
+		}
+		var id forkid.ID
+		copy(id.Hash[:], hash)
+		id.Next = *encodeInput.EthForkNext
+		r.Set(enr.WithEntry("eth", ethEntry{ForkID: id}))
+	}
+	if *encodeInput.Eth2 != "" {
+		eth2, err := hexutil.Decode(*encodeInput.Eth2)
+		if err != nil || len(eth2) != 16 {
+			return nil, fmt.Errorf("the eth2 entry must be 16 hex encoded bytes")
+		}
+		r.Set(enr.WithEntry("eth2", eth2))
+	}
+
+	for _, e := range *encodeInput.Entries {
+		key, value, found := strings.Cut(e, "=")
+		if !found {
+			return nil, fmt.Errorf("the entry %s isn't formatted as key=hexvalue", e)
+		}
+		b, err := hexutil.Decode(value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode the value of %s: %w", key, err)
+		}
+		r.Set(enr.WithEntry(key, b))
+	}
+	return &r, nil
+}
+
+func init() {
+	flagSet := EncodeCmd.Flags()
+	encodeInput.PrivateKey = flagSet.String("private-key", "", "The hex encoded node key signing the record")
+	encodeInput.KeyFile = flagSet.StringP("key-file", "f", "", "A file with the node key in hex format")
+	encodeInput.IP = flagSet.String("ip", "", "The IPv4 or IPv6 address of the node")
+	encodeInput.TCP = flagSet.Int("tcp", 0, "The TCP port of the node")
+	encodeInput.UDP = flagSet.Int("udp", 0, "The UDP discovery port of the node")
+	encodeInput.Seq = flagSet.Uint64("seq", 1, "The sequence number of the record")
+	encodeInput.EthForkHash = flagSet.String("eth-fork-hash", "", "The EIP-2124 fork hash of the eth entry, e.g. 0x9f3d2254")
+	encodeInput.EthForkNext = flagSet.Uint64("eth-fork-next", 0, "The next fork block or timestamp of the eth entry")
+	encodeInput.Eth2 = flagSet.String("eth2", "", "The hex encoded SSZ ENRForkID of the eth2 entry")
+	encodeInput.Entries = flagSet.StringArray("entry", nil, "An additional key=hexvalue entry, can be repeated")
+	EncodeCmd.MarkFlagsMutuallyExclusive("private-key", "key-file")
+}
@@ -2,16 +2,22 @@ package enr
 
 import (
 	_ "embed"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"strings"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 var (
@@ -63,6 +69,9 @@ var ENRCmd = &cobra.Command{
 			genericNode["ip"] = node.IP().String()
 			genericNode["tcp"] = fmt.Sprintf("%d", node.TCP())
 			genericNode["udp"] = fmt.Sprintf("%d", node.UDP())
+			if isENR {
+				describeRecord(node.Record(), genericNode)
+			}
 			jsonOut, err := json.Marshal(genericNode)
 			if err != nil {
 				log.Error().Err(err).Msg("Unable to convert node to json")
@@ -78,8 +87,93 @@ var ENRCmd = &cobra.Command{
 }
 
 func init() {
-	flagSet := ENRCmd.PersistentFlags()
+	flagSet := ENRCmd.Flags()
 	inputFileName = flagSet.String("file", "", "Provide a file that's holding ENRs")
+
+	ENRCmd.AddCommand(EncodeCmd)
+}
+
+// The `eth` entry of the record, which holds the EIP-2124 fork identifier.
+type ethEntry struct {
+	ForkID forkid.ID
+	Rest   []rlp.RawValue `rlp:"tail"`
+}
+
+// describeRecord adds every key/value pair of the record to the output. The known keys are
+// decoded and the others are output as hex encoded RLP.
+func describeRecord(r *enr.Record, out map[string]string) {
+	out["seq"] = fmt.Sprintf("%d", r.Seq())
+	out["signature"] = hexutil.Encode(r.Signature())
+
+	// The elements are the sequence number followed by the sorted key/value pairs.
+	elements := r.AppendElements(nil)
+	for i := 1; i+1 < len(elements); i += 2 {
+		key, ok := elements[i].(string)
+		if !ok {
+			continue
+		}
+		raw, ok := elements[i+1].(rlp.RawValue)
+		if !ok {
+			continue
+		}
+		if err := describeEntry(key, raw, out); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("Unable to decode record entry")
+			out[key] = hexutil.Encode(raw)
+		}
+	}
+}
+
+func describeEntry(key string, raw rlp.RawValue, out map[string]string) error {
+	switch key {
+	case "id":
+		var scheme string
+		if err := rlp.DecodeBytes(raw, &scheme); err != nil {
+			return err
+		}
+		out["scheme"] = scheme
+	case "ip", "tcp", "udp":
+		// Already part of the node output.
+	case "ip6":
+		var ip enr.IPv6
+		if err := rlp.DecodeBytes(raw, &ip); err != nil {
+			return err
+		}
+		out[key] = net.IP(ip).String()
+	case "tcp6", "udp6":
+		var port uint16
+		if err := rlp.DecodeBytes(raw, &port); err != nil {
+			return err
+		}
+		out[key] = fmt.Sprintf("%d", port)
+	case "eth":
+		var entry ethEntry
+		if err := rlp.DecodeBytes(raw, &entry); err != nil {
+			return err
+		}
+		out["eth.forkHash"] = hexutil.Encode(entry.ForkID.Hash[:])
+		out["eth.forkNext"] = fmt.Sprintf("%d", entry.ForkID.Next)
+	case "eth2":
+		// The SSZ encoded ENRForkID of the consensus layer.
+		var b []byte
+		if err := rlp.DecodeBytes(raw, &b); err != nil {
+			return err
+		}
+		if len(b) < 16 {
+			return fmt.Errorf("the eth2 entry is %d bytes long, expected 16", len(b))
+		}
+		out["eth2.forkDigest"] = hexutil.Encode(b[:4])
+		out["eth2.nextForkVersion"] = hexutil.Encode(b[4:8])
+		out["eth2.nextForkEpoch"] = fmt.Sprintf("%d", binary.LittleEndian.Uint64(b[8:16]))
+	default:
+		// Byte strings such as secp256k1, attnets or syncnets.
+		var b []byte
+		if err := rlp.DecodeBytes(raw, &b); err != nil {
+			out[key] = hexutil.Encode(raw)
+			return nil
+		}
+		out[key] = hexutil.Encode(b)
+	}
+	return nil
 }
 func getInputData(cmd *cobra.Command, args []string) ([]byte, error) {
 	if inputFileName != nil && *inputFileName != "" {
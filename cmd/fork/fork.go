@@ -11,6 +11,8 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/maticnetwork/polygon-cli/rpcclient"
+	"github.com/maticnetwork/polygon-cli/util"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
@@ -22,22 +24,64 @@ var (
 	blockHash             ethcommon.Hash
 	retryLimit            = 30
 	errRetryLimitExceeded = fmt.Errorf("unable to process request after hitting retry limit")
+
+	inputWatch         *bool
+	inputWatchInterval *time.Duration
+	inputWatchDepth    *uint64
+	inputWSURL         *string
 )
 
+// reorgEvent describes a reorg detected while watching the head of the chain.
+// In watch mode, each event is written to stdout as a JSON line.
+type reorgEvent struct {
+	Time     int64               `json:"time"`
+	Number   uint64              `json:"number"`
+	Depth    int                 `json:"depth"`
+	OldHead  ethcommon.Hash      `json:"oldHead"`
+	NewHead  ethcommon.Hash      `json:"newHead"`
+	Orphaned []ethcommon.Hash    `json:"orphaned"`
+	Signers  []ethcommon.Address `json:"signers"`
+}
+
 var ForkCmd = &cobra.Command{
 	Use:   "fork blockhash url",
 	Short: "Take a forked block and walk up the chain to do analysis.",
-	Long:  "",
+	Long: `Take a forked block and walk up the chain to do analysis.
+
+With --watch, only the RPC URL is required. The head of the chain is polled and
+every reorg that's detected is written to stdout as a JSON line containing the
+reorg depth, the orphaned blocks and the signers involved. A reorg is only
+reported when the hash of a block number already seen changes, a lower head
+with known hashes, e.g. from a lagging node behind a load balancer, is ignored.
+With --ws-url, the heads come from a new heads subscription instead of polling,
+and the heads missed while the WebSocket is disconnected are backfilled from
+the RPC URL.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		log.Info().Str("rpc", rpcURL).Str("blockHash", blockHash.String()).Msg("Starting Analysis")
 		c, err := ethclient.Dial(rpcURL)
 		if err != nil {
 			log.Error().Err(err).Str("rpc", rpcURL).Msg("Could not rpc dial connection")
 			return err
 		}
+		if *inputWatch {
+			return watchForReorgs(cmd.Context(), c)
+		}
+		log.Info().Str("rpc", rpcURL).Str("blockHash", blockHash.String()).Msg("Starting Analysis")
 		return walkTheBlocks(blockHash, c)
 	},
 	Args: func(cmd *cobra.Command, args []string) error {
+		if *inputWatch {
+			if len(args) != 1 {
+				return fmt.Errorf("an RPC URL is required in watch mode")
+			}
+			rpcURL = args[0]
+			if *inputWSURL != "" {
+				return util.ValidateUrl(*inputWSURL)
+			}
+			return nil
+		}
+		if *inputWSURL != "" {
+			return fmt.Errorf("--ws-url only applies in watch mode, use it with --watch")
+		}
 		if len(args) != 2 {
 			return fmt.Errorf("two arguments required a block hash and an RPC URL")
 		}
@@ -47,6 +91,13 @@ var ForkCmd = &cobra.Command{
 	},
 }
 
+func init() {
+	inputWatch = ForkCmd.PersistentFlags().Bool("watch", false, "Continuously watch the head of the chain and emit reorg events as JSON lines")
+	inputWatchInterval = ForkCmd.PersistentFlags().Duration("interval", 2*time.Second, "How often to poll the head of the chain in watch mode")
+	inputWatchDepth = ForkCmd.PersistentFlags().Uint64("depth", 128, "The number of recent headers to keep track of in watch mode")
+	inputWSURL = ForkCmd.PersistentFlags().String("ws-url", "", "A WebSocket endpoint whose new heads subscription replaces the polling in watch mode, the heads missed during disconnections are backfilled from the RPC URL")
+}
+
 func walkTheBlocks(inputBlockHash ethcommon.Hash, client *ethclient.Client) error {
 	log.Info().Msg("Starting block analysis")
 	ctx := context.Background()
@@ -63,6 +114,10 @@ func walkTheBlocks(inputBlockHash ethcommon.Hash, client *ethclient.Client) erro
 		return err
 	}
 
+	var (
+		orphaned []ethcommon.Hash
+		signers  = make(map[ethcommon.Address]struct{})
+	)
 	for {
 		potentialForkedBlock, err := getBlockByHash(ctx, inputBlockHash, client)
 		if err != nil {
@@ -101,12 +156,188 @@ func walkTheBlocks(inputBlockHash ethcommon.Hash, client *ethclient.Client) erro
 			log.Error().Err(err).Msg("Unable to save canonical block")
 			return err
 		}
+		orphaned = append(orphaned, potentialForkedBlock.Hash())
+		for _, b := range []*types.Block{potentialForkedBlock, canonicalBlock} {
+			if signer, err := ecrecover(b.Header()); err == nil {
+				signers[ethcommon.BytesToAddress(signer)] = struct{}{}
+			}
+		}
 		// Ever higher
 		inputBlockHash = potentialForkedBlock.ParentHash()
 	}
+
+	signerList := make([]ethcommon.Address, 0, len(signers))
+	for signer := range signers {
+		signerList = append(signerList, signer)
+	}
+	log.Info().
+		Int("depth", len(orphaned)).
+		Interface("orphaned", orphaned).
+		Interface("signers", signerList).
+		Str("folder", folderName).
+		Msg("Fork analysis complete")
+	return nil
+}
+
+// watchForReorgs polls the head of the chain and compares each new head with
+// the recent headers it has seen. When a known block number has a different
+// hash, the chain is walked back until the common ancestor is found and a
+// reorg event is emitted.
+func watchForReorgs(ctx context.Context, client *ethclient.Client) error {
+	var (
+		seen   = make(map[uint64]*types.Header)
+		maxNum uint64
+		ticker = time.NewTicker(*inputWatchInterval)
+	)
+	defer ticker.Stop()
+
+	if *inputWSURL != "" {
+		return subscribeForReorgs(ctx, client, seen, &maxNum)
+	}
+
+	log.Info().Dur("interval", *inputWatchInterval).Uint64("depth", *inputWatchDepth).Msg("Watching for reorgs")
+	for {
+		head, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to fetch the head of the chain")
+		} else if err = processHead(ctx, client, head, seen, &maxNum); err != nil {
+			log.Error().Err(err).Uint64("number", head.Number.Uint64()).Msg("Unable to process head")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// subscribeForReorgs processes the heads of the new heads subscription.
+func subscribeForReorgs(ctx context.Context, client *ethclient.Client, seen map[uint64]*types.Header, maxNum *uint64) error {
+	log.Info().Str("ws", *inputWSURL).Uint64("depth", *inputWatchDepth).Msg("Watching for reorgs")
+	heads := make(chan *types.Header)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- rpcclient.NewSubscriber(*inputWSURL, client.Client()).NewHeads(ctx, heads)
+	}()
+	for {
+		select {
+		case err := <-errCh:
+			return err
+		case head := <-heads:
+			if err := processHead(ctx, client, head, seen, maxNum); err != nil {
+				log.Error().Err(err).Uint64("number", head.Number.Uint64()).Msg("Unable to process head")
+			}
+		}
+	}
+}
+
+// processHead compares the head with the recent headers. A reorg is only
+// reported when the hash of an already seen height changes. A head below the
+// highest head seen whose hash matches, or which wasn't seen, comes from an
+// endpoint lagging behind, e.g. a node behind a load balancer, and is ignored.
+func processHead(ctx context.Context, client *ethclient.Client, head *types.Header, seen map[uint64]*types.Header, maxNum *uint64) error {
+	headNum := head.Number.Uint64()
+	known, ok := seen[headNum]
+	if ok && known.Hash() == head.Hash() && headNum == *maxNum {
+		return nil
+	}
+	if headNum < *maxNum && (!ok || known.Hash() == head.Hash()) {
+		log.Debug().Uint64("number", headNum).Uint64("highest", *maxNum).Msg("The head is behind the highest head seen, the endpoint is lagging")
+		return nil
+	}
+
+	var (
+		event = reorgEvent{NewHead: head.Hash()}
+		cur   = head
+		depth uint64
+	)
+	if old, ok := seen[*maxNum]; ok {
+		event.OldHead = old.Hash()
+	}
+
+	for {
+		n := cur.Number.Uint64()
+		known, ok := seen[n]
+		if ok && known.Hash() == cur.Hash() {
+			break
+		}
+		if ok {
+			event.Orphaned = append(event.Orphaned, known.Hash())
+			event.Signers = appendSigner(event.Signers, known)
+			event.Signers = appendSigner(event.Signers, cur)
+			event.Number = n
+		}
+		seen[n] = cur
+
+		// Stop once we're past everything we know about or the chain got too deep.
+		if (!ok && n <= *maxNum) || n == 0 || len(seen) == 1 {
+			break
+		}
+		if parent, ok := seen[n-1]; ok && parent.Hash() == cur.ParentHash {
+			break
+		}
+		if depth++; depth >= *inputWatchDepth {
+			log.Warn().Uint64("depth", depth).Msg("Reached the maximum watch depth without finding a common ancestor")
+			break
+		}
+
+		parent, err := client.HeaderByHash(ctx, cur.ParentHash)
+		if err != nil {
+			return err
+		}
+		cur = parent
+	}
+
+	// The blocks above a replaced block belonged to the branch which is no
+	// longer canonical.
+	if len(event.Orphaned) > 0 {
+		for n := headNum + 1; n <= *maxNum; n++ {
+			if orphan, ok := seen[n]; ok {
+				event.Orphaned = append(event.Orphaned, orphan.Hash())
+				event.Signers = appendSigner(event.Signers, orphan)
+				delete(seen, n)
+			}
+		}
+	}
+
+	*maxNum = headNum
+	for n := range seen {
+		if n+*inputWatchDepth < headNum {
+			delete(seen, n)
+		}
+	}
+
+	if len(event.Orphaned) == 0 {
+		log.Debug().Uint64("number", headNum).Str("hash", head.Hash().String()).Msg("New head")
+		return nil
+	}
+	event.Time = time.Now().Unix()
+	event.Depth = len(event.Orphaned)
+	out, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
 	return nil
 }
 
+// appendSigner recovers the signer of the header and adds it to the list if
+// it's not already there. Headers without a clique style seal are ignored.
+func appendSigner(signers []ethcommon.Address, header *types.Header) []ethcommon.Address {
+	raw, err := ecrecover(header)
+	if err != nil {
+		return signers
+	}
+	signer := ethcommon.BytesToAddress(raw)
+	for _, s := range signers {
+		if s == signer {
+			return signers
+		}
+	}
+	return append(signers, signer)
+}
+
 func writeBlock(folderName string, block *types.Block, isCanonical bool) error {
 	rawHeader, err := block.Header().MarshalJSON()
 	if err != nil {
@@ -121,7 +352,7 @@ func writeBlock(folderName string, block *types.Block, isCanonical bool) error {
 	}
 	fields["transactions"] = block.Transactions()
 	// TODO in the future if this is used in other chains or with different types of consensus this would need to be revised
-	signer, err := ecrecover(block)
+	signer, err := ecrecover(block.Header())
 	if err != nil {
 		log.Error().Err(err).Msg("Unable to recover signature")
 		return err
@@ -157,8 +388,7 @@ func getBlockByHash(ctx context.Context, bh ethcommon.Hash, client *ethclient.Cl
 	return nil, errRetryLimitExceeded
 }
 
-func ecrecover(block *types.Block) ([]byte, error) {
-	header := block.Header()
+func ecrecover(header *types.Header) ([]byte, error) {
 	sigStart := len(header.Extra) - ethcrypto.SignatureLength
 	if sigStart < 0 || sigStart > len(header.Extra) {
 		return nil, fmt.Errorf("unable to recover signature")
@@ -3,9 +3,11 @@ package fund
 import (
 	"errors"
 	"math"
+	"time"
 
 	_ "embed"
 
+	"github.com/maticnetwork/polygon-cli/signer"
 	"github.com/maticnetwork/polygon-cli/util"
 	"github.com/spf13/cobra"
 )
@@ -23,14 +25,25 @@ const defaultPassword = "password"
 type cmdFundParams struct {
 	RpcUrl     *string
 	PrivateKey *string
+	Signer     *signer.Flags
 
 	WalletsNumber      *uint64
 	UseHDDerivation    *bool
+	Mnemonic           *string
+	HDOffset           *uint64
 	WalletAddresses    *[]string
 	FundingAmountInEth *float64
 	OutputFile         *string
 
 	FunderAddress *string
+
+	// Direct transfer parameters.
+	Direct      *bool
+	Concurrency *uint64
+	Retries     *uint64
+	MineTimeout *time.Duration
+
+	VerifyBalances *bool
 }
 
 var (
@@ -58,10 +71,13 @@ func init() {
 
 	p.RpcUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8545", "The RPC endpoint url")
 	p.PrivateKey = flagSet.String("private-key", defaultPrivateKey, "The hex encoded private key that we'll use to send transactions")
+	p.Signer = signer.AddFlags(flagSet)
 
 	// Wallet parameters.
 	p.WalletsNumber = flagSet.Uint64P("number", "n", 10, "The number of wallets to fund")
-	p.UseHDDerivation = flagSet.Bool("hd-derivation", true, "Derive wallets to fund from the private key in a deterministic way")
+	p.UseHDDerivation = flagSet.Bool("hd-derivation", true, "Derive wallets to fund from the mnemonic in a deterministic way")
+	p.Mnemonic = flagSet.String("mnemonic", defaultMnemonic, "The mnemonic used to derive wallets")
+	p.HDOffset = flagSet.Uint64("hd-offset", 0, "The index of the first derived wallet, to fund a range of the derivation path")
 	p.WalletAddresses = flagSet.StringSlice("addresses", nil, "Comma-separated list of wallet addresses to fund")
 	p.FundingAmountInEth = flagSet.Float64P("eth-amount", "a", 0.05, "The amount of ether to send to each wallet")
 	p.OutputFile = flagSet.StringP("file", "f", "wallets.json", "The output JSON file path for storing the addresses and private keys of funded wallets")
@@ -73,6 +89,15 @@ func init() {
 	// Funder contract parameters.
 	p.FunderAddress = flagSet.String("contract-address", "", "The address of a pre-deployed Funder contract")
 
+	// Direct transfer parameters.
+	p.Direct = flagSet.Bool("direct", false, "Fund each wallet with its own transfer instead of using the Funder contract")
+	p.Concurrency = flagSet.Uint64("concurrency", 10, "The number of transfers sent concurrently in direct mode")
+	p.Retries = flagSet.Uint64("retries", 3, "The number of times a transfer is resent after a failure in direct mode")
+	p.MineTimeout = flagSet.Duration("mine-timeout", 2*time.Minute, "The maximum time waiting for a transfer to be mined in direct mode")
+	FundCmd.MarkFlagsMutuallyExclusive("direct", "contract-address")
+
+	p.VerifyBalances = flagSet.Bool("verify-balances", true, "Check the balance of every wallet once funded")
+
 	params = *p
 }
 
@@ -101,5 +126,13 @@ func checkFlags() error {
 		return errors.New("the output file is not specified")
 	}
 
+	// Check direct transfer flags.
+	if params.Concurrency != nil && *params.Concurrency == 0 {
+		return errors.New("the concurrency is set to zero")
+	}
+	if params.MineTimeout != nil && *params.MineTimeout <= 0 {
+		return errors.New("the mine timeout must be positive")
+	}
+
 	return nil
 }
@@ -8,7 +8,6 @@ import (
 	"errors"
 	"math/big"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -18,6 +17,8 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/maticnetwork/polygon-cli/bindings/funder"
 	"github.com/maticnetwork/polygon-cli/hdwallet"
+	"github.com/maticnetwork/polygon-cli/progress"
+	"github.com/maticnetwork/polygon-cli/signer"
 	"github.com/maticnetwork/polygon-cli/util"
 	"github.com/rs/zerolog/log"
 )
@@ -25,6 +26,9 @@ import (
 // The initial balance of Ether to send to the Funder contract.
 const funderContractBalanceInEth = 1_000.0
 
+// fundingPhases numbers the progress reports of the transfers and of the balance checks.
+var fundingPhases *progress.Phases
+
 // runFunding deploys or instantiates a `Funder` contract to bulk fund randomly generated wallets.
 // Wallets' addresses and private keys are saved to a file.
 func runFunding(ctx context.Context) error {
@@ -38,23 +42,9 @@ func runFunding(ctx context.Context) error {
 		return err
 	}
 
-	var privateKey *ecdsa.PrivateKey
+	var s signer.Signer
 	var chainID *big.Int
-	privateKey, chainID, err = initializeParams(ctx, c)
-	if err != nil {
-		return err
-	}
-
-	var tops *bind.TransactOpts
-	tops, err = bind.NewKeyedTransactorWithChainID(privateKey, chainID)
-	if err != nil {
-		log.Error().Err(err).Msg("Unable create transaction signer")
-		return err
-	}
-
-	// Deploy or instantiate the Funder contract.
-	var contract *funder.Funder
-	contract, err = deployOrInstantiateFunderContract(ctx, c, tops, privateKey)
+	s, chainID, err = initializeParams(ctx, c)
 	if err != nil {
 		return err
 	}
@@ -68,7 +58,7 @@ func runFunding(ctx context.Context) error {
 			addresses[i] = common.HexToAddress(address)
 		}
 	} else if *params.UseHDDerivation {
-		log.Info().Msg("Deriving wallets from the default mnemonic")
+		log.Info().Msg("Deriving wallets from the mnemonic")
 		addresses, err = deriveHDWallets(int(*params.WalletsNumber))
 	} else {
 		log.Info().Msg("Generating random wallets")
@@ -79,11 +69,30 @@ func runFunding(ctx context.Context) error {
 	}
 
 	// Fund wallets.
-	if err = fundWallets(ctx, c, tops, contract, addresses); err != nil {
+	phaseCount := 0
+	if *params.Direct {
+		phaseCount++
+	}
+	if *params.VerifyBalances {
+		phaseCount++
+	}
+	fundingPhases = progress.NewPhases("fund", phaseCount)
+	if *params.Direct {
+		err = fundWalletsWithTransfers(ctx, c, s, chainID, addresses)
+	} else {
+		err = fundWalletsWithContract(ctx, c, s, chainID, addresses)
+	}
+	if err != nil {
 		return err
 	}
 	log.Info().Msg("Wallet(s) funded! 💸")
 
+	if *params.VerifyBalances {
+		if err = verifyBalances(ctx, c, addresses); err != nil {
+			return err
+		}
+	}
+
 	log.Info().Msgf("Total execution time: %s", time.Since(startTime))
 	return nil
 }
@@ -102,12 +111,11 @@ func dialRpc(ctx context.Context) (*ethclient.Client, error) {
 }
 
 // Initialize  parameters.
-func initializeParams(ctx context.Context, c *ethclient.Client) (*ecdsa.PrivateKey, *big.Int, error) {
-	// Parse the private key.
-	trimmedHexPrivateKey := strings.TrimPrefix(*params.PrivateKey, "0x")
-	privateKey, err := crypto.HexToECDSA(trimmedHexPrivateKey)
+func initializeParams(ctx context.Context, c *ethclient.Client) (signer.Signer, *big.Int, error) {
+	// Set up the signer, from the private key unless another backend is selected.
+	s, err := params.Signer.New(ctx, *params.PrivateKey)
 	if err != nil {
-		log.Error().Err(err).Msg("Unable to process the private key")
+		log.Error().Err(err).Msg("Unable to set up the signer")
 		return nil, nil, err
 	}
 
@@ -119,12 +127,29 @@ func initializeParams(ctx context.Context, c *ethclient.Client) (*ecdsa.PrivateK
 		return nil, nil, err
 	}
 	log.Trace().Uint64("chainID", chainID.Uint64()).Msg("Detected chain ID")
-	return privateKey, chainID, nil
+	return s, chainID, nil
+}
+
+// fundWalletsWithContract funds the wallets through a Funder contract, deployed if needed.
+func fundWalletsWithContract(ctx context.Context, c *ethclient.Client, s signer.Signer, chainID *big.Int, addresses []common.Address) error {
+	tops, err := signer.TransactOpts(ctx, s, chainID)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable create transaction signer")
+		return err
+	}
+
+	// Deploy or instantiate the Funder contract.
+	var contract *funder.Funder
+	contract, err = deployOrInstantiateFunderContract(ctx, c, tops, s)
+	if err != nil {
+		return err
+	}
+	return fundWallets(ctx, c, tops, contract, addresses)
 }
 
 // deployOrInstantiateFunderContract deploys or instantiates a Funder contract.
 // If the pre-deployed address is specified, the contract will not be deployed.
-func deployOrInstantiateFunderContract(ctx context.Context, c *ethclient.Client, tops *bind.TransactOpts, privateKey *ecdsa.PrivateKey) (*funder.Funder, error) {
+func deployOrInstantiateFunderContract(ctx context.Context, c *ethclient.Client, tops *bind.TransactOpts, s signer.Signer) (*funder.Funder, error) {
 	// Deploy the contract if no pre-deployed address flag is provided.
 	var contractAddress common.Address
 	var err error
@@ -143,7 +168,7 @@ func deployOrInstantiateFunderContract(ctx context.Context, c *ethclient.Client,
 		// Note: `funderContractBalanceInWei` reprensents the initial balance of the Funder contract.
 		// The contract needs initial funds to be able to fund wallets.
 		funderContractBalanceInWei := util.EthToWei(funderContractBalanceInEth)
-		if err = util.SendTx(ctx, c, privateKey, &contractAddress, funderContractBalanceInWei, nil, uint64(30000)); err != nil {
+		if err = util.SendTx(ctx, c, s, &contractAddress, funderContractBalanceInWei, nil, uint64(30000)); err != nil {
 			return nil, err
 		}
 	} else {
@@ -160,21 +185,23 @@ func deployOrInstantiateFunderContract(ctx context.Context, c *ethclient.Client,
 	return contract, nil
 }
 
-// deriveWallets generates and exports a specified number of HD wallet addresses.
+// deriveWallets generates and exports a specified number of HD wallet addresses, starting at the
+// derivation index given by the offset.
 func deriveHDWallets(n int) ([]common.Address, error) {
-	wallet, err := hdwallet.NewPolyWallet(defaultMnemonic, defaultPassword)
+	wallet, err := hdwallet.NewPolyWallet(*params.Mnemonic, defaultPassword)
 	if err != nil {
 		return nil, err
 	}
 
 	var derivedWallets *hdwallet.PolyWalletExport
-	derivedWallets, err = wallet.ExportHDAddresses(n)
+	offset := int(*params.HDOffset)
+	derivedWallets, err = wallet.ExportHDAddresses(offset + n)
 	if err != nil {
 		return nil, err
 	}
 
 	addresses := make([]common.Address, n)
-	for i, wallet := range derivedWallets.Addresses {
+	for i, wallet := range derivedWallets.Addresses[offset:] {
 		addresses[i] = common.HexToAddress(wallet.ETHAddress)
 		log.Trace().Interface("address", addresses[i]).Str("privateKey", wallet.HexPrivateKey).Str("path", wallet.Path).Msg("New wallet derived")
 	}
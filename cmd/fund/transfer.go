@@ -0,0 +1,162 @@
+package fund
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/maticnetwork/polygon-cli/signer"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+// fundWalletsWithTransfers funds each wallet with its own transfer. The nonces are assigned
+// locally so the transfers can be sent concurrently. Once a transfer fails to be sent, its nonce
+// stays unused and the following transfers can't be mined, so the remaining ones aren't sent.
+func fundWalletsWithTransfers(ctx context.Context, c *ethclient.Client, s signer.Signer, chainID *big.Int, wallets []common.Address) error {
+	if len(wallets) == 0 {
+		return errors.New("no wallet to fund")
+	}
+	amount := util.EthToWei(*params.FundingAmountInEth)
+	from := s.Address()
+
+	nonce, err := c.PendingNonceAt(ctx, from)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to get the account nonce")
+		return err
+	}
+	gasPrice, err := c.SuggestGasPrice(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to get the gas price")
+		return err
+	}
+	// Every transfer costs the same, so the gas is only estimated once.
+	gasLimit, err := c.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &wallets[0], Value: amount})
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to estimate the transfer gas")
+		return err
+	}
+	log.Debug().Uint64("nonce", nonce).Interface("gasPrice", gasPrice).Uint64("gasLimit", gasLimit).Msg("Sending transfers")
+
+	txSigner := types.LatestSignerForChainID(chainID)
+	bar := fundingPhases.Next("funding wallets", int64(len(wallets)), "tx")
+	defer bar.Finish()
+	sem := make(chan struct{}, *params.Concurrency)
+	var wg sync.WaitGroup
+	var failed atomic.Int64
+	var stopped atomic.Bool
+	sent := 0
+	for i, to := range wallets {
+		if stopped.Load() {
+			break
+		}
+		to := to
+		tx := types.NewTx(&types.LegacyTx{
+			Nonce:    nonce + uint64(i),
+			Gas:      gasLimit,
+			GasPrice: gasPrice,
+			To:       &to,
+			Value:    amount,
+		})
+		// Signing happens in order since some signers, e.g. a Ledger, can't sign concurrently.
+		signedTx, err := s.SignTx(ctx, tx, txSigner)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to sign the transfer")
+			return err
+		}
+
+		sem <- struct{}{}
+		if stopped.Load() {
+			<-sem
+			break
+		}
+		sent++
+		wg.Add(1)
+		go func() {
+			defer func() {
+				bar.Add(1)
+				<-sem
+				wg.Done()
+			}()
+			if err := sendTransfer(ctx, c, signedTx, &stopped); err != nil {
+				log.Error().Err(err).Stringer("to", to).Stringer("txHash", signedTx.Hash()).Msg("Unable to fund wallet")
+				failed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := failed.Load(); n > 0 || sent < len(wallets) {
+		return fmt.Errorf("%d out of %d transfer(s) failed, %d not sent", n, len(wallets), len(wallets)-sent)
+	}
+	return nil
+}
+
+// sendTransfer sends the signed transaction, retrying after failures, and waits until it's mined or
+// --mine-timeout elapses. It sets stopped when the transaction can't be sent.
+func sendTransfer(ctx context.Context, c *ethclient.Client, tx *types.Transaction, stopped *atomic.Bool) error {
+	for attempt := uint64(0); ; attempt++ {
+		err := c.SendTransaction(ctx, tx)
+		// A previous attempt may have reached the node even though it failed.
+		if err == nil || strings.Contains(err.Error(), "already known") {
+			break
+		}
+		if attempt >= *params.Retries {
+			stopped.Store(true)
+			return err
+		}
+		log.Warn().Err(err).Stringer("txHash", tx.Hash()).Uint64("attempt", attempt+1).Msg("Transfer failed, retrying")
+		select {
+		case <-ctx.Done():
+			stopped.Store(true)
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt+1) * time.Second):
+		}
+	}
+
+	mineCtx, cancel := context.WithTimeout(ctx, *params.MineTimeout)
+	defer cancel()
+	receipt, err := bind.WaitMined(mineCtx, c, tx)
+	if err != nil {
+		return fmt.Errorf("the transfer wasn't mined: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return errors.New("the transfer was reverted")
+	}
+	return nil
+}
+
+// verifyBalances checks that every wallet holds at least the funding amount.
+func verifyBalances(ctx context.Context, c *ethclient.Client, wallets []common.Address) error {
+	amount := util.EthToWei(*params.FundingAmountInEth)
+	underfunded := 0
+	bar := fundingPhases.Next("verifying balances", int64(len(wallets)), "wallet")
+	defer bar.Finish()
+	for _, wallet := range wallets {
+		balance, err := c.BalanceAt(ctx, wallet, nil)
+		if err != nil {
+			log.Error().Err(err).Stringer("address", wallet).Msg("Unable to get the wallet balance")
+			return err
+		}
+		if balance.Cmp(amount) < 0 {
+			log.Warn().Stringer("address", wallet).Str("balance", balance.String()).Msg("Wallet is underfunded")
+			underfunded++
+		}
+		bar.Add(1)
+	}
+	if underfunded > 0 {
+		return fmt.Errorf("%d out of %d wallet(s) hold less than the funding amount", underfunded, len(wallets))
+	}
+	log.Info().Int("count", len(wallets)).Msg("Wallet(s) balance verified")
+	return nil
+}
@@ -0,0 +1,82 @@
+package gasestimator
+
+import (
+	_ "embed"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// maxBlocks is the largest window served by eth_feeHistory.
+const maxBlocks = 1024
+
+type cmdGasEstimatorParams struct {
+	RpcUrl      *string
+	Blocks      *uint64
+	Percentiles *[]float64
+	Probability *float64
+
+	Watch    *bool
+	Interval *time.Duration
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdGasEstimatorParams
+)
+
+// GasEstimatorCmd represents the gasestimator command.
+var GasEstimatorCmd = &cobra.Command{
+	Use:   "gasestimator",
+	Short: "Analyze the fee history and recommend fees.",
+	Long:  usage,
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGasEstimator(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdGasEstimatorParams)
+	flagSet := GasEstimatorCmd.Flags()
+
+	p.RpcUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8545", "The RPC endpoint url")
+	p.Blocks = flagSet.Uint64P("blocks", "b", 20, "The number of most recent blocks to analyze, up to 1024")
+	p.Percentiles = flagSet.Float64Slice("percentiles", []float64{10, 25, 50, 75, 90}, "The percentiles of the priority fees paid in each block")
+	p.Probability = flagSet.Float64P("probability", "p", 0.9, "The target inclusion probability of the recommended fee, between 0 and 1")
+
+	p.Watch = flagSet.BoolP("watch", "w", false, "Analyze the fee history again at every interval")
+	p.Interval = flagSet.Duration("interval", 12*time.Second, "The interval between two analyses in watch mode")
+
+	params = *p
+}
+
+func checkFlags() error {
+	if err := util.ValidateUrl(*params.RpcUrl); err != nil {
+		return err
+	}
+	if *params.Blocks == 0 || *params.Blocks > maxBlocks {
+		return errors.New("the number of blocks must be between 1 and 1024")
+	}
+	if *params.Probability <= 0 || *params.Probability > 1 {
+		return errors.New("the inclusion probability must be within (0, 1]")
+	}
+	if *params.Interval <= 0 {
+		return errors.New("the interval must be positive")
+	}
+	percentiles := *params.Percentiles
+	for _, p := range percentiles {
+		if p < 0 || p > 100 {
+			return errors.New("the percentiles must be between 0 and 100")
+		}
+	}
+	sort.Float64s(percentiles)
+	return nil
+}
@@ -0,0 +1,220 @@
+package gasestimator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	ethparams "github.com/ethereum/go-ethereum/params"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+// floorPercentile is the priority fee percentile a transaction must reach within a block to be considered
+// competitive in that block.
+const floorPercentile = 5
+
+type (
+	// FeeReport is the analysis of the fee history of a window of blocks. The fees are in Gwei.
+	FeeReport struct {
+		Time         time.Time
+		OldestBlock  uint64
+		NewestBlock  uint64
+		EmptyBlocks  int
+		GasUsedRatio float64
+		BaseFee      BaseFeeStats
+		PriorityFees []PriorityFeeStats
+		Recommended  Recommendation
+	}
+	BaseFeeStats struct {
+		Min  float64
+		Mean float64
+		Max  float64
+		// Change is the relative change of the base fee over the window, in percent.
+		Change float64
+		Last   float64
+		Next   float64
+	}
+	// PriorityFeeStats summarizes a percentile of the priority fees paid in each non empty block.
+	PriorityFeeStats struct {
+		Percentile float64
+		Min        float64
+		Median     float64
+		Max        float64
+	}
+	// Recommendation holds fees which would have been competitive in the target proportion of the blocks.
+	Recommendation struct {
+		Probability          float64
+		MaxPriorityFeePerGas float64
+		MaxFeePerGas         float64
+		// The same fees in wei, as expected by the gas price flags of the other commands.
+		MaxPriorityFeePerGasWei *big.Int
+		MaxFeePerGasWei         *big.Int
+	}
+)
+
+func runGasEstimator(ctx context.Context) error {
+	c, err := ethclient.DialContext(ctx, *params.RpcUrl)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	out := util.NewOutputWriter(os.Stdout)
+	ticker := time.NewTicker(*params.Interval)
+	defer ticker.Stop()
+	for {
+		report, err := analyzeFeeHistory(ctx, c, *params.Blocks, *params.Percentiles, *params.Probability)
+		if err != nil {
+			if !*params.Watch {
+				return err
+			}
+			log.Error().Err(err).Msg("Unable to analyze the fee history")
+		} else if err = out.Write(report); err != nil {
+			return err
+		}
+		if !*params.Watch {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// analyzeFeeHistory fetches the fee history of the latest blocks and summarizes it. The percentiles are sorted.
+func analyzeFeeHistory(ctx context.Context, c *ethclient.Client, blocks uint64, percentiles []float64, probability float64) (*FeeReport, error) {
+	// The floor percentile is requested along with the others, keeping them sorted as eth_feeHistory requires.
+	requested := append([]float64{}, percentiles...)
+	floor := sort.SearchFloat64s(requested, floorPercentile)
+	if floor == len(requested) || requested[floor] != floorPercentile {
+		requested = append(requested[:floor], append([]float64{floorPercentile}, requested[floor:]...)...)
+	}
+
+	history, err := c.FeeHistory(ctx, blocks, nil, requested)
+	if err != nil {
+		return nil, err
+	}
+	if len(history.BaseFee) < 2 {
+		return nil, errors.New("the fee history holds no block")
+	}
+
+	r := &FeeReport{Time: time.Now(), OldestBlock: history.OldestBlock.Uint64()}
+	r.NewestBlock = r.OldestBlock + uint64(len(history.GasUsedRatio)) - 1
+
+	// The last base fee is the one of the block after the newest.
+	baseFees := make([]float64, len(history.BaseFee))
+	for i, f := range history.BaseFee {
+		baseFees[i] = toGwei(f)
+	}
+	window := baseFees[:len(baseFees)-1]
+	r.BaseFee = BaseFeeStats{Min: math.Inf(1), Last: window[len(window)-1], Next: baseFees[len(baseFees)-1]}
+	for _, f := range window {
+		r.BaseFee.Min = math.Min(r.BaseFee.Min, f)
+		r.BaseFee.Max = math.Max(r.BaseFee.Max, f)
+		r.BaseFee.Mean += f / float64(len(window))
+	}
+	if window[0] > 0 {
+		r.BaseFee.Change = (r.BaseFee.Last - window[0]) / window[0] * 100
+	}
+
+	// Empty blocks report zero rewards, which would drag the percentiles down.
+	rewards := make([][]float64, len(requested))
+	for i, ratio := range history.GasUsedRatio {
+		r.GasUsedRatio += ratio / float64(len(history.GasUsedRatio))
+		if ratio == 0 || i >= len(history.Reward) {
+			r.EmptyBlocks++
+			continue
+		}
+		for j, reward := range history.Reward[i] {
+			rewards[j] = append(rewards[j], toGwei(reward))
+		}
+	}
+	for j, p := range requested {
+		if j == floor && len(requested) > len(percentiles) {
+			continue
+		}
+		stats := PriorityFeeStats{Percentile: p}
+		if fees := rewards[j]; len(fees) > 0 {
+			sort.Float64s(fees)
+			stats.Min, stats.Median, stats.Max = fees[0], quantile(fees, 0.5), fees[len(fees)-1]
+		}
+		r.PriorityFees = append(r.PriorityFees, stats)
+	}
+
+	// A tip reaching the floor percentile of a block was competitive in that block, so the quantile of the floors
+	// at the target probability would have been competitive in that proportion of the blocks.
+	var tip float64
+	if floors := rewards[floor]; len(floors) > 0 {
+		sort.Float64s(floors)
+		tip = quantile(floors, probability)
+	}
+	// As geth does, the max fee leaves room for the base fee to double.
+	r.Recommended = Recommendation{
+		Probability:          probability,
+		MaxPriorityFeePerGas: tip,
+		MaxFeePerGas:         2*r.BaseFee.Next + tip,
+	}
+	r.Recommended.MaxPriorityFeePerGasWei = toWei(r.Recommended.MaxPriorityFeePerGas)
+	r.Recommended.MaxFeePerGasWei = toWei(r.Recommended.MaxFeePerGas)
+	return r, nil
+}
+
+// quantile returns the value at the q quantile of the sorted values, rounding up.
+func quantile(sorted []float64, q float64) float64 {
+	i := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[max(0, min(i, len(sorted)-1))]
+}
+
+func toGwei(wei *big.Int) float64 {
+	if wei == nil {
+		return 0
+	}
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(ethparams.GWei)).Float64()
+	return gwei
+}
+
+func toWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(ethparams.GWei)).Int(nil)
+	return wei
+}
+
+// WriteText renders the report as tables.
+func (r *FeeReport) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetTitle(fmt.Sprintf("Blocks %d - %d (%d empty, %.1f%% gas used)", r.OldestBlock, r.NewestBlock, r.EmptyBlocks, r.GasUsedRatio*100))
+	t.AppendHeader(table.Row{"Base Fee (Gwei)", "Min", "Mean", "Max", "Last", "Next", "Change"})
+	t.AppendRow(table.Row{"", gwei(r.BaseFee.Min), gwei(r.BaseFee.Mean), gwei(r.BaseFee.Max), gwei(r.BaseFee.Last), gwei(r.BaseFee.Next), fmt.Sprintf("%+.2f%%", r.BaseFee.Change)})
+	t.Render()
+
+	t = table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Priority Fee (Gwei)", "Min", "Median", "Max"})
+	for _, p := range r.PriorityFees {
+		t.AppendRow(table.Row{fmt.Sprintf("p%g", p.Percentile), gwei(p.Min), gwei(p.Median), gwei(p.Max)})
+	}
+	t.Render()
+
+	t = table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{fmt.Sprintf("Recommended (%.0f%% inclusion)", r.Recommended.Probability*100), "Gwei", "Wei"})
+	t.AppendRow(table.Row{"Max Priority Fee", gwei(r.Recommended.MaxPriorityFeePerGas), r.Recommended.MaxPriorityFeePerGasWei})
+	t.AppendRow(table.Row{"Max Fee", gwei(r.Recommended.MaxFeePerGas), r.Recommended.MaxFeePerGasWei})
+	t.Render()
+	return nil
+}
+
+func gwei(f float64) string {
+	return fmt.Sprintf("%.4f", f)
+}
@@ -1,13 +1,17 @@
 package hash
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
 	"os"
 	"strings"
 
+	"github.com/maticnetwork/polygon-cli/util"
 	"github.com/spf13/cobra"
 
 	_ "embed"
@@ -17,6 +21,7 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 
+	"github.com/iden3/go-iden3-crypto/poseidon"
 	"golang.org/x/crypto/blake2b"
 	"golang.org/x/crypto/blake2s"
 	"golang.org/x/crypto/md4"       //nolint:staticcheck
@@ -48,35 +53,59 @@ var (
 		"blake2b_512",
 		"keccak256",
 		"keccak512",
+		"poseidon",
 	}
 	inputFileName *string
+	inputHex      *bool
+	inputEncoding *string
 )
 
 // hashCmd represents the hash command
 var HashCmd = &cobra.Command{
-	Use:   fmt.Sprintf("hash [%s]", strings.Join(supportedHashFunctions, "|")),
-	Short: "Provide common crypto hashing functions.",
-	Long:  usage,
-	Run: func(cmd *cobra.Command, args []string) {
+	Use:         fmt.Sprintf("hash [%s]", strings.Join(supportedHashFunctions, "|")),
+	Short:       "Provide common crypto hashing functions.",
+	Long:        usage,
+	Annotations: map[string]string{util.SideEffectFreeAnnotation: "true"},
+	Example: `# Hash a string with keccak256.
+$ polycli hash keccak256 hello # docutil:run
+
+# Hash hex encoded data and output the hash in base64.
+$ polycli hash sha256 --hex 0x68656c6c6f --encoding base64 # docutil:run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
 		data, err := getInputData(cmd, args)
 		if err != nil {
-			cmd.PrintErrf("There was an error reading input for hashing: %s", err.Error())
-			return
+			return fmt.Errorf("there was an error reading input for hashing: %w", err)
+		}
+		if *inputHex {
+			data, err = hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+			if err != nil {
+				return util.NewError(util.ValidationError, fmt.Errorf("there was an error decoding the hex input: %w", err))
+			}
 		}
 		h, err := getHash(args[0])
 		if err != nil {
-			cmd.PrintErrf("There was an error creating the hash function: %s", err.Error())
-			return
+			return fmt.Errorf("there was an error creating the hash function: %w", err)
 		}
 		h.Write(data)
 		hashOut := h.Sum(nil)
+		if p, ok := h.(*poseidonHash); ok && p.err != nil {
+			return util.NewError(util.ValidationError, fmt.Errorf("unable to compute the poseidon hash: %w", p.err))
+		}
+		if *inputEncoding == "base64" {
+			cmd.Println(base64.StdEncoding.EncodeToString(hashOut))
+			return nil
+		}
 		cmd.Println(hex.EncodeToString(hashOut))
-
+		return nil
 	},
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
 			return fmt.Errorf("expected 1 argument to specify hash function. got %d", len(args))
 		}
+		if *inputEncoding != "hex" && *inputEncoding != "base64" {
+			return fmt.Errorf("the encoding %s is not supported, use hex or base64", *inputEncoding)
+		}
 		for _, v := range supportedHashFunctions {
 			if v == args[0] {
 				return nil
@@ -90,6 +119,8 @@ var HashCmd = &cobra.Command{
 func init() {
 	flagSet := HashCmd.PersistentFlags()
 	inputFileName = flagSet.String("file", "", "Provide a filename to read and hash")
+	inputHex = flagSet.Bool("hex", false, "Decode the input as hex before hashing it")
+	inputEncoding = flagSet.String("encoding", "hex", "The encoding of the output [hex, base64]")
 }
 
 func getHash(name string) (hash.Hash, error) {
@@ -134,6 +165,8 @@ func getHash(name string) (hash.Hash, error) {
 		return sha3.NewLegacyKeccak256(), nil
 	case "keccak512":
 		return sha3.NewLegacyKeccak512(), nil
+	case "poseidon":
+		return new(poseidonHash), nil
 	}
 	var h hash.Hash
 	return h, fmt.Errorf("unable to create a hash function for %s", name)
@@ -156,3 +189,32 @@ func getInputData(cmd *cobra.Command, args []string) ([]byte, error) {
 
 	return io.ReadAll(os.Stdin)
 }
+
+// poseidonHash wraps the circom compatible Poseidon hash over the BN254 scalar
+// field so it can be used like any other hash.Hash. The input is buffered and
+// only hashed when Sum is called. Since Sum can't return an error, the error of
+// the hash, e.g. for an empty input, is kept in err.
+type poseidonHash struct {
+	buf bytes.Buffer
+	err error
+}
+
+func (p *poseidonHash) Write(b []byte) (int, error) { return p.buf.Write(b) }
+func (p *poseidonHash) Reset()                      { p.buf.Reset(); p.err = nil }
+func (p *poseidonHash) Size() int                   { return 32 }
+func (p *poseidonHash) BlockSize() int              { return 31 }
+
+func (p *poseidonHash) Sum(b []byte) []byte {
+	h, err := poseidon.HashBytes(p.buf.Bytes())
+	if err == nil && h == nil {
+		err = errors.New("the input is empty")
+	}
+	if err != nil {
+		p.err = err
+		return b
+	}
+	p.err = nil
+	out := make([]byte, p.Size())
+	h.FillBytes(out)
+	return append(b, out...)
+}
@@ -10,7 +10,10 @@ import (
 	"time"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/maticnetwork/polygon-cli/cmd/benchreport"
+	"github.com/maticnetwork/polygon-cli/rpcclient"
 	"github.com/maticnetwork/polygon-cli/rpctypes"
+	"github.com/maticnetwork/polygon-cli/signer"
 	"github.com/maticnetwork/polygon-cli/util"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
@@ -46,6 +49,8 @@ type (
 		CallOnlyLatestBlock           *bool
 		ChainID                       *uint64
 		PrivateKey                    *string
+		SignerFlags                   *signer.Flags
+		RPCClient                     *rpcclient.Flags
 		ToAddress                     *string
 		EthAmountInWei                *float64
 		RateLimit                     *float64
@@ -71,19 +76,30 @@ type (
 		SummaryOutputMode             *string
 		LegacyTransactionMode         *bool
 		SendOnly                      *bool
+		WaitForReceipts               *bool
+		ReceiptTimeout                *time.Duration
+		NonceStallTimeout             *time.Duration
+		FeeBumpPercent                *uint64
+		Record                        *string
+		Replay                        *string
+		ReplayTimeScale               *float64
 		RecallLength                  *uint64
 		ContractAddress               *string
 		ContractCallData              *string
 		ContractCallFunctionSignature *string
 		ContractCallFunctionArgs      *[]string
 		ContractCallPayable           *bool
+		ContractCallABIFile           *string
+		ContractCallFunctionName      *string
 		InscriptionContent            *string
 		BlobFeeCap                    *uint64
+		BlobCount                     *uint64
 
 		// Computed
 		CurrentGasPrice     *big.Int
 		CurrentGasTipCap    *big.Int
 		CurrentNonce        *uint64
+		Signer              signer.Signer
 		ECDSAPrivateKey     *ecdsa.PrivateKey
 		FromETHAddress      *ethcommon.Address
 		ToETHAddress        *ethcommon.Address
@@ -105,9 +121,6 @@ var (
 	loadTestResutsMutex sync.RWMutex
 	startBlockNumber    uint64
 	finalBlockNumber    uint64
-	startNonce          uint64
-	currentNonce        uint64
-	currentNonceMutex   sync.RWMutex
 	rl                  *rate.Limiter
 
 	hexwords = []byte{
@@ -172,6 +185,7 @@ var LoadtestCmd = &cobra.Command{
 		return checkLoadtestFlags()
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
 		return runLoadTest(cmd.Context())
 	},
 }
@@ -191,6 +205,16 @@ func checkLoadtestFlags() error {
 		return fmt.Errorf("the backoff factor needs to be non-zero positive. Given: %f", *ltp.AdaptiveBackoffFactor)
 	}
 
+	if *ltp.Replay != "" && *ltp.Record != "" {
+		return fmt.Errorf("a replay can't be recorded, the scenario is the same")
+	}
+	if *ltp.ReplayTimeScale < 0 {
+		return fmt.Errorf("the replay time scale can't be negative. Given: %f", *ltp.ReplayTimeScale)
+	}
+	if *ltp.Replay != "" && *ltp.Concurrency < 1 {
+		return fmt.Errorf("the concurrency needs to be positive to replay a scenario. Given: %d", *ltp.Concurrency)
+	}
+
 	return nil
 }
 
@@ -208,6 +232,8 @@ func initFlags() {
 	ltp.Concurrency = LoadtestCmd.PersistentFlags().Int64P("concurrency", "c", 1, "Number of requests to perform concurrently. Default is one request at a time.")
 	ltp.TimeLimit = LoadtestCmd.PersistentFlags().Int64P("time-limit", "t", -1, "Maximum number of seconds to spend for benchmarking. Use this to benchmark within a fixed total amount of time. Per default there is no time limit.")
 	ltp.PrivateKey = LoadtestCmd.PersistentFlags().String("private-key", codeQualityPrivateKey, "The hex encoded private key that we'll use to send transactions")
+	ltp.SignerFlags = signer.AddFlags(LoadtestCmd.PersistentFlags())
+	ltp.RPCClient = rpcclient.AddFlags(LoadtestCmd.PersistentFlags())
 	ltp.ChainID = LoadtestCmd.PersistentFlags().Uint64("chain-id", 0, "The chain id for the transactions.")
 	ltp.ToAddress = LoadtestCmd.PersistentFlags().String("to-address", "0xDEADBEEFDEADBEEFDEADBEEFDEADBEEFDEADBEEF", "The address that we're going to send to")
 	ltp.ToRandom = LoadtestCmd.PersistentFlags().Bool("to-random", false, "When doing a transfer test, should we send to random addresses rather than DEADBEEFx5")
@@ -230,7 +256,15 @@ func initFlags() {
 	ltp.SummaryOutputMode = LoadtestCmd.PersistentFlags().String("output-mode", "text", "Format mode for summary output (json | text)")
 	ltp.LegacyTransactionMode = LoadtestCmd.PersistentFlags().Bool("legacy", false, "Send a legacy transaction instead of an EIP1559 transaction.")
 	ltp.SendOnly = LoadtestCmd.PersistentFlags().Bool("send-only", false, "Send transactions and load without waiting for it to be mined.")
+	ltp.WaitForReceipts = LoadtestCmd.PersistentFlags().Bool("wait-for-receipts", false, "Block at the end of the run until the receipt of every sent transaction is observed, even with --send-only. The inclusion latency, dropped and replaced transactions and revert ratio are reported.")
+	ltp.ReceiptTimeout = LoadtestCmd.PersistentFlags().Duration("receipt-timeout", time.Minute, "With --wait-for-receipts, the transactions without receipt are counted as dropped once no new receipt is observed for this duration")
+	ltp.NonceStallTimeout = LoadtestCmd.PersistentFlags().Duration("nonce-stall-timeout", 0, "Once the mined nonce of the sender stops progressing for this duration, e.g. after a dropped transaction, replace the transaction holding it back with a copy with bumped fees. Disabled when zero")
+	ltp.FeeBumpPercent = LoadtestCmd.PersistentFlags().Uint64("fee-bump-percent", 10, "With --nonce-stall-timeout, the percentage by which the fees of a replacement transaction are increased, at least 100 for the blob transactions")
+	ltp.Record = LoadtestCmd.PersistentFlags().String("record", "", "Record every transaction signed during the run, with its timing, to this scenario file for --replay")
+	ltp.Replay = LoadtestCmd.PersistentFlags().String("replay", "", "Send the transactions of a scenario file recorded with --record instead of generating new ones")
+	ltp.ReplayTimeScale = LoadtestCmd.PersistentFlags().Float64("replay-time-scale", 1, "With --replay, the factor applied to the recorded timing of the transactions: 0.5 replays twice as fast, 0 as fast as --concurrency allows")
 	ltp.BlobFeeCap = LoadtestCmd.Flags().Uint64("blob-fee-cap", 100000, "The blob fee cap, or the maximum blob fee per chunk, in Gwei.")
+	ltp.BlobCount = LoadtestCmd.Flags().Uint64("blob-count", 1, "The number of blobs carried by each blob transaction, up to 6.")
 
 	// Local flags.
 	ltp.Modes = LoadtestCmd.Flags().StringSliceP("mode", "m", []string{"t"}, `The testing mode to use. It can be multiple like: "t,c,d,f"
@@ -248,7 +282,8 @@ v3 - UniswapV3 swaps
 R - total recall
 rpc - call random rpc methods
 cc, contract-call - call a contract method
-inscription - sending inscription transactions`)
+inscription - sending inscription transactions
+blob - sending blob transactions`)
 	ltp.Function = LoadtestCmd.Flags().Uint64P("function", "f", 1, "A specific function to be called if running with --mode f or a specific precompiled contract when running with --mode a")
 	ltp.ByteCount = LoadtestCmd.Flags().Uint64P("byte-count", "b", 1024, "If we're in store mode, this controls how many bytes we'll try to store in our contract")
 	ltp.LtAddress = LoadtestCmd.Flags().String("lt-address", "", "The address of a pre-deployed load test contract")
@@ -259,7 +294,9 @@ inscription - sending inscription transactions`)
 	ltp.ContractAddress = LoadtestCmd.Flags().String("contract-address", "", "The address of the contract that will be used in --mode contract-call. This must be paired up with --mode contract-call and --calldata")
 	ltp.ContractCallData = LoadtestCmd.Flags().String("calldata", "", "The hex encoded calldata passed in. The format is function signature + arguments encoded together. This must be paired up with --mode contract-call and --contract-address")
 	ltp.ContractCallFunctionSignature = LoadtestCmd.Flags().String("function-signature", "", "The contract's function signature that will be called. The format is '<function name>(<types...>)'. This must be paired up with '--mode contract-call' and '--contract-address'. If the function requires parameters you can pass them with '--function-arg <value>'.")
-	ltp.ContractCallFunctionArgs = LoadtestCmd.Flags().StringSlice("function-arg", []string{}, `The arguments that will be passed to a contract function call. This must be paired up with "--mode contract-call" and "--contract-address". Args can be passed multiple times: "--function-arg 'test' --function-arg 999" or comma separated values "--function-arg "test",9". The ordering of the arguments must match the ordering of the function parameters.`)
+	ltp.ContractCallFunctionArgs = LoadtestCmd.Flags().StringSlice("function-arg", []string{}, `The arguments that will be passed to a contract function call. This must be paired up with "--mode contract-call" and "--contract-address". Args can be passed multiple times: "--function-arg 'test' --function-arg 999" or comma separated values "--function-arg "test",9". The ordering of the arguments must match the ordering of the function parameters. Args can be templates evaluated for every call: "{{randAddress}}", "{{randUint 1 1000}}", "{{randBytes 32}}", "{{counter}}" and "{{sender}}".`)
+	ltp.ContractCallABIFile = LoadtestCmd.Flags().String("abi-file", "", "The JSON ABI of the contract. This must be paired up with --function-name, which selects the function to call in --mode contract-call")
+	ltp.ContractCallFunctionName = LoadtestCmd.Flags().String("function-name", "", "The name of the ABI function called in --mode contract-call. This must be paired up with --abi-file")
 	ltp.ContractCallPayable = LoadtestCmd.Flags().Bool("contract-call-payable", false, "Use this flag if the function is payable, the value amount passed will be from --eth-amount. This must be paired up with --mode contract-call and --contract-address")
 	ltp.InscriptionContent = LoadtestCmd.Flags().String("inscription-content", `data:,{"p":"erc-20","op":"mint","tick":"TEST","amt":"1"}`, "The inscription content that will be encoded as calldata. This must be paired up with --mode inscription")
 
@@ -270,4 +307,5 @@ inscription - sending inscription transactions`)
 
 func initSubCommands() {
 	LoadtestCmd.AddCommand(uniswapV3LoadTestCmd)
+	LoadtestCmd.AddCommand(benchreport.NewReportCmd())
 }
@@ -1,19 +1,27 @@
 package loadtest
 
 import (
+	"context"
 	cryptorand "crypto/rand"
 	"crypto/sha256"
 	_ "embed"
 	"fmt"
+	"math/big"
 	"math/rand"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/rs/zerolog/log"
 
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// maxBlobsPerTx is the number of blobs filling a block.
+const maxBlobsPerTx = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+
 type BlobCommitment struct {
 	Blob          kzg4844.Blob
 	Commitment    kzg4844.Commitment
@@ -105,3 +113,65 @@ func appendBlobCommitment(tx *types.BlobTx) error {
 	tx.Sidecar.Proofs = append(tx.Sidecar.Proofs, blobCommitment.Proof)
 	return nil
 }
+
+// getBlobFeeCap returns the blob fee cap in wei.
+func getBlobFeeCap() *big.Int {
+	return new(big.Int).Mul(new(big.Int).SetUint64(*inputLoadTestParams.BlobFeeCap), big.NewInt(params.GWei))
+}
+
+// summarizeBlobGas reports the blobs included in the blocks of the load test and how the blob base fee evolved.
+func summarizeBlobGas(ctx context.Context, c *ethclient.Client, startBlockNumber, endBlockNumber uint64) {
+	blobFeeCap := getBlobFeeCap()
+	var blobs, fullBlocks, overCapBlocks uint64
+	blobsPerBlock := make([]float64, 0)
+	blobBaseFees := make([]float64, 0)
+	for n := startBlockNumber; n <= endBlockNumber; n++ {
+		header, err := c.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			log.Error().Err(err).Uint64("blockNumber", n).Msg("Unable to get the block header")
+			return
+		}
+		if header.ExcessBlobGas == nil || header.BlobGasUsed == nil {
+			log.Warn().Uint64("blockNumber", n).Msg("The block has no blob gas fields, the chain doesn't support blobs")
+			return
+		}
+		blobFee := eip4844.CalcBlobFee(*header.ExcessBlobGas)
+		blockBlobs := *header.BlobGasUsed / params.BlobTxBlobGasPerBlob
+		blobs += blockBlobs
+		if *header.BlobGasUsed >= params.MaxBlobGasPerBlock {
+			fullBlocks++
+		}
+		if blobFee.Cmp(blobFeeCap) > 0 {
+			overCapBlocks++
+		}
+		blobsPerBlock = append(blobsPerBlock, float64(blockBlobs))
+		f, _ := new(big.Float).SetInt(blobFee).Float64()
+		blobBaseFees = append(blobBaseFees, f)
+		log.Debug().Uint64("blockNumber", n).Uint64("blobs", blockBlobs).Uint64("excessBlobGas", *header.ExcessBlobGas).Str("blobBaseFee", blobFee.String()).Msg("Block blob gas")
+	}
+	if len(blobBaseFees) == 0 {
+		return
+	}
+
+	minBlobs, medianBlobs, maxBlobs := getMinMedianMax(blobsPerBlock)
+	minFee, medianFee, maxFee := getMinMedianMax(blobBaseFees)
+	log.Info().
+		Uint64("blocks", uint64(len(blobBaseFees))).
+		Uint64("blobs", blobs).
+		Float64("minBlobsPerBlock", minBlobs).
+		Float64("medianBlobsPerBlock", medianBlobs).
+		Float64("maxBlobsPerBlock", maxBlobs).
+		Uint64("fullBlocks", fullBlocks).
+		Msg("Blob inclusion")
+	log.Info().
+		Float64("firstBlobBaseFee", blobBaseFees[0]).
+		Float64("lastBlobBaseFee", blobBaseFees[len(blobBaseFees)-1]).
+		Float64("minBlobBaseFee", minFee).
+		Float64("medianBlobBaseFee", medianFee).
+		Float64("maxBlobBaseFee", maxFee).
+		Str("blobFeeCap", blobFeeCap.String()).
+		Msg("Blob base fee in wei")
+	if overCapBlocks > 0 {
+		log.Warn().Uint64("blocks", overCapBlocks).Msg("The blob base fee exceeded the blob fee cap, the blob transactions couldn't be included in these blocks")
+	}
+}
@@ -0,0 +1,154 @@
+package loadtest
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/maticnetwork/polygon-cli/abi"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// contractCallSignature is the signature of the function of the contract call mode.
+	contractCallSignature string
+	// contractCallArgs are the templates of the arguments, nil when they're static.
+	contractCallArgs []*template.Template
+	// contractCallCounter is the value of the counter template function.
+	contractCallCounter atomic.Uint64
+	// contractCallRand is the source of the random template functions, guarded by contractCallRandMu
+	// since the templates are evaluated concurrently. It's apart from randSrc, which the other modes
+	// read without a lock.
+	contractCallRand   *rand.Rand
+	contractCallRandMu sync.Mutex
+)
+
+// contractCallFuncs are the functions usable in the argument templates.
+var contractCallFuncs = template.FuncMap{
+	// randAddress returns a random address.
+	"randAddress": func() string {
+		var addr ethcommon.Address
+		contractCallRandMu.Lock()
+		contractCallRand.Read(addr[:])
+		contractCallRandMu.Unlock()
+		return addr.String()
+	},
+	// randUint returns a random integer within [min, max].
+	"randUint": func(min, max uint64) (string, error) {
+		if max < min {
+			return "", fmt.Errorf("randUint: the max %d is less than the min %d", max, min)
+		}
+		span := new(big.Int).SetUint64(max - min)
+		span.Add(span, big.NewInt(1))
+		contractCallRandMu.Lock()
+		n := new(big.Int).Rand(contractCallRand, span)
+		contractCallRandMu.Unlock()
+		return n.Add(n, new(big.Int).SetUint64(min)).String(), nil
+	},
+	// randBytes returns n random bytes, hex encoded.
+	"randBytes": func(n int) string {
+		b := make([]byte, n)
+		contractCallRandMu.Lock()
+		contractCallRand.Read(b)
+		contractCallRandMu.Unlock()
+		return hexutil.Encode(b)
+	},
+	// counter returns a sequential counter, starting at 0 and increasing with every call.
+	"counter": func() uint64 {
+		return contractCallCounter.Add(1) - 1
+	},
+	// sender returns the address sending the transactions.
+	"sender": func() string {
+		return inputLoadTestParams.FromETHAddress.String()
+	},
+}
+
+// initContractCall resolves the function signature, from the ABI when it's given, and parses the argument templates.
+func initContractCall() error {
+	ltp := inputLoadTestParams
+	contractCallSignature = *ltp.ContractCallFunctionSignature
+	if *ltp.ContractCallABIFile != "" {
+		if contractCallSignature != "" {
+			return errors.New("the function is either given by --function-signature or by --abi-file and --function-name")
+		}
+		sig, err := getABIFunctionSignature(*ltp.ContractCallABIFile, *ltp.ContractCallFunctionName)
+		if err != nil {
+			return err
+		}
+		contractCallSignature = sig
+		log.Info().Str("signature", sig).Msg("Calling the function of the ABI")
+	} else if *ltp.ContractCallFunctionName != "" {
+		return errors.New("--function-name must be paired with --abi-file")
+	}
+
+	contractCallArgs = nil
+	contractCallRand = rand.New(rand.NewSource(*ltp.Seed))
+	templated := false
+	tmpls := make([]*template.Template, 0, len(*ltp.ContractCallFunctionArgs))
+	for i, arg := range *ltp.ContractCallFunctionArgs {
+		t, err := template.New(fmt.Sprintf("arg%d", i)).Funcs(contractCallFuncs).Parse(arg)
+		if err != nil {
+			return fmt.Errorf("unable to parse the template of the function argument %d: %w", i, err)
+		}
+		templated = templated || strings.Contains(arg, "{{")
+		tmpls = append(tmpls, t)
+	}
+	if templated {
+		contractCallArgs = tmpls
+	}
+	return nil
+}
+
+// getABIFunctionSignature returns the signature of the named function of the ABI file, e.g. transfer(address,uint256).
+func getABIFunctionSignature(file, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("--abi-file must be paired with --function-name")
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	contractABI, err := ethabi.JSON(f)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse the ABI: %w", err)
+	}
+	method, ok := contractABI.Methods[name]
+	if !ok {
+		names := make([]string, 0, len(contractABI.Methods))
+		for n := range contractABI.Methods {
+			names = append(names, n)
+		}
+		return "", fmt.Errorf("the ABI has no function %s, the functions are: %s", name, strings.Join(names, ", "))
+	}
+	return method.Sig, nil
+}
+
+// getContractCallData returns the calldata of a contract call, with the argument templates evaluated.
+func getContractCallData() (string, error) {
+	ltp := inputLoadTestParams
+	if *ltp.ContractCallData != "" {
+		return *ltp.ContractCallData, nil
+	}
+	if contractCallArgs == nil {
+		return abi.AbiEncode(contractCallSignature, *ltp.ContractCallFunctionArgs)
+	}
+	args := make([]string, 0, len(contractCallArgs))
+	for _, t := range contractCallArgs {
+		var sb strings.Builder
+		if err := t.Execute(&sb, nil); err != nil {
+			return "", err
+		}
+		args = append(args, sb.String())
+	}
+	return abi.AbiEncode(contractCallSignature, args)
+}
@@ -25,9 +25,10 @@ import (
 	"github.com/maticnetwork/polygon-cli/bindings/tester"
 	"github.com/maticnetwork/polygon-cli/bindings/tokens"
 	uniswapv3loadtest "github.com/maticnetwork/polygon-cli/cmd/loadtest/uniswapv3"
+	"github.com/maticnetwork/polygon-cli/progress"
 
-	"github.com/maticnetwork/polygon-cli/abi"
 	"github.com/maticnetwork/polygon-cli/rpctypes"
+	"github.com/maticnetwork/polygon-cli/signer"
 	"github.com/maticnetwork/polygon-cli/util"
 
 	ethereum "github.com/ethereum/go-ethereum"
@@ -35,7 +36,6 @@ import (
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	ethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/rs/zerolog/log"
@@ -181,10 +181,10 @@ func initializeLoadTestParams(ctx context.Context, c *ethclient.Client) error {
 		inputLoadTestParams.CurrentGasTipCap = gasTipCap
 	}
 
-	trimmedHexPrivateKey := strings.TrimPrefix(*inputLoadTestParams.PrivateKey, "0x")
-	privateKey, err := ethcrypto.HexToECDSA(trimmedHexPrivateKey)
+	// The hex private key is used unless another signing backend is selected.
+	s, err := inputLoadTestParams.SignerFlags.New(ctx, *inputLoadTestParams.PrivateKey)
 	if err != nil {
-		log.Error().Err(err).Msg("Couldn't process the hex private key")
+		log.Error().Err(err).Msg("Couldn't set up the transaction signer")
 		return err
 	}
 
@@ -195,7 +195,7 @@ func initializeLoadTestParams(ctx context.Context, c *ethclient.Client) error {
 		return err
 	}
 	log.Trace().Uint64("blocknumber", blockNumber).Msg("Current Block Number")
-	ethAddress := ethcrypto.PubkeyToAddress(privateKey.PublicKey)
+	ethAddress := s.Address()
 
 	nonce, err := c.NonceAt(ctx, ethAddress, bigBlockNumber)
 	if err != nil {
@@ -248,7 +248,8 @@ func initializeLoadTestParams(ctx context.Context, c *ethclient.Client) error {
 	inputLoadTestParams.SendAmount = amt
 	inputLoadTestParams.CurrentGasPrice = gas
 	inputLoadTestParams.CurrentNonce = &nonce
-	inputLoadTestParams.ECDSAPrivateKey = privateKey
+	inputLoadTestParams.Signer = s
+	inputLoadTestParams.ECDSAPrivateKey, _ = signer.PrivateKey(s)
 	inputLoadTestParams.FromETHAddress = &ethAddress
 	if *inputLoadTestParams.ChainID == 0 {
 		*inputLoadTestParams.ChainID = chainID.Uint64()
@@ -288,18 +289,45 @@ func initializeLoadTestParams(ctx context.Context, c *ethclient.Client) error {
 		log.Trace().Msg("Setting call only mode since we're doing RPC testing")
 		*inputLoadTestParams.CallOnly = true
 	}
-	if hasMode(loadTestModeContractCall, inputLoadTestParams.ParsedModes) && (*inputLoadTestParams.ContractAddress == "" || (*inputLoadTestParams.ContractCallData == "" && *inputLoadTestParams.ContractCallFunctionSignature == "")) {
-		return errors.New("`--contract-call` requires both a `--contract-address` and calldata, either with `--calldata` or `--function-signature --function-arg` flags.")
+	if hasMode(loadTestModeContractCall, inputLoadTestParams.ParsedModes) && (*inputLoadTestParams.ContractAddress == "" || (*inputLoadTestParams.ContractCallData == "" && *inputLoadTestParams.ContractCallFunctionSignature == "" && *inputLoadTestParams.ContractCallABIFile == "")) {
+		return errors.New("`--contract-call` requires both a `--contract-address` and calldata, either with `--calldata`, `--function-signature --function-arg` or `--abi-file --function-name --function-arg` flags.")
 	}
 	if *inputLoadTestParams.CallOnly && *inputLoadTestParams.AdaptiveRateLimit {
 		return errors.New("using call only with adaptive rate limit doesn't make sense")
 	}
+	usesPrecompiles := hasMode(loadTestModePrecompiledContract, inputLoadTestParams.ParsedModes) || hasMode(loadTestModePrecompiledContracts, inputLoadTestParams.ParsedModes) || hasMode(loadTestModeRandom, inputLoadTestParams.ParsedModes)
+	if usesPrecompiles && inputLoadTestParams.ECDSAPrivateKey == nil {
+		return errors.New("the precompiled contract modes need the raw private key and can't be used with a keystore, KMS or Ledger signer")
+	}
+	if *inputLoadTestParams.NonceStallTimeout > 0 && *inputLoadTestParams.FeeBumpPercent == 0 {
+		return errors.New("the fee bump percentage must be positive for the replacements to be accepted")
+	}
+	if *inputLoadTestParams.BlobCount < 1 || *inputLoadTestParams.BlobCount > maxBlobsPerTx {
+		return fmt.Errorf("the blob count must be between 1 and %d", maxBlobsPerTx)
+	}
 	if hasMode(loadTestModeBlob, inputLoadTestParams.ParsedModes) && inputLoadTestParams.MultiMode {
 		return errors.New("Blob mode should only be used by itself. Blob mode will take significantly longer than other transactions to finalize, and the address will be reserved, preventing other transactions form being made.")
 	}
 
 	randSrc = rand.New(rand.NewSource(*inputLoadTestParams.Seed))
 
+	if hasMode(loadTestModeContractCall, inputLoadTestParams.ParsedModes) {
+		if err := initContractCall(); err != nil {
+			return err
+		}
+	}
+
+	if *inputLoadTestParams.Record != "" {
+		if *inputLoadTestParams.CallOnly {
+			return errors.New("there are no transactions to record in call only mode")
+		}
+		recorder, err = newScenarioRecorder(*inputLoadTestParams.Record, *inputLoadTestParams.ChainID, ethAddress)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to create the scenario file")
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -311,11 +339,8 @@ func initNonce(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) err
 		return err
 	}
 
-	// Get pending nonce to be prevent nonce collision (if tx from same sender is already present)
-	currentNonce, err = c.PendingNonceAt(ctx, *inputLoadTestParams.FromETHAddress)
-	startNonce = currentNonce
-
-	if err != nil {
+	nonceMgr = newNonceManager(c, *inputLoadTestParams.NonceStallTimeout, *inputLoadTestParams.FeeBumpPercent)
+	if _, err = nonceMgr.Init(ctx, *inputLoadTestParams.FromETHAddress); err != nil {
 		log.Error().Err(err).Msg("Unable to get account nonce")
 		return err
 	}
@@ -324,8 +349,13 @@ func initNonce(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) err
 }
 
 func completeLoadTest(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) error {
+	startNonce, currentNonce := nonceMgr.Range(*inputLoadTestParams.FromETHAddress)
 	log.Debug().Uint64("startNonce", startNonce).Uint64("lastNonce", currentNonce).Msg("Finished main load test loop")
-	if *inputLoadTestParams.SendOnly {
+	if *inputLoadTestParams.SendOnly && !*inputLoadTestParams.WaitForReceipts {
+		if tracker != nil {
+			tracker.Stop(ctx)
+		}
+		nonceMgr.Stop()
 		log.Info().Uint64("transactionsSent", currentNonce-startNonce).Msg("SendOnly mode enabled - skipping wait period and summarization")
 		return nil
 	}
@@ -336,6 +366,21 @@ func completeLoadTest(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Clie
 	if err != nil {
 		log.Error().Err(err).Msg("There was an issue waiting for all transactions to be mined")
 	}
+	if tracker != nil {
+		if *inputLoadTestParams.WaitForReceipts {
+			sent := sentNonces(loadTestResults)
+			nonces := make([]uint64, 0, len(sent))
+			for n := range sent {
+				nonces = append(nonces, n)
+			}
+			bar := loadTestPhases.Next("waiting for receipts", int64(len(nonces)), "tx")
+			tracker.Wait(ctx, nonces, *inputLoadTestParams.ReceiptTimeout, bar)
+			bar.Finish()
+		}
+		nonceMgr.Stop()
+		tracker.Stop(ctx)
+		tracker.summarizeReceipts(loadTestResults)
+	}
 	if len(loadTestResults) == 0 {
 		return errors.New("no transactions observed")
 	}
@@ -356,6 +401,9 @@ func completeLoadTest(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Clie
 			log.Error().Err(err).Msg("There was an issue creating the load test summary")
 		}
 	}
+	if hasMode(loadTestModeBlob, inputLoadTestParams.ParsedModes) {
+		summarizeBlobGas(ctx, c, startBlockNumber, finalBlockNumber)
+	}
 	lightSummary(loadTestResults, startTime, endTime, rl)
 
 	return nil
@@ -377,7 +425,7 @@ func runLoadTest(ctx context.Context) error {
 	}
 
 	// Dial the Ethereum RPC server.
-	rpc, err := ethrpc.DialContext(ctx, *inputLoadTestParams.RPCUrl)
+	rpc, err := inputLoadTestParams.RPCClient.Dial(ctx, *inputLoadTestParams.RPCUrl)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable to dial rpc")
 		return err
@@ -385,11 +433,21 @@ func runLoadTest(ctx context.Context) error {
 	defer rpc.Close()
 	rpc.SetHeader("Accept-Encoding", "identity")
 	ec := ethclient.NewClient(rpc)
+	// The transactions recorded so far are flushed on every return path, the failed runs included.
+	defer func() {
+		if cErr := recorder.Close(); cErr != nil {
+			log.Error().Err(cErr).Msg("Unable to write the scenario")
+		}
+	}()
 
 	// Define the main loop function.
 	// Make sure to define any logic associated to the load test (initialization, main load test loop
 	// or completion steps) in this function in order to handle cancellation signals properly.
 	loopFunc := func() error {
+		if *inputLoadTestParams.Replay != "" {
+			return runReplay(ctx, ec, rpc)
+		}
+
 		if err = initializeLoadTestParams(ctx, ec); err != nil {
 			log.Error().Err(err).Msg("Error initializing load test parameters")
 			return err
@@ -418,18 +476,19 @@ func runLoadTest(ctx context.Context) error {
 	}()
 
 	// Wait for the load test to complete, either due to time limit, interrupt signal, or completion.
+	var loopErr error
 	select {
 	case <-overallTimer.C:
 		log.Info().Msg("Time's up")
 	case <-sigCh:
 		log.Info().Msg("Interrupted.. Stopping load test")
-	case err = <-errCh:
-		if err != nil {
-			log.Fatal().Err(err).Msg("Received critical error while running load test")
+	case loopErr = <-errCh:
+		if loopErr != nil {
+			log.Error().Err(loopErr).Msg("Received critical error while running load test")
 		}
 	}
 	log.Info().Msg("Finished")
-	return nil
+	return loopErr
 }
 
 func updateRateLimit(ctx context.Context, rl *rate.Limiter, rpc *ethrpc.Client, steadyStateQueueSize uint64, rateLimitIncrement uint64, cycleDuration time.Duration, backoff float64) {
@@ -461,6 +520,9 @@ func updateRateLimit(ctx context.Context, rl *rate.Limiter, rpc *ethrpc.Client,
 	}
 }
 
+// loadTestPhases numbers the progress reports of the requests and of the wait for their receipts.
+var loadTestPhases *progress.Phases
+
 func mainLoop(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) error {
 	ltp := inputLoadTestParams
 	log.Trace().Interface("Input Params", ltp).Msg("Params")
@@ -468,7 +530,6 @@ func mainLoop(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) erro
 	routines := *ltp.Concurrency
 	requests := *ltp.Requests
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 	mode := ltp.Mode
 	steadyStateTxPoolSize := *ltp.SteadyStateTxPoolSize
 	adaptiveRateLimitIncrement := *ltp.AdaptiveRateLimitIncrement
@@ -482,7 +543,12 @@ func mainLoop(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) erro
 		go updateRateLimit(rateLimitCtx, rl, rpc, steadyStateTxPoolSize, adaptiveRateLimitIncrement, time.Duration(*ltp.AdaptiveCycleDuration)*time.Second, *ltp.AdaptiveBackoffFactor)
 	}
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	// The transactions are configured with the base fee, unless in legacy mode.
+	if !*ltp.LegacyTransactionMode && ltp.CurrentBaseFee == nil {
+		return util.Errorf(util.ValidationError, "EIP-1559 not activated, please use --legacy")
+	}
+
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	tops = configureTransactOpts(tops)
 	// configureTransactOpts will set some parameters meant for load testing that could interfere with the deployment of our contracts
 	tops.GasLimit = 0
@@ -592,7 +658,18 @@ func mainLoop(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) erro
 	if err != nil {
 		return err
 	}
-	log.Debug().Uint64("currentNonce", currentNonce).Msg("Starting main load test loop")
+	if !*ltp.CallOnly {
+		tracker = newReceiptTracker(c, rpc, *ltp.FromETHAddress, startBlockNumber+1)
+		tracker.Start(ctx)
+		nonceMgr.Start(ctx)
+	}
+	log.Debug().Msg("Starting main load test loop")
+	phaseCount := 1
+	if !*ltp.CallOnly && *ltp.WaitForReceipts {
+		phaseCount = 2
+	}
+	loadTestPhases = progress.NewPhases("loadtest", phaseCount)
+	bar := loadTestPhases.Next("sending requests", routines*requests, "req")
 	var wg sync.WaitGroup
 	for i = 0; i < routines; i = i + 1 {
 		log.Trace().Int64("routine", i).Msg("Starting Thread")
@@ -616,10 +693,11 @@ func mainLoop(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) erro
 				if retryForNonce {
 					retryForNonce = false
 				} else {
-					currentNonceMutex.Lock()
-					myNonceValue = currentNonce
-					currentNonce = currentNonce + 1
-					currentNonceMutex.Unlock()
+					if myNonceValue, tErr = nonceMgr.Next(ctx, *ltp.FromETHAddress); tErr != nil {
+						log.Error().Err(tErr).Msg("Unable to get account nonce")
+						bar.Add(1)
+						continue
+					}
 				}
 
 				localMode := mode
@@ -651,7 +729,7 @@ func mainLoop(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) erro
 				case loadTestModePrecompiledContracts:
 					startReq, endReq, tErr = loadTestCallPrecompiledContracts(ctx, c, myNonceValue, ltContract, false)
 				case loadTestModeRecall:
-					startReq, endReq, tErr = loadTestRecall(ctx, c, myNonceValue, recallTransactions[int(currentNonce)%len(recallTransactions)])
+					startReq, endReq, tErr = loadTestRecall(ctx, c, myNonceValue, recallTransactions[int(myNonceValue)%len(recallTransactions)])
 				case loadTestModeUniswapV3:
 					swapAmountIn := big.NewInt(int64(*uniswapv3LoadTestParams.SwapAmountInput))
 					startReq, endReq, tErr = runUniswapV3Loadtest(ctx, c, myNonceValue, uniswapV3Config, poolConfig, swapAmountIn)
@@ -667,6 +745,7 @@ func mainLoop(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) erro
 					log.Error().Str("mode", mode.String()).Msg("We've arrived at a load test mode that we don't recognize")
 				}
 				recordSample(i, j, tErr, startReq, endReq, myNonceValue)
+				bar.Add(1)
 				if tErr != nil {
 					log.Error().Err(tErr).Uint64("nonce", myNonceValue).Int64("request time", endReq.Sub(startReq).Milliseconds()).Msg("Recorded an error while sending transactions")
 					// The nonce is used to index the recalled transactions in call-only mode. We don't want to retry a transaction if it legit failed on the chain
@@ -691,6 +770,7 @@ func mainLoop(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) erro
 	}
 	log.Trace().Msg("Finished starting go routines. Waiting..")
 	wg.Wait()
+	bar.Finish()
 	cancel()
 	if *ltp.CallOnly {
 		return nil
@@ -803,9 +883,8 @@ func loadTestTransaction(ctx context.Context, c *ethclient.Client, nonce uint64)
 
 	amount := ltp.SendAmount
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -924,9 +1003,8 @@ func loadTestDeploy(ctx context.Context, c *ethclient.Client, nonce uint64) (t1
 	ltp := inputLoadTestParams
 
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -960,11 +1038,10 @@ func loadTestFunction(ctx context.Context, c *ethclient.Client, nonce uint64, lt
 	ltp := inputLoadTestParams
 
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 	iterations := ltp.Iterations
 	f := getCurrentLoadTestFunction()
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -1002,7 +1079,7 @@ func loadTestCallPrecompiledContracts(ctx context.Context, c *ethclient.Client,
 		f = tester.GetRandomPrecompiledContractAddress()
 	}
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -1031,9 +1108,8 @@ func loadTestInc(ctx context.Context, c *ethclient.Client, nonce uint64, ltContr
 	ltp := inputLoadTestParams
 
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -1062,9 +1138,8 @@ func loadTestStore(ctx context.Context, c *ethclient.Client, nonce uint64, ltCon
 	ltp := inputLoadTestParams
 
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -1101,9 +1176,8 @@ func loadTestERC20(ctx context.Context, c *ethclient.Client, nonce uint64, erc20
 	amount := ltp.SendAmount
 
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -1139,9 +1213,8 @@ func loadTestERC721(ctx context.Context, c *ethclient.Client, nonce uint64, erc7
 	}
 
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -1171,9 +1244,8 @@ func loadTestRecall(ctx context.Context, c *ethclient.Client, nonce uint64, orig
 	ltp := inputLoadTestParams
 
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -1331,9 +1403,8 @@ func loadTestContractCall(ctx context.Context, c *ethclient.Client, nonce uint64
 	to := ltp.ContractETHAddress
 
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -1347,23 +1418,12 @@ func loadTestContractCall(ctx context.Context, c *ethclient.Client, nonce uint64
 	tops = configureTransactOpts(tops)
 	gasPrice, gasTipCap := getSuggestedGasPrices(ctx, c)
 
-	var stringCallData string
-
-	if *inputLoadTestParams.ContractCallData == "" && *inputLoadTestParams.ContractCallFunctionSignature == "" {
-		log.Error().Err(fmt.Errorf("Missing calldata for function call"))
+	stringCallData, err := getContractCallData()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode calldata")
 		return
 	}
 
-	if *inputLoadTestParams.ContractCallData != "" {
-		stringCallData = *inputLoadTestParams.ContractCallData
-	} else {
-		stringCallData, err = abi.AbiEncode(*inputLoadTestParams.ContractCallFunctionSignature, *inputLoadTestParams.ContractCallFunctionArgs)
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to encode calldata")
-			return
-		}
-	}
-
 	calldata, err := hex.DecodeString(strings.TrimPrefix(stringCallData, "0x"))
 	if err != nil {
 		log.Error().Err(err).Msg("Unable to decode calldata string")
@@ -1433,9 +1493,8 @@ func loadTestInscription(ctx context.Context, c *ethclient.Client, nonce uint64)
 	to := ltp.FromETHAddress
 
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
@@ -1513,12 +1572,10 @@ func loadTestBlob(ctx context.Context, c *ethclient.Client, nonce uint64) (t1 ti
 
 	amount := ltp.SendAmount
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
 	gasLimit := uint64(21000)
 	gasPrice, gasTipCap := getSuggestedGasPrices(ctx, c)
-	// blobFeeCap := uint64(1000000000) // 1eth
-	blobFeeCap := ltp.BlobFeeCap
+	blobFeeCap, _ := uint256.FromBig(getBlobFeeCap())
 
 	// Initialize blobTx with blob transaction type
 	blobTx := ethtypes.BlobTx{
@@ -1526,7 +1583,7 @@ func loadTestBlob(ctx context.Context, c *ethclient.Client, nonce uint64) (t1 ti
 		Nonce:      nonce,
 		GasTipCap:  uint256.NewInt(gasTipCap.Uint64()),
 		GasFeeCap:  uint256.NewInt(gasPrice.Uint64()),
-		BlobFeeCap: uint256.NewInt(*blobFeeCap),
+		BlobFeeCap: blobFeeCap,
 		Gas:        gasLimit,
 		To:         *to,
 		Value:      uint256.NewInt(amount.Uint64()),
@@ -1545,18 +1602,23 @@ func loadTestBlob(ctx context.Context, c *ethclient.Client, nonce uint64) (t1 ti
 	// createBlob() is called to commit the randomly generated byte slice with KZG.
 	// generateBlobCommitment() will do the same for the Commitment and Proof.
 	// Append all the blob related computed values to the blobTx struct.
-	err = appendBlobCommitment(&blobTx)
-	if err != nil {
-		log.Error().Err(err).Msg("Unable to parse blob")
-		return
+	for i := uint64(0); i < *ltp.BlobCount; i++ {
+		err = appendBlobCommitment(&blobTx)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to parse blob")
+			return
+		}
 	}
 	tx := types.NewTx(&blobTx)
 
-	stx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), privateKey)
+	stx, err := ltp.Signer.SignTx(ctx, tx, types.LatestSignerForChainID(chainID))
 	if err != nil {
 		log.Error().Err(err).Msg("Unable to sign transaction")
 		return
 	}
+	tracker.recordSigned(stx)
+	nonceMgr.recordSigned(*ltp.FromETHAddress, stx)
+	recorder.record(stx)
 
 	t1 = time.Now()
 	defer func() { t2 = time.Now() }()
@@ -1623,21 +1685,17 @@ func configureTransactOpts(tops *bind.TransactOpts) *bind.TransactOpts {
 
 	// if we're in legacy mode, there's no point doing anything else in this function
 	if *ltp.LegacyTransactionMode {
-		return tops
+		return trackSigner(tops)
 	}
 
 	if ltp.ForcePriorityGasPrice != nil && *ltp.ForcePriorityGasPrice != 0 {
 		tops.GasTipCap = big.NewInt(0).SetUint64(*ltp.ForcePriorityGasPrice)
 	}
 
-	if ltp.CurrentBaseFee == nil {
-		log.Fatal().Msg("EIP-1559 not activated. Please use --legacy")
-	}
-
 	tops.GasPrice = nil
 	tops.GasFeeCap = big.NewInt(0).Add(ltp.CurrentBaseFee, ltp.CurrentGasTipCap)
 
-	return tops
+	return trackSigner(tops)
 }
 
 func waitForFinalBlock(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client, startBlockNumber, startNonce, endNonce uint64) (uint64, error) {
@@ -0,0 +1,362 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/holiman/uint256"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	nonceCheckInterval = 2 * time.Second
+	// blobFeeBumpPercent is the minimum increase of the fees of a blob transaction replacement, the blob
+	// pool of geth requiring the fees to be doubled.
+	blobFeeBumpPercent = 100
+)
+
+type (
+	// nonceManager allocates the nonces of the senders locally and keeps the last transaction signed with
+	// each nonce which isn't mined yet. A dropped or underpriced transaction holds back every later
+	// transaction of its sender, so once the mined nonce of a sender stops progressing, the transaction
+	// holding it back is replaced by a copy with bumped fees.
+	nonceManager struct {
+		c *ethclient.Client
+		// stallTimeout is how long the mined nonce of a sender may stay still before its transaction is
+		// replaced. The recovery is disabled when zero.
+		stallTimeout   time.Duration
+		feeBumpPercent uint64
+
+		mu      sync.Mutex
+		senders map[ethcommon.Address]*senderNonces
+
+		cancel context.CancelFunc
+		done   chan struct{}
+	}
+	senderNonces struct {
+		start uint64
+		next  uint64
+		// mined is the nonce of the sender in the latest block, and progressAt the last time it increased
+		// or a replacement was sent.
+		mined      uint64
+		progressAt time.Time
+		pending    map[uint64]*types.Transaction
+		replaced   uint64
+		dropped    uint64
+	}
+)
+
+var nonceMgr *nonceManager
+
+func newNonceManager(c *ethclient.Client, stallTimeout time.Duration, feeBumpPercent uint64) *nonceManager {
+	return &nonceManager{
+		c:              c,
+		stallTimeout:   stallTimeout,
+		feeBumpPercent: feeBumpPercent,
+		senders:        make(map[ethcommon.Address]*senderNonces),
+		done:           make(chan struct{}),
+	}
+}
+
+func newSenderNonces(nonce uint64) *senderNonces {
+	return &senderNonces{
+		start:      nonce,
+		next:       nonce,
+		mined:      nonce,
+		progressAt: time.Now(),
+		pending:    make(map[uint64]*types.Transaction),
+	}
+}
+
+// Init starts allocating the nonces of the sender from its pending nonce, to avoid colliding with the
+// transactions of the sender already in the pool.
+func (m *nonceManager) Init(ctx context.Context, from ethcommon.Address) (uint64, error) {
+	nonce, err := m.c.PendingNonceAt(ctx, from)
+	if err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	m.senders[from] = newSenderNonces(nonce)
+	m.mu.Unlock()
+	return nonce, nil
+}
+
+// Next allocates the next nonce of the sender, initializing the sender first if it's unknown.
+func (m *nonceManager) Next(ctx context.Context, from ethcommon.Address) (uint64, error) {
+	m.mu.Lock()
+	_, ok := m.senders[from]
+	m.mu.Unlock()
+	if !ok {
+		nonce, err := m.c.PendingNonceAt(ctx, from)
+		if err != nil {
+			return 0, err
+		}
+		m.mu.Lock()
+		// Another caller may have initialized the sender in the meantime.
+		if _, ok = m.senders[from]; !ok {
+			m.senders[from] = newSenderNonces(nonce)
+		}
+		m.mu.Unlock()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.senders[from]
+	nonce := s.next
+	s.next += 1
+	return nonce, nil
+}
+
+// Range returns the first nonce allocated to the sender and the next one, both zero when the sender is
+// unknown.
+func (m *nonceManager) Range(from ethcommon.Address) (start, next uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.senders[from]
+	if !ok {
+		return 0, 0
+	}
+	return s.start, s.next
+}
+
+func (m *nonceManager) recordSigned(from ethcommon.Address, tx *types.Transaction) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.senders[from]
+	// The transactions signed before the load test, like the contract deployments, aren't tracked.
+	if !ok || tx.Nonce() < s.mined || tx.Nonce() >= s.next {
+		return
+	}
+	s.pending[tx.Nonce()] = tx
+}
+
+// Start checks the progress of the senders until Stop is called. It does nothing when the recovery is
+// disabled.
+func (m *nonceManager) Start(ctx context.Context) {
+	if m.stallTimeout <= 0 {
+		return
+	}
+	ctx, m.cancel = context.WithCancel(ctx)
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(nonceCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.check(ctx); err != nil && ctx.Err() == nil {
+					log.Warn().Err(err).Msg("Unable to check the nonces of the senders")
+				}
+			}
+		}
+	}()
+}
+
+// Stop ends the recovery, if it was started, and reports the replaced transactions.
+func (m *nonceManager) Stop() {
+	if m.cancel == nil {
+		return
+	}
+	m.cancel()
+	<-m.done
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for from, s := range m.senders {
+		log.Info().
+			Stringer("sender", from).
+			Uint64("replaced", s.replaced).
+			Uint64("dropped", s.dropped).
+			Msg("Nonce recovery")
+	}
+}
+
+// check replaces the transaction holding back each sender whose mined nonce didn't progress for the stall
+// timeout.
+func (m *nonceManager) check(ctx context.Context) error {
+	m.mu.Lock()
+	senders := make([]ethcommon.Address, 0, len(m.senders))
+	for from := range m.senders {
+		senders = append(senders, from)
+	}
+	m.mu.Unlock()
+
+	for _, from := range senders {
+		mined, err := m.c.NonceAt(ctx, from, nil)
+		if err != nil {
+			return err
+		}
+
+		m.mu.Lock()
+		s := m.senders[from]
+		if mined > s.mined {
+			for n := s.mined; n < mined; n++ {
+				delete(s.pending, n)
+			}
+			s.mined, s.progressAt = mined, time.Now()
+		}
+		stalled := s.mined < s.next && time.Since(s.progressAt) > m.stallTimeout
+		nonce, stuck := s.mined, s.pending[s.mined]
+		if stalled {
+			// The next replacement waits for another stall timeout.
+			s.progressAt = time.Now()
+		}
+		m.mu.Unlock()
+
+		if !stalled {
+			continue
+		}
+		if err = m.replace(ctx, from, nonce, stuck); err != nil {
+			log.Warn().Err(err).Uint64("nonce", nonce).Msg("Unable to replace the stalled transaction")
+		}
+	}
+	return nil
+}
+
+// replace sends a copy of the transaction with bumped fees, or a transfer to the sender itself when no
+// transaction was signed with the nonce, e.g. when the signing failed. The copy is kept as the transaction of
+// the nonce even when it is rejected, so the fees keep increasing until a replacement is accepted.
+func (m *nonceManager) replace(ctx context.Context, from ethcommon.Address, nonce uint64, tx *types.Transaction) error {
+	gasPrice, gasTipCap := getSuggestedGasPrices(ctx, m.c)
+	dropped := true
+	var err error
+	var replacement *types.Transaction
+	if tx == nil {
+		replacement = selfTransfer(from, nonce, gasPrice, gasTipCap)
+	} else {
+		_, _, err = m.c.TransactionByHash(ctx, tx.Hash())
+		dropped = errors.Is(err, ethereum.NotFound)
+		if err != nil && !dropped {
+			return err
+		}
+		if replacement, err = bumpFees(tx, m.feeBumpPercent, gasPrice, gasTipCap); err != nil {
+			return err
+		}
+	}
+	chainID := new(big.Int).SetUint64(*inputLoadTestParams.ChainID)
+	stx, err := inputLoadTestParams.Signer.SignTx(ctx, replacement, types.LatestSignerForChainID(chainID))
+	if err != nil {
+		return err
+	}
+	recorder.record(stx)
+
+	m.mu.Lock()
+	s := m.senders[from]
+	if stx.Nonce() >= s.mined {
+		s.pending[stx.Nonce()] = stx
+	}
+	s.replaced += 1
+	if dropped {
+		s.dropped += 1
+	}
+	m.mu.Unlock()
+
+	l := log.Info().Uint64("nonce", nonce).Bool("dropped", dropped).Stringer("hash", stx.Hash())
+	if tx != nil {
+		l = l.Stringer("replacedHash", tx.Hash())
+	}
+	l.Msg("Replacing stalled transaction")
+	return m.c.SendTransaction(ctx, stx)
+}
+
+// selfTransfer returns an empty transfer filling the nonce.
+func selfTransfer(from ethcommon.Address, nonce uint64, gasPrice, gasTipCap *big.Int) *types.Transaction {
+	if *inputLoadTestParams.LegacyTransactionMode {
+		return types.NewTx(&types.LegacyTx{Nonce: nonce, GasPrice: gasPrice, Gas: 21000, To: &from, Value: new(big.Int)})
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   new(big.Int).SetUint64(*inputLoadTestParams.ChainID),
+		Nonce:     nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasPrice,
+		Gas:       21000,
+		To:        &from,
+		Value:     new(big.Int),
+	})
+}
+
+// bumpFees returns a copy of the transaction with its fees increased by the percentage, and at least to the
+// suggested gas price and tip. The fees of the blob transactions are increased by at least
+// blobFeeBumpPercent.
+func bumpFees(tx *types.Transaction, percent uint64, gasPrice, gasTipCap *big.Int) (*types.Transaction, error) {
+	if tx.Type() == types.BlobTxType && percent < blobFeeBumpPercent {
+		percent = blobFeeBumpPercent
+	}
+	bump := func(fee, floor *big.Int) *big.Int {
+		// Rounded up so the increase is never below the percentage required by the pool.
+		bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+		bumped.Add(bumped, big.NewInt(99)).Div(bumped, big.NewInt(100))
+		if floor != nil && floor.Cmp(bumped) > 0 {
+			return new(big.Int).Set(floor)
+		}
+		return bumped
+	}
+	feeCap, tipCap := bump(tx.GasFeeCap(), gasPrice), bump(tx.GasTipCap(), gasTipCap)
+	if tipCap.Cmp(feeCap) > 0 {
+		feeCap = tipCap
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType:
+		return types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: feeCap,
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}), nil
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasPrice:   feeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}), nil
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tipCap,
+			GasFeeCap:  feeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}), nil
+	case types.BlobTxType:
+		return types.NewTx(&types.BlobTx{
+			ChainID:    uint256.MustFromBig(tx.ChainId()),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  uint256.MustFromBig(tipCap),
+			GasFeeCap:  uint256.MustFromBig(feeCap),
+			Gas:        tx.Gas(),
+			To:         *tx.To(),
+			Value:      uint256.MustFromBig(tx.Value()),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+			BlobFeeCap: uint256.MustFromBig(bump(tx.BlobGasFeeCap(), nil)),
+			BlobHashes: tx.BlobHashes(),
+			Sidecar:    tx.BlobTxSidecar(),
+		}), nil
+	default:
+		return nil, fmt.Errorf("unable to replace transactions of type %d", tx.Type())
+	}
+}
@@ -0,0 +1,71 @@
+package loadtest
+
+import (
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+func TestBumpFees(t *testing.T) {
+	to := ethcommon.HexToAddress("0x01")
+	for name, tc := range map[string]struct {
+		tx                *types.Transaction
+		gasPrice, tipCap  int64
+		feeCap, tip, blob int64
+	}{
+		"legacy": {
+			tx:     types.NewTx(&types.LegacyTx{Nonce: 3, GasPrice: big.NewInt(1000), Gas: 21000, To: &to}),
+			feeCap: 1100, tip: 1100,
+		},
+		"rounded up": {
+			tx:     types.NewTx(&types.DynamicFeeTx{Nonce: 3, GasFeeCap: big.NewInt(1001), GasTipCap: big.NewInt(11), Gas: 21000, To: &to}),
+			feeCap: 1102, tip: 13,
+		},
+		"suggested prices above the bump": {
+			tx:       types.NewTx(&types.DynamicFeeTx{Nonce: 3, GasFeeCap: big.NewInt(1000), GasTipCap: big.NewInt(10), Gas: 21000, To: &to}),
+			gasPrice: 5000, tipCap: 100,
+			feeCap: 5000, tip: 100,
+		},
+		"tip above the fee cap": {
+			tx:     types.NewTx(&types.DynamicFeeTx{Nonce: 3, GasFeeCap: big.NewInt(1000), GasTipCap: big.NewInt(10), Gas: 21000, To: &to}),
+			tipCap: 2000,
+			feeCap: 2000, tip: 2000,
+		},
+		"blob doubled": {
+			tx: types.NewTx(&types.BlobTx{
+				ChainID:    uint256.NewInt(1),
+				Nonce:      3,
+				GasFeeCap:  uint256.NewInt(1000),
+				GasTipCap:  uint256.NewInt(10),
+				Gas:        21000,
+				To:         to,
+				BlobFeeCap: uint256.NewInt(7),
+			}),
+			feeCap: 2000, tip: 20, blob: 14,
+		},
+	} {
+		var gasPrice, tipCap *big.Int
+		if tc.gasPrice != 0 {
+			gasPrice = big.NewInt(tc.gasPrice)
+		}
+		if tc.tipCap != 0 {
+			tipCap = big.NewInt(tc.tipCap)
+		}
+		bumped, err := bumpFees(tc.tx, 10, gasPrice, tipCap)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if bumped.Type() != tc.tx.Type() || bumped.Nonce() != tc.tx.Nonce() || bumped.Gas() != tc.tx.Gas() || *bumped.To() != to {
+			t.Errorf("%s: the replacement doesn't match the transaction", name)
+		}
+		if bumped.GasFeeCap().Int64() != tc.feeCap || bumped.GasTipCap().Int64() != tc.tip {
+			t.Errorf("%s: expected the fee cap %d and tip %d, got %v and %v", name, tc.feeCap, tc.tip, bumped.GasFeeCap(), bumped.GasTipCap())
+		}
+		if tc.blob != 0 && bumped.BlobGasFeeCap().Int64() != tc.blob {
+			t.Errorf("%s: expected the blob fee cap %d, got %v", name, tc.blob, bumped.BlobGasFeeCap())
+		}
+	}
+}
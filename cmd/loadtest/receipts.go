@@ -0,0 +1,298 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/maticnetwork/polygon-cli/progress"
+	"github.com/maticnetwork/polygon-cli/rpcclient"
+	"github.com/maticnetwork/polygon-cli/rpctypes"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	receiptPollInterval = 500 * time.Millisecond
+	// receiptBlockPageSize is the maximum number of blocks fetched at once.
+	receiptBlockPageSize = 50
+)
+
+type (
+	// receiptTracker watches the new blocks for the transactions of the sender, so the inclusion of every
+	// transaction is observed while the load test runs.
+	receiptTracker struct {
+		c    *ethclient.Client
+		rpc  *ethrpc.Client
+		from ethcommon.Address
+
+		mu sync.Mutex
+		// signed is the hash of the last transaction signed for each nonce.
+		signed    map[uint64]ethcommon.Hash
+		confirmed map[uint64]txConfirmation
+		nextBlock uint64
+
+		cancel context.CancelFunc
+		done   chan struct{}
+	}
+	txConfirmation struct {
+		Hash        ethcommon.Hash
+		BlockNumber uint64
+		Status      uint64
+		ObservedAt  time.Time
+	}
+)
+
+var tracker *receiptTracker
+
+func newReceiptTracker(c *ethclient.Client, rpc *ethrpc.Client, from ethcommon.Address, startBlock uint64) *receiptTracker {
+	return &receiptTracker{
+		c:         c,
+		rpc:       rpc,
+		from:      from,
+		signed:    make(map[uint64]ethcommon.Hash),
+		confirmed: make(map[uint64]txConfirmation),
+		nextBlock: startBlock,
+		done:      make(chan struct{}),
+	}
+}
+
+// trackSigner wraps the signer of the transact opts to record every transaction signed, for the receipts,
+// the nonce recovery and the scenario.
+func trackSigner(tops *bind.TransactOpts) *bind.TransactOpts {
+	if (tracker == nil && nonceMgr == nil && recorder == nil) || tops.Signer == nil {
+		return tops
+	}
+	signerFn := tops.Signer
+	tops.Signer = func(address ethcommon.Address, tx *types.Transaction) (*types.Transaction, error) {
+		stx, err := signerFn(address, tx)
+		if err == nil {
+			tracker.recordSigned(stx)
+			nonceMgr.recordSigned(address, stx)
+			recorder.record(stx)
+		}
+		return stx, err
+	}
+	return tops
+}
+
+func (t *receiptTracker) recordSigned(tx *types.Transaction) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	t.signed[tx.Nonce()] = tx.Hash()
+	t.mu.Unlock()
+}
+
+// Start polls the new blocks until Stop is called.
+func (t *receiptTracker) Start(ctx context.Context) {
+	ctx, t.cancel = context.WithCancel(ctx)
+	go func() {
+		defer close(t.done)
+		ticker := time.NewTicker(receiptPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := t.poll(ctx); err != nil && ctx.Err() == nil {
+					log.Warn().Err(err).Msg("Unable to fetch the receipts of the new blocks")
+				}
+			}
+		}
+	}()
+}
+
+// Stop ends the polling after catching up with the latest block.
+func (t *receiptTracker) Stop(ctx context.Context) {
+	t.cancel()
+	<-t.done
+	if err := t.poll(ctx); err != nil {
+		log.Warn().Err(err).Msg("Unable to fetch the receipts of the last blocks")
+	}
+}
+
+// Wait blocks until every nonce is confirmed, or until no new confirmation was observed for the timeout,
+// reporting the confirmations to the bar.
+func (t *receiptTracker) Wait(ctx context.Context, nonces []uint64, timeout time.Duration, bar *progress.Bar) {
+	lastProgress := time.Now()
+	lastCount := -1
+	for {
+		t.mu.Lock()
+		count := 0
+		for _, n := range nonces {
+			if _, ok := t.confirmed[n]; ok {
+				count++
+			}
+		}
+		t.mu.Unlock()
+		bar.Set(int64(count))
+		if count == len(nonces) {
+			return
+		}
+		if count != lastCount {
+			log.Debug().Int("confirmed", count).Int("sent", len(nonces)).Msg("Waiting for receipts")
+			lastCount, lastProgress = count, time.Now()
+		}
+		if time.Since(lastProgress) > timeout {
+			log.Warn().Int("confirmed", count).Int("sent", len(nonces)).Dur("timeout", timeout).Msg("Gave up waiting for receipts")
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(receiptPollInterval):
+		}
+	}
+}
+
+// poll records the transactions of the sender in the blocks since the last poll.
+func (t *receiptTracker) poll(ctx context.Context) error {
+	head, err := t.c.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	for t.nextBlock <= head {
+		observedAt := time.Now()
+		end := min(t.nextBlock+receiptBlockPageSize-1, head)
+		rawBlocks, err := util.GetBlockRange(ctx, t.nextBlock, end, t.rpc)
+		if err != nil {
+			return err
+		}
+
+		hashes := make([]ethcommon.Hash, 0)
+		pending := make(map[ethcommon.Hash]txConfirmation)
+		nonces := make(map[ethcommon.Hash]uint64)
+		for _, rb := range rawBlocks {
+			var block rpctypes.RawBlockResponse
+			if err = json.Unmarshal(*rb, &block); err != nil {
+				return err
+			}
+			for _, tx := range block.Transactions {
+				if tx.From.ToAddress() != t.from {
+					continue
+				}
+				hash := tx.Hash.ToHash()
+				hashes = append(hashes, hash)
+				nonces[hash] = tx.Nonce.ToUint64()
+				pending[hash] = txConfirmation{Hash: hash, BlockNumber: block.Number.ToUint64(), ObservedAt: observedAt}
+			}
+		}
+
+		receipts, err := t.getReceipts(ctx, hashes)
+		if err != nil {
+			return err
+		}
+		t.mu.Lock()
+		for _, r := range receipts {
+			hash := r.TransactionHash.ToHash()
+			confirmation := pending[hash]
+			confirmation.Status = r.Status.ToUint64()
+			t.confirmed[nonces[hash]] = confirmation
+		}
+		t.mu.Unlock()
+		t.nextBlock = end + 1
+	}
+	return nil
+}
+
+func (t *receiptTracker) getReceipts(ctx context.Context, hashes []ethcommon.Hash) ([]rpctypes.RawTxReceipt, error) {
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+	receipts := make([]rpctypes.RawTxReceipt, len(hashes))
+	elems := make([]ethrpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		elems[i] = ethrpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []interface{}{hash}, Result: &receipts[i]}
+	}
+	if err := rpcclient.BatchCall(ctx, t.rpc, elems, int(*inputLoadTestParams.BatchSize), 1); err != nil {
+		return nil, err
+	}
+	for _, e := range elems {
+		if e.Error != nil {
+			return nil, e.Error
+		}
+	}
+	return receipts, nil
+}
+
+// sentNonces returns the nonces of the transactions which were sent without error, with their send time.
+func sentNonces(samples []loadTestSample) map[uint64]time.Time {
+	sent := make(map[uint64]time.Time)
+	for _, s := range samples {
+		if s.IsError {
+			continue
+		}
+		if t, ok := sent[s.Nonce]; !ok || s.RequestTime.Before(t) {
+			sent[s.Nonce] = s.RequestTime
+		}
+	}
+	return sent
+}
+
+// summarizeReceipts reports the inclusion latency, the dropped and replaced transactions and the success ratio.
+func (t *receiptTracker) summarizeReceipts(samples []loadTestSample) {
+	sent := sentNonces(samples)
+	if len(sent) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var included, succeeded, reverted, replaced, dropped uint64
+	latencies := make([]float64, 0, len(sent))
+	for nonce, sentAt := range sent {
+		confirmation, ok := t.confirmed[nonce]
+		if !ok {
+			dropped++
+			continue
+		}
+		included++
+		if confirmation.Status == types.ReceiptStatusSuccessful {
+			succeeded++
+		} else {
+			reverted++
+		}
+		if hash, ok := t.signed[nonce]; ok && hash != confirmation.Hash {
+			replaced++
+		}
+		latencies = append(latencies, confirmation.ObservedAt.Sub(sentAt).Seconds())
+	}
+
+	log.Info().
+		Int("sent", len(sent)).
+		Uint64("included", included).
+		Uint64("dropped", dropped).
+		Uint64("replaced", replaced).
+		Msg("Transaction inclusion")
+	if included == 0 {
+		return
+	}
+	log.Info().
+		Uint64("succeeded", succeeded).
+		Uint64("reverted", reverted).
+		Float64("successRatio", float64(succeeded)/float64(included)).
+		Float64("revertRatio", float64(reverted)/float64(included)).
+		Msg("Transaction receipts")
+	sort.Float64s(latencies)
+	log.Info().
+		Float64("p50", percentile(latencies, 50)).
+		Float64("p90", percentile(latencies, 90)).
+		Float64("p99", percentile(latencies, 99)).
+		Float64("max", latencies[len(latencies)-1]).
+		Msg("Inclusion latency in seconds")
+}
+
+// percentile returns the p-th percentile of the sorted values.
+func percentile(sorted []float64, p int) float64 {
+	return sorted[(len(sorted)-1)*p/100]
+}
@@ -0,0 +1,254 @@
+package loadtest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/maticnetwork/polygon-cli/progress"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	// scenarioHeader is the first line of a scenario file, the transactions following it one per line.
+	scenarioHeader struct {
+		ChainID   uint64            `json:"chainId"`
+		From      ethcommon.Address `json:"from"`
+		StartTime time.Time         `json:"startTime"`
+	}
+	// scenarioEntry is a transaction signed during the recorded run, with its offset since the start.
+	scenarioEntry struct {
+		Offset time.Duration `json:"offset"`
+		Tx     hexutil.Bytes `json:"tx"`
+	}
+	// scenarioRecorder writes every transaction signed by the load test to a scenario file.
+	scenarioRecorder struct {
+		mu     sync.Mutex
+		f      *os.File
+		w      *bufio.Writer
+		start  time.Time
+		count  int
+		closed bool
+	}
+	scenario struct {
+		Header  scenarioHeader
+		Entries []scenarioEntry
+		Txs     []*types.Transaction
+	}
+)
+
+// recorder is set with --record.
+var recorder *scenarioRecorder
+
+func newScenarioRecorder(path string, chainID uint64, from ethcommon.Address) (*scenarioRecorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &scenarioRecorder{f: f, w: bufio.NewWriter(f), start: time.Now()}
+	if err = r.writeLine(scenarioHeader{ChainID: chainID, From: from, StartTime: r.start}); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *scenarioRecorder) writeLine(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err = r.w.Write(b); err != nil {
+		return err
+	}
+	return r.w.WriteByte('\n')
+}
+
+// record appends the signed transaction to the scenario. The transactions signed once the recorder is
+// closed, e.g. after the time limit, are left out.
+func (r *scenarioRecorder) record(tx *types.Transaction) {
+	if r == nil {
+		return
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		log.Error().Err(err).Stringer("hash", tx.Hash()).Msg("Unable to encode the transaction for the scenario")
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	if err = r.writeLine(scenarioEntry{Offset: time.Since(r.start), Tx: raw}); err != nil {
+		log.Error().Err(err).Msg("Unable to record the transaction")
+		return
+	}
+	r.count++
+}
+
+// Close flushes the scenario file.
+func (r *scenarioRecorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	err := r.w.Flush()
+	if cErr := r.f.Close(); err == nil {
+		err = cErr
+	}
+	log.Info().Str("file", r.f.Name()).Int("transactions", r.count).Msg("Recorded the scenario")
+	return err
+}
+
+func readScenario(path string) (*scenario, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s := new(scenario)
+	scanner := bufio.NewScanner(f)
+	// The blob transactions carry their sidecar.
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	if !scanner.Scan() {
+		if err = scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("the scenario %s is empty", path)
+	}
+	if err = json.Unmarshal(scanner.Bytes(), &s.Header); err != nil {
+		return nil, fmt.Errorf("invalid scenario header: %w", err)
+	}
+	for line := 2; scanner.Scan(); line++ {
+		var e scenarioEntry
+		if err = json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("invalid scenario entry on line %d: %w", line, err)
+		}
+		tx := new(types.Transaction)
+		if err = tx.UnmarshalBinary(e.Tx); err != nil {
+			return nil, fmt.Errorf("invalid transaction on line %d: %w", line, err)
+		}
+		s.Entries = append(s.Entries, e)
+		s.Txs = append(s.Txs, tx)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.Txs) == 0 {
+		return nil, fmt.Errorf("the scenario %s has no transaction", path)
+	}
+	return s, nil
+}
+
+// runReplay sends the raw transactions of the scenario with their recorded timing, scaled by
+// --replay-time-scale, and reports the same statistics as the load test.
+func runReplay(ctx context.Context, c *ethclient.Client, rpc *ethrpc.Client) error {
+	ltp := inputLoadTestParams
+	s, err := readScenario(*ltp.Replay)
+	if err != nil {
+		return err
+	}
+	chainID, err := c.ChainID(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to fetch chain ID")
+		return err
+	}
+	if chainID.Uint64() != s.Header.ChainID {
+		return fmt.Errorf("the scenario was recorded on chain %d, the endpoint is on chain %d", s.Header.ChainID, chainID.Uint64())
+	}
+	nonce, err := c.NonceAt(ctx, s.Header.From, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to get account nonce")
+		return err
+	}
+	if first := s.Txs[0].Nonce(); nonce != first {
+		log.Warn().Uint64("accountNonce", nonce).Uint64("scenarioNonce", first).Msg("The nonce of the sender doesn't match the start of the scenario, the transactions may be rejected")
+	}
+	if startBlockNumber, err = c.BlockNumber(ctx); err != nil {
+		log.Error().Err(err).Msg("Failed to get current block number")
+		return err
+	}
+	log.Info().Int("transactions", len(s.Txs)).Stringer("from", s.Header.From).Float64("timeScale", *ltp.ReplayTimeScale).Msg("Replaying the scenario")
+
+	tracker = newReceiptTracker(c, rpc, s.Header.From, startBlockNumber+1)
+	tracker.Start(ctx)
+	phaseCount := 1
+	if *ltp.WaitForReceipts {
+		phaseCount = 2
+	}
+	loadTestPhases = progress.NewPhases("replay", phaseCount)
+	bar := loadTestPhases.Next("sending transactions", int64(len(s.Txs)), "tx")
+
+	concurrency := *ltp.Concurrency
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	start := time.Now()
+replay:
+	for i, tx := range s.Txs {
+		delay := time.Until(start.Add(time.Duration(float64(s.Entries[i].Offset) * *ltp.ReplayTimeScale)))
+		select {
+		case <-ctx.Done():
+			break replay
+		case <-time.After(delay):
+		}
+		select {
+		case <-ctx.Done():
+			break replay
+		case sem <- struct{}{}:
+		}
+		tracker.recordSigned(tx)
+		wg.Add(1)
+		go func(i int64, tx *types.Transaction) {
+			defer wg.Done()
+			t1 := time.Now()
+			err := c.SendTransaction(ctx, tx)
+			recordSample(i%concurrency, i, err, t1, time.Now(), tx.Nonce())
+			bar.Add(1)
+			<-sem
+			if err != nil {
+				log.Error().Err(err).Uint64("nonce", tx.Nonce()).Stringer("hash", tx.Hash()).Msg("Recorded an error while replaying transactions")
+			}
+		}(int64(i), tx)
+	}
+	wg.Wait()
+	bar.Finish()
+	if len(loadTestResults) == 0 {
+		return errors.New("no transactions replayed")
+	}
+	endTime := time.Now()
+
+	if *ltp.WaitForReceipts {
+		sent := sentNonces(loadTestResults)
+		nonces := make([]uint64, 0, len(sent))
+		for n := range sent {
+			nonces = append(nonces, n)
+		}
+		bar := loadTestPhases.Next("waiting for receipts", int64(len(nonces)), "tx")
+		tracker.Wait(ctx, nonces, *ltp.ReceiptTimeout, bar)
+		bar.Finish()
+		endTime = time.Now()
+	}
+	tracker.Stop(ctx)
+	if *ltp.WaitForReceipts {
+		tracker.summarizeReceipts(loadTestResults)
+	}
+	lightSummary(loadTestResults, loadTestResults[0].RequestTime, endTime, nil)
+	return nil
+}
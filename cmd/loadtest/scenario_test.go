@@ -0,0 +1,83 @@
+package loadtest
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestScenarioRoundTrip(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := ethcommon.HexToAddress("0x01")
+	signer := types.LatestSignerForChainID(big.NewInt(1337))
+	var txs []*types.Transaction
+	for _, tx := range []types.TxData{
+		&types.LegacyTx{Nonce: 0, GasPrice: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(1)},
+		&types.DynamicFeeTx{ChainID: big.NewInt(1337), Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 50000, Data: []byte{1, 2, 3}},
+	} {
+		stx, err := types.SignNewTx(key, signer, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, stx)
+	}
+
+	path := filepath.Join(t.TempDir(), "scenario.jsonl")
+	r, err := newScenarioRecorder(path, 1337, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.record(txs[0])
+	time.Sleep(10 * time.Millisecond)
+	r.record(txs[1])
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// The transactions signed once closed are left out.
+	r.record(txs[0])
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := readScenario(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Header.ChainID != 1337 || s.Header.From != from || !s.Header.StartTime.Equal(r.start) {
+		t.Errorf("unexpected header %+v", s.Header)
+	}
+	if len(s.Txs) != len(txs) || len(s.Entries) != len(txs) {
+		t.Fatalf("expected %d transactions, got %d", len(txs), len(s.Txs))
+	}
+	for i, tx := range txs {
+		if s.Txs[i].Hash() != tx.Hash() {
+			t.Errorf("transaction %d: expected %v, got %v", i, tx.Hash(), s.Txs[i].Hash())
+		}
+	}
+	if s.Entries[0].Offset < 0 || s.Entries[1].Offset-s.Entries[0].Offset < 10*time.Millisecond {
+		t.Errorf("unexpected offsets %s and %s", s.Entries[0].Offset, s.Entries[1].Offset)
+	}
+}
+
+func TestReadScenarioWithoutTransactions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.jsonl")
+	r, err := newScenarioRecorder(path, 1337, ethcommon.Address{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = readScenario(path); err == nil {
+		t.Error("expected an error for a scenario without transaction")
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/maticnetwork/polygon-cli/bindings/uniswapv3"
 	uniswapv3loadtest "github.com/maticnetwork/polygon-cli/cmd/loadtest/uniswapv3"
+	"github.com/maticnetwork/polygon-cli/signer"
 	"github.com/rs/zerolog/log"
 )
 
@@ -33,6 +34,7 @@ var uniswapV3LoadTestCmd = &cobra.Command{
 		return checkUniswapV3LoadtestFlags()
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
 		// Override root command `mode` flag.
 		inputLoadTestParams.Modes = &[]string{"v3"}
 
@@ -133,9 +135,8 @@ func initUniswapV3Loadtest(ctx context.Context, c *ethclient.Client, tops *bind.
 func runUniswapV3Loadtest(ctx context.Context, c *ethclient.Client, nonce uint64, uniswapV3Config uniswapv3loadtest.UniswapV3Config, poolConfig uniswapv3loadtest.PoolConfig, swapAmountIn *big.Int) (t1 time.Time, t2 time.Time, err error) {
 	ltp := inputLoadTestParams
 	chainID := new(big.Int).SetUint64(*ltp.ChainID)
-	privateKey := ltp.ECDSAPrivateKey
 
-	tops, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	tops, err := signer.TransactOpts(ctx, ltp.Signer, chainID)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable create transaction signer")
 		return
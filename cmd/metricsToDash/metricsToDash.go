@@ -13,6 +13,7 @@ var (
 	//go:embed usage.md
 	usage                                 string
 	inputMetricsToDashFile                *string
+	inputMetricsToDashFormat              *string
 	inputMetricsToDashPrefix              *string
 	inputMetricsToDashTitle               *string
 	inputMetricsToDashDesc                *string
@@ -34,6 +35,7 @@ var MetricsToDashCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		do := dashboard.DashboardOptions{
 			File:                *inputMetricsToDashFile,
+			Format:              *inputMetricsToDashFormat,
 			Title:               *inputMetricsToDashTitle,
 			Prefix:              *inputMetricsToDashPrefix,
 			Description:         *inputMetricsToDashDesc,
@@ -59,14 +61,15 @@ func init() {
 
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
-	inputMetricsToDashFile = MetricsToDashCmd.PersistentFlags().StringP("input-file", "i", "", "the metrics file to be used")
+	inputMetricsToDashFile = MetricsToDashCmd.PersistentFlags().StringP("input-file", "i", "", "the metrics file to be used, or the URL of a Prometheus metrics endpoint")
+	inputMetricsToDashFormat = MetricsToDashCmd.PersistentFlags().StringP("format", "f", "datadog", "the dashboard format [datadog, grafana]")
 	inputMetricsToDashPrefix = MetricsToDashCmd.PersistentFlags().StringP("prefix", "p", "", "prefix to use before all metrics")
 	inputMetricsToDashTitle = MetricsToDashCmd.PersistentFlags().StringP("title", "t", "Polycli Dashboard", "title for the dashboard")
 	inputMetricsToDashDesc = MetricsToDashCmd.PersistentFlags().StringP("desc", "d", "Polycli Dashboard", "description for the dashboard")
 	inputMetricsToDashWidth = MetricsToDashCmd.PersistentFlags().IntP("width", "W", 4, "widget width")
 	inputMetricsToDashHeight = MetricsToDashCmd.PersistentFlags().IntP("height", "H", 3, "widget height")
 
-	inputMetricsToDashTemplateVars = MetricsToDashCmd.PersistentFlags().StringArrayP("template-vars", "T", []string{}, "The template variables to use for the dashboard")
+	inputMetricsToDashTemplateVars = MetricsToDashCmd.PersistentFlags().StringArrayP("template-vars", "T", []string{}, "The template variables to use for the dashboard, Grafana dashboards default to job and instance")
 	inputMetricsToDashTemplateVarDefaults = MetricsToDashCmd.PersistentFlags().StringArrayP("template-var-defaults", "D", []string{}, "The defaults to use for the template variables")
 
 	inputMetricsToDashStripPrefixes = MetricsToDashCmd.PersistentFlags().StringArrayP("strip-prefix", "s", []string{}, "A prefix that can be removed from the metrics")
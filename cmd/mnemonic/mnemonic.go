@@ -1,23 +1,56 @@
 package mnemonic
 
 import (
+	_ "embed"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/maticnetwork/polygon-cli/hdwallet"
+	"github.com/maticnetwork/polygon-cli/util"
 	"github.com/spf13/cobra"
+	"github.com/tyler-smith/go-bip39"
 )
 
 var (
+	//go:embed usage.md
+	usage              string
 	inputMnemonicWords *int
 	inputMnemonicLang  *string
+	inputEntropy       *string
+	inputPassphrase    *string
 )
 
+type mnemonicInfo struct {
+	Valid       bool
+	Language    string
+	Words       int
+	EntropyBits int    `json:",omitempty"`
+	Error       string `json:",omitempty"`
+}
+
 // mnemonicCmd represents the mnemonic command
 var MnemonicCmd = &cobra.Command{
 	Use:   "mnemonic",
 	Short: "Generate a BIP39 mnemonic seed.",
-	Long:  "",
+	Long:  usage,
+	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if *inputEntropy != "" {
+			entropy, err := hex.DecodeString(strings.TrimPrefix(*inputEntropy, "0x"))
+			if err != nil {
+				return fmt.Errorf("unable to decode the entropy: %w", err)
+			}
+			mnemonic, err := hdwallet.MnemonicFromEntropy(entropy, *inputMnemonicLang)
+			if err != nil {
+				return err
+			}
+			cmd.Println(mnemonic)
+			return nil
+		}
+
 		mnemonic, err := hdwallet.NewMnemonic(*inputMnemonicWords, *inputMnemonicLang)
 		if err != nil {
 			return err
@@ -26,6 +59,9 @@ var MnemonicCmd = &cobra.Command{
 		return nil
 	},
 	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if *inputEntropy != "" && cmd.Flags().Changed("words") {
+			return fmt.Errorf("the number of words is given by the entropy size, --words can't be used with --entropy")
+		}
 		if *inputMnemonicWords < 12 {
 			return fmt.Errorf("the number of words in the mnemonic must be 12 or more. Given: %d", *inputMnemonicWords)
 		}
@@ -40,7 +76,114 @@ var MnemonicCmd = &cobra.Command{
 	},
 }
 
+var ValidateCmd = &cobra.Command{
+	Use:   "validate [mnemonic]",
+	Short: "Check the words and the checksum of a mnemonic.",
+	Long:  "Check that every word of the mnemonic belongs to the wordlist and that the checksum matches. The language is detected unless --language is given. The mnemonic is read from stdin when it's not given as argument.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mnemonic, err := getMnemonic(args)
+		if err != nil {
+			return err
+		}
+		lang, err := getMnemonicLanguage(cmd, mnemonic)
+		info := mnemonicInfo{Language: lang, Words: len(strings.Fields(mnemonic))}
+		if err == nil {
+			var entropy []byte
+			if entropy, err = hdwallet.MnemonicToEntropy(mnemonic, lang); err == nil {
+				info.Valid = true
+				info.EntropyBits = len(entropy) * 8
+			}
+		}
+		if err != nil {
+			info.Error = err.Error()
+		}
+		if err = util.NewOutputWriter(os.Stdout).Write(info); err != nil {
+			return err
+		}
+		if !info.Valid {
+			// The command was used properly, only the mnemonic is wrong.
+			cmd.SilenceUsage = true
+			return fmt.Errorf("the mnemonic is invalid")
+		}
+		return nil
+	},
+}
+
+var EntropyCmd = &cobra.Command{
+	Use:   "entropy [mnemonic]",
+	Short: "Convert a mnemonic to its hex encoded entropy.",
+	Long:  "Convert a mnemonic to its hex encoded entropy. The language is detected unless --language is given. The mnemonic is read from stdin when it's not given as argument.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mnemonic, err := getMnemonic(args)
+		if err != nil {
+			return err
+		}
+		lang, err := getMnemonicLanguage(cmd, mnemonic)
+		if err != nil {
+			return err
+		}
+		entropy, err := hdwallet.MnemonicToEntropy(mnemonic, lang)
+		if err != nil {
+			return err
+		}
+		cmd.Println(hex.EncodeToString(entropy))
+		return nil
+	},
+}
+
+var SeedCmd = &cobra.Command{
+	Use:   "seed [mnemonic]",
+	Short: "Convert a mnemonic to its hex encoded BIP39 seed.",
+	Long:  "Convert a mnemonic and an optional passphrase to the hex encoded 64 bytes BIP39 seed which wallets derive keys from. The mnemonic is validated first. It's read from stdin when it's not given as argument.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mnemonic, err := getMnemonic(args)
+		if err != nil {
+			return err
+		}
+		lang, err := getMnemonicLanguage(cmd, mnemonic)
+		if err != nil {
+			return err
+		}
+		if _, err = hdwallet.MnemonicToEntropy(mnemonic, lang); err != nil {
+			return err
+		}
+		seed := bip39.NewSeed(strings.Join(strings.Fields(mnemonic), " "), *inputPassphrase)
+		cmd.Println(hex.EncodeToString(seed))
+		return nil
+	},
+}
+
+// getMnemonic returns the mnemonic given as arguments or read from stdin.
+func getMnemonic(args []string) (string, error) {
+	if len(args) > 0 {
+		return strings.Join(args, " "), nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	mnemonic := strings.TrimSpace(string(data))
+	if mnemonic == "" {
+		return "", fmt.Errorf("no mnemonic was given")
+	}
+	return mnemonic, nil
+}
+
+// getMnemonicLanguage returns the language given by the flag or detected from the words.
+func getMnemonicLanguage(cmd *cobra.Command, mnemonic string) (string, error) {
+	if cmd.Flags().Changed("language") {
+		return strings.ToLower(*inputMnemonicLang), nil
+	}
+	return hdwallet.DetectMnemonicLanguage(mnemonic)
+}
+
 func init() {
-	inputMnemonicWords = MnemonicCmd.PersistentFlags().Int("words", 24, "The number of words to use in the mnemonic")
+	MnemonicCmd.AddCommand(ValidateCmd)
+	MnemonicCmd.AddCommand(EntropyCmd)
+	MnemonicCmd.AddCommand(SeedCmd)
+
+	inputMnemonicWords = MnemonicCmd.Flags().Int("words", 24, "The number of words to use in the mnemonic")
+	inputEntropy = MnemonicCmd.Flags().String("entropy", "", "Build the mnemonic from this hex encoded entropy of 16 to 32 bytes rather than a random one")
 	inputMnemonicLang = MnemonicCmd.PersistentFlags().String("language", "english", "Which language to use [ChineseSimplified, ChineseTraditional, Czech, English, French, Italian, Japanese, Korean, Spanish]")
+	inputPassphrase = SeedCmd.Flags().String("passphrase", "", "The optional BIP39 passphrase")
 }
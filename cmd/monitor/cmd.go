@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/maticnetwork/polygon-cli/rpcclient"
 	"github.com/maticnetwork/polygon-cli/util"
 	"github.com/spf13/cobra"
 )
@@ -21,6 +22,8 @@ var (
 	subBatchSize    int
 	blockCacheLimit int
 	intervalStr     string
+	rpcFlags        *rpcclient.Flags
+	wsUrl           string
 
 	defaultBatchSize = 100
 )
@@ -81,12 +84,19 @@ func init() {
 	MonitorCmd.PersistentFlags().IntVarP(&subBatchSize, "sub-batch-size", "s", 50, "Number of requests per sub-batch")
 	MonitorCmd.PersistentFlags().IntVarP(&blockCacheLimit, "cache-limit", "c", 200, "Number of cached blocks for the LRU block data structure (Min 100)")
 	MonitorCmd.PersistentFlags().StringVarP(&intervalStr, "interval", "i", "5s", "Amount of time between batch block rpc calls")
+	MonitorCmd.PersistentFlags().StringVar(&wsUrl, "ws-url", "", "A WebSocket endpoint whose new heads trigger the refreshes instead of the interval, the heads missed during disconnections are backfilled from the RPC URL")
+	rpcFlags = rpcclient.AddFlags(MonitorCmd.PersistentFlags())
 }
 
 func checkFlags() (err error) {
 	if err = util.ValidateUrl(rpcUrl); err != nil {
 		return
 	}
+	if wsUrl != "" {
+		if err = util.ValidateUrl(wsUrl); err != nil {
+			return
+		}
+	}
 
 	interval, err = time.ParseDuration(intervalStr)
 	if err != nil {
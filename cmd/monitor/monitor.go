@@ -5,7 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"strings"
+	"os"
 	"sync"
 	"time"
 
@@ -14,13 +14,14 @@ import (
 
 	_ "embed"
 
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	ethrpc "github.com/ethereum/go-ethereum/rpc"
 
-	"github.com/cenkalti/backoff/v4"
 	termui "github.com/gizak/termui/v3"
 	"github.com/maticnetwork/polygon-cli/cmd/monitor/ui"
 	"github.com/maticnetwork/polygon-cli/metrics"
+	"github.com/maticnetwork/polygon-cli/rpcclient"
 	"github.com/maticnetwork/polygon-cli/rpctypes"
 	"github.com/rs/zerolog/log"
 )
@@ -72,12 +73,12 @@ type (
 		BlocksLock          sync.RWMutex `json:"-"`
 	}
 	chainState struct {
-		HeadBlock    uint64
-		ChainID      *big.Int
-		PeerCount    uint64
-		GasPrice     *big.Int
-		PendingCount uint64
-		QueuedCount  uint64
+		HeadBlock    uint64   `json:"headBlock"`
+		ChainID      *big.Int `json:"chainID"`
+		PeerCount    uint64   `json:"peerCount"`
+		GasPrice     *big.Int `json:"gasPrice"`
+		PendingCount uint64   `json:"pendingCount"`
+		QueuedCount  uint64   `json:"queuedCount"`
 	}
 	historicalDataPoint struct {
 		SampleTime  time.Time
@@ -96,7 +97,7 @@ const (
 )
 
 func monitor(ctx context.Context) error {
-	rpc, err := ethrpc.DialContext(ctx, rpcUrl)
+	rpc, err := rpcFlags.Dial(ctx, rpcUrl)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable to dial rpc")
 		return err
@@ -126,6 +127,33 @@ func monitor(ctx context.Context) error {
 
 	observedPendingTxs = make(historicalRange, 0)
 
+	// With a WebSocket endpoint, the new heads trigger the refreshes. The
+	// heads received during a refresh are coalesced into the next one.
+	var refresh chan struct{}
+	if wsUrl != "" {
+		refresh = make(chan struct{}, 1)
+		heads := make(chan *types.Header)
+		go func() {
+			if err := rpcclient.NewSubscriber(wsUrl, rpc).NewHeads(ctx, heads); err != nil && ctx.Err() == nil {
+				log.Error().Err(err).Msg("The new heads subscription ended")
+			}
+		}()
+		go func() {
+			for range heads {
+				select {
+				case refresh <- struct{}{}:
+				default:
+				}
+			}
+		}()
+	}
+
+	// The terminal UI is the text output, the other output formats print the
+	// chain state on every refresh instead.
+	if util.GetOutputFormat() != util.TextOutput {
+		return writeChainStates(ctx, ec, refresh)
+	}
+
 	isUiRendered := false
 	errChan := make(chan error)
 	go func() {
@@ -153,7 +181,15 @@ func monitor(ctx context.Context) error {
 					isUiRendered = true
 				}
 
-				time.Sleep(interval)
+				if refresh == nil {
+					time.Sleep(interval)
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-refresh:
+				}
 			}
 		}
 	}()
@@ -195,6 +231,37 @@ func getChainState(ctx context.Context, ec *ethclient.Client) (*chainState, erro
 
 }
 
+// writeChainStates writes the chain state with the output writer on every
+// refresh, until the context is done.
+func writeChainStates(ctx context.Context, ec *ethclient.Client, refresh <-chan struct{}) error {
+	w := util.NewOutputWriter(os.Stdout)
+	for {
+		cs, err := getChainState(ctx, ec)
+		if err != nil {
+			log.Error().Err(err).Msg("Encountered issue fetching network information")
+		} else {
+			// The states are separate documents of a YAML stream.
+			if util.GetOutputFormat() == util.YAMLOutput {
+				fmt.Println("---")
+			}
+			if err = w.Write(cs); err != nil {
+				return err
+			}
+		}
+
+		var next <-chan time.Time
+		if refresh == nil {
+			next = time.After(interval)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-next:
+		case <-refresh:
+		}
+	}
+}
+
 func (h historicalRange) getValues(limit int) []float64 {
 	values := make([]float64, len(h))
 	for idx, v := range h {
@@ -327,20 +394,9 @@ func (ms *monitorStatus) processBatchesConcurrently(ctx context.Context, rpc *et
 			}
 			subBatch := blms[i:end]
 
-			b := backoff.NewExponentialBackOff()
-			b.MaxElapsedTime = 3 * time.Minute
-			retryable := func() error {
-				err := rpc.BatchCallContext(ctx, subBatch)
-				if err != nil {
-					log.Error().Err(err).Msg("BatchCallContext error - retry loop")
-					if strings.Contains(err.Error(), "limit") {
-						return backoff.Permanent(err)
-					}
-				}
-				return nil
-			}
-			if err := backoff.Retry(retryable, b); err != nil {
-				log.Error().Err(err).Msg("unable to retry")
+			// The failed requests are retried by the RPC client.
+			if err := rpc.BatchCallContext(ctx, subBatch); err != nil {
+				log.Error().Err(err).Msg("Unable to fetch the sub-batch")
 				errorsMutex.Lock()
 				errs = append(errs, err)
 				errorsMutex.Unlock()
@@ -2,14 +2,15 @@ package nodekey
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/binary"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net"
+	"os"
 
 	_ "embed"
 
@@ -20,6 +21,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/exp/slices"
+
+	"github.com/maticnetwork/polygon-cli/util"
 )
 
 // libp2p (substrate/avail) - https://github.com/libp2p/specs/blob/master/peer-ids/peer-ids.md
@@ -42,6 +45,7 @@ var (
 	inputNodeKeyTCP             *int
 	inputNodeKeyUDP             *int
 	inputNodeKeyFile            *string
+	inputNodeKeyFileFormat      *string
 	inputNodeKeySign            *bool
 	inputNodeKeySeed            *uint64
 	inputNodeKeyMarshalProtobuf *bool
@@ -53,6 +57,9 @@ type (
 		PrivateKey     string
 		FullPrivateKey string `json:",omitempty"`
 		ENR            string `json:",omitempty"`
+		Enode          string `json:",omitempty"`
+		PeerID         string `json:",omitempty"`
+		Libp2pKey      string `json:",omitempty"`
 		Seed           uint64 `json:",omitempty"`
 	}
 )
@@ -88,13 +95,7 @@ var NodekeyCmd = &cobra.Command{
 			return fmt.Errorf("%s is not implemented yet", *inputNodeKeyProtocol)
 		}
 
-		out, err := json.Marshal(nko)
-		if err != nil {
-			return fmt.Errorf("could not json marshal the key data %w", err)
-		}
-		fmt.Println(string(out))
-
-		return nil
+		return util.NewOutputWriter(os.Stdout).Write(nko)
 	},
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 0 {
@@ -112,16 +113,19 @@ var NodekeyCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
+			if *inputNodeKeyFileFormat != "hex" && *inputNodeKeyFileFormat != "libp2p" {
+				return fmt.Errorf("the file format %s is not supported, expected hex or libp2p", *inputNodeKeyFileFormat)
+			}
 		}
 		if *inputNodeKeyProtocol == "libp2p" {
-			invalidFlags := []string{"file", "ip", "tcp", "udp", "sign", "seed"}
+			invalidFlags := []string{"file", "file-format", "ip", "tcp", "udp", "sign", "seed"}
 			err := validateNodeKeyFlags(cmd, invalidFlags)
 			if err != nil {
 				return err
 			}
 		}
 		if *inputNodeKeyProtocol == "seed-libp2p" {
-			invalidFlags := []string{"file", "ip", "tcp", "udp", "sign"}
+			invalidFlags := []string{"file", "file-format", "ip", "tcp", "udp", "sign"}
 			err := validateNodeKeyFlags(cmd, invalidFlags)
 			if err != nil {
 				return err
@@ -172,7 +176,7 @@ func generateDevp2pNodeKey() (nodeKeyOut, error) {
 	nodeKey, err := gethcrypto.GenerateKey()
 
 	if *inputNodeKeyFile != "" {
-		nodeKey, err = gethcrypto.LoadECDSA(*inputNodeKeyFile)
+		nodeKey, err = loadDevp2pNodeKey(*inputNodeKeyFile, *inputNodeKeyFileFormat)
 	}
 	if err != nil {
 		return nodeKeyOut{}, fmt.Errorf("could not generate key: %w", err)
@@ -198,11 +202,57 @@ func generateDevp2pNodeKey() (nodeKeyOut, error) {
 		}
 	}
 
-	// ko.ENR = n.URLv4()
 	nko.ENR = n.String()
+	nko.Enode = n.URLv4()
+
+	// The same secp256k1 key can be used by libp2p based clients, so we also
+	// output the peer ID and the protobuf encoded key they expect.
+	libp2pKey, err := libp2pcrypto.UnmarshalSecp256k1PrivateKey(prvKeyBytes)
+	if err != nil {
+		return nodeKeyOut{}, fmt.Errorf("unable to convert the key to libp2p format: %w", err)
+	}
+	id, err := libp2ppeer.IDFromPrivateKey(libp2pKey)
+	if err != nil {
+		return nodeKeyOut{}, fmt.Errorf("unable to retrieve the peer ID from the private key, %w", err)
+	}
+	nko.PeerID = id.String()
+	rawLibp2pKey, err := libp2pcrypto.MarshalPrivateKey(libp2pKey)
+	if err != nil {
+		return nodeKeyOut{}, fmt.Errorf("unable to marshal the libp2p private key, %w", err)
+	}
+	nko.Libp2pKey = hex.EncodeToString(rawLibp2pKey)
 	return nko, nil
 }
 
+// loadDevp2pNodeKey reads the secp256k1 node key of another client. The hex
+// format is the nodekey file of geth, bor and erigon. The libp2p format is the
+// protobuf encoded private key of the libp2p based clients, either in hex as
+// output by this command or binary as stored by go-libp2p.
+func loadDevp2pNodeKey(file, format string) (*ecdsa.PrivateKey, error) {
+	if format != "libp2p" {
+		return gethcrypto.LoadECDSA(file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if decoded, hexErr := hex.DecodeString(string(bytes.TrimSpace(data))); hexErr == nil {
+		data = decoded
+	}
+	libp2pKey, err := libp2pcrypto.UnmarshalPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the libp2p key: %w", err)
+	}
+	if libp2pKey.Type() != libp2pcrypto.Secp256k1 {
+		return nil, fmt.Errorf("the libp2p key is a %s key, devp2p requires a secp256k1 key", libp2pKey.Type())
+	}
+	raw, err := libp2pKey.Raw()
+	if err != nil {
+		return nil, err
+	}
+	return gethcrypto.ToECDSA(raw)
+}
+
 // That function can generate seeded keys but it shouldn't be used for production environments.
 // It was created to allow us to create keys that work with the avail light client's method of generating keys
 // User shouldn't encounter these problems but devs, be aware of:
@@ -260,4 +310,5 @@ func init() {
 	inputNodeKeyMarshalProtobuf = NodekeyCmd.PersistentFlags().BoolP("marshal-protobuf", "m", false, "If true the libp2p key will be marshaled to protobuf format rather than raw")
 
 	inputNodeKeyFile = NodekeyCmd.PersistentFlags().StringP("file", "f", "", "A file with the private nodekey in hex format")
+	inputNodeKeyFileFormat = NodekeyCmd.PersistentFlags().String("file-format", "hex", "The format of the key file: hex for a geth, bor or erigon nodekey, or libp2p for a protobuf encoded secp256k1 libp2p key in hex or binary")
 }
@@ -1,6 +1,9 @@
 package nodekey
 
 import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
 	"testing"
 
 	libp2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
@@ -102,3 +105,80 @@ func TestGenerateSeededLibp2pNodeKey(t *testing.T) {
 		}
 	}
 }
+
+func TestDevp2pNodeKeyConversion(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "nodekey")
+	if err := os.WriteFile(keyFile, []byte("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"), 0600); err != nil {
+		t.Fatalf("could not write key file: %v", err)
+	}
+	*inputNodeKeyFile = keyFile
+	defer func() { *inputNodeKeyFile = "" }()
+
+	res, err := generateDevp2pNodeKey()
+	if err != nil {
+		t.Fatalf("could not load eth key: %v", err)
+	}
+
+	expectedEnode := "enode://4e3b81af9c2234cad09d679ce6035ed1392347ce64ce405f5dcd36228a25de6e47fd35c4215d1edf53e6f83de344615ce719bdb0fd878f6ed76f06dd277956de@0.0.0.0:30303?discport=0"
+	if res.Enode != expectedEnode {
+		t.Errorf("enode does not match, got %v", res.Enode)
+	}
+	if res.PeerID != "16Uiu2HAkzh4QdxB7ijzVnwd6JFNNe4bcr1JBwYUypXe3pX5BRpqK" {
+		t.Errorf("peer ID does not match, got %v", res.PeerID)
+	}
+	if res.Libp2pKey != "080212204c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318" {
+		t.Errorf("libp2p key does not match, got %v", res.Libp2pKey)
+	}
+}
+
+func TestLibp2pNodeKeyRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "nodekey")
+	if err := os.WriteFile(keyFile, []byte("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"), 0600); err != nil {
+		t.Fatalf("could not write key file: %v", err)
+	}
+	*inputNodeKeyFile = keyFile
+	defer func() { *inputNodeKeyFile = ""; *inputNodeKeyFileFormat = "hex" }()
+
+	expected, err := generateDevp2pNodeKey()
+	if err != nil {
+		t.Fatalf("could not load eth key: %v", err)
+	}
+	rawLibp2pKey, err := hex.DecodeString(expected.Libp2pKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The libp2p key is read back both as output in hex and as stored by go-libp2p.
+	for name, data := range map[string][]byte{"hex": []byte(expected.Libp2pKey + "\n"), "binary": rawLibp2pKey} {
+		libp2pFile := filepath.Join(dir, name)
+		if err = os.WriteFile(libp2pFile, data, 0600); err != nil {
+			t.Fatalf("could not write key file: %v", err)
+		}
+		*inputNodeKeyFile = libp2pFile
+		*inputNodeKeyFileFormat = "libp2p"
+		res, err := generateDevp2pNodeKey()
+		if err != nil {
+			t.Fatalf("%s: could not convert the libp2p key: %v", name, err)
+		}
+		if res != expected {
+			t.Errorf("%s: the converted key %+v doesn't match %+v", name, res, expected)
+		}
+	}
+
+	ed25519Key, _, err := libp2pcrypto.GenerateEd25519Key(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := libp2pcrypto.MarshalPrivateKey(ed25519Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(keyFile, data, 0600); err != nil {
+		t.Fatalf("could not write key file: %v", err)
+	}
+	*inputNodeKeyFile = keyFile
+	if _, err = generateDevp2pNodeKey(); err == nil {
+		t.Error("expected the ed25519 key to be rejected")
+	}
+}
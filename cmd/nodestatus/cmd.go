@@ -0,0 +1,83 @@
+package nodestatus
+
+import (
+	_ "embed"
+	"time"
+
+	"github.com/maticnetwork/polygon-cli/rpcclient"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// unhealthyExitCode is the exit code when the node is unhealthy, the failures
+// to query the node exiting with the code of their error category.
+const unhealthyExitCode = util.ResultExitCode
+
+type cmdNodeStatusParams struct {
+	RpcUrl    *string
+	RPCClient *rpcclient.Flags
+
+	ChainID      *uint64
+	MinPeers     *uint64
+	MaxBlockAge  *time.Duration
+	AllowSyncing *bool
+
+	Watch    *bool
+	Interval *time.Duration
+	Samples  *uint64
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdNodeStatusParams
+)
+
+// NodeStatusCmd represents the node-status command.
+var NodeStatusCmd = &cobra.Command{
+	Use:     "node-status",
+	Aliases: []string{"nodestatus"},
+	Short:   "Summarize the health of a node from its RPC, exiting with a non-zero code when it's unhealthy.",
+	Long:    usage,
+	Args:    cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// An unhealthy node is a result, not a usage error.
+		cmd.SilenceUsage = true
+		return reportStatus(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdNodeStatusParams)
+	flagSet := NodeStatusCmd.Flags()
+
+	p.RpcUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8545", "The RPC endpoint url")
+	p.RPCClient = rpcclient.AddFlags(flagSet)
+
+	p.ChainID = flagSet.Uint64("chain-id", 0, "The expected chain ID, 0 to accept any")
+	p.MinPeers = flagSet.Uint64("min-peers", 0, "The minimum number of peers of a healthy node, 0 to disable")
+	p.MaxBlockAge = flagSet.Duration("max-block-age", 0, "The maximum age of the latest block of a healthy node, 0 to disable")
+	p.AllowSyncing = flagSet.Bool("allow-syncing", false, "Consider a syncing node healthy")
+
+	p.Watch = flagSet.BoolP("watch", "w", false, "Report the status continuously")
+	p.Interval = flagSet.Duration("interval", 30*time.Second, "The interval between two reports in watch mode")
+	p.Samples = flagSet.Uint64("samples", 0, "The number of reports in watch mode, 0 to report until interrupted")
+
+	params = *p
+}
+
+func checkFlags() error {
+	if err := util.ValidateUrl(*params.RpcUrl); err != nil {
+		return err
+	}
+	if *params.MaxBlockAge < 0 {
+		return util.Errorf(util.ValidationError, "the maximum block age is negative")
+	}
+	if *params.Interval <= 0 {
+		return util.Errorf(util.ValidationError, "the reporting interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package nodestatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	rpcBlock struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
+	}
+	syncProgress struct {
+		CurrentBlock hexutil.Uint64 `json:"currentBlock"`
+		HighestBlock hexutil.Uint64 `json:"highestBlock"`
+	}
+	txPoolStatus struct {
+		Pending hexutil.Uint64 `json:"pending"`
+		Queued  hexutil.Uint64 `json:"queued"`
+	}
+	nodeInfo struct {
+		Name  string `json:"name"`
+		Enode string `json:"enode"`
+	}
+
+	// NodeStatus is the health of a node at a point in time. The fields of
+	// the optional methods are nil when the node doesn't serve them.
+	NodeStatus struct {
+		Time        time.Time
+		Client      string `json:",omitempty"`
+		Enode       string `json:",omitempty"`
+		ChainID     uint64
+		BlockNumber uint64
+		BlockAge    time.Duration
+		Syncing     bool
+		// HighestBlock is the highest block known by a syncing node.
+		HighestBlock  *uint64 `json:",omitempty"`
+		Peers         *uint64 `json:",omitempty"`
+		TxPoolPending *uint64 `json:",omitempty"`
+		TxPoolQueued  *uint64 `json:",omitempty"`
+		// Unavailable lists the optional methods the node doesn't serve.
+		Unavailable []string `json:",omitempty"`
+		Problems    []string `json:",omitempty"`
+	}
+
+	// statusLine renders a status as a single line in watch mode.
+	statusLine struct {
+		*NodeStatus
+	}
+)
+
+func reportStatus(ctx context.Context) error {
+	rpc, err := params.RPCClient.Dial(ctx, *params.RpcUrl)
+	if err != nil {
+		return util.NewNetworkError(err)
+	}
+	defer rpc.Close()
+
+	out := util.NewOutputWriter(os.Stdout)
+	if !*params.Watch {
+		s, err := getStatus(ctx, rpc)
+		if err != nil {
+			return err
+		}
+		if err = out.Write(s); err != nil {
+			return err
+		}
+		return unhealthyError(s.Problems)
+	}
+
+	ticker := time.NewTicker(*params.Interval)
+	defer ticker.Stop()
+	var problems []string
+	for sample := uint64(0); *params.Samples == 0 || sample < *params.Samples; sample++ {
+		if sample > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		s, err := getStatus(ctx, rpc)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to get the node status")
+			// A node that can't be queried isn't healthy either.
+			problems = []string{err.Error()}
+			continue
+		}
+		for _, p := range s.Problems {
+			log.Warn().Msg(p)
+		}
+		problems = s.Problems
+		if err = out.Write(statusLine{s}); err != nil {
+			return err
+		}
+	}
+	// The exit code reflects the last report.
+	return unhealthyError(problems)
+}
+
+func getStatus(ctx context.Context, rpc *ethrpc.Client) (*NodeStatus, error) {
+	var chainID, blockNumber, peers hexutil.Uint64
+	var block rpcBlock
+	var syncing json.RawMessage
+	var pool txPoolStatus
+	var clientVersion string
+	var info nodeInfo
+	elems := []ethrpc.BatchElem{
+		{Method: "eth_chainId", Result: &chainID},
+		{Method: "eth_blockNumber", Result: &blockNumber},
+		{Method: "eth_getBlockByNumber", Args: []any{"latest", false}, Result: &block},
+		{Method: "eth_syncing", Result: &syncing},
+		// The other methods belong to namespaces which may be disabled.
+		{Method: "net_peerCount", Result: &peers},
+		{Method: "txpool_status", Result: &pool},
+		{Method: "web3_clientVersion", Result: &clientVersion},
+		{Method: "admin_nodeInfo", Result: &info},
+	}
+	const required = 4
+	if err := rpc.BatchCallContext(ctx, elems); err != nil {
+		return nil, util.NewNetworkError(err)
+	}
+	for _, e := range elems[:required] {
+		if e.Error != nil {
+			return nil, util.NewNetworkError(fmt.Errorf("unable to call %s: %w", e.Method, e.Error))
+		}
+	}
+
+	now := time.Now()
+	s := &NodeStatus{
+		Time:        now,
+		Client:      clientVersion,
+		Enode:       info.Enode,
+		ChainID:     uint64(chainID),
+		BlockNumber: uint64(blockNumber),
+		BlockAge:    now.Sub(time.Unix(int64(block.Timestamp), 0)).Truncate(time.Second),
+	}
+	for _, e := range elems[required:] {
+		if e.Error != nil {
+			log.Debug().Err(e.Error).Str("method", e.Method).Msg("Optional method unavailable")
+			s.Unavailable = append(s.Unavailable, e.Method)
+			continue
+		}
+		switch e.Method {
+		case "net_peerCount":
+			n := uint64(peers)
+			s.Peers = &n
+		case "txpool_status":
+			pending, queued := uint64(pool.Pending), uint64(pool.Queued)
+			s.TxPoolPending, s.TxPoolQueued = &pending, &queued
+		case "admin_nodeInfo":
+			if s.Client == "" {
+				s.Client = info.Name
+			}
+		}
+	}
+
+	// eth_syncing returns false when the node isn't syncing.
+	if string(syncing) != "false" {
+		var progress syncProgress
+		if err := json.Unmarshal(syncing, &progress); err != nil {
+			return nil, util.Errorf(util.NetworkError, "unable to parse the eth_syncing result %s: %w", syncing, err)
+		}
+		highest := uint64(progress.HighestBlock)
+		s.Syncing, s.HighestBlock = true, &highest
+	}
+
+	if s.Syncing && !*params.AllowSyncing {
+		s.Problems = append(s.Problems, fmt.Sprintf("the node is syncing, at block %d of %d", s.BlockNumber, *s.HighestBlock))
+	}
+	if id := *params.ChainID; id > 0 && s.ChainID != id {
+		s.Problems = append(s.Problems, fmt.Sprintf("the chain ID is %d instead of %d", s.ChainID, id))
+	}
+	if limit := *params.MinPeers; limit > 0 {
+		if s.Peers == nil {
+			s.Problems = append(s.Problems, "the peer count is unavailable")
+		} else if *s.Peers < limit {
+			s.Problems = append(s.Problems, fmt.Sprintf("the node has %d peers, fewer than %d", *s.Peers, limit))
+		}
+	}
+	if limit := *params.MaxBlockAge; limit > 0 && s.BlockAge > limit {
+		s.Problems = append(s.Problems, fmt.Sprintf("the latest block %d is %s old, more than %s", s.BlockNumber, s.BlockAge, limit))
+	}
+	return s, nil
+}
+
+// unhealthyError returns the error making polycli exit with the unhealthy
+// exit code when there are problems.
+func unhealthyError(problems []string) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return &util.ExitError{Code: unhealthyExitCode, Err: fmt.Errorf("the node is unhealthy: %s", strings.Join(problems, "; "))}
+}
+
+// WriteText renders the status as a table.
+func (s *NodeStatus) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendRows([]table.Row{
+		{"Client", s.Client},
+		{"Chain ID", s.ChainID},
+		{"Latest Block", s.BlockNumber},
+		{"Block Age", s.BlockAge},
+		{"Syncing", syncingText(s)},
+		{"Peers", optional(s.Peers)},
+		{"Pending Transactions", optional(s.TxPoolPending)},
+		{"Queued Transactions", optional(s.TxPoolQueued)},
+	})
+	if s.Enode != "" {
+		t.AppendRow(table.Row{"Enode", s.Enode})
+	}
+	t.Render()
+	for _, p := range s.Problems {
+		if _, err := fmt.Fprintln(w, "Problem:", p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteText renders the status as a single line.
+func (s statusLine) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s block=%d age=%s syncing=%t peers=%s pending=%s queued=%s problems=%d\n",
+		s.Time.Format(time.RFC3339), s.BlockNumber, s.BlockAge, s.Syncing, optional(s.Peers), optional(s.TxPoolPending), optional(s.TxPoolQueued), len(s.Problems))
+	return err
+}
+
+func syncingText(s *NodeStatus) string {
+	if !s.Syncing {
+		return "no"
+	}
+	return fmt.Sprintf("yes, highest block %d", *s.HighestBlock)
+}
+
+// optional renders the value of an optional method, which is n/a when the
+// node doesn't serve it.
+func optional(v *uint64) string {
+	if v == nil {
+		return "n/a"
+	}
+	return fmt.Sprint(*v)
+}
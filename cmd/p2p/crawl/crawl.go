@@ -2,11 +2,14 @@ package crawl
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p/discover"
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/oschwald/geoip2-golang"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
@@ -24,6 +27,9 @@ type (
 		Database             string
 		RevalidationInterval string
 		OnlyURLs             bool
+		Discovery            string
+		SQLitePath           string
+		GeoIPDatabase        string
 
 		revalidationInterval time.Duration
 	}
@@ -53,6 +59,10 @@ var CrawlCmd = &cobra.Command{
 			return err
 		}
 
+		if inputCrawlParams.Discovery != "v4" && inputCrawlParams.Discovery != "v5" {
+			return fmt.Errorf("unsupported discovery protocol %q, expected v4 or v5", inputCrawlParams.Discovery)
+		}
+
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -79,19 +89,47 @@ var CrawlCmd = &cobra.Command{
 			return err
 		}
 
-		disc, err := discover.ListenV4(socket, ln, cfg)
-		if err != nil {
-			return err
+		var c *crawler
+		switch inputCrawlParams.Discovery {
+		case "v5":
+			disc, err := discover.ListenV5(socket, ln, cfg)
+			if err != nil {
+				return err
+			}
+			defer disc.Close()
+			c = newCrawler(nodes, disc, disc.RandomNodes())
+		default:
+			disc, err := discover.ListenV4(socket, ln, cfg)
+			if err != nil {
+				return err
+			}
+			defer disc.Close()
+			c = newCrawler(nodes, disc, disc.RandomNodes())
 		}
-		defer disc.Close()
-
-		c := newCrawler(nodes, disc, disc.RandomNodes())
 		c.revalidateInterval = inputCrawlParams.revalidationInterval
 
+		if inputCrawlParams.GeoIPDatabase != "" {
+			c.geoip, err = geoip2.Open(inputCrawlParams.GeoIPDatabase)
+			if err != nil {
+				return fmt.Errorf("unable to open the GeoIP database: %w", err)
+			}
+			defer c.geoip.Close()
+		}
+
 		log.Info().Msg("Starting crawl")
 
 		output := c.run(inputCrawlParams.timeout, inputCrawlParams.Threads)
 
+		if c.geoip != nil {
+			logCountries(output)
+		}
+
+		if inputCrawlParams.SQLitePath != "" {
+			if err = p2p.WriteNodeSetSQLite(inputCrawlParams.SQLitePath, output); err != nil {
+				return fmt.Errorf("unable to write the nodes to %s: %w", inputCrawlParams.SQLitePath, err)
+			}
+		}
+
 		if inputCrawlParams.OnlyURLs {
 			return p2p.WriteURLs(inputCrawlParams.NodesFile, output)
 		}
@@ -100,6 +138,34 @@ var CrawlCmd = &cobra.Command{
 	},
 }
 
+// logCountries logs the number of nodes crawled successfully per country.
+func logCountries(ns p2p.NodeSet) {
+	counts := make(map[string]int)
+	for _, events := range ns {
+		if len(events) == 0 {
+			continue
+		}
+		if event := events[len(events)-1]; event.Error == "" {
+			counts[event.Country]++
+		}
+	}
+	countries := make([]string, 0, len(counts))
+	for country := range counts {
+		countries = append(countries, country)
+	}
+	sort.Slice(countries, func(i, j int) bool { return counts[countries[i]] > counts[countries[j]] })
+
+	dict := zerolog.Dict()
+	for _, country := range countries {
+		name := country
+		if name == "" {
+			name = "unknown"
+		}
+		dict = dict.Int(name, counts[country])
+	}
+	log.Info().Dict("countries", dict).Msg("Crawled nodes by country")
+}
+
 func init() {
 	CrawlCmd.PersistentFlags().StringVarP(&inputCrawlParams.Bootnodes, "bootnodes", "b", "",
 		`Comma separated nodes used for bootstrapping. At least one bootnode is
@@ -113,4 +179,7 @@ required, so other nodes in the network can discover each other.`)
 	CrawlCmd.PersistentFlags().StringVarP(&inputCrawlParams.Database, "database", "d", "", "Node database for updating and storing client information")
 	CrawlCmd.PersistentFlags().StringVarP(&inputCrawlParams.RevalidationInterval, "revalidation-interval", "r", "10m", "Time before retrying to connect to a failed peer")
 	CrawlCmd.PersistentFlags().BoolVarP(&inputCrawlParams.OnlyURLs, "only-urls", "u", true, "Only writes the enode URLs to the output")
+	CrawlCmd.PersistentFlags().StringVar(&inputCrawlParams.Discovery, "discovery", "v4", "Discovery protocol used for the crawl [v4, v5]")
+	CrawlCmd.PersistentFlags().StringVar(&inputCrawlParams.SQLitePath, "sqlite-path", "", "Also write the crawled nodes to this SQLite database")
+	CrawlCmd.PersistentFlags().StringVar(&inputCrawlParams.GeoIPDatabase, "geoip-database", "", "MaxMind GeoIP2 or GeoLite2 country or city database used to record the country of the nodes")
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/oschwald/geoip2-golang"
 	"github.com/rs/zerolog/log"
 
 	"github.com/maticnetwork/polygon-cli/p2p"
@@ -23,6 +24,7 @@ type crawler struct {
 
 	// settings
 	revalidateInterval time.Duration
+	geoip              *geoip2.Reader
 	mu                 sync.Mutex
 }
 
@@ -168,11 +170,17 @@ func (c *crawler) updateNode(n *enode.Node) int {
 		c.mu.Lock()
 		result := p2p.NodeJSON{
 			URL:    n.URLv4(),
+			ENR:    n.String(),
 			Hello:  hello,
 			Status: status,
 			Time:   time.Now().Unix(),
 			Nodes:  nodes,
 		}
+		if c.geoip != nil {
+			if country, geoErr := c.geoip.Country(n.IP()); geoErr == nil {
+				result.Country = country.Country.IsoCode
+			}
+		}
 		if err != nil {
 			result.Error = err.Error()
 		}
@@ -0,0 +1,89 @@
+package diff
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/maticnetwork/polygon-cli/p2p"
+	"github.com/maticnetwork/polygon-cli/util"
+)
+
+const jsonIndent = "    "
+
+type (
+	diffParams struct {
+		OldNodesFile string
+		NewNodesFile string
+		OutputFile   string
+	}
+	diffOutput struct {
+		Added     []string `json:"added"`
+		Removed   []string `json:"removed"`
+		Updated   []string `json:"updated"`
+		Unchanged int      `json:"unchanged"`
+	}
+)
+
+var (
+	inputDiffParams diffParams
+)
+
+var DiffCmd = &cobra.Command{
+	Use:   "diff [old nodes file] [new nodes file]",
+	Short: "Compare the output of two crawls.",
+	Long: `Compare two nodes files generated by the crawl command and output the
+nodes which have been added, removed or updated between them. The files can be
+lists of enode URLs or the node sets written with --only-urls=false. Nodes are
+matched by their node ID, and a node is updated when its ENR has a higher
+sequence number, e.g. after an IP address or a fork ID change.`,
+	Args: cobra.ExactArgs(2),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		inputDiffParams.OldNodesFile = args[0]
+		inputDiffParams.NewNodesFile = args[1]
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldNodes, err := p2p.ReadNodeSet(inputDiffParams.OldNodesFile)
+		if err != nil {
+			return err
+		}
+		newNodes, err := p2p.ReadNodeSet(inputDiffParams.NewNodesFile)
+		if err != nil {
+			return err
+		}
+
+		added, removed, updated, unchanged := p2p.DiffNodes(oldNodes, newNodes)
+		output := diffOutput{
+			Added:     make([]string, 0, len(added)),
+			Removed:   make([]string, 0, len(removed)),
+			Updated:   make([]string, 0, len(updated)),
+			Unchanged: unchanged,
+		}
+		for _, n := range added {
+			output.Added = append(output.Added, n.String())
+		}
+		for _, n := range removed {
+			output.Removed = append(output.Removed, n.String())
+		}
+		for _, n := range updated {
+			output.Updated = append(output.Updated, n.String())
+		}
+
+		if len(inputDiffParams.OutputFile) == 0 {
+			return util.NewOutputWriter(os.Stdout).Write(output)
+		}
+
+		bytes, err := json.MarshalIndent(output, "", jsonIndent)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(inputDiffParams.OutputFile, bytes, 0644)
+	},
+}
+
+func init() {
+	DiffCmd.PersistentFlags().StringVarP(&inputDiffParams.OutputFile, "output-file", "o", "", "Write the diff to output file (default stdout)")
+}
@@ -1,25 +1,42 @@
 package ping
 
 import (
+	"fmt"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	"github.com/maticnetwork/polygon-cli/p2p"
+	"github.com/maticnetwork/polygon-cli/util"
 )
 
 type (
 	pingParams struct {
-		Threads    int
-		OutputFile string
-		NodesFile  string
-		Listen     bool
+		Threads     int
+		OutputFile  string
+		NodesFile   string
+		Listen      bool
+		Report      bool
+		NetworkID   uint64
+		GenesisHash string
+		ForkID      []byte
+
+		genesisHash *common.Hash
 	}
 )
 
+// headTimeout is how long the peer has to answer the request for its head
+// header in the report.
+const headTimeout = 10 * time.Second
+
 var (
 	inputPingParams pingParams
 )
@@ -32,17 +49,45 @@ var PingCmd = &cobra.Command{
 This command will establish a handshake and status exchange to get the Hello and
 Status messages and output JSON. If providing a enode/enr rather than a nodes
 file, then the connection will remain open by default (--listen=true), and you
-can see other messages the peer sends (e.g. blocks, transactions, etc.).`,
+can see other messages the peer sends (e.g. blocks, transactions, etc.).
+
+When pinging a single enode/enr, a report of the peer's client, capabilities,
+network ID, genesis, fork ID, and head is printed instead of the JSON output
+(--report=true). Pass --network-id, --genesis-hash, or --fork-id to check the
+peer is on the expected network, the command fails if it isn't. The connection
+is closed once the report is printed, unless --listen is given explicitly.`,
 	Args: cobra.MinimumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		inputPingParams.genesisHash = nil
+		if hash := inputPingParams.GenesisHash; hash != "" {
+			b, err := hexutil.Decode(hash)
+			if err != nil || len(b) != common.HashLength {
+				return fmt.Errorf("invalid genesis hash %s", hash)
+			}
+			h := common.BytesToHash(b)
+			inputPingParams.genesisHash = &h
+		}
+		return nil
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		nodes := []*enode.Node{}
+		single := false
 		if input, err := p2p.ReadNodeSet(args[0]); err == nil {
 			nodes = input
 		} else if node, err := p2p.ParseNode(args[0]); err == nil {
 			nodes = append(nodes, node)
+			single = true
 		} else {
 			return err
 		}
+		shouldReport := single && inputPingParams.Report
+		// The report is a quick check, the peer is only listened to afterwards
+		// when asked explicitly.
+		shouldListen := inputPingParams.Listen && (!shouldReport || cmd.Flags().Changed("listen"))
+
+		// reportErr is the error of the single node, returned once the node set
+		// is written.
+		var reportErr error
 
 		output := make(p2p.NodeSet)
 
@@ -81,6 +126,7 @@ can see other messages the peer sends (e.g. blocks, transactions, etc.).`,
 					status *p2p.Status
 				)
 
+				start := time.Now()
 				conn, err := p2p.Dial(node)
 				if err != nil {
 					log.Error().Err(err).Msg("Dial failed")
@@ -93,7 +139,19 @@ can see other messages the peer sends (e.g. blocks, transactions, etc.).`,
 					log.Info().Interface("hello", hello).Interface("status", status).Msg("Peering messages received")
 				}
 
-				if err == nil && inputPingParams.Listen {
+				if err == nil && shouldReport {
+					latency := time.Since(start)
+					header, headErr := conn.QueryHeader(status.Head, headTimeout)
+					if headErr != nil {
+						log.Warn().Err(headErr).Msg("Failed to query head header")
+					}
+					err = writeReport(node, hello, status, header, latency)
+				}
+				if shouldReport {
+					reportErr = err
+				}
+
+				if err == nil && shouldListen {
 					// If the dial and peering were successful, listen to the peer for messages.
 					if err = conn.ReadAndServe(count); err != nil {
 						log.Error().Err(err).Msg("Received error")
@@ -122,14 +180,52 @@ can see other messages the peer sends (e.g. blocks, transactions, etc.).`,
 		}
 		wg.Wait()
 
-		return p2p.WriteNodeSet(inputPingParams.OutputFile, output, true)
+		// The report replaces the node set on stdout.
+		if !shouldReport || len(inputPingParams.OutputFile) > 0 {
+			if err := p2p.WriteNodeSet(inputPingParams.OutputFile, output, true); err != nil {
+				return err
+			}
+		}
+
+		if reportErr != nil {
+			cmd.SilenceUsage = true
+		}
+		return reportErr
 	},
 }
 
+// writeReport prints the report of the peer, including its head header if the
+// peer returned it. It returns an error if the peer isn't on the expected
+// network.
+func writeReport(node *enode.Node, hello *p2p.Hello, status *p2p.Status, header *types.Header, latency time.Duration) error {
+	r := newReport(node, hello, status, latency)
+	if header != nil {
+		number := header.Number.Uint64()
+		headTime := time.Unix(int64(header.Time), 0)
+		r.HeadNumber, r.HeadTime = &number, &headTime
+	}
+
+	if err := util.NewOutputWriter(os.Stdout).Write(r); err != nil {
+		return err
+	}
+
+	if len(r.Problems) > 0 {
+		return fmt.Errorf("peer is not on the expected network: %s", strings.Join(r.Problems, ", "))
+	}
+	return nil
+}
+
 func init() {
-	PingCmd.PersistentFlags().StringVarP(&inputPingParams.OutputFile, "output", "o", "", "Write ping results to output file (default stdout)")
+	PingCmd.PersistentFlags().StringVarP(&inputPingParams.OutputFile, "output-file", "o", "", "Write ping results to output file (default stdout)")
 	PingCmd.PersistentFlags().IntVarP(&inputPingParams.Threads, "parallel", "p", 16, "How many parallel pings to attempt")
 	PingCmd.PersistentFlags().BoolVarP(&inputPingParams.Listen, "listen", "l", true,
 		`Keep the connection open and listen to the peer. This only works if the first
-argument is an enode/enr, not a nodes file.`)
+argument is an enode/enr, not a nodes file. With --report, the peer is only
+listened to when the flag is given explicitly.`)
+	PingCmd.Flags().BoolVar(&inputPingParams.Report, "report", true,
+		`Print a report of the peer instead of the JSON output. This only works if the
+first argument is an enode/enr, not a nodes file.`)
+	PingCmd.Flags().Uint64VarP(&inputPingParams.NetworkID, "network-id", "n", 0, "Expected network ID of the peer (0 to skip the check)")
+	PingCmd.Flags().StringVar(&inputPingParams.GenesisHash, "genesis-hash", "", "Expected 0x prefixed genesis block hash of the peer")
+	PingCmd.Flags().BytesHexVar(&inputPingParams.ForkID, "fork-id", nil, "Expected hex encoded fork id of the peer (omit the 0x)")
 }
@@ -0,0 +1,101 @@
+package ping
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/jedib0t/go-pretty/v6/table"
+
+	"github.com/maticnetwork/polygon-cli/p2p"
+)
+
+// report is the diagnostic of a single peer, answering whether it's healthy
+// and on the expected network.
+type report struct {
+	URL             string
+	Client          string
+	Capabilities    []string
+	ProtocolVersion uint32
+	NetworkID       uint64
+	Genesis         common.Hash
+	ForkID          string
+	ForkNext        uint64
+	Head            common.Hash
+	HeadNumber      *uint64    `json:",omitempty"`
+	HeadTime        *time.Time `json:",omitempty"`
+	TotalDifficulty *big.Int
+	// Latency is the time to dial the peer and complete the handshake and
+	// status exchange.
+	Latency  time.Duration
+	Problems []string `json:",omitempty"`
+}
+
+func newReport(node *enode.Node, hello *p2p.Hello, status *p2p.Status, latency time.Duration) *report {
+	r := &report{
+		URL:             node.URLv4(),
+		Client:          hello.Name,
+		ProtocolVersion: status.ProtocolVersion,
+		NetworkID:       status.NetworkID,
+		Genesis:         status.Genesis,
+		ForkID:          hex.EncodeToString(status.ForkID.Hash[:]),
+		ForkNext:        status.ForkID.Next,
+		Head:            status.Head,
+		TotalDifficulty: status.TD,
+		Latency:         latency,
+	}
+	for _, c := range hello.Caps {
+		r.Capabilities = append(r.Capabilities, c.String())
+	}
+
+	if id := inputPingParams.NetworkID; id != 0 && id != r.NetworkID {
+		r.Problems = append(r.Problems, fmt.Sprintf("network ID mismatch: %d (expected %d)", r.NetworkID, id))
+	}
+	if hash := inputPingParams.genesisHash; hash != nil && *hash != r.Genesis {
+		r.Problems = append(r.Problems, fmt.Sprintf("genesis mismatch: %v (expected %v)", r.Genesis, *hash))
+	}
+	if id := inputPingParams.ForkID; len(id) > 0 && !bytes.Equal(id, status.ForkID.Hash[:]) {
+		r.Problems = append(r.Problems, fmt.Sprintf("fork ID mismatch: %s (expected %x)", r.ForkID, id))
+	}
+
+	return r
+}
+
+// WriteText renders the report as a table, followed by the problems found.
+func (r *report) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendRows([]table.Row{
+		{"Enode", r.URL},
+		{"Client", r.Client},
+		{"Capabilities", fmt.Sprint(r.Capabilities)},
+		{"Protocol Version", r.ProtocolVersion},
+		{"Network ID", r.NetworkID},
+		{"Genesis", r.Genesis},
+		{"Fork ID", fmt.Sprintf("%s (next %d)", r.ForkID, r.ForkNext)},
+		{"Head", r.Head},
+	})
+	if r.HeadNumber != nil {
+		t.AppendRow(table.Row{"Head Number", *r.HeadNumber})
+	}
+	if r.HeadTime != nil {
+		t.AppendRow(table.Row{"Head Age", time.Since(*r.HeadTime).Round(time.Second)})
+	}
+	t.AppendRows([]table.Row{
+		{"Total Difficulty", r.TotalDifficulty},
+		{"Latency", r.Latency.Round(time.Millisecond)},
+	})
+	t.Render()
+
+	for _, p := range r.Problems {
+		if _, err := fmt.Fprintln(w, "Problem:", p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package sensor
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
@@ -37,6 +38,8 @@ type (
 		NetworkID                    uint64
 		NodesFile                    string
 		TrustedNodesFile             string
+		Database                     string
+		SQLitePath                   string
 		ProjectID                    string
 		DatabaseID                   string
 		SensorID                     string
@@ -105,6 +108,10 @@ var SensorCmd = &cobra.Command{
 			return errors.New("network ID must be greater than zero")
 		}
 
+		if inputSensorParams.Database != "datastore" && inputSensorParams.Database != "sqlite" {
+			return fmt.Errorf("database must be either datastore or sqlite, got %s", inputSensorParams.Database)
+		}
+
 		if inputSensorParams.ShouldRunPprof {
 			go func() {
 				addr := fmt.Sprintf(":%v", inputSensorParams.PprofPort)
@@ -154,17 +161,10 @@ var SensorCmd = &cobra.Command{
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		db := database.NewDatastore(cmd.Context(), database.DatastoreOptions{
-			ProjectID:                    inputSensorParams.ProjectID,
-			DatabaseID:                   inputSensorParams.DatabaseID,
-			SensorID:                     inputSensorParams.SensorID,
-			MaxConcurrency:               inputSensorParams.MaxDatabaseConcurrency,
-			ShouldWriteBlocks:            inputSensorParams.ShouldWriteBlocks,
-			ShouldWriteBlockEvents:       inputSensorParams.ShouldWriteBlockEvents,
-			ShouldWriteTransactions:      inputSensorParams.ShouldWriteTransactions,
-			ShouldWriteTransactionEvents: inputSensorParams.ShouldWriteTransactionEvents,
-			TTL:                          inputSensorParams.TTL,
-		})
+		db, err := newDatabase(cmd.Context())
+		if err != nil {
+			return err
+		}
 
 		// Fetch the latest block which will be used later when crafting the status
 		// message. This call will only be made once and stored in the head field
@@ -283,6 +283,35 @@ var SensorCmd = &cobra.Command{
 	},
 }
 
+// newDatabase creates the database the sensor writes the blocks, transactions,
+// and their events to.
+func newDatabase(ctx context.Context) (database.Database, error) {
+	if inputSensorParams.Database == "sqlite" {
+		return database.NewSQLite(ctx, database.SQLiteOptions{
+			Path:                         inputSensorParams.SQLitePath,
+			SensorID:                     inputSensorParams.SensorID,
+			MaxConcurrency:               inputSensorParams.MaxDatabaseConcurrency,
+			ShouldWriteBlocks:            inputSensorParams.ShouldWriteBlocks,
+			ShouldWriteBlockEvents:       inputSensorParams.ShouldWriteBlockEvents,
+			ShouldWriteTransactions:      inputSensorParams.ShouldWriteTransactions,
+			ShouldWriteTransactionEvents: inputSensorParams.ShouldWriteTransactionEvents,
+			TTL:                          inputSensorParams.TTL,
+		})
+	}
+
+	return database.NewDatastore(ctx, database.DatastoreOptions{
+		ProjectID:                    inputSensorParams.ProjectID,
+		DatabaseID:                   inputSensorParams.DatabaseID,
+		SensorID:                     inputSensorParams.SensorID,
+		MaxConcurrency:               inputSensorParams.MaxDatabaseConcurrency,
+		ShouldWriteBlocks:            inputSensorParams.ShouldWriteBlocks,
+		ShouldWriteBlockEvents:       inputSensorParams.ShouldWriteBlockEvents,
+		ShouldWriteTransactions:      inputSensorParams.ShouldWriteTransactions,
+		ShouldWriteTransactionEvents: inputSensorParams.ShouldWriteTransactionEvents,
+		TTL:                          inputSensorParams.TTL,
+	}), nil
+}
+
 // getLatestBlock will get the latest block from an RPC provider.
 func getLatestBlock(url string) (*rpctypes.RawBlockResponse, error) {
 	client, err := rpc.Dial(url)
@@ -306,6 +335,10 @@ func init() {
 	if err := SensorCmd.MarkFlagRequired("network-id"); err != nil {
 		log.Error().Err(err).Msg("Failed to mark network-id as required persistent flag")
 	}
+	SensorCmd.Flags().StringVar(&inputSensorParams.Database, "database", "datastore",
+		`Database to write the blocks, transactions, and the times peers announced them
+to (datastore|sqlite)`)
+	SensorCmd.Flags().StringVar(&inputSensorParams.SQLitePath, "sqlite-path", "sensor.db", "SQLite database file when using --database sqlite")
 	SensorCmd.PersistentFlags().StringVarP(&inputSensorParams.ProjectID, "project-id", "p", "", "GCP project ID")
 	SensorCmd.PersistentFlags().StringVarP(&inputSensorParams.DatabaseID, "database-id", "d", "", "Datastore database ID")
 	SensorCmd.Flags().StringVarP(&inputSensorParams.SensorID, "sensor-id", "s", "", "Sensor ID when writing block/tx events")
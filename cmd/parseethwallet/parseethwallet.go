@@ -4,11 +4,15 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"github.com/maticnetwork/polygon-cli/gethkeystore"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/maticnetwork/polygon-cli/gethkeystore"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/secp256k1"
 	"golang.org/x/crypto/sha3"
@@ -24,14 +28,23 @@ var (
 	usage                  string
 	inputFileName          *string
 	inputPassword          *string
+	inputPasswordFile      *string
 	inputRawHexPrivateKey  *string
 	inputKeyStoreDirectory *string
+	inputLightKDF          *bool
+	inputShowPrivateKey    *bool
 )
 
 type outKey struct {
+	File       string `json:",omitempty"`
 	Address    string
 	PublicKey  string
-	PrivateKey string
+	PrivateKey string `json:",omitempty"`
+	Version    int
+	ID         string `json:",omitempty"`
+	Cipher     string
+	KDF        string
+	KDFParams  map[string]any
 }
 
 var ParseETHWalletCmd = &cobra.Command{
@@ -39,53 +52,137 @@ var ParseETHWalletCmd = &cobra.Command{
 	Short: "Extract the private key from an eth wallet.",
 	Long:  usage,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		password, err := getPassword()
+		if err != nil {
+			return err
+		}
+
 		// it would be nice to have a generic reader
 		if *inputRawHexPrivateKey != "" {
 			trimmedHexPrivateKey := strings.TrimPrefix(*inputRawHexPrivateKey, "0x")
-			ks := keystore.NewKeyStore(*inputKeyStoreDirectory, keystore.StandardScryptN, keystore.StandardScryptP)
+			scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+			if *inputLightKDF {
+				scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+			}
+			ks := keystore.NewKeyStore(*inputKeyStoreDirectory, scryptN, scryptP)
 			pk, err := crypto.HexToECDSA(trimmedHexPrivateKey)
 			if err != nil {
 				return err
 			}
-			_, err = ks.ImportECDSA(pk, *inputPassword)
+			account, err := ks.ImportECDSA(pk, password)
 			if err != nil {
 				return err
 			}
+			log.Info().Str("address", account.Address.Hex()).Str("file", account.URL.Path).Msg("Imported the private key into the keystore")
 			return nil
 		}
 
-		rawData, err := getInputData(cmd, args)
-		if err != nil {
-			return err
-		}
-		k := new(gethkeystore.RawKeystoreData)
-		err = json.Unmarshal(rawData, &k)
-		if err != nil {
-			return err
+		if *inputFileName != "" {
+			info, err := os.Stat(*inputFileName)
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				keys, err := parseKeystoreDir(*inputFileName, password)
+				if err != nil {
+					return err
+				}
+				return util.NewOutputWriter(os.Stdout).Write(keys)
+			}
 		}
-		d, err := keystore.DecryptDataV3(k.Crypto, *inputPassword)
+
+		rawData, err := getInputData(cmd, args)
 		if err != nil {
 			return err
 		}
-		ok := toOutputKey(d)
-		outData, err := json.Marshal(ok)
+		ok, err := parseKeystore(rawData, password)
 		if err != nil {
 			return err
 		}
-		fmt.Println(string(outData))
-		return nil
+		return util.NewOutputWriter(os.Stdout).Write(ok)
 	},
 	Args: func(cmd *cobra.Command, args []string) error {
+		if *inputPassword != "" && *inputPasswordFile != "" {
+			return fmt.Errorf("the --password and --password-file flags can't be used together")
+		}
 		return nil
 	},
 }
 
 func init() {
 	flagSet := ParseETHWalletCmd.PersistentFlags()
-	inputFileName = flagSet.String("file", "", "Provide a file with the key information ")
+	inputFileName = flagSet.String("file", "", "Provide a file with the key information, or a directory of key files")
 	inputPassword = flagSet.String("password", "", "An optional password use to unlock the key")
+	inputPasswordFile = flagSet.String("password-file", "", "A file holding the password, used instead of --password")
 	inputRawHexPrivateKey = flagSet.String("hexkey", "", "An optional hexkey that would be use to generate a geth style key")
 	inputKeyStoreDirectory = flagSet.String("keystore", "/tmp/keystore", "The directory where keys would be stored when importing a raw hex")
+	inputLightKDF = flagSet.Bool("light-kdf", false, "Use the faster but weaker scrypt parameters when importing a raw hex")
+	inputShowPrivateKey = flagSet.Bool("show-private-key", true, "Output the decrypted private key")
+}
+
+// getPassword returns the password given by flag or read from the password file. Trailing
+// newlines of the file are ignored.
+func getPassword() (string, error) {
+	if *inputPasswordFile == "" {
+		return *inputPassword, nil
+	}
+	data, err := os.ReadFile(*inputPasswordFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// parseKeystore decrypts the geth keystore JSON and describes the key and its encryption.
+func parseKeystore(rawData []byte, password string) (*outKey, error) {
+	k := new(gethkeystore.RawKeystoreData)
+	err := json.Unmarshal(rawData, &k)
+	if err != nil {
+		return nil, err
+	}
+	d, err := keystore.DecryptDataV3(k.Crypto, password)
+	if err != nil {
+		return nil, err
+	}
+	ok := toOutputKey(d)
+	if !*inputShowPrivateKey {
+		ok.PrivateKey = ""
+	}
+	ok.Version = k.Version
+	ok.ID = k.ID
+	ok.Cipher = k.Crypto.Cipher
+	ok.KDF = k.Crypto.KDF
+	ok.KDFParams = k.Crypto.KDFParams
+	return &ok, nil
+}
+
+// parseKeystoreDir decrypts every key file of the directory. The files which can't be decrypted
+// are reported and skipped.
+func parseKeystoreDir(dir, password string) ([]*outKey, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*outKey, 0, len(entries))
+	for _, e := range entries {
+		// Skip directories and editor backups or dotfiles, like geth does.
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") || strings.HasSuffix(e.Name(), "~") {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		rawData, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		ok, err := parseKeystore(rawData, password)
+		if err != nil {
+			log.Warn().Err(err).Str("file", path).Msg("Unable to decrypt the key file")
+			continue
+		}
+		ok.File = path
+		keys = append(keys, ok)
+	}
+	return keys, nil
 }
 
 func getInputData(cmd *cobra.Command, args []string) ([]byte, error) {
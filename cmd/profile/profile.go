@@ -0,0 +1,122 @@
+package profile
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	polyprofile "github.com/maticnetwork/polygon-cli/profile"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed usage.md
+	usage string
+)
+
+// Entries are the values of the address book.
+type Entries []polyprofile.Entry
+
+// ProfileCmd represents the profile command.
+var ProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage the address book of named endpoints, chains and accounts referenced by @name in the flags.",
+	Long:  usage,
+	Args:  cobra.NoArgs,
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the endpoints, chains and accounts of the address book.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := polyprofile.Load()
+		if err != nil {
+			return err
+		}
+		return util.NewOutputWriter(os.Stdout).Write(Entries(store.Entries()))
+	},
+}
+
+var setCmd = &cobra.Command{
+	Use:   "set <endpoint|chain|account> <name> <value>",
+	Short: "Add a name to the address book, or change its value.",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, err := parseKind(args[0])
+		if err != nil {
+			return err
+		}
+		if kind == polyprofile.Endpoint {
+			if err = util.ValidateUrl(args[2]); err != nil {
+				return err
+			}
+		}
+		store, err := polyprofile.Load()
+		if err != nil {
+			return err
+		}
+		if err = store.Set(kind, args[1], args[2]); err != nil {
+			return err
+		}
+		if err = store.Save(); err != nil {
+			return err
+		}
+		log.Info().Str("kind", string(kind)).Str("name", args[1]).Msg("Saved the address book")
+		return nil
+	},
+}
+
+var removeCmd = &cobra.Command{
+	Use:   "remove <endpoint|chain|account> <name>",
+	Short: "Remove a name from the address book.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, err := parseKind(args[0])
+		if err != nil {
+			return err
+		}
+		store, err := polyprofile.Load()
+		if err != nil {
+			return err
+		}
+		if !store.Remove(kind, args[1]) {
+			return fmt.Errorf("no %s named %s", kind, args[1])
+		}
+		return store.Save()
+	},
+}
+
+func parseKind(s string) (polyprofile.Kind, error) {
+	names := make([]string, len(polyprofile.Kinds))
+	for i, k := range polyprofile.Kinds {
+		if string(k) == s {
+			return k, nil
+		}
+		names[i] = string(k)
+	}
+	return "", fmt.Errorf("unknown kind %s, the kinds are: %s", s, strings.Join(names, ", "))
+}
+
+// WriteText renders the entries as a table.
+func (e Entries) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Kind", "Name", "Value"})
+	for _, entry := range e {
+		t.AppendRow(table.Row{entry.Kind, entry.Name, entry.Value})
+	}
+	t.Render()
+	return nil
+}
+
+func init() {
+	ProfileCmd.AddCommand(listCmd)
+	ProfileCmd.AddCommand(setCmd)
+	ProfileCmd.AddCommand(removeCmd)
+}
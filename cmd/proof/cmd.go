@@ -0,0 +1,122 @@
+package proof
+
+import (
+	_ "embed"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/maticnetwork/polygon-cli/rpcclient"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// invalidProofExitCode is the exit code when a proof doesn't verify, the
+// failures to query the node exiting with the code of their error category.
+const invalidProofExitCode = util.ResultExitCode
+
+type cmdProofParams struct {
+	RpcUrl    *string
+	RPCClient *rpcclient.Flags
+
+	Address   *string
+	Slots     *[]string
+	Block     *string
+	StateRoot *string
+
+	address   common.Address
+	slots     []common.Hash
+	block     string
+	stateRoot *common.Hash
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdProofParams
+)
+
+// ProofCmd represents the proof command.
+var ProofCmd = &cobra.Command{
+	Use:   "proof",
+	Short: "Fetch the merkle proofs of an account and its storage slots and verify them against the state root.",
+	Long:  usage,
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// An invalid proof is a result, not a usage error.
+		cmd.SilenceUsage = true
+		return verify(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdProofParams)
+	flagSet := ProofCmd.Flags()
+
+	p.RpcUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8545", "The RPC endpoint url")
+	p.RPCClient = rpcclient.AddFlags(flagSet)
+
+	p.Address = flagSet.StringP("address", "a", "", "The address of the account to prove")
+	p.Slots = flagSet.StringSliceP("slots", "s", nil, "The storage slots to prove, as numbers or 32 bytes hex")
+	p.Block = flagSet.StringP("block", "b", "latest", "The block of the proofs, as a number or a tag like latest or finalized")
+	p.StateRoot = flagSet.String("state-root", "", "The trusted state root to verify the proofs against instead of the one of the block reported by the RPC")
+
+	params = *p
+}
+
+func checkFlags() error {
+	if err := util.ValidateUrl(*params.RpcUrl); err != nil {
+		return err
+	}
+	if *params.Address == "" {
+		return util.Errorf(util.ValidationError, "the address is required")
+	}
+	if !common.IsHexAddress(*params.Address) {
+		return util.Errorf(util.ValidationError, "invalid address %s", *params.Address)
+	}
+	params.address = common.HexToAddress(*params.Address)
+
+	params.slots = nil
+	for _, s := range *params.Slots {
+		slot, err := parseSlot(s)
+		if err != nil {
+			return err
+		}
+		params.slots = append(params.slots, slot)
+	}
+
+	switch b := *params.Block; b {
+	case "latest", "pending", "earliest", "safe", "finalized":
+		params.block = b
+	default:
+		n, err := strconv.ParseUint(b, 0, 64)
+		if err != nil {
+			return util.Errorf(util.ValidationError, "invalid block %s", b)
+		}
+		params.block = hexutil.EncodeUint64(n)
+	}
+
+	params.stateRoot = nil
+	if root := *params.StateRoot; root != "" {
+		b, err := hexutil.Decode(root)
+		if err != nil || len(b) != common.HashLength {
+			return util.Errorf(util.ValidationError, "invalid state root %s", root)
+		}
+		h := common.BytesToHash(b)
+		params.stateRoot = &h
+	}
+	return nil
+}
+
+// parseSlot parses a slot, given as a decimal or a 0x prefixed hex number.
+func parseSlot(s string) (common.Hash, error) {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok || n.Sign() < 0 || n.BitLen() > 256 {
+		return common.Hash{}, util.Errorf(util.ValidationError, "invalid slot %s", s)
+	}
+	return common.BigToHash(n), nil
+}
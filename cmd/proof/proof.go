@@ -0,0 +1,225 @@
+package proof
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/util"
+)
+
+type (
+	rpcBlock struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Hash      common.Hash    `json:"hash"`
+		StateRoot common.Hash    `json:"stateRoot"`
+	}
+	// accountResult is the result of eth_getProof.
+	accountResult struct {
+		AccountProof []hexutil.Bytes `json:"accountProof"`
+		Balance      *hexutil.Big    `json:"balance"`
+		CodeHash     common.Hash     `json:"codeHash"`
+		Nonce        hexutil.Uint64  `json:"nonce"`
+		StorageHash  common.Hash     `json:"storageHash"`
+		StorageProof []storageResult `json:"storageProof"`
+	}
+	storageResult struct {
+		Value *hexutil.Big    `json:"value"`
+		Proof []hexutil.Bytes `json:"proof"`
+	}
+
+	// VerifiedAccount holds the values of an account read from the proofs,
+	// which only depend on the state root, not on the honesty of the RPC.
+	VerifiedAccount struct {
+		Address     common.Address
+		BlockNumber uint64
+		BlockHash   common.Hash
+		StateRoot   common.Hash
+		// Exists is false when the proof shows the account isn't in the state.
+		Exists      bool
+		Nonce       uint64
+		Balance     *big.Int
+		CodeHash    common.Hash
+		StorageRoot common.Hash
+		Storage     []VerifiedSlot `json:",omitempty"`
+		// Mismatches lists the values claimed by the RPC which differ from the
+		// proofs.
+		Mismatches []string `json:",omitempty"`
+	}
+	VerifiedSlot struct {
+		Slot  common.Hash
+		Value common.Hash
+	}
+)
+
+func verify(ctx context.Context) error {
+	rpc, err := params.RPCClient.Dial(ctx, *params.RpcUrl)
+	if err != nil {
+		return util.NewNetworkError(err)
+	}
+	defer rpc.Close()
+
+	// The proofs are requested at the number of the block so they match its
+	// state root even if the tag moves on in between.
+	var block rpcBlock
+	if err = rpc.CallContext(ctx, &block, "eth_getBlockByNumber", params.block, false); err != nil {
+		return util.NewNetworkError(err)
+	}
+	if block.Hash == (common.Hash{}) {
+		return util.Errorf(util.ValidationError, "block %s not found", *params.Block)
+	}
+	slots := make([]string, len(params.slots))
+	for i, s := range params.slots {
+		slots[i] = s.Hex()
+	}
+	var res accountResult
+	if err = rpc.CallContext(ctx, &res, "eth_getProof", params.address, slots, hexutil.EncodeUint64(uint64(block.Number))); err != nil {
+		return util.NewNetworkError(err)
+	}
+	if len(res.StorageProof) != len(params.slots) {
+		return util.Errorf(util.NetworkError, "the RPC returned %d storage proofs for %d slots", len(res.StorageProof), len(params.slots))
+	}
+
+	root := block.StateRoot
+	if params.stateRoot != nil {
+		root = *params.stateRoot
+	}
+	a, err := verifyProofs(root, &res)
+	if err != nil {
+		return &util.ExitError{Code: invalidProofExitCode, Err: fmt.Errorf("invalid proof: %w", err)}
+	}
+	a.BlockNumber, a.BlockHash = uint64(block.Number), block.Hash
+	if err = util.NewOutputWriter(os.Stdout).Write(a); err != nil {
+		return err
+	}
+	if len(a.Mismatches) > 0 {
+		return &util.ExitError{Code: invalidProofExitCode, Err: fmt.Errorf("the RPC values don't match the proofs: %s", strings.Join(a.Mismatches, "; "))}
+	}
+	return nil
+}
+
+// verifyProofs reads the account and its storage from the proofs, and
+// compares them with the values claimed by the RPC.
+func verifyProofs(root common.Hash, res *accountResult) (*VerifiedAccount, error) {
+	a := &VerifiedAccount{
+		Address:     params.address,
+		StateRoot:   root,
+		Balance:     new(big.Int),
+		CodeHash:    types.EmptyCodeHash,
+		StorageRoot: types.EmptyRootHash,
+	}
+	value, err := trie.VerifyProof(root, crypto.Keccak256(params.address.Bytes()), proofDB(res.AccountProof))
+	if err != nil {
+		return nil, fmt.Errorf("account proof: %w", err)
+	}
+	if value != nil {
+		var acc types.StateAccount
+		if err = rlp.DecodeBytes(value, &acc); err != nil {
+			return nil, fmt.Errorf("unable to decode the account: %w", err)
+		}
+		a.Exists = true
+		a.Nonce, a.Balance, a.CodeHash, a.StorageRoot = acc.Nonce, acc.Balance.ToBig(), common.BytesToHash(acc.CodeHash), acc.Root
+	}
+
+	claimedBalance := new(big.Int)
+	if res.Balance != nil {
+		claimedBalance = res.Balance.ToInt()
+	}
+	if uint64(res.Nonce) != a.Nonce {
+		a.Mismatches = append(a.Mismatches, fmt.Sprintf("nonce %d instead of %d", res.Nonce, a.Nonce))
+	}
+	if claimedBalance.Cmp(a.Balance) != 0 {
+		a.Mismatches = append(a.Mismatches, fmt.Sprintf("balance %s instead of %s", claimedBalance, a.Balance))
+	}
+	// Some clients return zero hashes for the accounts which don't exist.
+	if res.CodeHash != a.CodeHash && (a.Exists || res.CodeHash != (common.Hash{})) {
+		a.Mismatches = append(a.Mismatches, fmt.Sprintf("code hash %v instead of %v", res.CodeHash, a.CodeHash))
+	}
+	if res.StorageHash != a.StorageRoot && (a.Exists || res.StorageHash != (common.Hash{})) {
+		a.Mismatches = append(a.Mismatches, fmt.Sprintf("storage hash %v instead of %v", res.StorageHash, a.StorageRoot))
+	}
+
+	for i, slot := range params.slots {
+		var v common.Hash
+		// An empty storage trie has no node to prove the absence of the slot.
+		if a.StorageRoot != types.EmptyRootHash {
+			value, err := trie.VerifyProof(a.StorageRoot, crypto.Keccak256(slot.Bytes()), proofDB(res.StorageProof[i].Proof))
+			if err != nil {
+				return nil, fmt.Errorf("storage proof of slot %v: %w", slot, err)
+			}
+			if value != nil {
+				_, content, _, err := rlp.Split(value)
+				if err != nil {
+					return nil, fmt.Errorf("unable to decode the value of slot %v: %w", slot, err)
+				}
+				v = common.BytesToHash(content)
+			}
+		}
+		a.Storage = append(a.Storage, VerifiedSlot{Slot: slot, Value: v})
+
+		claimed := new(big.Int)
+		if res.StorageProof[i].Value != nil {
+			claimed = res.StorageProof[i].Value.ToInt()
+		}
+		if claimed.Cmp(v.Big()) != 0 {
+			a.Mismatches = append(a.Mismatches, fmt.Sprintf("slot %v value %#x instead of %v", slot, claimed, v))
+		}
+	}
+	return a, nil
+}
+
+// proofDB returns the nodes of a proof keyed by their hash.
+func proofDB(proof []hexutil.Bytes) *memorydb.Database {
+	db := memorydb.New()
+	for _, node := range proof {
+		// The writes to a memory database don't fail.
+		_ = db.Put(crypto.Keccak256(node), node)
+	}
+	return db
+}
+
+// WriteText renders the verified values as tables, followed by the
+// mismatches.
+func (a *VerifiedAccount) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendRows([]table.Row{
+		{"Address", a.Address},
+		{"Block", fmt.Sprintf("%d (%v)", a.BlockNumber, a.BlockHash)},
+		{"State Root", a.StateRoot},
+		{"Exists", a.Exists},
+		{"Nonce", a.Nonce},
+		{"Balance", a.Balance},
+		{"Code Hash", a.CodeHash},
+		{"Storage Root", a.StorageRoot},
+	})
+	t.Render()
+
+	if len(a.Storage) > 0 {
+		t = table.NewWriter()
+		t.SetOutputMirror(w)
+		t.AppendHeader(table.Row{"Slot", "Value"})
+		for _, s := range a.Storage {
+			t.AppendRow(table.Row{s.Slot, s.Value})
+		}
+		t.Render()
+	}
+
+	for _, m := range a.Mismatches {
+		if _, err := fmt.Fprintln(w, "Mismatch:", m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
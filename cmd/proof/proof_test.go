@@ -0,0 +1,116 @@
+package proof
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/maticnetwork/polygon-cli/util"
+)
+
+// proofFixture holds the eth_getProof results of a state with three
+// accounts, the first one having code and the slot 0 set to 42.
+type proofFixture struct {
+	Block  rpcBlock                         `json:"block"`
+	Proofs map[common.Address]accountResult `json:"proofs"`
+}
+
+// proofService answers eth_getBlockByNumber and eth_getProof with the
+// fixture, returning the result being tested as the proof.
+type proofService struct {
+	block  rpcBlock
+	result *accountResult
+}
+
+func (s *proofService) GetBlockByNumber(tag string, full bool) rpcBlock {
+	return s.block
+}
+
+func (s *proofService) GetProof(address common.Address, slots []string, block string) *accountResult {
+	return s.result
+}
+
+func TestVerifyProofs(t *testing.T) {
+	data, err := os.ReadFile("testdata/getproof.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture proofFixture
+	if err = json.Unmarshal(data, &fixture); err != nil {
+		t.Fatal(err)
+	}
+
+	service := &proofService{block: fixture.Block}
+	srv := ethrpc.NewServer()
+	if err = srv.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Stop()
+	httpSrv := httptest.NewServer(srv)
+	defer httpSrv.Close()
+	*params.RpcUrl = httpSrv.URL
+
+	account := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	absent := common.HexToAddress("0x4000000000000000000000000000000000000004")
+	for name, tc := range map[string]struct {
+		address    common.Address
+		slots      []string
+		tamper     func(*accountResult)
+		exists     bool
+		balance    int64
+		slot       int64
+		mismatches int
+		exitCode   int
+	}{
+		"valid account": {address: account, slots: []string{"0"}, exists: true, balance: 1e18, slot: 42},
+		"tampered balance": {
+			address: account,
+			slots:   []string{"0"},
+			tamper: func(res *accountResult) {
+				res.Balance = (*hexutil.Big)(big.NewInt(2e18))
+			},
+			exists:     true,
+			balance:    1e18,
+			slot:       42,
+			mismatches: 1,
+			exitCode:   invalidProofExitCode,
+		},
+		"absent account": {address: absent},
+	} {
+		res := fixture.Proofs[tc.address]
+		if tc.tamper != nil {
+			tc.tamper(&res)
+		}
+		*params.Address = tc.address.Hex()
+		*params.Slots = tc.slots
+		if err = checkFlags(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		a, err := verifyProofs(fixture.Block.StateRoot, &res)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if a.Exists != tc.exists || a.Balance.Cmp(big.NewInt(tc.balance)) != 0 || len(a.Mismatches) != tc.mismatches {
+			t.Errorf("%s: unexpected account %+v", name, a)
+		}
+		if len(tc.slots) > 0 && (len(a.Storage) != 1 || a.Storage[0].Value.Big().Int64() != tc.slot) {
+			t.Errorf("%s: unexpected storage %+v", name, a.Storage)
+		}
+
+		service.result = &res
+		exitCode := 0
+		if err = verify(context.Background()); err != nil {
+			_, exitCode = util.ClassifyError(err)
+		}
+		if exitCode != tc.exitCode {
+			t.Errorf("%s: expected the exit code %d, got %d (%v)", name, tc.exitCode, exitCode, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package publish
+
+import (
+	_ "embed"
+	"time"
+
+	"github.com/maticnetwork/polygon-cli/rpcclient"
+	"github.com/maticnetwork/polygon-cli/signer"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+type cmdPublishParams struct {
+	RpcUrls   *[]string
+	WatchUrl  *string
+	RPCClient *rpcclient.Flags
+	Signer    *signer.Flags
+
+	File         *string
+	Wait         *bool
+	Timeout      *time.Duration
+	PollInterval *time.Duration
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdPublishParams
+)
+
+// PublishCmd represents the publish command.
+var PublishCmd = &cobra.Command{
+	Use:   "publish [raw transactions...]",
+	Short: "Broadcast signed raw transactions to several RPC endpoints concurrently.",
+	Long:  usage,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		txs, err := readTransactions(args)
+		if err != nil {
+			return err
+		}
+		// A rejected transaction is a result, not a usage error.
+		cmd.SilenceUsage = true
+		return publish(cmd.Context(), txs)
+	},
+}
+
+func init() {
+	p := new(cmdPublishParams)
+	flagSet := PublishCmd.Flags()
+
+	p.RpcUrls = flagSet.StringSliceP("rpc-url", "r", []string{"http://localhost:8545"}, "The RPC endpoint urls the transactions are sent to, comma separated or repeated")
+	p.WatchUrl = flagSet.String("watch-url", "", "The RPC endpoint url polled for the transactions to appear in its txpool and be included (default: the first --rpc-url)")
+	p.RPCClient = rpcclient.AddFlags(flagSet)
+	p.Signer = signer.AddFlags(flagSet)
+
+	p.File = flagSet.StringP("file", "f", "", "Path to a file containing one hex encoded raw transaction per line, - for stdin")
+	p.Wait = flagSet.Bool("wait", true, "Wait for the transactions to be included in a block")
+	p.Timeout = flagSet.Duration("timeout", 2*time.Minute, "The maximum time waiting for the transactions to be seen and included")
+	p.PollInterval = flagSet.Duration("poll-interval", 200*time.Millisecond, "The interval between two polls of the watch endpoint")
+
+	params = *p
+}
+
+func checkFlags() error {
+	if len(*params.RpcUrls) == 0 {
+		return util.Errorf(util.ValidationError, "at least one RPC endpoint url is required")
+	}
+	for _, url := range *params.RpcUrls {
+		if err := util.ValidateUrl(url); err != nil {
+			return err
+		}
+	}
+	if *params.WatchUrl == "" {
+		*params.WatchUrl = (*params.RpcUrls)[0]
+	}
+	if err := util.ValidateUrl(*params.WatchUrl); err != nil {
+		return err
+	}
+	if *params.Timeout <= 0 {
+		return util.Errorf(util.ValidationError, "the timeout must be positive")
+	}
+	if *params.PollInterval <= 0 {
+		return util.Errorf(util.ValidationError, "the poll interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,353 @@
+package publish
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/signer"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	// transaction is a signed raw transaction, or an unsigned one without raw
+	// encoding until it is signed.
+	transaction struct {
+		raw []byte
+		tx  *types.Transaction
+	}
+
+	// PublishResult is the outcome of the broadcast of every transaction.
+	PublishResult struct {
+		Transactions []*TxResult
+	}
+	// TxResult is the outcome of the broadcast of a transaction. The durations
+	// are measured from the moment the transaction was sent.
+	TxResult struct {
+		Hash      common.Hash
+		Endpoints []EndpointResult
+		// SeenAfter is the time until the transaction was first seen by the
+		// watch endpoint, either pending or included.
+		SeenAfter     time.Duration `json:",omitempty"`
+		BlockNumber   uint64        `json:",omitempty"`
+		IncludedAfter time.Duration `json:",omitempty"`
+		// Status is the status of the receipt, 1 for success and 0 for a
+		// reverted transaction. It is only set once the transaction is included.
+		Status *uint64 `json:",omitempty"`
+		Error  string  `json:",omitempty"`
+
+		sentAt time.Time
+	}
+	// EndpointResult is the response of an endpoint to eth_sendRawTransaction.
+	EndpointResult struct {
+		Url      string
+		Accepted bool
+		Latency  time.Duration
+		Error    string `json:",omitempty"`
+	}
+)
+
+// readTransactions decodes the raw transactions of the arguments, or of the
+// file when there are none. A JSON object is an unsigned transaction, in the
+// format of `polycli signer sign`.
+func readTransactions(args []string) ([]transaction, error) {
+	lines := args
+	if len(lines) == 0 {
+		r := io.Reader(os.Stdin)
+		if *params.File != "" && *params.File != "-" {
+			f, err := os.Open(*params.File)
+			if err != nil {
+				return nil, util.NewError(util.ValidationError, err)
+			}
+			defer f.Close()
+			r = f
+		}
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, 1<<24)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	var txs []transaction
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "{") {
+			var args apitypes.SendTxArgs
+			if err := json.Unmarshal([]byte(line), &args); err != nil {
+				return nil, util.Errorf(util.ValidationError, "unable to parse the unsigned transaction %d: %w", i, err)
+			}
+			txs = append(txs, transaction{tx: args.ToTransaction()})
+			continue
+		}
+		raw, err := hexutil.Decode(line)
+		if err != nil {
+			return nil, util.Errorf(util.ValidationError, "unable to decode the transaction %d: %w", i, err)
+		}
+		tx := new(types.Transaction)
+		if err = tx.UnmarshalBinary(raw); err != nil {
+			return nil, util.Errorf(util.ValidationError, "unable to parse the transaction %d: %w", i, err)
+		}
+		txs = append(txs, transaction{raw: raw, tx: tx})
+	}
+	if len(txs) == 0 {
+		return nil, util.Errorf(util.ValidationError, "no transaction to publish")
+	}
+	return txs, nil
+}
+
+func publish(ctx context.Context, txs []transaction) error {
+	clients := make([]*ethrpc.Client, len(*params.RpcUrls))
+	for i, url := range *params.RpcUrls {
+		c, err := params.RPCClient.Dial(ctx, url)
+		if err != nil {
+			return util.NewNetworkError(fmt.Errorf("unable to dial %s: %w", url, err))
+		}
+		defer c.Close()
+		clients[i] = c
+	}
+	watch, err := params.RPCClient.Dial(ctx, *params.WatchUrl)
+	if err != nil {
+		return util.NewNetworkError(fmt.Errorf("unable to dial %s: %w", *params.WatchUrl, err))
+	}
+	defer watch.Close()
+	if err = signTransactions(ctx, ethclient.NewClient(watch), txs); err != nil {
+		return err
+	}
+
+	// The transactions are sent one after the other, so the nonces of an
+	// account reach the endpoints in order.
+	result := &PublishResult{}
+	for _, tx := range txs {
+		r := broadcast(ctx, clients, tx)
+		result.Transactions = append(result.Transactions, r)
+		log.Debug().Stringer("hash", r.Hash).Int("accepted", r.acceptedCount()).Msg("Transaction sent")
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, *params.Timeout)
+	defer cancel()
+	ec := ethclient.NewClient(watch)
+	var wg sync.WaitGroup
+	for _, r := range result.Transactions {
+		if r.acceptedCount() == 0 {
+			r.Error = "rejected by every endpoint"
+			continue
+		}
+		wg.Add(1)
+		go func(r *TxResult) {
+			defer wg.Done()
+			track(watchCtx, ec, r)
+		}(r)
+	}
+	wg.Wait()
+
+	if err = util.NewOutputWriter(os.Stdout).Write(result); err != nil {
+		return err
+	}
+	failed, reverted := 0, 0
+	for _, r := range result.Transactions {
+		if r.Status != nil && *r.Status != types.ReceiptStatusSuccessful {
+			reverted++
+		} else if r.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return util.Errorf(util.NetworkError, "%d of %d transactions failed to be published", failed, len(result.Transactions))
+	}
+	if reverted > 0 {
+		return util.Errorf(util.ResultError, "%d of %d transactions reverted", reverted, len(result.Transactions))
+	}
+	return nil
+}
+
+// signTransactions signs the unsigned transactions with the signer selected by
+// the flags, for the chain of the watch endpoint.
+func signTransactions(ctx context.Context, ec *ethclient.Client, txs []transaction) error {
+	var s signer.Signer
+	var chainID *big.Int
+	for i := range txs {
+		if txs[i].raw != nil {
+			continue
+		}
+		if s == nil {
+			if !params.Signer.IsSet() {
+				return util.Errorf(util.ValidationError, "the unsigned transactions need a signer: --keystore, --kms, --ledger or --signer-url")
+			}
+			var err error
+			if s, err = params.Signer.New(ctx, ""); err != nil {
+				return util.NewError(util.AuthError, err)
+			}
+			if chainID, err = ec.ChainID(ctx); err != nil {
+				return util.NewNetworkError(fmt.Errorf("unable to get the chain id: %w", err))
+			}
+		}
+		signed, err := s.SignTx(ctx, txs[i].tx, types.LatestSignerForChainID(chainID))
+		if err != nil {
+			return util.Errorf(util.AuthError, "unable to sign the transaction %d: %w", i, err)
+		}
+		if txs[i].raw, err = signed.MarshalBinary(); err != nil {
+			return err
+		}
+		txs[i].tx = signed
+		log.Debug().Stringer("hash", signed.Hash()).Stringer("from", s.Address()).Msg("Signed transaction")
+	}
+	return nil
+}
+
+// broadcast sends the transaction to every endpoint concurrently.
+func broadcast(ctx context.Context, clients []*ethrpc.Client, tx transaction) *TxResult {
+	r := &TxResult{
+		Hash:      tx.tx.Hash(),
+		Endpoints: make([]EndpointResult, len(clients)),
+		sentAt:    time.Now(),
+	}
+	encoded := hexutil.Encode(tx.raw)
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c *ethrpc.Client) {
+			defer wg.Done()
+			e := EndpointResult{Url: (*params.RpcUrls)[i]}
+			var hash common.Hash
+			err := c.CallContext(ctx, &hash, "eth_sendRawTransaction", encoded)
+			e.Latency = time.Since(r.sentAt)
+			switch {
+			case err == nil:
+				e.Accepted = true
+			case isKnown(err):
+				// The transaction propagated to the endpoint before it was sent.
+				e.Accepted = true
+				e.Error = err.Error()
+			default:
+				e.Error = err.Error()
+			}
+			r.Endpoints[i] = e
+		}(i, c)
+	}
+	wg.Wait()
+	return r
+}
+
+func isKnown(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction")
+}
+
+// track polls the watch endpoint until the transaction is seen, then until
+// it's included when waiting for the inclusion.
+func track(ctx context.Context, ec *ethclient.Client, r *TxResult) {
+	ticker := time.NewTicker(*params.PollInterval)
+	defer ticker.Stop()
+	poll := func(done func() (bool, error)) error {
+		for {
+			ok, err := done()
+			if err != nil && !errors.Is(err, ethereum.NotFound) {
+				log.Debug().Err(err).Stringer("hash", r.Hash).Msg("Unable to poll the watch endpoint")
+			}
+			if ok {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+	}
+
+	err := poll(func() (bool, error) {
+		_, _, err := ec.TransactionByHash(ctx, r.Hash)
+		if err != nil {
+			return false, err
+		}
+		r.SeenAfter = time.Since(r.sentAt)
+		return true, nil
+	})
+	if err != nil {
+		r.Error = "not seen by the watch endpoint before the timeout"
+		return
+	}
+	if !*params.Wait {
+		return
+	}
+
+	err = poll(func() (bool, error) {
+		receipt, err := ec.TransactionReceipt(ctx, r.Hash)
+		if err != nil {
+			return false, err
+		}
+		r.IncludedAfter = time.Since(r.sentAt)
+		r.BlockNumber = receipt.BlockNumber.Uint64()
+		r.Status = &receipt.Status
+		return true, nil
+	})
+	if err != nil {
+		r.Error = "not included before the timeout"
+	} else if *r.Status != types.ReceiptStatusSuccessful {
+		r.Error = "reverted"
+	}
+}
+
+func (r *TxResult) acceptedCount() int {
+	n := 0
+	for _, e := range r.Endpoints {
+		if e.Accepted {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteText renders the responses of the endpoints, then the propagation and
+// inclusion of the transactions.
+func (p *PublishResult) WriteText(w io.Writer) error {
+	endpoints := table.NewWriter()
+	endpoints.SetOutputMirror(w)
+	endpoints.AppendHeader(table.Row{"Transaction", "Endpoint", "Accepted", "Latency", "Error"})
+	for _, r := range p.Transactions {
+		for _, e := range r.Endpoints {
+			endpoints.AppendRow(table.Row{r.Hash, e.Url, e.Accepted, e.Latency.Round(time.Millisecond), e.Error})
+		}
+	}
+	endpoints.Render()
+
+	txs := table.NewWriter()
+	txs.SetOutputMirror(w)
+	txs.AppendHeader(table.Row{"Transaction", "Accepted", "Seen After", "Block", "Included After", "Status", "Error"})
+	for _, r := range p.Transactions {
+		row := table.Row{r.Hash, fmt.Sprintf("%d/%d", r.acceptedCount(), len(r.Endpoints)), "", "", "", "", r.Error}
+		if r.SeenAfter > 0 {
+			row[2] = r.SeenAfter.Round(time.Millisecond)
+		}
+		if r.Status != nil {
+			row[3], row[4], row[5] = r.BlockNumber, r.IncludedAfter.Round(time.Millisecond), *r.Status
+		}
+		txs.AppendRow(row)
+	}
+	txs.Render()
+	return nil
+}
@@ -0,0 +1,94 @@
+package retest
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+type cmdRetestParams struct {
+	RpcUrl *string
+	File   *string
+	Method *string
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdRetestParams
+
+	// txHashes are the transactions to replay, from the arguments and the file.
+	txHashes []ethcommon.Hash
+)
+
+// RetestCmd represents the retest command.
+var RetestCmd = &cobra.Command{
+	Use:   "retest [tx hash...]",
+	Short: "Replay transactions against the state of their parent block and compare with the on-chain result.",
+	Long:  usage,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags(args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRetest(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdRetestParams)
+	flagSet := RetestCmd.Flags()
+
+	p.RpcUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8545", "The RPC endpoint url of an archive node")
+	p.File = flagSet.StringP("file", "f", "", "A file with a transaction hash per line to replay along with the arguments")
+	p.Method = flagSet.String("method", "debug_traceCall", "The method used to replay the transactions: debug_traceCall or eth_call, which only compares the status and return data")
+
+	params = *p
+}
+
+func checkFlags(args []string) error {
+	if err := util.ValidateUrl(*params.RpcUrl); err != nil {
+		return err
+	}
+	if *params.Method != "debug_traceCall" && *params.Method != "eth_call" {
+		return fmt.Errorf("the method %s is not supported", *params.Method)
+	}
+
+	values := append([]string{}, args...)
+	if *params.File != "" {
+		f, err := os.Open(*params.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+				values = append(values, line)
+			}
+		}
+		if err = scanner.Err(); err != nil {
+			return err
+		}
+	}
+	if len(values) == 0 {
+		return errors.New("at least one transaction hash is required")
+	}
+
+	txHashes = make([]ethcommon.Hash, 0, len(values))
+	for _, v := range values {
+		b, err := hexutil.Decode(v)
+		if err != nil || len(b) != ethcommon.HashLength {
+			return fmt.Errorf("%s is not a transaction hash", v)
+		}
+		txHashes = append(txHashes, ethcommon.BytesToHash(b))
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package retest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	rpcTransaction struct {
+		BlockNumber          *hexutil.Big       `json:"blockNumber"`
+		TransactionIndex     hexutil.Uint64     `json:"transactionIndex"`
+		Type                 hexutil.Uint64     `json:"type"`
+		From                 ethcommon.Address  `json:"from"`
+		To                   *ethcommon.Address `json:"to"`
+		Gas                  hexutil.Uint64     `json:"gas"`
+		GasPrice             *hexutil.Big       `json:"gasPrice"`
+		MaxFeePerGas         *hexutil.Big       `json:"maxFeePerGas"`
+		MaxPriorityFeePerGas *hexutil.Big       `json:"maxPriorityFeePerGas"`
+		MaxFeePerBlobGas     *hexutil.Big       `json:"maxFeePerBlobGas"`
+		BlobVersionedHashes  []ethcommon.Hash   `json:"blobVersionedHashes"`
+		Value                *hexutil.Big       `json:"value"`
+		Input                hexutil.Bytes      `json:"input"`
+		AccessList           *types.AccessList  `json:"accessList"`
+	}
+	// callFrame is a frame of the callTracer output.
+	callFrame struct {
+		GasUsed hexutil.Uint64 `json:"gasUsed"`
+		Output  hexutil.Bytes  `json:"output"`
+		Error   string         `json:"error"`
+		Calls   []callFrame    `json:"calls"`
+		Logs    []callLog      `json:"logs"`
+	}
+	callLog struct {
+		Address  ethcommon.Address `json:"address"`
+		Topics   []ethcommon.Hash  `json:"topics"`
+		Data     hexutil.Bytes     `json:"data"`
+		Position hexutil.Uint      `json:"position"`
+	}
+
+	// Execution is the outcome of a transaction. The fields which aren't known are nil.
+	Execution struct {
+		Success    bool
+		GasUsed    *uint64        `json:",omitempty"`
+		ReturnData *hexutil.Bytes `json:",omitempty"`
+		Logs       []*types.Log   `json:",omitempty"`
+		Error      string         `json:",omitempty"`
+	}
+	ReplayResult struct {
+		Hash        ethcommon.Hash
+		BlockNumber uint64
+		// Index is the position of the transaction in the block. The transactions before it in the block aren't
+		// applied to the state of the replay.
+		Index       uint64
+		OnChain     Execution
+		Replay      Execution
+		Diverged    bool
+		Divergences []string `json:",omitempty"`
+	}
+	ReplayResults []ReplayResult
+)
+
+func runRetest(ctx context.Context) error {
+	rpc, err := ethrpc.DialContext(ctx, *params.RpcUrl)
+	if err != nil {
+		return err
+	}
+	defer rpc.Close()
+	c := ethclient.NewClient(rpc)
+
+	results := make(ReplayResults, 0, len(txHashes))
+	diverged := 0
+	for _, hash := range txHashes {
+		r, err := replay(ctx, rpc, c, hash)
+		if err != nil {
+			return fmt.Errorf("unable to replay %s: %w", hash, err)
+		}
+		if r.Diverged {
+			diverged++
+			log.Warn().Str("hash", hash.Hex()).Strs("divergences", r.Divergences).Msg("The replay diverges from the on-chain result")
+		}
+		results = append(results, *r)
+	}
+	log.Info().Int("transactions", len(results)).Int("diverged", diverged).Msg("Replay done")
+	return util.NewOutputWriter(os.Stdout).Write(results)
+}
+
+// replay executes the transaction on the state of its parent block, in the context of its block, and compares the
+// outcome with its receipt.
+func replay(ctx context.Context, rpc *ethrpc.Client, c *ethclient.Client, hash ethcommon.Hash) (*ReplayResult, error) {
+	var tx *rpcTransaction
+	if err := rpc.CallContext(ctx, &tx, "eth_getTransactionByHash", hash); err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, errors.New("the transaction was not found")
+	}
+	if tx.BlockNumber == nil {
+		return nil, errors.New("the transaction is pending")
+	}
+	receipt, err := c.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	header, err := c.HeaderByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ReplayResult{Hash: hash, BlockNumber: receipt.BlockNumber.Uint64(), Index: uint64(tx.TransactionIndex)}
+	r.OnChain = Execution{Success: receipt.Status == types.ReceiptStatusSuccessful, GasUsed: &receipt.GasUsed, Logs: receipt.Logs}
+	if r.OnChain.Logs == nil {
+		r.OnChain.Logs = []*types.Log{}
+	}
+	// The return data isn't part of the receipt, so it's only known when the node can trace the transaction.
+	var onChainTrace callFrame
+	if err = rpc.CallContext(ctx, &onChainTrace, "debug_traceTransaction", hash, map[string]any{"tracer": "callTracer", "tracerConfig": map[string]any{"onlyTopCall": true}}); err != nil {
+		log.Debug().Err(err).Str("hash", hash.Hex()).Msg("Unable to trace the transaction, the return data won't be compared")
+	} else {
+		r.OnChain.ReturnData = &onChainTrace.Output
+	}
+
+	parent := hexutil.EncodeBig(new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	args := callArgs(tx)
+	overrides := blockOverrides(header)
+	if *params.Method == "eth_call" {
+		var output hexutil.Bytes
+		err = rpc.CallContext(ctx, &output, "eth_call", args, parent, nil, overrides)
+		r.Replay = Execution{Success: err == nil, ReturnData: &output}
+		if err != nil {
+			var dataErr ethrpc.DataError
+			if !errors.As(err, &dataErr) {
+				return nil, err
+			}
+			r.Replay.Error = err.Error()
+			if data, ok := dataErr.ErrorData().(string); ok {
+				output, _ = hexutil.Decode(data)
+			}
+		}
+	} else {
+		var trace callFrame
+		config := map[string]any{"tracer": "callTracer", "tracerConfig": map[string]any{"withLog": true}, "blockOverrides": overrides}
+		if err = rpc.CallContext(ctx, &trace, "debug_traceCall", args, parent, config); err != nil {
+			return nil, err
+		}
+		gasUsed := uint64(trace.GasUsed)
+		r.Replay = Execution{Success: trace.Error == "", GasUsed: &gasUsed, ReturnData: &trace.Output, Error: trace.Error, Logs: flattenLogs(&trace, nil)}
+	}
+
+	r.Divergences = compare(r.OnChain, r.Replay)
+	r.Diverged = len(r.Divergences) > 0
+	return r, nil
+}
+
+// callArgs returns the call arguments matching the transaction.
+func callArgs(tx *rpcTransaction) map[string]any {
+	args := map[string]any{
+		"from":  tx.From,
+		"to":    tx.To,
+		"gas":   tx.Gas,
+		"value": tx.Value,
+		"input": tx.Input,
+	}
+	if tx.AccessList != nil {
+		args["accessList"] = tx.AccessList
+	}
+	switch tx.Type {
+	case types.LegacyTxType, types.AccessListTxType:
+		args["gasPrice"] = tx.GasPrice
+	default:
+		args["maxFeePerGas"] = tx.MaxFeePerGas
+		args["maxPriorityFeePerGas"] = tx.MaxPriorityFeePerGas
+	}
+	if tx.Type == types.BlobTxType {
+		args["maxFeePerBlobGas"] = tx.MaxFeePerBlobGas
+		args["blobVersionedHashes"] = tx.BlobVersionedHashes
+	}
+	return args
+}
+
+// blockOverrides sets the block context of the replay to the block of the transaction, since the call otherwise
+// runs in a block following the parent block.
+func blockOverrides(h *types.Header) map[string]any {
+	overrides := map[string]any{
+		"number":     (*hexutil.Big)(h.Number),
+		"difficulty": (*hexutil.Big)(h.Difficulty),
+		"time":       hexutil.Uint64(h.Time),
+		"gasLimit":   hexutil.Uint64(h.GasLimit),
+		"coinbase":   h.Coinbase,
+	}
+	// The random override turns on the merge rules, so it only applies to proof of stake blocks.
+	if h.Difficulty.Sign() == 0 {
+		overrides["random"] = h.MixDigest
+	}
+	if h.BaseFee != nil {
+		overrides["baseFee"] = (*hexutil.Big)(h.BaseFee)
+	}
+	return overrides
+}
+
+// flattenLogs returns the logs of the frame and its sub calls in the order they were emitted. The position of a
+// log is the number of sub calls made before it.
+func flattenLogs(frame *callFrame, logs []*types.Log) []*types.Log {
+	if logs == nil {
+		logs = []*types.Log{}
+	}
+	if frame.Error != "" {
+		// The logs of reverted frames are discarded.
+		return logs
+	}
+	next := 0
+	emit := func(position int) {
+		for ; next < len(frame.Logs) && int(frame.Logs[next].Position) <= position; next++ {
+			l := frame.Logs[next]
+			logs = append(logs, &types.Log{Address: l.Address, Topics: l.Topics, Data: l.Data})
+		}
+	}
+	for i := range frame.Calls {
+		emit(i)
+		logs = flattenLogs(&frame.Calls[i], logs)
+	}
+	emit(len(frame.Calls))
+	return logs
+}
+
+// compare lists the differences between the on-chain and replayed executions, ignoring the unknown fields.
+func compare(onChain, replay Execution) []string {
+	var divergences []string
+	if onChain.Success != replay.Success {
+		divergences = append(divergences, fmt.Sprintf("status: on-chain success %t, replay success %t", onChain.Success, replay.Success))
+	}
+	if onChain.GasUsed != nil && replay.GasUsed != nil && *onChain.GasUsed != *replay.GasUsed {
+		divergences = append(divergences, fmt.Sprintf("gas used: on-chain %d, replay %d", *onChain.GasUsed, *replay.GasUsed))
+	}
+	if onChain.ReturnData != nil && replay.ReturnData != nil && !bytes.Equal(*onChain.ReturnData, *replay.ReturnData) {
+		divergences = append(divergences, fmt.Sprintf("return data: on-chain %s, replay %s", onChain.ReturnData, replay.ReturnData))
+	}
+	if onChain.Logs != nil && replay.Logs != nil {
+		if len(onChain.Logs) != len(replay.Logs) {
+			divergences = append(divergences, fmt.Sprintf("logs: on-chain %d, replay %d", len(onChain.Logs), len(replay.Logs)))
+		} else {
+			for i := range onChain.Logs {
+				if !equalLogs(onChain.Logs[i], replay.Logs[i]) {
+					divergences = append(divergences, fmt.Sprintf("log %d differs", i))
+				}
+			}
+		}
+	}
+	return divergences
+}
+
+func equalLogs(a, b *types.Log) bool {
+	if a.Address != b.Address || len(a.Topics) != len(b.Topics) || !bytes.Equal(a.Data, b.Data) {
+		return false
+	}
+	for i := range a.Topics {
+		if a.Topics[i] != b.Topics[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteText renders the results as a table.
+func (rs ReplayResults) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Transaction", "Block", "Index", "Success", "Gas Used", "Logs", "Result"})
+	for _, r := range rs {
+		result := "match"
+		if r.Diverged {
+			result = "DIVERGED\n" + strings.Join(r.Divergences, "\n")
+		}
+		t.AppendRow(table.Row{
+			r.Hash.Hex(), r.BlockNumber, r.Index,
+			fmt.Sprintf("%t / %t", r.OnChain.Success, r.Replay.Success),
+			fmt.Sprintf("%s / %s", formatGas(r.OnChain.GasUsed), formatGas(r.Replay.GasUsed)),
+			fmt.Sprintf("%s / %s", formatLogs(r.OnChain.Logs), formatLogs(r.Replay.Logs)),
+			result,
+		})
+	}
+	t.SetCaption("The values are given as on-chain / replay.")
+	t.Render()
+	return nil
+}
+
+func formatGas(gas *uint64) string {
+	if gas == nil {
+		return "-"
+	}
+	return fmt.Sprint(*gas)
+}
+
+func formatLogs(logs []*types.Log) string {
+	if logs == nil {
+		return "-"
+	}
+	return fmt.Sprint(len(logs))
+}
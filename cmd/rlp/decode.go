@@ -0,0 +1,301 @@
+package rlp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethrlp "github.com/ethereum/go-ethereum/rlp"
+	"github.com/spf13/cobra"
+
+	"github.com/maticnetwork/polygon-cli/util"
+)
+
+type (
+	// Decoded is the structure of RLP data. The items are nested lists of
+	// hex encoded strings, which the encode command turns back into RLP.
+	Decoded struct {
+		// Prefix is the type byte of a typed transaction or receipt, which
+		// comes before the RLP payload.
+		Prefix *hexutil.Uint64 `json:",omitempty"`
+		Items  any
+		// Type is the type the data was decoded into, if any.
+		Type  string `json:",omitempty"`
+		Value any    `json:",omitempty"`
+	}
+
+	// decoder decodes the data into one of the common types, failing when
+	// the data doesn't fit the type.
+	decoder func(data []byte) (any, error)
+
+	account struct {
+		Nonce    uint64
+		Balance  *big.Int
+		Root     common.Hash
+		CodeHash common.Hash
+	}
+	block struct {
+		Header       *types.Header
+		Transactions []*types.Transaction
+		Uncles       []*types.Header
+		Withdrawals  []*types.Withdrawal `json:",omitempty"`
+	}
+)
+
+// decoders are tried in order when the type is detected, the stricter
+// first.
+var decoders = []struct {
+	name   string
+	decode decoder
+}{
+	{"block", decodeBlock},
+	{"header", decodeHeader},
+	{"transaction", decodeTransaction},
+	{"receipt", decodeReceipt},
+	{"account", decodeAccount},
+}
+
+var decodeType *string
+
+var DecodeCmd = &cobra.Command{
+	Use:     "decode [hex]",
+	Aliases: []string{"unwrap"},
+	Short:   "Decode hex encoded RLP into a nested structure, detecting the common types.",
+	Long: `Decode hex encoded RLP from the argument, --file or stdin into nested lists of strings. With --type auto,
+the data is also decoded as the first common type it fits: a block, a header, a transaction, a receipt, or an account.`,
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		switch *decodeType {
+		case "auto", "raw":
+			return nil
+		}
+		for _, d := range decoders {
+			if d.name == *decodeType {
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown type %s", *decodeType)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		input, err := getInputData(args)
+		if err != nil {
+			return err
+		}
+		data, err := hexutil.Decode(normalizeHex(string(input)))
+		if err != nil {
+			return fmt.Errorf("unable to decode the hex input: %w", err)
+		}
+		d, err := decode(data, *decodeType)
+		if err != nil {
+			return err
+		}
+		return util.NewOutputWriter(os.Stdout).Write(d)
+	},
+}
+
+func init() {
+	decodeType = DecodeCmd.Flags().StringP("type", "t", "auto", "The type to decode: auto to detect it, raw to only decode the structure, block, header, transaction, receipt, or account")
+}
+
+func normalizeHex(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		s = "0x" + s
+	}
+	return s
+}
+
+func decode(data []byte, typ string) (*Decoded, error) {
+	if len(data) == 0 {
+		return nil, errors.New("the input is empty")
+	}
+	d := new(Decoded)
+	payload := data
+	// A typed envelope starts with a byte which isn't a valid RLP prefix of
+	// a list.
+	if data[0] <= 0x7f && len(data) > 1 {
+		prefix := hexutil.Uint64(data[0])
+		d.Prefix = &prefix
+		payload = data[1:]
+	}
+	items, rest, err := decodeItem(payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("%d bytes remain after the RLP value", len(rest))
+	}
+	d.Items = items
+
+	for _, dec := range decoders {
+		if typ != "auto" && typ != dec.name {
+			continue
+		}
+		v, err := dec.decode(data)
+		if err == nil {
+			d.Type, d.Value = dec.name, v
+			break
+		}
+		if typ != "auto" {
+			return nil, fmt.Errorf("unable to decode a %s: %w", typ, err)
+		}
+	}
+	return d, nil
+}
+
+// decodeItem decodes the first RLP value of the data into nested lists of
+// strings and returns the remaining data.
+func decodeItem(data []byte) (any, []byte, error) {
+	kind, content, rest, err := ethrlp.Split(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if kind != ethrlp.List {
+		return hexutil.Bytes(content), rest, nil
+	}
+	items := make([]any, 0)
+	for len(content) > 0 {
+		var item any
+		if item, content, err = decodeItem(content); err != nil {
+			return nil, nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rest, nil
+}
+
+func decodeBlock(data []byte) (any, error) {
+	b := new(types.Block)
+	if err := ethrlp.DecodeBytes(data, b); err != nil {
+		return nil, err
+	}
+	return block{Header: b.Header(), Transactions: b.Transactions(), Uncles: b.Uncles(), Withdrawals: b.Withdrawals()}, nil
+}
+
+func decodeHeader(data []byte) (any, error) {
+	h := new(types.Header)
+	if err := ethrlp.DecodeBytes(data, h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// decodeTransaction decodes a legacy or typed transaction, along with its
+// sender when the signature is valid.
+func decodeTransaction(data []byte) (any, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	b, err := tx.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var v map[string]any
+	if err = json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	if from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); err == nil {
+		v["from"] = from
+	}
+	return v, nil
+}
+
+func decodeReceipt(data []byte) (any, error) {
+	r := new(types.Receipt)
+	if err := r.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// decodeAccount decodes an account of the state trie or, in the slim format,
+// of the snapshot.
+func decodeAccount(data []byte) (any, error) {
+	slim := new(types.SlimAccount)
+	if err := ethrlp.DecodeBytes(data, slim); err != nil {
+		return nil, err
+	}
+	for _, h := range [][]byte{slim.Root, slim.CodeHash} {
+		if len(h) != 0 && len(h) != common.HashLength {
+			return nil, fmt.Errorf("invalid hash length %d", len(h))
+		}
+	}
+	a, err := types.FullAccount(data)
+	if err != nil {
+		return nil, err
+	}
+	return account{Nonce: a.Nonce, Balance: a.Balance.ToBig(), Root: a.Root, CodeHash: common.BytesToHash(a.CodeHash)}, nil
+}
+
+// WriteText renders the structure with a line per string, followed by the
+// decoded type.
+func (d *Decoded) WriteText(w io.Writer) error {
+	var b strings.Builder
+	if d.Prefix != nil {
+		fmt.Fprintf(&b, "prefix %#x\n", uint64(*d.Prefix))
+	}
+	writeItem(&b, d.Items, 0)
+	if d.Type != "" {
+		v, err := json.MarshalIndent(d.Value, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "\n%s:\n%s\n", d.Type, v)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeItem(b *strings.Builder, item any, depth int) {
+	indent := strings.Repeat("  ", depth)
+	items, ok := item.([]any)
+	if !ok {
+		s := item.(hexutil.Bytes)
+		fmt.Fprintf(b, "%s%s%s\n", indent, s, annotate(s))
+		return
+	}
+	fmt.Fprintf(b, "%s[ # %d items\n", indent, len(items))
+	for _, i := range items {
+		writeItem(b, i, depth+1)
+	}
+	fmt.Fprintf(b, "%s]\n", indent)
+}
+
+// annotate returns the likely meanings of a string: an integer for the short
+// ones, and the text for the printable ones of at least three characters.
+func annotate(s []byte) string {
+	var meanings []string
+	if len(s) > 0 && len(s) <= 8 {
+		var n uint64
+		for _, c := range s {
+			n = n<<8 | uint64(c)
+		}
+		meanings = append(meanings, fmt.Sprint(n))
+	}
+	if len(s) >= 3 && isPrintable(string(s)) {
+		meanings = append(meanings, fmt.Sprintf("%q", s))
+	}
+	if len(meanings) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(meanings, ", ") + ")"
+}
+
+func isPrintable(s string) bool {
+	for _, r := range s {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
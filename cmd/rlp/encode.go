@@ -0,0 +1,116 @@
+package rlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrlp "github.com/ethereum/go-ethereum/rlp"
+	"github.com/spf13/cobra"
+)
+
+var encodePrefix *string
+
+var EncodeCmd = &cobra.Command{
+	Use:     "encode [json]",
+	Aliases: []string{"wrap"},
+	Short:   "Encode JSON nested lists into hex encoded RLP.",
+	Long: `Encode JSON from the argument, --file or stdin into hex encoded RLP. Arrays are encoded as lists, 0x prefixed strings as
+bytes, numbers as big endian integers, and other strings as UTF-8 text. The output of the decode command is accepted
+as well, its prefix being the type byte of a typed envelope.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		input, err := getInputData(args)
+		if err != nil {
+			return err
+		}
+		prefix, items, err := parseEncodeInput(input)
+		if err != nil {
+			return err
+		}
+		if *encodePrefix != "" {
+			if prefix, err = hexutil.Decode(normalizeHex(*encodePrefix)); err != nil {
+				return fmt.Errorf("invalid prefix: %w", err)
+			}
+		}
+		value, err := toEncodable(items)
+		if err != nil {
+			return err
+		}
+		data, err := ethrlp.EncodeToBytes(value)
+		if err != nil {
+			return err
+		}
+		fmt.Println(hexutil.Encode(append(prefix, data...)))
+		return nil
+	},
+}
+
+func init() {
+	encodePrefix = EncodeCmd.Flags().String("prefix", "", "The hex encoded type byte written before the RLP, e.g. 0x02 for a dynamic fee transaction")
+}
+
+// parseEncodeInput returns the items to encode, which are either the JSON
+// value or the items of a decoded structure along with its prefix.
+func parseEncodeInput(input []byte) ([]byte, any, error) {
+	dec := json.NewDecoder(bytes.NewReader(input))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse the JSON input: %w", err)
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, v, nil
+	}
+	items, ok := m["Items"]
+	if !ok {
+		return nil, nil, fmt.Errorf("a JSON object must be the output of the decode command")
+	}
+	var prefix []byte
+	if p, ok := m["Prefix"].(string); ok {
+		n, err := hexutil.DecodeUint64(p)
+		if err != nil || n > 0x7f {
+			return nil, nil, fmt.Errorf("invalid prefix %s", p)
+		}
+		prefix = []byte{byte(n)}
+	}
+	return prefix, items, nil
+}
+
+// toEncodable converts the JSON value into values the RLP encoder accepts.
+func toEncodable(v any) (any, error) {
+	switch v := v.(type) {
+	case []any:
+		items := make([]any, len(v))
+		for i := range v {
+			item, err := toEncodable(v[i])
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case string:
+		if strings.HasPrefix(v, "0x") {
+			b, err := hexutil.Decode(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid hex string %s: %w", v, err)
+			}
+			return b, nil
+		}
+		return []byte(v), nil
+	case json.Number:
+		n, ok := new(big.Int).SetString(v.String(), 10)
+		if !ok || n.Sign() < 0 {
+			return nil, fmt.Errorf("%s isn't a non-negative integer", v)
+		}
+		return n, nil
+	default:
+		return nil, fmt.Errorf("unable to encode %v, only arrays, strings and numbers are supported", v)
+	}
+}
@@ -0,0 +1,40 @@
+package rlp
+
+import (
+	_ "embed"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed usage.md
+	usage         string
+	inputFileName *string
+)
+
+var RLPCmd = &cobra.Command{
+	Use:   "rlp",
+	Short: "Decode RLP into a readable structure and encode JSON into RLP.",
+	Long:  usage,
+}
+
+func init() {
+	inputFileName = RLPCmd.PersistentFlags().String("file", "", "Provide a file holding the input instead of an argument or stdin")
+
+	RLPCmd.AddCommand(DecodeCmd)
+	RLPCmd.AddCommand(EncodeCmd)
+}
+
+// getInputData returns the input from the file, the arguments, or stdin.
+func getInputData(args []string) ([]byte, error) {
+	if *inputFileName != "" {
+		return os.ReadFile(*inputFileName)
+	}
+	if len(args) > 0 {
+		return []byte(strings.Join(args, " ")), nil
+	}
+	return io.ReadAll(os.Stdin)
+}
@@ -0,0 +1,80 @@
+package rollupstatus
+
+import (
+	_ "embed"
+	"time"
+
+	"github.com/maticnetwork/polygon-cli/rpcclient"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// alertExitCode is the exit code when a threshold is exceeded, the failures to
+// query the node exiting with the code of their error category.
+const alertExitCode = util.ResultExitCode
+
+type cmdRollupStatusParams struct {
+	RpcUrl    *string
+	RPCClient *rpcclient.Flags
+
+	MaxVirtualLag  *uint64
+	MaxVerifiedLag *uint64
+	MaxBlockAge    *time.Duration
+
+	Watch    *bool
+	Interval *time.Duration
+	Samples  *uint64
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdRollupStatusParams
+)
+
+// RollupStatusCmd represents the rollup-status command.
+var RollupStatusCmd = &cobra.Command{
+	Use:   "rollup-status",
+	Short: "Report the batch lag between the trusted, virtual and verified states of a zkEVM node.",
+	Long:  usage,
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// An exceeded threshold is a result, not a usage error.
+		cmd.SilenceUsage = true
+		return reportStatus(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdRollupStatusParams)
+	flagSet := RollupStatusCmd.Flags()
+
+	p.RpcUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8123", "The RPC endpoint url of the zkEVM node")
+	p.RPCClient = rpcclient.AddFlags(flagSet)
+
+	p.MaxVirtualLag = flagSet.Uint64("max-virtual-lag", 0, "Alert when the trusted batch is more than this number of batches ahead of the virtual batch, 0 to disable")
+	p.MaxVerifiedLag = flagSet.Uint64("max-verified-lag", 0, "Alert when the virtual batch is more than this number of batches ahead of the verified batch, 0 to disable")
+	p.MaxBlockAge = flagSet.Duration("max-block-age", 0, "Alert when the latest L2 block is older than this duration, 0 to disable")
+
+	p.Watch = flagSet.BoolP("watch", "w", false, "Report the status continuously")
+	p.Interval = flagSet.Duration("interval", 30*time.Second, "The interval between two reports in watch mode")
+	p.Samples = flagSet.Uint64("samples", 0, "The number of reports in watch mode, 0 to report until interrupted")
+
+	params = *p
+}
+
+func checkFlags() error {
+	if err := util.ValidateUrl(*params.RpcUrl); err != nil {
+		return err
+	}
+	if *params.MaxBlockAge < 0 {
+		return util.Errorf(util.ValidationError, "the maximum block age is negative")
+	}
+	if *params.Interval <= 0 {
+		return util.Errorf(util.ValidationError, "the reporting interval must be positive")
+	}
+	return nil
+}
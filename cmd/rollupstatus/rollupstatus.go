@@ -0,0 +1,173 @@
+package rollupstatus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	rpcBlock struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
+	}
+
+	// RollupStatus is the state of the batches of a zkEVM node at a point in
+	// time. The trusted batches are closed by the sequencer, the virtual ones
+	// are sequenced on L1 and the verified ones are proved on L1.
+	RollupStatus struct {
+		Time          time.Time
+		BlockNumber   uint64
+		BlockAge      time.Duration
+		TrustedBatch  uint64
+		VirtualBatch  uint64
+		VerifiedBatch uint64
+		// VirtualLag is the number of trusted batches not yet virtual.
+		VirtualLag uint64
+		// VerifiedLag is the number of virtual batches not yet verified.
+		VerifiedLag uint64
+		Alerts      []string `json:",omitempty"`
+	}
+
+	// statusLine renders a status as a single line in watch mode.
+	statusLine struct {
+		*RollupStatus
+	}
+)
+
+func reportStatus(ctx context.Context) error {
+	rpc, err := params.RPCClient.Dial(ctx, *params.RpcUrl)
+	if err != nil {
+		return util.NewNetworkError(err)
+	}
+	defer rpc.Close()
+
+	out := util.NewOutputWriter(os.Stdout)
+	if !*params.Watch {
+		s, err := getStatus(ctx, rpc)
+		if err != nil {
+			return err
+		}
+		if err = out.Write(s); err != nil {
+			return err
+		}
+		return alertError(s.Alerts)
+	}
+
+	ticker := time.NewTicker(*params.Interval)
+	defer ticker.Stop()
+	var alerts []string
+	for sample := uint64(0); *params.Samples == 0 || sample < *params.Samples; sample++ {
+		if sample > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+		s, err := getStatus(ctx, rpc)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to get the rollup status")
+			continue
+		}
+		for _, a := range s.Alerts {
+			log.Warn().Msg(a)
+		}
+		alerts = s.Alerts
+		if err = out.Write(statusLine{s}); err != nil {
+			return err
+		}
+	}
+	// The exit code reflects the last report.
+	return alertError(alerts)
+}
+
+func getStatus(ctx context.Context, rpc *ethrpc.Client) (*RollupStatus, error) {
+	var trusted, virtual, verified hexutil.Uint64
+	var block rpcBlock
+	elems := []ethrpc.BatchElem{
+		{Method: "zkevm_batchNumber", Result: &trusted},
+		{Method: "zkevm_virtualBatchNumber", Result: &virtual},
+		{Method: "zkevm_verifiedBatchNumber", Result: &verified},
+		{Method: "eth_getBlockByNumber", Args: []any{"latest", false}, Result: &block},
+	}
+	if err := rpc.BatchCallContext(ctx, elems); err != nil {
+		return nil, util.NewNetworkError(err)
+	}
+	for _, e := range elems {
+		if e.Error != nil {
+			return nil, util.NewNetworkError(fmt.Errorf("unable to call %s, is the endpoint a zkEVM node? %w", e.Method, e.Error))
+		}
+	}
+
+	now := time.Now()
+	s := &RollupStatus{
+		Time:          now,
+		BlockNumber:   uint64(block.Number),
+		BlockAge:      now.Sub(time.Unix(int64(block.Timestamp), 0)).Truncate(time.Second),
+		TrustedBatch:  uint64(trusted),
+		VirtualBatch:  uint64(virtual),
+		VerifiedBatch: uint64(verified),
+	}
+	// The batch numbers come from separate calls, so a batch could move on in between.
+	if s.TrustedBatch > s.VirtualBatch {
+		s.VirtualLag = s.TrustedBatch - s.VirtualBatch
+	}
+	if s.VirtualBatch > s.VerifiedBatch {
+		s.VerifiedLag = s.VirtualBatch - s.VerifiedBatch
+	}
+
+	if limit := *params.MaxVirtualLag; limit > 0 && s.VirtualLag > limit {
+		s.Alerts = append(s.Alerts, fmt.Sprintf("%d trusted batches aren't virtual, more than %d", s.VirtualLag, limit))
+	}
+	if limit := *params.MaxVerifiedLag; limit > 0 && s.VerifiedLag > limit {
+		s.Alerts = append(s.Alerts, fmt.Sprintf("%d virtual batches aren't verified, more than %d", s.VerifiedLag, limit))
+	}
+	if limit := *params.MaxBlockAge; limit > 0 && s.BlockAge > limit {
+		s.Alerts = append(s.Alerts, fmt.Sprintf("the latest block %d is %s old, more than %s", s.BlockNumber, s.BlockAge, limit))
+	}
+	return s, nil
+}
+
+// alertError returns the error making polycli exit with the alert exit code
+// when there are alerts.
+func alertError(alerts []string) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	return &util.ExitError{Code: alertExitCode, Err: fmt.Errorf("rollup status alerts: %s", strings.Join(alerts, "; "))}
+}
+
+// WriteText renders the status as a table.
+func (s *RollupStatus) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"", "Number", "Lag"})
+	t.AppendRow(table.Row{"Latest Block", s.BlockNumber, s.BlockAge})
+	t.AppendRow(table.Row{"Trusted Batch", s.TrustedBatch, ""})
+	t.AppendRow(table.Row{"Virtual Batch", s.VirtualBatch, s.VirtualLag})
+	t.AppendRow(table.Row{"Verified Batch", s.VerifiedBatch, s.VerifiedLag})
+	t.Render()
+	for _, a := range s.Alerts {
+		if _, err := fmt.Fprintln(w, "ALERT:", a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteText renders the status as a single line.
+func (s statusLine) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s block=%d age=%s trusted=%d virtual=%d verified=%d virtual_lag=%d verified_lag=%d alerts=%d\n",
+		s.Time.Format(time.RFC3339), s.BlockNumber, s.BlockAge, s.TrustedBatch, s.VirtualBatch, s.VerifiedBatch, s.VirtualLag, s.VerifiedLag, len(s.Alerts))
+	return err
+}
@@ -3,37 +3,62 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/maticnetwork/polygon-cli/cmd/fork"
 	"github.com/maticnetwork/polygon-cli/cmd/p2p"
 	"github.com/maticnetwork/polygon-cli/cmd/parseethwallet"
+	"github.com/maticnetwork/polygon-cli/profile"
+	"github.com/maticnetwork/polygon-cli/progress"
 	"github.com/maticnetwork/polygon-cli/util"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/maticnetwork/polygon-cli/cmd/abi"
+	"github.com/maticnetwork/polygon-cli/cmd/blockfetcher"
+	"github.com/maticnetwork/polygon-cli/cmd/console"
+	"github.com/maticnetwork/polygon-cli/cmd/contract"
+	"github.com/maticnetwork/polygon-cli/cmd/dabench"
 	"github.com/maticnetwork/polygon-cli/cmd/dbbench"
+	"github.com/maticnetwork/polygon-cli/cmd/dbextract"
 	"github.com/maticnetwork/polygon-cli/cmd/dumpblocks"
 	"github.com/maticnetwork/polygon-cli/cmd/ecrecover"
 	"github.com/maticnetwork/polygon-cli/cmd/enr"
 	"github.com/maticnetwork/polygon-cli/cmd/fund"
+	"github.com/maticnetwork/polygon-cli/cmd/gasestimator"
 	"github.com/maticnetwork/polygon-cli/cmd/hash"
 	"github.com/maticnetwork/polygon-cli/cmd/loadtest"
 	"github.com/maticnetwork/polygon-cli/cmd/metricsToDash"
 	"github.com/maticnetwork/polygon-cli/cmd/mnemonic"
 	"github.com/maticnetwork/polygon-cli/cmd/monitor"
 	"github.com/maticnetwork/polygon-cli/cmd/nodekey"
+	"github.com/maticnetwork/polygon-cli/cmd/nodestatus"
+	polyprofile "github.com/maticnetwork/polygon-cli/cmd/profile"
+	"github.com/maticnetwork/polygon-cli/cmd/proof"
+	"github.com/maticnetwork/polygon-cli/cmd/publish"
+	"github.com/maticnetwork/polygon-cli/cmd/retest"
+	"github.com/maticnetwork/polygon-cli/cmd/rlp"
+	"github.com/maticnetwork/polygon-cli/cmd/rollupstatus"
 	"github.com/maticnetwork/polygon-cli/cmd/rpcfuzz"
+	"github.com/maticnetwork/polygon-cli/cmd/rpcproxy"
+	"github.com/maticnetwork/polygon-cli/cmd/selector"
 	"github.com/maticnetwork/polygon-cli/cmd/signer"
+	"github.com/maticnetwork/polygon-cli/cmd/statesize"
+	"github.com/maticnetwork/polygon-cli/cmd/trace"
+	"github.com/maticnetwork/polygon-cli/cmd/txpool"
+	"github.com/maticnetwork/polygon-cli/cmd/ulxly"
 	"github.com/maticnetwork/polygon-cli/cmd/version"
 	"github.com/maticnetwork/polygon-cli/cmd/wallet"
 )
 
 var (
-	cfgFile   string
-	verbosity int
-	pretty    bool
+	cfgFile     string
+	verbosity   string
+	pretty      bool
+	quiet       bool
+	output      string
+	errorFormat string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -41,15 +66,28 @@ var rootCmd *cobra.Command
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// The error of a failed command is printed to stderr, as text or as JSON with
+// --error-format json, and polycli exits with the code of its category.
 func Execute() {
-	err := rootCmd.Execute()
+	cmd, err := rootCmd.ExecuteC()
 	if err != nil {
-		os.Exit(1)
+		command := ""
+		if cmd != nil {
+			command = cmd.CommandPath()
+		}
+		// Cobra doesn't type the error of an unknown subcommand.
+		if strings.HasPrefix(err.Error(), "unknown command") {
+			err = util.NewError(util.ValidationError, err)
+		}
+		os.Exit(util.WriteError(os.Stderr, err, command, util.OutputFormat(errorFormat)))
 	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	// The logging flags of the root command apply even to the subcommands
+	// with their own persistent pre-run hook.
+	cobra.EnableTraverseRunHooks = true
 	rootCmd = NewPolycliCommand()
 }
 
@@ -69,12 +107,21 @@ func initConfig() {
 		viper.SetConfigName(".polygon-cli")
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
+	// Read in the `POLYCLI_*` environment variables that match.
+	util.ConfigureViper()
 
-	// If a config file is found, read it in.
+	// If a config file is found, read it in. A config file given explicitly must exist.
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	} else if cfgFile != "" {
+		cobra.CheckErr(err)
 	}
+
+	// Fill the flags which weren't given on the command line.
+	cobra.CheckErr(util.BindFlags(rootCmd))
+
+	// Replace the `@name` references to the address book.
+	cobra.CheckErr(profile.Resolve(rootCmd))
 }
 
 // NewPolycliCommand creates the `polycli` command.
@@ -84,20 +131,43 @@ func NewPolycliCommand() *cobra.Command {
 		Use:   "polycli",
 		Short: "A Swiss Army knife of blockchain tools.",
 		Long:  "Polycli is a collection of tools that are meant to be useful while building, testing, and running block chain applications.",
+		// The errors are printed by Execute.
+		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			util.SetLogLevel(verbosity)
+			if f := util.OutputFormat(errorFormat); f != util.TextOutput && f != util.JSONOutput {
+				return util.Errorf(util.ValidationError, "unsupported error format: %s", errorFormat)
+			}
+			level, err := util.ParseVerbosity(verbosity)
+			if err != nil {
+				return util.NewError(util.ValidationError, err)
+			}
+			util.SetLogLevel(int(level))
 			logMode := util.JSON
 			if pretty {
 				logMode = util.Console
 			}
-			return util.SetLogMode(logMode)
+			if err := util.SetLogMode(logMode); err != nil {
+				return err
+			}
+			progress.SetQuiet(quiet)
+			if !cmd.Flags().Changed("output") {
+				return nil
+			}
+			return util.NewError(util.ValidationError, util.SetOutputFormat(output))
 		},
 	}
 
 	// Define flags and configuration settings.
 	cmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.polygon-cli.yaml)")
-	cmd.PersistentFlags().IntVarP(&verbosity, "verbosity", "v", 500, "0 - Silent\n100 Panic\n200 Fatal\n300 Error\n400 Warning\n500 Info\n600 Debug\n700 Trace")
-	cmd.PersistentFlags().BoolVar(&pretty, "pretty-logs", true, "Should logs be in pretty format or JSON")
+	cmd.PersistentFlags().StringVarP(&verbosity, "verbosity", "v", "info", "The log level: silent, panic, fatal, error, warn, info, debug or trace. Numbers are accepted too, on two scales:\ngeth-style from 0 to 5: 0 silent, 1 error, 2 warn, 3 info, 4 debug, 5 trace\npolycli-style above 5: 100 panic, 200 fatal, 300 error, 400 warn, 500 info, 600 debug, 700 trace")
+	cmd.PersistentFlags().BoolVar(&pretty, "pretty-logs", true, "Log in a human readable console format, or as JSON lines with --pretty-logs=false")
+	cmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "Don't report the progress of the long running commands, shown as progress bars on a terminal and periodic log lines otherwise")
+	cmd.PersistentFlags().StringVar(&profile.File, "profiles", "", "address book file of the endpoints, chains and accounts referenced by @name in the flags (default is $HOME/.polygon-cli/profiles.yaml)")
+	cmd.PersistentFlags().StringVar(&output, "output", string(util.TextOutput), "The format of the command results [text, json, yaml]")
+	cmd.PersistentFlags().StringVar(&errorFormat, "error-format", string(util.TextOutput), "The format of the error printed to stderr when a command fails [text, json]. The exit code is 2 for a failed check, e.g. an unhealthy node, 3 for a validation error, 4 for a network error, 5 for an authentication error, 6 for a timeout and 1 otherwise")
+	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return util.NewError(util.ValidationError, err)
+	})
 
 	// Define local flags which will only run when this action is called directly.
 	cmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
@@ -106,24 +176,46 @@ func NewPolycliCommand() *cobra.Command {
 	// Define commands.
 	cmd.AddCommand(
 		abi.ABICmd,
+		blockfetcher.BlockFetcherCmd,
+		console.ConsoleCmd,
+		contract.ContractCmd,
 		dumpblocks.DumpblocksCmd,
 		ecrecover.EcRecoverCmd,
 		fork.ForkCmd,
 		fund.FundCmd,
+		gasestimator.GasEstimatorCmd,
 		hash.HashCmd,
 		enr.ENRCmd,
+		dabench.DABenchCmd,
 		dbbench.DBBenchCmd,
+		dbextract.DBExtractCmd,
 		loadtest.LoadtestCmd,
 		metricsToDash.MetricsToDashCmd,
 		mnemonic.MnemonicCmd,
 		monitor.MonitorCmd,
 		nodekey.NodekeyCmd,
+		nodestatus.NodeStatusCmd,
 		p2p.P2pCmd,
 		parseethwallet.ParseETHWalletCmd,
+		polyprofile.ProfileCmd,
+		proof.ProofCmd,
+		publish.PublishCmd,
+		retest.RetestCmd,
+		rlp.RLPCmd,
+		rollupstatus.RollupStatusCmd,
 		rpcfuzz.RPCFuzzCmd,
+		rpcproxy.RPCProxyCmd,
+		selector.SelectorCmd,
 		signer.SignerCmd,
+		statesize.StateSizeCmd,
+		trace.TraceCmd,
+		txpool.TxPoolCmd,
+		ulxly.UlxlyCmd,
 		version.VersionCmd,
 		wallet.WalletCmd,
 	)
+	util.AnnotateFlagEnvVars(cmd)
+	util.MarkValidationErrors(cmd)
+	profile.AddRefs(cmd)
 	return cmd
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	fuzz "github.com/google/gofuzz"
 	"github.com/maticnetwork/polygon-cli/cmd/rpcfuzz/argfuzz"
+	"github.com/maticnetwork/polygon-cli/rpcclient"
 	"github.com/maticnetwork/polygon-cli/util"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -19,18 +20,23 @@ var (
 	usage string
 
 	// flags
-	rpcUrl               *string
-	testPrivateHexKey    *string
-	testContractAddress  *string
-	testNamespaces       *string
-	testFuzz             *bool
-	testFuzzNum          *int
-	seed                 *int64
-	testOutputExportPath *string
-	testExportJson       *bool
-	testExportCSV        *bool
-	testExportMarkdown   *bool
-	testExportHTML       *bool
+	rpcUrl                  *string
+	rpcFlags                *rpcclient.Flags
+	testPrivateHexKey       *string
+	testContractAddress     *string
+	testNamespaces          *string
+	testFuzz                *bool
+	testFuzzNum             *int
+	seed                    *int64
+	testOutputExportPath    *string
+	testExportJson          *bool
+	testExportCSV           *bool
+	testExportMarkdown      *bool
+	testExportHTML          *bool
+	testOpenRPC             *string
+	testOpenRPCPermutations *int
+	diffRpcUrl              *string
+	diffIgnore              *[]string
 )
 
 var RPCFuzzCmd = &cobra.Command{
@@ -61,6 +67,11 @@ func init() {
 	testExportCSV = flagSet.Bool("csv", false, "Flag to indicate that output will be exported as a CSV.")
 	testExportMarkdown = flagSet.Bool("md", false, "Flag to indicate that output will be exported as a Markdown.")
 	testExportHTML = flagSet.Bool("html", false, "Flag to indicate that output will be exported as a HTML.")
+	testOpenRPC = flagSet.String("openrpc", "", "The path or url of an OpenRPC spec, e.g. the execution-apis openrpc.json, to generate additional tests from")
+	testOpenRPCPermutations = flagSet.Int("openrpc-permutations", 8, "The maximum number of parameter permutations generated per method of the OpenRPC spec")
+	diffRpcUrl = flagSet.String("diff-rpc-url", "", "A second RPC endpoint receiving every request, the tests then failing when the responses of the endpoints diverge instead of being validated")
+	diffIgnore = flagSet.StringSlice("diff-ignore", defaultDiffIgnore, "The normalization rules of the differential mode, as method:path, ignoring the fields of the results which legitimately differ, e.g. eth_getBlockByNumber:.totalDifficulty, or the whole response with the path .")
+	rpcFlags = rpcclient.AddFlags(flagSet)
 
 	argfuzz.SetSeed(seed)
 
@@ -99,6 +110,19 @@ func checkFlags() (err error) {
 	}
 	log.Info().Strs("namespaces", enabledNamespaces).Msg("Enabling namespaces")
 
+	if *diffRpcUrl != "" {
+		if err = util.ValidateUrl(*diffRpcUrl); err != nil {
+			return
+		}
+		if diffRules, err = parseDiffRules(*diffIgnore); err != nil {
+			return
+		}
+	}
+
+	if *testOpenRPCPermutations < 1 {
+		return fmt.Errorf("the number of OpenRPC permutations must be positive")
+	}
+
 	testPrivateKey = privateKey
 	testEthAddress = ethAddress
 
@@ -0,0 +1,308 @@
+package rpcfuzz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/maticnetwork/polygon-cli/cmd/rpcfuzz/testreporter"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// A divergence is confirmed by calling both endpoints again, since one of them may lag behind, e.g.
+	// not having imported the latest block yet.
+	diffAttempts   = 3
+	diffRetryDelay = time.Second
+)
+
+type (
+	// diffEndpoint is an endpoint receiving the requests in the differential mode.
+	diffEndpoint struct {
+		rpc  *rpc.Client
+		http wrappedHttpClient
+	}
+
+	// diffResponse is the response of an endpoint, the error class being the JSON-RPC error code, or
+	// empty when the call succeeded.
+	diffResponse struct {
+		Result     any    `json:"result,omitempty"`
+		ErrorClass string `json:"errorClass,omitempty"`
+	}
+
+	// DiffResult holds the diverging responses of the endpoints.
+	DiffResult struct {
+		Primary   diffResponse `json:"primary"`
+		Secondary diffResponse `json:"secondary"`
+	}
+
+	// diffRule ignores the fields at the path of the results of the methods matching the pattern.
+	diffRule struct {
+		methods string
+		path    []diffPathSegment
+	}
+	// diffPathSegment is a field, * for any field, followed by [] when it is an array whose every
+	// element is selected.
+	diffPathSegment struct {
+		field string
+		each  bool
+	}
+)
+
+// defaultDiffIgnore are the normalization rules of the results which legitimately differ between
+// clients, or between two calls.
+var defaultDiffIgnore = []string{
+	"web3_clientVersion:.",
+	"eth_coinbase:.",
+	"eth_accounts:.",
+	"eth_sign*:.",
+	"net_peerCount:.",
+	"eth_syncing:.",
+	"eth_gasPrice:.",
+	"eth_maxPriorityFeePerGas:.",
+	"eth_feeHistory:.",
+	"eth_newFilter:.",
+	"eth_newBlockFilter:.",
+	"eth_newPendingTransactionFilter:.",
+	"eth_getFilterChanges:.",
+	"eth_getFilterLogs:.",
+	"eth_uninstallFilter:.",
+	"eth_send*:.",
+}
+
+var diffRules []diffRule
+
+// parseDiffRules parses the rules, written as method:path, the method being a glob pattern and the path
+// a list of fields like .transactions[].yParity, or . for the whole response including the error.
+func parseDiffRules(rules []string) ([]diffRule, error) {
+	parsed := make([]diffRule, 0, len(rules))
+	for _, r := range rules {
+		methods, p, ok := strings.Cut(r, ":")
+		if !ok || methods == "" || !strings.HasPrefix(p, ".") {
+			return nil, fmt.Errorf("the normalization rule %q isn't of the form method:.path", r)
+		}
+		if _, err := path.Match(methods, ""); err != nil {
+			return nil, fmt.Errorf("invalid method pattern in the normalization rule %q: %w", r, err)
+		}
+		rule := diffRule{methods: methods}
+		if p != "." {
+			for _, field := range strings.Split(p[1:], ".") {
+				s := diffPathSegment{field: strings.TrimSuffix(field, "[]")}
+				s.each = s.field != field
+				if s.field == "" {
+					return nil, fmt.Errorf("empty field in the normalization rule %q", r)
+				}
+				rule.path = append(rule.path, s)
+			}
+		}
+		parsed = append(parsed, rule)
+	}
+	return parsed, nil
+}
+
+// CallRPCAndDiff sends the request of the test to both endpoints and fails when their responses diverge
+// once normalized.
+func CallRPCAndDiff(ctx context.Context, primary, secondary diffEndpoint, currTest RPCTest) testreporter.TestResult {
+	currTestResult := testreporter.New(currTest.GetName(), currTest.GetMethod(), 1)
+	diffArgs(ctx, primary, secondary, currTest, currTest.GetArgs(), &currTestResult)
+	return currTestResult
+}
+
+// CallRPCWithFuzzAndDiff sends each fuzzed request of the test to both endpoints.
+func CallRPCWithFuzzAndDiff(ctx context.Context, primary, secondary diffEndpoint, currTest RPCTest) testreporter.TestResult {
+	currTestResult := testreporter.New(currTest.GetName()+"-FUZZED", currTest.GetMethod(), *testFuzzNum)
+
+	originalArgs := currTest.GetArgs()
+	for i := 0; i < *testFuzzNum; i++ {
+		args := originalArgs
+		fuzzer.Fuzz(&args)
+		diffArgs(ctx, primary, secondary, currTest, args, &currTestResult)
+	}
+	return currTestResult
+}
+
+func diffArgs(ctx context.Context, primary, secondary diffEndpoint, currTest RPCTest, args []interface{}, currTestResult *testreporter.TestResult) {
+	var res DiffResult
+	var divergence string
+	for attempt := 1; attempt <= diffAttempts; attempt++ {
+		var err error
+		if res.Primary, err = callEndpoint(ctx, primary, currTest, args); err != nil {
+			currTestResult.Fail(args, nil, fmt.Errorf("primary endpoint: %w", err))
+			return
+		}
+		if res.Secondary, err = callEndpoint(ctx, secondary, currTest, args); err != nil {
+			currTestResult.Fail(args, nil, fmt.Errorf("secondary endpoint: %w", err))
+			return
+		}
+		if divergence = diffResponses(currTest.GetMethod(), &res); divergence == "" {
+			currTestResult.Pass(args, res.Primary.Result, nil)
+			return
+		}
+		if attempt < diffAttempts {
+			time.Sleep(diffRetryDelay)
+		}
+	}
+
+	log.Warn().Str("name", currTest.GetName()).Str("method", currTest.GetMethod()).Interface("args", args).
+		Str("divergence", divergence).Msg("The endpoints diverge")
+	currTestResult.Fail(args, res, errors.New(divergence))
+}
+
+// callEndpoint sends the request to the endpoint. An error is returned when the endpoint couldn't be
+// reached, the error responses are classified by their code.
+func callEndpoint(ctx context.Context, e diffEndpoint, currTest RPCTest, args []interface{}) (diffResponse, error) {
+	if _, ok := currTest.(*RPCTestRawHTTP); ok {
+		rpcResponse, err := sendRawHTTP(e.http, currTest.GetMethod(), args)
+		if err != nil {
+			return diffResponse{}, err
+		}
+		if rpcResponse.Error != nil {
+			return diffResponse{ErrorClass: fmt.Sprint(rpcResponse.Error.Code)}, nil
+		}
+		return diffResponse{Result: rpcResponse.Result}, nil
+	}
+
+	var result any
+	err := e.rpc.CallContext(ctx, &result, currTest.GetMethod(), args...)
+	var rpcErr rpc.Error
+	var httpErr rpc.HTTPError
+	switch {
+	case err == nil:
+		return diffResponse{Result: result}, nil
+	case errors.As(err, &rpcErr):
+		return diffResponse{ErrorClass: fmt.Sprint(rpcErr.ErrorCode())}, nil
+	case errors.As(err, &httpErr):
+		return diffResponse{ErrorClass: fmt.Sprintf("HTTP %d", httpErr.StatusCode)}, nil
+	default:
+		return diffResponse{}, err
+	}
+}
+
+// diffResponses normalizes the responses and describes their first difference, the error messages being
+// ignored since they vary between clients.
+func diffResponses(method string, res *DiffResult) string {
+	for _, r := range diffRules {
+		if ok, _ := path.Match(r.methods, method); !ok {
+			continue
+		}
+		if len(r.path) == 0 {
+			return ""
+		}
+		ignorePath(res.Primary.Result, r.path)
+		ignorePath(res.Secondary.Result, r.path)
+	}
+
+	if res.Primary.ErrorClass != res.Secondary.ErrorClass {
+		return fmt.Sprintf("error class %s on the primary endpoint and %s on the secondary one",
+			errorClassName(res.Primary.ErrorClass), errorClassName(res.Secondary.ErrorClass))
+	}
+	return firstDifference(res.Primary.Result, res.Secondary.Result, "")
+}
+
+func errorClassName(class string) string {
+	if class == "" {
+		return "none"
+	}
+	return class
+}
+
+// ignorePath deletes the fields at the path of the decoded JSON value.
+func ignorePath(v any, p []diffPathSegment) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	fields := []string{p[0].field}
+	if p[0].field == "*" {
+		fields = sortedKeys(m)
+	}
+	for _, f := range fields {
+		child, ok := m[f]
+		switch {
+		case !ok:
+		case len(p) == 1:
+			delete(m, f)
+		case p[0].each:
+			elements, _ := child.([]any)
+			for _, e := range elements {
+				ignorePath(e, p[1:])
+			}
+		default:
+			ignorePath(child, p[1:])
+		}
+	}
+}
+
+// firstDifference returns the path of the first difference of the decoded JSON values with their values
+// there, or an empty string when they are equal.
+func firstDifference(a, b any, p string) string {
+	switch ta := a.(type) {
+	case map[string]any:
+		tb, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := sortedKeys(ta)
+		for k := range tb {
+			if _, ok := ta[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if d := firstDifference(ta[k], tb[k], p+"."+k); d != "" {
+				return d
+			}
+		}
+		return ""
+	case []any:
+		tb, ok := b.([]any)
+		if !ok {
+			break
+		}
+		if len(ta) != len(tb) {
+			return fmt.Sprintf("%s: %d elements on the primary endpoint and %d on the secondary one", rootPath(p), len(ta), len(tb))
+		}
+		for i := range ta {
+			if d := firstDifference(ta[i], tb[i], fmt.Sprintf("%s[%d]", p, i)); d != "" {
+				return d
+			}
+		}
+		return ""
+	}
+	if reflect.DeepEqual(a, b) {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s on the primary endpoint and %s on the secondary one", rootPath(p), jsonString(a), jsonString(b))
+}
+
+func rootPath(p string) string {
+	if p == "" {
+		return "."
+	}
+	return p
+}
+
+func jsonString(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
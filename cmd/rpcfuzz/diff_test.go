@@ -0,0 +1,160 @@
+package rpcfuzz
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestParseDiffRules(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rule     string
+		expected diffRule
+		invalid  bool
+	}{
+		"whole response": {rule: "eth_sign*:.", expected: diffRule{methods: "eth_sign*"}},
+		"field": {rule: "eth_getBlockByNumber:.size", expected: diffRule{
+			methods: "eth_getBlockByNumber",
+			path:    []diffPathSegment{{field: "size"}},
+		}},
+		"array and wildcard": {rule: "eth_*:.transactions[].*", expected: diffRule{
+			methods: "eth_*",
+			path:    []diffPathSegment{{field: "transactions", each: true}, {field: "*"}},
+		}},
+		"no method":       {rule: ":.size", invalid: true},
+		"no path":         {rule: "eth_chainId", invalid: true},
+		"relative path":   {rule: "eth_chainId:size", invalid: true},
+		"empty field":     {rule: "eth_getBlockByNumber:.transactions..hash", invalid: true},
+		"empty array":     {rule: "eth_getBlockByNumber:.[]", invalid: true},
+		"invalid pattern": {rule: "eth_[:.", invalid: true},
+	} {
+		rules, err := parseDiffRules([]string{tc.rule})
+		if tc.invalid {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %+v", name, rules)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(rules) != 1 || !reflect.DeepEqual(rules[0], tc.expected) {
+			t.Errorf("%s: expected %+v, got %+v", name, tc.expected, rules)
+		}
+	}
+
+	if _, err := parseDiffRules(defaultDiffIgnore); err != nil {
+		t.Errorf("invalid default rules: %v", err)
+	}
+}
+
+func TestIgnorePath(t *testing.T) {
+	block := `{"hash": "0x1", "size": "0x2", "transactions": [{"hash": "0x3", "yParity": "0x0"}, {"hash": "0x4", "v": "0x1b"}]}`
+	for name, tc := range map[string]struct {
+		value, path, expected string
+	}{
+		"field":          {block, ".size", `{"hash": "0x1", "transactions": [{"hash": "0x3", "yParity": "0x0"}, {"hash": "0x4", "v": "0x1b"}]}`},
+		"missing field":  {block, ".miner", block},
+		"array elements": {block, ".transactions[].yParity", `{"hash": "0x1", "size": "0x2", "transactions": [{"hash": "0x3"}, {"hash": "0x4", "v": "0x1b"}]}`},
+		"whole array":    {block, ".transactions[]", `{"hash": "0x1", "size": "0x2"}`},
+		"wildcard":       {block, ".*", `{}`},
+		"wildcard in array": {
+			`{"a": {"x": 1, "y": 2}, "b": {"x": 3}}`, ".*.x", `{"a": {"y": 2}, "b": {}}`,
+		},
+		"not an object": {`["0x1"]`, ".size", `["0x1"]`},
+		"not an array":  {`{"transactions": "0x1"}`, ".transactions[].hash", `{"transactions": "0x1"}`},
+	} {
+		rules, err := parseDiffRules([]string{"eth_test:" + tc.path})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		v := decodeJSON(t, tc.value)
+		ignorePath(v, rules[0].path)
+		if expected := decodeJSON(t, tc.expected); !reflect.DeepEqual(v, expected) {
+			t.Errorf("%s: expected %v, got %v", name, expected, v)
+		}
+	}
+}
+
+func TestFirstDifference(t *testing.T) {
+	for name, tc := range map[string]struct {
+		a, b, expected string
+	}{
+		"equal":          {`{"a": [1, {"b": "c"}]}`, `{"a": [1, {"b": "c"}]}`, ""},
+		"scalar":         {`"0x1"`, `"0x2"`, `.: "0x1" on the primary endpoint and "0x2" on the secondary one`},
+		"nested field":   {`{"a": {"b": 1, "c": 2}}`, `{"a": {"b": 1, "c": 3}}`, `.a.c: 2 on the primary endpoint and 3 on the secondary one`},
+		"missing field":  {`{"a": 1}`, `{"a": 1, "b": 2}`, `.b: null on the primary endpoint and 2 on the secondary one`},
+		"sorted fields":  {`{"b": 1, "a": 1}`, `{"b": 2, "a": 2}`, `.a: 1 on the primary endpoint and 2 on the secondary one`},
+		"array length":   {`{"a": [1, 2]}`, `{"a": [1]}`, `.a: 2 elements on the primary endpoint and 1 on the secondary one`},
+		"array element":  {`[{"a": 1}, {"a": 2}]`, `[{"a": 1}, {"a": 3}]`, `[1].a: 2 on the primary endpoint and 3 on the secondary one`},
+		"type":           {`{"a": [1]}`, `{"a": {"0": 1}}`, `.a: [1] on the primary endpoint and {"0":1} on the secondary one`},
+		"null and empty": {`null`, `[]`, `.: null on the primary endpoint and [] on the secondary one`},
+	} {
+		if d := firstDifference(decodeJSON(t, tc.a), decodeJSON(t, tc.b), ""); d != tc.expected {
+			t.Errorf("%s: expected %q, got %q", name, tc.expected, d)
+		}
+	}
+}
+
+func TestDiffResponses(t *testing.T) {
+	rules, err := parseDiffRules([]string{"eth_sign*:.", "eth_getBlock*:.transactions[].yParity"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := diffRules
+	diffRules = rules
+	defer func() { diffRules = previous }()
+
+	for name, tc := range map[string]struct {
+		method   string
+		res      DiffResult
+		expected string
+	}{
+		"same error class": {
+			method: "eth_call",
+			res:    DiffResult{Primary: diffResponse{ErrorClass: "-32000"}, Secondary: diffResponse{ErrorClass: "-32000"}},
+		},
+		"different error classes": {
+			method:   "eth_call",
+			res:      DiffResult{Primary: diffResponse{ErrorClass: "-32000"}, Secondary: diffResponse{ErrorClass: "-32602"}},
+			expected: "error class -32000 on the primary endpoint and -32602 on the secondary one",
+		},
+		"error on one endpoint": {
+			method:   "eth_call",
+			res:      DiffResult{Primary: diffResponse{Result: "0x"}, Secondary: diffResponse{ErrorClass: "HTTP 500"}},
+			expected: "error class none on the primary endpoint and HTTP 500 on the secondary one",
+		},
+		"ignored method": {
+			method: "eth_sign",
+			res:    DiffResult{Primary: diffResponse{Result: "0x1"}, Secondary: diffResponse{ErrorClass: "-32601"}},
+		},
+		"ignored field": {
+			method: "eth_getBlockByNumber",
+			res: DiffResult{
+				Primary:   diffResponse{Result: decodeJSON(t, `{"transactions": [{"hash": "0x1", "yParity": "0x0"}]}`)},
+				Secondary: diffResponse{Result: decodeJSON(t, `{"transactions": [{"hash": "0x1"}]}`)},
+			},
+		},
+		"other field": {
+			method: "eth_getBlockByNumber",
+			res: DiffResult{
+				Primary:   diffResponse{Result: decodeJSON(t, `{"transactions": [{"hash": "0x1", "yParity": "0x0"}]}`)},
+				Secondary: diffResponse{Result: decodeJSON(t, `{"transactions": [{"hash": "0x2"}]}`)},
+			},
+			expected: `.transactions[0].hash: "0x1" on the primary endpoint and "0x2" on the secondary one`,
+		},
+	} {
+		if d := diffResponses(tc.method, &tc.res); d != tc.expected {
+			t.Errorf("%s: expected %q, got %q", name, tc.expected, d)
+		}
+	}
+}
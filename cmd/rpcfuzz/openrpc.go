@@ -0,0 +1,263 @@
+package rpcfuzz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	// openRPCSpec is the subset of an OpenRPC document, such as the
+	// execution-apis spec, needed to generate the tests.
+	// https://spec.open-rpc.org/
+	openRPCSpec struct {
+		Methods    []openRPCMethod `json:"methods"`
+		Components struct {
+			Schemas            map[string]json.RawMessage          `json:"schemas"`
+			ContentDescriptors map[string]openRPCContentDescriptor `json:"contentDescriptors"`
+		} `json:"components"`
+	}
+	openRPCMethod struct {
+		Name     string                     `json:"name"`
+		Params   []openRPCContentDescriptor `json:"params"`
+		Result   *openRPCContentDescriptor  `json:"result"`
+		Examples []openRPCExample           `json:"examples"`
+	}
+	openRPCContentDescriptor struct {
+		Ref      string          `json:"$ref"`
+		Name     string          `json:"name"`
+		Required bool            `json:"required"`
+		Schema   json.RawMessage `json:"schema"`
+	}
+	openRPCExample struct {
+		Name   string `json:"name"`
+		Params []struct {
+			Name  string `json:"name"`
+			Value any    `json:"value"`
+		} `json:"params"`
+	}
+)
+
+// openRPCSkippedMethods are the methods which change the state of the chain
+// or need unlocked accounts, so the examples of the spec can't be replayed.
+var openRPCSkippedMethods = regexp.MustCompile(`^(eth_send|eth_sign|engine_)`)
+
+// loadOpenRPCTests generates the tests of the methods of the OpenRPC spec at
+// the path or URL. The arguments are the permutations of the values of the
+// examples and the enums of the param schemas, the optional params being
+// omitted in turn. The results are validated against the result schemas.
+func loadOpenRPCTests(ctx context.Context, location string) ([]RPCTest, error) {
+	data, err := readOpenRPCSpec(ctx, location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the OpenRPC spec %s: %w", location, err)
+	}
+	var spec openRPCSpec
+	if err = json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("unable to parse the OpenRPC spec %s: %w", location, err)
+	}
+
+	tests := make([]RPCTest, 0)
+	for _, m := range spec.Methods {
+		if openRPCSkippedMethods.MatchString(m.Name) {
+			log.Trace().Str("method", m.Name).Msg("Skipping OpenRPC method with side effects")
+			continue
+		}
+		methodTests, err := spec.methodTests(m)
+		if err != nil {
+			log.Warn().Err(err).Str("method", m.Name).Msg("Unable to generate the OpenRPC tests")
+			continue
+		}
+		tests = append(tests, methodTests...)
+	}
+	log.Info().Int("methods", len(spec.Methods)).Int("tests", len(tests)).Msg("Generated the OpenRPC tests")
+	return tests, nil
+}
+
+func readOpenRPCSpec(ctx context.Context, location string) ([]byte, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		return os.ReadFile(location)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (s *openRPCSpec) methodTests(m openRPCMethod) ([]RPCTest, error) {
+	if m.Result == nil {
+		return nil, fmt.Errorf("the method has no result")
+	}
+	result := s.descriptor(*m.Result)
+	validator, err := s.resultValidator(result.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]openRPCContentDescriptor, len(m.Params))
+	candidates := make([][]any, len(m.Params))
+	for i, p := range m.Params {
+		params[i] = s.descriptor(p)
+		candidates[i] = s.paramValues(m, i, params[i])
+	}
+
+	// The calls have every required param, and any number of the optional
+	// ones, which can only be omitted from the end.
+	required := 0
+	for i, p := range params {
+		if p.Required {
+			required = i + 1
+		}
+	}
+	tests := make([]RPCTest, 0)
+	for n := len(params); n >= required; n-- {
+		for _, args := range permutations(candidates[:n], *testOpenRPCPermutations-len(tests)) {
+			tests = append(tests, &RPCTestGeneric{
+				Name:      fmt.Sprintf("RPCTestOpenRPC_%s_%d", m.Name, len(tests)),
+				Method:    m.Name,
+				Args:      args,
+				Validator: validator,
+			})
+		}
+	}
+	if len(tests) == 0 {
+		return nil, fmt.Errorf("no value for the required params")
+	}
+	return tests, nil
+}
+
+// descriptor resolves the reference to a content descriptor of the components.
+func (s *openRPCSpec) descriptor(d openRPCContentDescriptor) openRPCContentDescriptor {
+	if d.Ref == "" {
+		return d
+	}
+	name := d.Ref[strings.LastIndex(d.Ref, "/")+1:]
+	if resolved, ok := s.Components.ContentDescriptors[name]; ok {
+		return resolved
+	}
+	log.Warn().Str("ref", d.Ref).Msg("Unable to resolve the OpenRPC content descriptor")
+	return d
+}
+
+// paramValues returns the distinct values of the param in the examples of the
+// method, then the values of the enums of its schema.
+func (s *openRPCSpec) paramValues(m openRPCMethod, index int, p openRPCContentDescriptor) []any {
+	values := make([]any, 0)
+	seen := make(map[string]struct{})
+	add := func(v any) {
+		key, err := json.Marshal(v)
+		if err != nil {
+			return
+		}
+		if _, ok := seen[string(key)]; ok {
+			return
+		}
+		seen[string(key)] = struct{}{}
+		values = append(values, v)
+	}
+
+	for _, e := range m.Examples {
+		for i, ep := range e.Params {
+			if ep.Name == p.Name || (ep.Name == "" && i == index) {
+				add(ep.Value)
+			}
+		}
+	}
+	var schema any
+	if err := json.Unmarshal(p.Schema, &schema); err == nil {
+		for _, v := range s.enumValues(schema, 0) {
+			add(v)
+		}
+	}
+	return values
+}
+
+// enumValues collects the enums of the schema and of its alternatives,
+// following the references to the component schemas.
+func (s *openRPCSpec) enumValues(schema any, depth int) []any {
+	obj, ok := schema.(map[string]any)
+	// The depth guards against recursive schemas.
+	if !ok || depth > 8 {
+		return nil
+	}
+	if ref, ok := obj["$ref"].(string); ok {
+		raw, ok := s.Components.Schemas[ref[strings.LastIndex(ref, "/")+1:]]
+		if !ok {
+			return nil
+		}
+		var resolved any
+		if err := json.Unmarshal(raw, &resolved); err != nil {
+			return nil
+		}
+		return s.enumValues(resolved, depth+1)
+	}
+
+	var values []any
+	if enum, ok := obj["enum"].([]any); ok {
+		values = append(values, enum...)
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		alternatives, _ := obj[key].([]any)
+		for _, a := range alternatives {
+			values = append(values, s.enumValues(a, depth+1)...)
+		}
+	}
+	return values
+}
+
+// resultValidator validates the results against the schema, the component
+// schemas being embedded so the references resolve.
+func (s *openRPCSpec) resultValidator(schema json.RawMessage) (func(result interface{}) error, error) {
+	if len(schema) == 0 {
+		return nil, fmt.Errorf("the result has no schema")
+	}
+	doc, err := json.Marshal(map[string]any{
+		"allOf":      []json.RawMessage{schema},
+		"components": map[string]any{"schemas": s.Components.Schemas},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ValidateJSONSchema(string(doc)), nil
+}
+
+// permutations returns up to limit combinations of one value of each of the
+// candidates, the first values of the candidates coming first.
+func permutations(candidates [][]any, limit int) [][]any {
+	if limit <= 0 {
+		return nil
+	}
+	result := [][]any{{}}
+	for _, values := range candidates {
+		if len(values) == 0 {
+			return nil
+		}
+		next := make([][]any, 0, len(result)*len(values))
+		for _, prefix := range result {
+			for _, v := range values {
+				args := append(append(make([]any, 0, len(prefix)+1), prefix...), v)
+				next = append(next, args)
+			}
+		}
+		result = next
+	}
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
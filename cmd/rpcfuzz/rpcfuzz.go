@@ -175,9 +175,13 @@ var (
 	testEthAddress        ethcommon.Address
 	testAccountNonce      uint64
 	testAccountNonceMutex sync.Mutex
-	currentChainID        *big.Int
-	fuzzer                *fuzz.Fuzzer
-	enabledNamespaces     []string
+	// signErr is the first failure to sign the transaction of a test, which fails the run once the tests
+	// are done.
+	signErr           error
+	signErrOnce       sync.Once
+	currentChainID    *big.Int
+	fuzzer            *fuzz.Fuzzer
+	enabledNamespaces []string
 	// in the future allTests could be used to for
 	// fuzzing.. E.g. loop over the various tests, and mutate the
 	// Args before sending
@@ -213,7 +217,15 @@ func runRpcFuzz(ctx context.Context) error {
 		log.Warn().Msg("Setting --export-path must pair with a export type: --json, --csv, --md, or --html")
 	}
 
-	rpcClient, err := rpc.DialContext(ctx, *rpcUrl)
+	var openRPCTests []RPCTest
+	if *testOpenRPC != "" {
+		var err error
+		if openRPCTests, err = loadOpenRPCTests(ctx, *testOpenRPC); err != nil {
+			return err
+		}
+	}
+
+	rpcClient, err := rpcFlags.Dial(ctx, *rpcUrl)
 	if err != nil {
 		return err
 	}
@@ -241,10 +253,25 @@ func runRpcFuzz(ctx context.Context) error {
 
 	log.Trace().Uint64("nonce", nonce).Uint64("chainId", chainId.Uint64()).Msg("Doing test setup")
 	setupTests(ctx, rpcClient)
+	allTests = append(allTests, openRPCTests...)
 
 	httpClient := &http.Client{}
 	wrappedHTTPClient := wrappedHttpClient{httpClient, *rpcUrl}
 
+	// In the differential mode, the requests are also sent to the second endpoint and the responses
+	// compared instead of validated.
+	var primary, secondary *diffEndpoint
+	if *diffRpcUrl != "" {
+		diffClient, err := rpcFlags.Dial(ctx, *diffRpcUrl)
+		if err != nil {
+			return err
+		}
+		defer diffClient.Close()
+		primary = &diffEndpoint{rpc: rpcClient, http: wrappedHTTPClient}
+		secondary = &diffEndpoint{rpc: diffClient, http: wrappedHttpClient{httpClient, *diffRpcUrl}}
+		log.Info().Str("primary", *rpcUrl).Str("secondary", *diffRpcUrl).Msg("Comparing the responses of the endpoints")
+	}
+
 	for _, t := range allTests {
 		if !shouldRunTest(t) {
 			log.Trace().Str("name", t.GetName()).Str("method", t.GetMethod()).Msg("Skipping test")
@@ -252,7 +279,12 @@ func runRpcFuzz(ctx context.Context) error {
 		}
 		log.Trace().Str("name", t.GetName()).Str("method", t.GetMethod()).Msg("Running Test")
 
-		currTestResult := CallRPCAndValidate(ctx, rpcClient, wrappedHTTPClient, t)
+		var currTestResult testreporter.TestResult
+		if secondary != nil {
+			currTestResult = CallRPCAndDiff(ctx, *primary, *secondary, t)
+		} else {
+			currTestResult = CallRPCAndValidate(ctx, rpcClient, wrappedHTTPClient, t)
+		}
 		testResults.AddTestResult(currTestResult)
 
 		if *testFuzz {
@@ -261,7 +293,12 @@ func runRpcFuzz(ctx context.Context) error {
 			log.Info().Str("method", t.GetMethod()).Msg("Running with fuzzed args")
 			go func(t RPCTest) {
 				defer fuzzedTestsGroup.Done()
-				currTestResult := CallRPCWithFuzzAndValidate(ctx, rpcClient, t)
+				var currTestResult testreporter.TestResult
+				if secondary != nil {
+					currTestResult = CallRPCWithFuzzAndDiff(ctx, *primary, *secondary, t)
+				} else {
+					currTestResult = CallRPCWithFuzzAndValidate(ctx, rpcClient, t)
+				}
 				testResultsCh <- currTestResult
 			}(t)
 		}
@@ -293,6 +330,9 @@ func runRpcFuzz(ctx context.Context) error {
 	}
 	testResults.PrintTabularResult()
 
+	if signErr != nil {
+		return fmt.Errorf("unable to sign the test transactions: %w", signErr)
+	}
 	return nil
 }
 
@@ -1684,7 +1724,8 @@ func ArgsSignTransaction(ctx context.Context, rpcClient *rpc.Client, tx *RPCTest
 
 		stringTx, err := getSignedRawTx(tx, curNonce)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to sign tx")
+			signErrOnce.Do(func() { signErr = err })
+			return []interface{}{""}
 		}
 
 		testAccountNonce += 1
@@ -1701,7 +1742,8 @@ func ArgsSignTransactionWithNonce(ctx context.Context, rpcClient *rpc.Client, tx
 	return func() []interface{} {
 		stringTx, err := getSignedRawTx(tx, nonce)
 		if err != nil {
-			log.Fatal().Err(err).Msg("Failed to sign tx")
+			signErrOnce.Do(func() { signErr = err })
+			return []interface{}{""}
 		}
 
 		args := []interface{}{hexutil.Encode(stringTx)}
@@ -1885,47 +1927,9 @@ func CallRPCAndValidate(ctx context.Context, rpcClient *rpc.Client, wrappedHttpC
 	var err error
 	switch currTest.(type) {
 	case *RPCTestRawHTTP:
-		// Marshal the HTTP request payload.
-		var payload []byte
-		payload, err = json.Marshal(args)
-		if err != nil {
-			log.Fatal().Err(err).Msg("Unable to marshal HTTP request payload")
-		}
-
-		// Create the request.
-		var request *http.Request
-		request, err = http.NewRequest(currTest.GetMethod(), wrappedHttpClient.url, bytes.NewBuffer(payload)) // TODO: fix
-		if err != nil {
-			log.Fatal().Err(err).Msg("Unable to create HTTP request")
-
-		}
-		request.Header.Set("Content-Type", "application/json")
-
-		// Send the request.
-		var response *http.Response
-		response, err = wrappedHttpClient.client.Do(request)
-		if err != nil {
-			log.Error().Err(err).Msg("Unable to send HTTP request")
-			break
-		}
-		defer response.Body.Close()
-
-		// Read the response body.
-		var body []byte
-		body, err = io.ReadAll(response.Body)
-		if err != nil {
-			log.Error().Err(err).Msg("Unable to read HTTP body")
-			break
-		}
-
-		// Marshal the response and extract the error if there is any.
-		var rpcResponse RPCJSONResponse
-		err = json.Unmarshal(body, &rpcResponse)
-		if err != nil {
-			log.Error().Err(err).Msg("Unable to unmarshal HTTP body")
-			break
-		}
-		if rpcResponse.Error != nil {
+		var rpcResponse *RPCJSONResponse
+		rpcResponse, err = sendRawHTTP(wrappedHttpClient, currTest.GetMethod(), args)
+		if err == nil && rpcResponse.Error != nil {
 			result = &rpcResponse.Error
 		}
 	default:
@@ -1957,6 +1961,49 @@ func CallRPCAndValidate(ctx context.Context, rpcClient *rpc.Client, wrappedHttpC
 	return currTestResult
 }
 
+// sendRawHTTP sends the args as the payload of an HTTP request with the method, and decodes the
+// JSON-RPC response.
+func sendRawHTTP(wrappedHttpClient wrappedHttpClient, method string, args []interface{}) (*RPCJSONResponse, error) {
+	// Marshal the HTTP request payload.
+	payload, err := json.Marshal(args)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to marshal HTTP request payload")
+		return nil, err
+	}
+
+	// Create the request.
+	request, err := http.NewRequest(method, wrappedHttpClient.url, bytes.NewBuffer(payload)) // TODO: fix
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to create HTTP request")
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	// Send the request.
+	response, err := wrappedHttpClient.client.Do(request)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to send HTTP request")
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	// Read the response body.
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to read HTTP body")
+		return nil, err
+	}
+
+	// Marshal the response and extract the error if there is any.
+	var rpcResponse RPCJSONResponse
+	err = json.Unmarshal(body, &rpcResponse)
+	if err != nil {
+		log.Error().Err(err).Msg("Unable to unmarshal HTTP body")
+		return nil, err
+	}
+	return &rpcResponse, nil
+}
+
 func CallRPCWithFuzzAndValidate(ctx context.Context, rpcClient *rpc.Client, currTest RPCTest) testreporter.TestResult {
 	currTestResult := testreporter.New(currTest.GetName()+"-FUZZED", currTest.GetMethod(), *testFuzzNum)
 
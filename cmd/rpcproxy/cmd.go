@@ -0,0 +1,131 @@
+package rpcproxy
+
+import (
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+type cmdRPCProxyParams struct {
+	Upstream      *string
+	ListenAddress *string
+	Faults        *[]string
+	LogBodies     *bool
+
+	Latency       *time.Duration
+	Jitter        *time.Duration
+	ErrorRate     *float64
+	MalformedRate *float64
+	RateLimitRate *float64
+
+	// defaults are the faults of the methods without their own.
+	defaults faults
+	// methods are the faults given per method with --fault.
+	methods map[string]faults
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdRPCProxyParams
+)
+
+// RPCProxyCmd represents the rpcproxy command.
+var RPCProxyCmd = &cobra.Command{
+	Use:   "rpcproxy",
+	Short: "Proxy JSON-RPC requests to an upstream endpoint, injecting latency, errors, malformed and rate limited responses.",
+	Long:  usage,
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return serve(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdRPCProxyParams)
+	flagSet := RPCProxyCmd.Flags()
+
+	p.Upstream = flagSet.StringP("upstream", "u", "http://localhost:8545", "The RPC endpoint url the requests are proxied to")
+	p.ListenAddress = flagSet.StringP("listen-address", "l", "127.0.0.1:8546", "The address the proxy listens on")
+	p.LogBodies = flagSet.Bool("log-bodies", false, "Log the bodies of the requests and responses")
+
+	p.Latency = flagSet.Duration("latency", 0, "The latency added to every request")
+	p.Jitter = flagSet.Duration("jitter", 0, "The maximum random latency added on top of --latency")
+	p.ErrorRate = flagSet.Float64("error-rate", 0, "The fraction of the requests answered with a JSON-RPC error instead of being proxied")
+	p.MalformedRate = flagSet.Float64("malformed-rate", 0, "The fraction of the responses that are truncated into invalid JSON")
+	p.RateLimitRate = flagSet.Float64("rate-limit-rate", 0, "The fraction of the requests answered with HTTP 429 Too Many Requests")
+	p.Faults = flagSet.StringArray("fault", nil, "The faults of a method, overriding the defaults, e.g. eth_call:latency=200ms,error-rate=0.1. Can be repeated")
+
+	params = *p
+}
+
+func checkFlags() error {
+	if err := util.ValidateUrl(*params.Upstream); err != nil {
+		return err
+	}
+	params.defaults = faults{
+		Latency:       *params.Latency,
+		Jitter:        *params.Jitter,
+		ErrorRate:     *params.ErrorRate,
+		MalformedRate: *params.MalformedRate,
+		RateLimitRate: *params.RateLimitRate,
+	}
+	if err := params.defaults.validate(); err != nil {
+		return err
+	}
+	params.methods = make(map[string]faults, len(*params.Faults))
+	for _, f := range *params.Faults {
+		method, fs, err := parseFault(f, params.defaults)
+		if err != nil {
+			return err
+		}
+		params.methods[method] = fs
+	}
+	return nil
+}
+
+// parseFault parses the faults of a method in the method:key=value,... form,
+// the faults which aren't given being the defaults.
+func parseFault(s string, defaults faults) (string, faults, error) {
+	method, settings, ok := strings.Cut(s, ":")
+	if !ok || method == "" || settings == "" {
+		return "", faults{}, fmt.Errorf("the fault %q isn't in the method:key=value,... form", s)
+	}
+	fs := defaults
+	for _, setting := range strings.Split(settings, ",") {
+		key, value, ok := strings.Cut(setting, "=")
+		if !ok {
+			return "", faults{}, fmt.Errorf("the setting %q of the fault of %s isn't in the key=value form", setting, method)
+		}
+		var err error
+		switch key {
+		case "latency":
+			fs.Latency, err = time.ParseDuration(value)
+		case "jitter":
+			fs.Jitter, err = time.ParseDuration(value)
+		case "error-rate":
+			fs.ErrorRate, err = strconv.ParseFloat(value, 64)
+		case "malformed-rate":
+			fs.MalformedRate, err = strconv.ParseFloat(value, 64)
+		case "rate-limit-rate":
+			fs.RateLimitRate, err = strconv.ParseFloat(value, 64)
+		default:
+			return "", faults{}, fmt.Errorf("unknown setting %q in the fault of %s", key, method)
+		}
+		if err != nil {
+			return "", faults{}, fmt.Errorf("invalid %s in the fault of %s: %w", key, method, err)
+		}
+	}
+	if err := fs.validate(); err != nil {
+		return "", faults{}, fmt.Errorf("invalid fault of %s: %w", method, err)
+	}
+	return method, fs, nil
+}
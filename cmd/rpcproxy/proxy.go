@@ -0,0 +1,318 @@
+package rpcproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// rateLimitErrorCode is the JSON-RPC error code of a rate limited request,
+	// as returned by geth and most providers.
+	rateLimitErrorCode = -32005
+	// injectedErrorCode is the JSON-RPC error code of an injected error.
+	injectedErrorCode = -32603
+)
+
+type (
+	// faults are the faults injected in the requests of a method. The rates
+	// are the probabilities of each fault, drawn independently for every
+	// request.
+	faults struct {
+		Latency       time.Duration
+		Jitter        time.Duration
+		ErrorRate     float64
+		MalformedRate float64
+		RateLimitRate float64
+	}
+
+	fault int
+
+	rpcRequest struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+	}
+	rpcError struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	rpcErrorResponse struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Error   rpcError        `json:"error"`
+	}
+
+	proxy struct {
+		upstream string
+		client   *http.Client
+	}
+)
+
+const (
+	noFault fault = iota
+	rateLimited
+	errored
+	malformed
+)
+
+func (f fault) String() string {
+	switch f {
+	case rateLimited:
+		return "rate-limit"
+	case errored:
+		return "error"
+	case malformed:
+		return "malformed"
+	default:
+		return "none"
+	}
+}
+
+func (f faults) validate() error {
+	if f.Latency < 0 || f.Jitter < 0 {
+		return errors.New("the latency and the jitter can't be negative")
+	}
+	for _, r := range []float64{f.ErrorRate, f.MalformedRate, f.RateLimitRate} {
+		if r < 0 || r > 1 {
+			return fmt.Errorf("the rate %v isn't between 0 and 1", r)
+		}
+	}
+	return nil
+}
+
+// draw returns the latency and the fault of a request, the rate limit taking
+// precedence over the error, which takes precedence over the malformed
+// response.
+func (f faults) draw() (time.Duration, fault) {
+	latency := f.Latency
+	if f.Jitter > 0 {
+		latency += time.Duration(rand.Int63n(int64(f.Jitter)))
+	}
+	switch {
+	case rand.Float64() < f.RateLimitRate:
+		return latency, rateLimited
+	case rand.Float64() < f.ErrorRate:
+		return latency, errored
+	case rand.Float64() < f.MalformedRate:
+		return latency, malformed
+	default:
+		return latency, noFault
+	}
+}
+
+func faultsOf(method string) faults {
+	if fs, ok := params.methods[method]; ok {
+		return fs
+	}
+	return params.defaults
+}
+
+func serve(ctx context.Context) error {
+	p := &proxy{upstream: *params.Upstream, client: &http.Client{}}
+	server := &http.Server{Addr: *params.ListenAddress, Handler: p}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	log.Info().Str("address", *params.ListenAddress).Str("upstream", p.upstream).Int("methods", len(params.methods)).Msg("Starting the RPC proxy")
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	requests, batch := parseRequests(body)
+	methods := make([]string, len(requests))
+	drawn := make([]fault, len(requests))
+	var delay time.Duration
+	var limited, failed, corrupt bool
+	for i, req := range requests {
+		latency, f := faultsOf(req.Method).draw()
+		methods[i], drawn[i], delay = req.Method, f, max(delay, latency)
+		limited = limited || f == rateLimited
+		failed = failed || f == errored
+		corrupt = corrupt || f == malformed
+	}
+
+	// A batch is as slow as its slowest request.
+	select {
+	case <-r.Context().Done():
+		return
+	case <-time.After(delay):
+	}
+
+	status, header, response, err := p.respond(r, body, requests, batch, drawn, limited, failed)
+	if err != nil {
+		log.Error().Err(err).Strs("methods", methods).Msg("Unable to proxy the request")
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	if corrupt && !limited {
+		response = response[:len(response)/2]
+	}
+
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	if limited {
+		w.Header().Set("Retry-After", "1")
+	}
+	w.WriteHeader(status)
+	if _, err = w.Write(response); err != nil {
+		log.Debug().Err(err).Msg("Unable to write the response")
+	}
+
+	faultNames := make([]string, len(drawn))
+	for i, f := range drawn {
+		faultNames[i] = f.String()
+	}
+	l := log.Info().Strs("methods", methods).Strs("faults", faultNames).Int("status", status).Dur("duration", time.Since(start))
+	if *params.LogBodies {
+		l = l.Str("request", string(body)).Str("response", string(response))
+	}
+	l.Msg("Proxied request")
+}
+
+// respond returns the response to the requests. The rate limited requests are
+// answered without contacting the upstream, and the requests with an injected
+// error are left out of what is sent to the upstream.
+func (p *proxy) respond(r *http.Request, body []byte, requests []rpcRequest, batch bool, drawn []fault, limited, failed bool) (int, http.Header, []byte, error) {
+	header := http.Header{"Content-Type": {"application/json"}}
+	if limited {
+		response, err := errorResponses(requests, batch, rpcError{rateLimitErrorCode, "rate limit exceeded (injected)"})
+		return http.StatusTooManyRequests, header, response, err
+	}
+	if !failed {
+		return p.forward(r, body)
+	}
+
+	// Only the requests without an injected error are sent to the upstream.
+	var forwarded []json.RawMessage
+	var raws []json.RawMessage
+	if batch {
+		if err := json.Unmarshal(body, &raws); err != nil {
+			return 0, nil, nil, err
+		}
+	} else {
+		raws = []json.RawMessage{body}
+	}
+	for i, raw := range raws {
+		if drawn[i] != errored {
+			forwarded = append(forwarded, raw)
+		}
+	}
+	upstream := make(map[string]json.RawMessage)
+	if len(forwarded) > 0 {
+		b, err := json.Marshal(forwarded)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		status, h, response, err := p.forward(r, b)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		var responses []json.RawMessage
+		if err = json.Unmarshal(response, &responses); err != nil {
+			// Not a batch response, e.g. an HTTP error of the upstream.
+			return status, h, response, nil
+		}
+		for _, res := range responses {
+			var req rpcRequest
+			if err = json.Unmarshal(res, &req); err == nil {
+				upstream[string(req.ID)] = res
+			}
+		}
+		header = h
+	}
+
+	responses := make([]json.RawMessage, 0, len(requests))
+	for i, req := range requests {
+		if drawn[i] == errored {
+			b, err := json.Marshal(rpcErrorResponse{"2.0", req.ID, rpcError{injectedErrorCode, "internal error (injected)"}})
+			if err != nil {
+				return 0, nil, nil, err
+			}
+			responses = append(responses, b)
+		} else if res, ok := upstream[string(req.ID)]; ok {
+			responses = append(responses, res)
+		}
+	}
+	if !batch {
+		return http.StatusOK, header, responses[0], nil
+	}
+	response, err := json.Marshal(responses)
+	return http.StatusOK, header, response, err
+}
+
+// forward sends the body to the upstream with the method and the headers of
+// the request.
+func (p *proxy) forward(r *http.Request, body []byte) (int, http.Header, []byte, error) {
+	req, err := http.NewRequestWithContext(r.Context(), r.Method, p.upstream, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	req.Header = r.Header.Clone()
+	// The transport negotiates its own compression so the responses can be
+	// read and altered.
+	req.Header.Del("Accept-Encoding")
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer res.Body.Close()
+	response, err := io.ReadAll(res.Body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	res.Header.Del("Content-Length")
+	res.Header.Del("Content-Encoding")
+	return res.StatusCode, res.Header, response, nil
+}
+
+// parseRequests returns the requests of the body and whether it's a batch. A
+// body which isn't a JSON-RPC request has no request and is proxied as is.
+func parseRequests(body []byte) ([]rpcRequest, bool) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var requests []rpcRequest
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return nil, false
+		}
+		return requests, true
+	}
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil || req.Method == "" {
+		return nil, false
+	}
+	return []rpcRequest{req}, false
+}
+
+// errorResponses returns the same error for every request.
+func errorResponses(requests []rpcRequest, batch bool, rpcErr rpcError) ([]byte, error) {
+	responses := make([]rpcErrorResponse, len(requests))
+	for i, req := range requests {
+		responses[i] = rpcErrorResponse{"2.0", req.ID, rpcErr}
+	}
+	if !batch {
+		return json.Marshal(responses[0])
+	}
+	return json.Marshal(responses)
+}
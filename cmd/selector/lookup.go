@@ -0,0 +1,127 @@
+package selector
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// functions and events are the embedded signatures, the most common ones on Ethereum and Polygon.
+	//go:embed functions.txt
+	functions string
+	//go:embed events.txt
+	events string
+)
+
+// database maps the selectors and the event topics, in hex, to their signatures.
+type database struct {
+	selectors map[string][]string
+	topics    map[string][]string
+}
+
+// newDatabase loads the embedded signatures and the ones of the files, which can be functions or events.
+func newDatabase(files []string) (*database, error) {
+	db := &database{selectors: make(map[string][]string), topics: make(map[string][]string)}
+	if err := db.load(strings.NewReader(functions), true, false); err != nil {
+		return nil, err
+	}
+	if err := db.load(strings.NewReader(events), false, true); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		err = db.load(f, true, true)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to load the signatures of %s: %w", file, err)
+		}
+	}
+	return db, nil
+}
+
+// load adds the signatures, one per line, the empty lines and the ones starting with # being ignored.
+func (db *database) load(r io.Reader, asFunctions, asEvents bool) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sig, _, err := parseSignature(line)
+		if err != nil {
+			return err
+		}
+		hash := hexutil.Encode(crypto.Keccak256([]byte(sig)))
+		if asFunctions {
+			db.selectors[hash[:10]] = appendUnique(db.selectors[hash[:10]], sig)
+		}
+		if asEvents {
+			db.topics[hash] = appendUnique(db.topics[hash], sig)
+		}
+	}
+	return scanner.Err()
+}
+
+func appendUnique(sigs []string, sig string) []string {
+	for _, s := range sigs {
+		if s == sig {
+			return sigs
+		}
+	}
+	return append(sigs, sig)
+}
+
+// fourByteResponse is a page of the signatures returned by the 4byte directory API.
+type fourByteResponse struct {
+	Results []struct {
+		TextSignature string `json:"text_signature"`
+	} `json:"results"`
+}
+
+// lookupOnline queries the 4byte directory for the signatures of a selector, or of an event topic.
+func lookupOnline(baseURL, hash string, event bool) ([]string, error) {
+	endpoint := "/api/v1/signatures/"
+	if event {
+		endpoint = "/api/v1/event-signatures/"
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(strings.TrimSuffix(baseURL, "/") + endpoint + "?hex_signature=" + url.QueryEscape(hash))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the 4byte directory returned %s", resp.Status)
+	}
+	var page fourByteResponse
+	if err = json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, err
+	}
+
+	var sigs []string
+	for _, r := range page.Results {
+		sig, _, err := parseSignature(r.TextSignature)
+		if err != nil {
+			log.Debug().Err(err).Str("signature", r.TextSignature).Msg("Skipping an invalid signature")
+			continue
+		}
+		sigs = appendUnique(sigs, sig)
+	}
+	return sigs, nil
+}
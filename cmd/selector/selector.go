@@ -0,0 +1,236 @@
+package selector
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+type cmdSelectorParams struct {
+	Online      *bool
+	FourByteURL *string
+	Signatures  *[]string
+}
+
+type (
+	// Result identifies an input: the selector and the event topic of a signature, or the signatures of a
+	// selector, an event topic or call data.
+	Result struct {
+		Input      string
+		Kind       string
+		Selector   string   `json:",omitempty"`
+		Topic      string   `json:",omitempty"`
+		Signatures []string `json:",omitempty"`
+		// Source is where the signatures were found: the embedded database with the --signatures files, or
+		// the 4byte directory.
+		Source string `json:",omitempty"`
+		// Arguments are the arguments of the call data decoded with the first signature.
+		Arguments []string `json:",omitempty"`
+	}
+	Results []Result
+)
+
+const (
+	kindSignature = "signature"
+	kindSelector  = "selector"
+	kindTopic     = "topic"
+	kindCallData  = "calldata"
+)
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdSelectorParams
+)
+
+// SelectorCmd represents the selector command.
+var SelectorCmd = &cobra.Command{
+	Use:         "selector [signature|selector|topic|calldata]...",
+	Aliases:     []string{"4byte"},
+	Short:       "Compute the selectors of signatures and look up the signatures of selectors, topics and call data.",
+	Long:        usage,
+	Annotations: map[string]string{util.SideEffectFreeAnnotation: "true"},
+	Example: `# Compute the selector and the event topic of a signature.
+$ polycli selector 'transfer(address,uint256)' # docutil:run
+
+# Identify call data.
+$ polycli selector 0xa9059cbb00000000000000000000000085da99c8a7c2c95964c8efd687e95e632fc533d60000000000000000000000000000000000000000000000000000000000000063 # docutil:run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		return runSelector(args)
+	},
+}
+
+func init() {
+	p := new(cmdSelectorParams)
+	flagSet := SelectorCmd.Flags()
+
+	p.Online = flagSet.Bool("online", false, "Look up the selectors and topics missing from the embedded database in the 4byte directory")
+	p.FourByteURL = flagSet.String("4byte-url", "https://www.4byte.directory", "The url of the 4byte directory")
+	p.Signatures = flagSet.StringSlice("signatures", nil, "Files with additional signatures, one per line")
+
+	params = *p
+}
+
+func runSelector(args []string) error {
+	db, err := newDatabase(*params.Signatures)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		if args, err = readLines(os.Stdin); err != nil {
+			return err
+		}
+	}
+
+	results := make(Results, 0, len(args))
+	for _, input := range args {
+		r, err := identify(db, input)
+		if err != nil {
+			return err
+		}
+		results = append(results, r)
+	}
+	return util.NewOutputWriter(os.Stdout).Write(results)
+}
+
+// readLines returns the non-empty lines of the reader.
+func readLines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// identify hashes the signatures, and looks up the signatures of the hex inputs by their length: a 4 bytes
+// selector, a 32 bytes event topic, or call data starting with a selector.
+func identify(db *database, input string) (Result, error) {
+	r := Result{Input: input}
+	if strings.Contains(input, "(") {
+		sig, _, err := parseSignature(input)
+		if err != nil {
+			return r, err
+		}
+		hash := hexutil.Encode(crypto.Keccak256([]byte(sig)))
+		r.Kind, r.Selector, r.Topic, r.Signatures = kindSignature, hash[:10], hash, []string{sig}
+		return r, nil
+	}
+
+	data, err := hexutil.Decode("0x" + strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return r, fmt.Errorf("%q is neither a signature nor hex data: %w", input, err)
+	}
+	switch {
+	case len(data) == 4:
+		r.Kind, r.Selector = kindSelector, hexutil.Encode(data)
+		r.Signatures, r.Source = lookup(db, r.Selector, false)
+	case len(data) == 32:
+		r.Kind, r.Topic = kindTopic, hexutil.Encode(data)
+		r.Signatures, r.Source = lookup(db, r.Topic, true)
+	case len(data) > 4:
+		r.Kind, r.Selector = kindCallData, hexutil.Encode(data[:4])
+		r.Signatures, r.Source = lookup(db, r.Selector, false)
+		r.Signatures, r.Arguments = decodeCallData(r.Signatures, data[4:])
+	default:
+		return r, fmt.Errorf("%q is shorter than a selector", input)
+	}
+	return r, nil
+}
+
+// lookup returns the signatures of the selector or topic from the database, or from the 4byte directory
+// with --online, and where they were found.
+func lookup(db *database, hash string, event bool) ([]string, string) {
+	sigs := db.selectors[hash]
+	if event {
+		sigs = db.topics[hash]
+	}
+	if len(sigs) > 0 {
+		return sigs, "embedded"
+	}
+	if !*params.Online {
+		return nil, ""
+	}
+	sigs, err := lookupOnline(*params.FourByteURL, hash, event)
+	if err != nil {
+		log.Warn().Err(err).Str("hash", hash).Msg("Unable to look up the 4byte directory")
+		return nil, ""
+	}
+	if len(sigs) == 0 {
+		return nil, ""
+	}
+	return sigs, "4byte.directory"
+}
+
+// decodeCallData keeps the signatures the arguments can be decoded with, a selector colliding with others,
+// and returns the arguments decoded with the first one. All the signatures are kept when none fits.
+func decodeCallData(sigs []string, data []byte) ([]string, []string) {
+	var matching []string
+	var decoded []string
+	for _, sig := range sigs {
+		_, args, err := parseSignature(sig)
+		if err != nil {
+			continue
+		}
+		values, err := decodeArguments(args, data)
+		if err != nil {
+			log.Debug().Err(err).Str("signature", sig).Msg("The call data doesn't match the signature")
+			continue
+		}
+		if matching == nil {
+			decoded = values
+		}
+		matching = append(matching, sig)
+	}
+	if len(matching) == 0 {
+		return sigs, nil
+	}
+	return matching, decoded
+}
+
+// WriteText renders the results as a table, with a row per signature followed by the decoded arguments.
+func (rs Results) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Input", "Kind", "Hash", "Signature", "Source"})
+	for _, r := range rs {
+		input := r.Input
+		if len(input) > 20 {
+			input = input[:18] + "…"
+		}
+		switch {
+		case r.Kind == kindSignature:
+			t.AppendRow(table.Row{input, "selector", r.Selector, r.Signatures[0], ""})
+			t.AppendRow(table.Row{"", "topic", r.Topic, r.Signatures[0], ""})
+			continue
+		case len(r.Signatures) == 0:
+			t.AppendRow(table.Row{input, r.Kind, r.Selector + r.Topic, "unknown", ""})
+			continue
+		}
+		for i, sig := range r.Signatures {
+			if i > 0 {
+				input = ""
+			}
+			t.AppendRow(table.Row{input, r.Kind, r.Selector + r.Topic, sig, r.Source})
+		}
+		for i, arg := range r.Arguments {
+			t.AppendRow(table.Row{"", fmt.Sprintf("  arg %d", i), "", arg, ""})
+		}
+	}
+	t.Render()
+	return nil
+}
@@ -0,0 +1,216 @@
+package selector
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"unicode"
+
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// parseSignature parses a function or event signature as written in Solidity or in an ABI, e.g.
+// "function transfer(address to, uint amount) returns (bool)", ignoring the parameter names and the return
+// values. It returns the canonical signature, e.g. transfer(address,uint256), with its arguments.
+func parseSignature(sig string) (string, gethabi.Arguments, error) {
+	sig = strings.TrimSpace(sig)
+	for _, keyword := range []string{"function", "event", "error"} {
+		if rest, ok := strings.CutPrefix(sig, keyword+" "); ok {
+			sig = strings.TrimSpace(rest)
+			break
+		}
+	}
+	open := strings.IndexByte(sig, '(')
+	if open < 0 {
+		return "", nil, fmt.Errorf("the signature %q has no parameter list", sig)
+	}
+	name := strings.TrimSpace(sig[:open])
+	if !isIdentifier(name) {
+		return "", nil, fmt.Errorf("invalid name %q in the signature %q", name, sig)
+	}
+	inner, _, err := matchParen(sig[open:])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid signature %q: %w", sig, err)
+	}
+	marshalings, err := parseParameters(inner)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid signature %q: %w", sig, err)
+	}
+
+	args := make(gethabi.Arguments, len(marshalings))
+	types := make([]string, len(marshalings))
+	for i, m := range marshalings {
+		t, err := gethabi.NewType(m.Type, "", m.Components)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid signature %q: %w", sig, err)
+		}
+		args[i] = gethabi.Argument{Name: m.Name, Type: t}
+		types[i] = t.String()
+	}
+	return fmt.Sprintf("%s(%s)", name, strings.Join(types, ",")), args, nil
+}
+
+// matchParen returns the content of the parentheses starting s, and what follows them.
+func matchParen(s string) (string, string, error) {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s[1:i], s[i+1:], nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("unbalanced parentheses")
+}
+
+// parseParameters parses a comma separated list of parameters, each a type optionally followed by a data
+// location, the indexed keyword and a name, the tuples being written between parentheses.
+func parseParameters(s string) ([]gethabi.ArgumentMarshaling, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var params []gethabi.ArgumentMarshaling
+	depth, start := 0, 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) {
+			switch s[i] {
+			case '(':
+				depth++
+				continue
+			case ')':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		param, err := parseParameter(strings.TrimSpace(s[start:i]), len(params))
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+		start = i + 1
+	}
+	return params, nil
+}
+
+func parseParameter(s string, index int) (gethabi.ArgumentMarshaling, error) {
+	// the tuple components need a name to be unpacked into a struct
+	param := gethabi.ArgumentMarshaling{Name: fmt.Sprintf("arg%d", index)}
+	if s == "" {
+		return param, fmt.Errorf("empty parameter")
+	}
+	if strings.HasPrefix(s, "tuple(") {
+		s = s[len("tuple"):]
+	}
+	if strings.HasPrefix(s, "(") {
+		inner, rest, err := matchParen(s)
+		if err != nil {
+			return param, err
+		}
+		if param.Components, err = parseParameters(inner); err != nil {
+			return param, err
+		}
+		suffix, _, _ := strings.Cut(rest, " ")
+		param.Type = "tuple" + strings.TrimSpace(suffix)
+		return param, nil
+	}
+	param.Type = canonicalType(strings.Fields(s)[0])
+	return param, nil
+}
+
+// canonicalType replaces the aliases uint, int and byte by the types they stand for.
+func canonicalType(t string) string {
+	base, suffix := t, ""
+	if i := strings.IndexByte(t, '['); i >= 0 {
+		base, suffix = t[:i], t[i:]
+	}
+	switch base {
+	case "uint", "int":
+		base += "256"
+	case "byte":
+		base = "bytes1"
+	}
+	return base + suffix
+}
+
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeArguments unpacks the call data following the selector, failing when it doesn't match the arguments.
+func decodeArguments(args gethabi.Arguments, data []byte) ([]string, error) {
+	if len(data)%32 != 0 {
+		return nil, fmt.Errorf("the call data isn't a multiple of 32 bytes")
+	}
+	values, err := args.UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+	// the encoding of the arguments is unique, so an encoding which differs reveals a wrong signature
+	// decoding garbage, e.g. a shorter list of static arguments
+	encoded, err := args.PackValues(values)
+	if err != nil || !bytes.Equal(encoded, data) {
+		return nil, fmt.Errorf("the call data doesn't match the arguments")
+	}
+	formatted := make([]string, len(values))
+	for i, v := range values {
+		formatted[i] = formatValue(reflect.ValueOf(v))
+	}
+	return formatted, nil
+}
+
+// formatValue formats an unpacked value, the bytes in hex and the tuples between parentheses.
+func formatValue(v reflect.Value) string {
+	switch t := v.Interface().(type) {
+	case common.Address:
+		return t.Hex()
+	case *big.Int:
+		return t.String()
+	case []byte:
+		return hexutil.Encode(t)
+	}
+	switch v.Kind() {
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		elems := make([]string, v.Len())
+		for i := range elems {
+			elems[i] = formatValue(v.Index(i))
+		}
+		return "[" + strings.Join(elems, ", ") + "]"
+	case reflect.Struct:
+		fields := make([]string, v.NumField())
+		for i := range fields {
+			fields[i] = formatValue(v.Field(i))
+		}
+		return "(" + strings.Join(fields, ", ") + ")"
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
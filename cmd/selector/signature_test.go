@@ -0,0 +1,52 @@
+package selector
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseSignature(t *testing.T) {
+	for input, expected := range map[string]string{
+		"transfer(address,uint256)": "transfer(address,uint256)",
+		"function transfer(address to, uint amount) external returns (bool)":   "transfer(address,uint256)",
+		"event Transfer(address indexed from, address indexed to, uint value)": "Transfer(address,address,uint256)",
+		"f((uint, bytes32[2])[] calldata orders, tuple(int,byte) p)":           "f((uint256,bytes32[2])[],(int256,bytes1))",
+		"totalSupply()": "totalSupply()",
+	} {
+		sig, _, err := parseSignature(input)
+		if err != nil {
+			t.Errorf("%s: %v", input, err)
+		} else if sig != expected {
+			t.Errorf("%s: got %s, expected %s", input, sig, expected)
+		}
+	}
+	for _, input := range []string{"transfer", "(address)", "f(address", "f(foo)", "f(address,)"} {
+		if _, _, err := parseSignature(input); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
+
+func TestDecodeCallData(t *testing.T) {
+	_, args, err := parseSignature("f((address,uint256[])[],bytes)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type order struct {
+		Arg0 common.Address
+		Arg1 []*big.Int
+	}
+	data, err := args.Pack([]order{{common.HexToAddress("0x01"), []*big.Int{big.NewInt(7), big.NewInt(8)}}}, []byte{0xca, 0xfe})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigs, values := decodeCallData([]string{"g(uint256)", "f((address,uint256[])[],bytes)"}, data)
+	expected := []string{"[(0x0000000000000000000000000000000000000001, [7, 8])]", "0xcafe"}
+	if !reflect.DeepEqual(sigs, []string{"f((address,uint256[])[],bytes)"}) || !reflect.DeepEqual(values, expected) {
+		t.Fatalf("got %q %q", sigs, values)
+	}
+}
@@ -1,9 +1,7 @@
 package signer
 
 import (
-	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -16,7 +14,6 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
-	"hash/crc32"
 	"math/big"
 	"os"
 	"strings"
@@ -27,17 +24,17 @@ import (
 	accounts2 "github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/google/tink/go/kwp/subtle"
 	"github.com/manifoldco/promptui"
-	"github.com/maticnetwork/polygon-cli/gethkeystore"
+	polysigner "github.com/maticnetwork/polygon-cli/signer"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"google.golang.org/api/iterator"
 	"google.golang.org/protobuf/types/known/durationpb"
-	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // signerOpts are the input arguments for these commands
@@ -45,9 +42,13 @@ type signerOpts struct {
 	keystore       *string
 	privateKey     *string
 	kms            *string
+	ledger         *string
+	signerURL      *string
 	keyID          *string
 	unsafePassword *string
 	dataFile       *string
+	message        *string
+	typedData      *string
 	signerType     *string
 	chainID        *uint64
 	gcpProjectID   *string
@@ -88,67 +89,19 @@ var SignCmd = &cobra.Command{
 	Args:    cobra.NoArgs,
 	PreRunE: sanityCheck,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if *inputSignerOpts.keystore == "" && *inputSignerOpts.privateKey == "" && *inputSignerOpts.kms == "" {
-			return fmt.Errorf("no valid keystore was specified")
-		}
-
-		if *inputSignerOpts.keystore != "" {
-			ks := keystore.NewKeyStore(*inputSignerOpts.keystore, keystore.StandardScryptN, keystore.StandardScryptP)
-			accounts := ks.Accounts()
-			var accountToUnlock *accounts2.Account
-			for _, a := range accounts {
-				if a.Address.String() == *inputSignerOpts.keyID {
-					accountToUnlock = &a
-					break
-				}
-			}
-			if accountToUnlock == nil {
-				accountStrings := ""
-				for _, a := range accounts {
-					accountStrings += a.Address.String() + " "
-				}
-				return fmt.Errorf("the account with address <%s> could not be found in list [%s]", *inputSignerOpts.keyID, accountStrings)
-			}
-			password, err := getKeystorePassword()
-			if err != nil {
-				return err
-			}
-
-			err = ks.Unlock(*accountToUnlock, password)
-			if err != nil {
-				return err
-			}
-			// chainID := new(big.Int).SetUint64(*inputSignerOpts.chainID)
-
-			// ks.SignTx(*accountToUnlock, &tx, chainID)
-			log.Info().Str("path", accountToUnlock.URL.Path).Msg("Unlocked account")
-			encryptedKey, err := os.ReadFile(accountToUnlock.URL.Path)
-			if err != nil {
-				return err
-			}
-			privKey, err := gethkeystore.DecryptKeystoreFile(encryptedKey, password)
-			if err != nil {
-				return err
-			}
-			return sign(privKey)
+		s, err := getKeySigner(cmd.Context())
+		if err != nil {
+			return err
 		}
+		log.Info().Str("address", s.Address().String()).Msg("Signing")
 
-		if *inputSignerOpts.privateKey != "" {
-			pk, err := crypto.HexToECDSA(*inputSignerOpts.privateKey)
-			if err != nil {
-				return err
-			}
-			return sign(pk)
+		if cmd.Flags().Changed("message") {
+			return signMessage(cmd.Context(), s)
 		}
-		if *inputSignerOpts.kms == "GCP" {
-			tx, err := getTxDataToSign()
-			if err != nil {
-				return err
-			}
-			gcpKMS := GCPKMS{}
-			return gcpKMS.Sign(cmd.Context(), tx)
+		if cmd.Flags().Changed("typed-data") {
+			return signTypedData(cmd.Context(), s)
 		}
-		return fmt.Errorf("not implemented")
+		return sign(cmd.Context(), s)
 	},
 }
 
@@ -203,7 +156,7 @@ var CreateCmd = &cobra.Command{
 
 var ListCmd = &cobra.Command{
 	Use:     "list",
-	Short:   "List the keys in the keyring / keystore",
+	Short:   "List the keys in the keyring / keystore / external signer",
 	Long:    listCmdUsage,
 	Args:    cobra.NoArgs,
 	PreRunE: sanityCheck,
@@ -220,6 +173,9 @@ var ListCmd = &cobra.Command{
 			gcpKMS := GCPKMS{}
 			return gcpKMS.ListKeyRingKeys(cmd.Context())
 		}
+		if *inputSignerOpts.signerURL != "" {
+			return listSignerAccounts(cmd.Context())
+		}
 		return fmt.Errorf("unable to list accounts")
 	},
 }
@@ -281,7 +237,47 @@ func getTxDataToSign() (*types.Transaction, error) {
 	return tx.ToTransaction(), nil
 
 }
-func sign(pk *ecdsa.PrivateKey) error {
+
+// getKeySigner returns the signer of the key selected by the flags.
+func getKeySigner(ctx context.Context) (polysigner.Signer, error) {
+	switch {
+	case *inputSignerOpts.keystore != "":
+		if !common.IsHexAddress(*inputSignerOpts.keyID) {
+			return nil, fmt.Errorf("the key id must be the address of the keystore account")
+		}
+		password, err := getKeystorePassword()
+		if err != nil {
+			return nil, err
+		}
+		return polysigner.NewKeystoreSigner(*inputSignerOpts.keystore, common.HexToAddress(*inputSignerOpts.keyID), password)
+	case *inputSignerOpts.privateKey != "":
+		return polysigner.NewHexSigner(*inputSignerOpts.privateKey)
+	case *inputSignerOpts.kms == "GCP":
+		name := fmt.Sprintf("projects/%s/locations/%s/keyRings/%s/cryptoKeys/%s/cryptoKeyVersions/%d", *inputSignerOpts.gcpProjectID, *inputSignerOpts.gcpRegion, *inputSignerOpts.gcpKeyRingID, *inputSignerOpts.keyID, *inputSignerOpts.gcpKeyVersion)
+		return polysigner.NewGCPKMSSigner(ctx, name)
+	case *inputSignerOpts.kms != "":
+		return polysigner.NewKMSSigner(ctx, *inputSignerOpts.kms, *inputSignerOpts.keyID)
+	case *inputSignerOpts.ledger != "":
+		return polysigner.NewLedgerSigner(*inputSignerOpts.ledger)
+	case *inputSignerOpts.signerURL != "":
+		return polysigner.NewClefSigner(ctx, *inputSignerOpts.signerURL, *inputSignerOpts.keyID)
+	}
+	return nil, fmt.Errorf("no valid keystore was specified")
+}
+
+// listSignerAccounts lists the accounts of the external signer.
+func listSignerAccounts(ctx context.Context) error {
+	accounts, err := polysigner.ListClefAccounts(ctx, *inputSignerOpts.signerURL)
+	if err != nil {
+		return err
+	}
+	for idx, a := range accounts {
+		log.Info().Str("account", a.String()).Int("index", idx).Msg("Account")
+	}
+	return nil
+}
+
+func sign(ctx context.Context, s polysigner.Signer) error {
 	tx, err := getTxDataToSign()
 	if err != nil {
 		return err
@@ -290,13 +286,70 @@ func sign(pk *ecdsa.PrivateKey) error {
 	if err != nil {
 		return err
 	}
-	signedTx, err := types.SignTx(tx, signer, pk)
+	signedTx, err := s.SignTx(ctx, tx, signer)
 	if err != nil {
 		return err
 	}
 	return outputSignedTx(signedTx)
 }
 
+// signMessage signs the message as an EIP-191 personal message. A 0x prefixed
+// message is decoded from hex.
+func signMessage(ctx context.Context, s polysigner.Signer) error {
+	msg := []byte(*inputSignerOpts.message)
+	if strings.HasPrefix(*inputSignerOpts.message, "0x") {
+		var err error
+		if msg, err = hexutil.Decode(*inputSignerOpts.message); err != nil {
+			return err
+		}
+	}
+	sig, err := s.SignMessage(ctx, msg)
+	if err != nil {
+		return err
+	}
+	out := make(map[string]any, 0)
+	out["address"] = s.Address().String()
+	out["message"] = hexutil.Encode(msg)
+	out["signature"] = hexutil.Encode(sig)
+	outJSON, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(outJSON))
+	return nil
+}
+
+// signTypedData signs the EIP-712 typed data of the JSON file, formatted as
+// the parameter of `eth_signTypedData_v4`.
+func signTypedData(ctx context.Context, s polysigner.Signer) error {
+	data, err := os.ReadFile(*inputSignerOpts.typedData)
+	if err != nil {
+		return err
+	}
+	var typedData apitypes.TypedData
+	if err = json.Unmarshal(data, &typedData); err != nil {
+		return fmt.Errorf("unable to parse the typed data: %w", err)
+	}
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return err
+	}
+	sig, err := s.SignTypedData(ctx, typedData)
+	if err != nil {
+		return err
+	}
+	out := make(map[string]any, 0)
+	out["address"] = s.Address().String()
+	out["hash"] = hexutil.Encode(hash)
+	out["signature"] = hexutil.Encode(sig)
+	outJSON, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(outJSON))
+	return nil
+}
+
 func outputSignedTx(signedTx *types.Transaction) error {
 	rawTx, err := signedTx.MarshalBinary()
 	if err != nil {
@@ -623,104 +676,6 @@ type pkcs8 struct {
 	// optional attributes omitted.
 }
 
-func (g *GCPKMS) Sign(ctx context.Context, tx *types.Transaction) error {
-	name := fmt.Sprintf("projects/%s/locations/%s/keyRings/%s/cryptoKeys/%s/cryptoKeyVersions/%d", *inputSignerOpts.gcpProjectID, *inputSignerOpts.gcpRegion, *inputSignerOpts.gcpKeyRingID, *inputSignerOpts.keyID, *inputSignerOpts.gcpKeyVersion)
-
-	client, err := kms.NewKeyManagementClient(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to create kms client: %w", err)
-	}
-	defer client.Close()
-
-	signer, err := getSigner()
-	if err != nil {
-		return err
-	}
-	digest := signer.Hash(tx)
-
-	// Optional but recommended: Compute digest's CRC32C.
-	crc32c := func(data []byte) uint32 {
-		t := crc32.MakeTable(crc32.Castagnoli)
-		return crc32.Checksum(data, t)
-
-	}
-	digestCRC32C := crc32c(digest.Bytes())
-
-	req := &kmspb.AsymmetricSignRequest{
-		Name: name,
-		Digest: &kmspb.Digest{
-			Digest: &kmspb.Digest_Sha256{
-				Sha256: digest.Bytes(),
-			},
-		},
-		DigestCrc32C: wrapperspb.Int64(int64(digestCRC32C)),
-	}
-
-	// Call the API.
-	result, err := client.AsymmetricSign(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to sign digest: %w", err)
-	}
-
-	// Optional, but recommended: perform integrity verification on result.
-	// For more details on ensuring E2E in-transit integrity to and from Cloud KMS visit:
-	// https://cloud.google.com/kms/docs/data-integrity-guidelines
-	if !result.VerifiedDigestCrc32C {
-		return fmt.Errorf("AsymmetricSign: request corrupted in-transit")
-	}
-	if result.Name != req.Name {
-		return fmt.Errorf("AsymmetricSign: request corrupted in-transit")
-	}
-	if int64(crc32c(result.Signature)) != result.SignatureCrc32C.Value {
-		return fmt.Errorf("AsymmetricSign: response corrupted in-transit")
-	}
-
-	gcpPubKey, err := getPublicKeyByName(ctx, client, name)
-	if err != nil {
-		return err
-	}
-
-	// Verify Elliptic Curve signature.
-	var parsedSig struct{ R, S *big.Int }
-	if _, err = asn1.Unmarshal(result.Signature, &parsedSig); err != nil {
-		return fmt.Errorf("asn1.Unmarshal: %w", err)
-	}
-	ethSig := make([]byte, 0)
-
-	ethSig = append(ethSig, bigIntTo32Bytes(parsedSig.R)...)
-	ethSig = append(ethSig, bigIntTo32Bytes(parsedSig.S)...)
-	ethSig = append(ethSig, 0)
-
-	// Feels like a hack, but I cna't figure out a better way to determine the recovery ID than this since google isn't returning it. More research is required
-	pubKey, err := crypto.Ecrecover(digest.Bytes(), ethSig)
-	if err != nil || !bytes.Equal(pubKey, gcpPubKey.PublicKey.Bytes) {
-		ethSig[64] = 1
-	}
-	pubKey, err = crypto.Ecrecover(digest.Bytes(), ethSig)
-	if err != nil || !bytes.Equal(pubKey, gcpPubKey.PublicKey.Bytes) {
-		return fmt.Errorf("unable to determine recovery identifier value: %w", err)
-	}
-	// pubKeyAddr := common.BytesToAddress(crypto.Keccak256(pubKey[1:])[12:])
-	pubKeyAddr := gcpPubKeyToEthAddress(gcpPubKey)
-	log.Info().
-		Str("hexSignature", hex.EncodeToString(result.Signature)).
-		Str("ethSignature", hex.EncodeToString(ethSig)).
-		Msg("Got signature")
-
-	log.Info().
-		Str("recoveredPub", hex.EncodeToString(pubKey)).
-		Str("gcpPub", hex.EncodeToString(gcpPubKey.PublicKey.Bytes)).
-		Str("ethAddress", pubKeyAddr.String()).
-		Msg("Recovered pub key")
-
-	signedTx, err := tx.WithSignature(signer, ethSig)
-	if err != nil {
-		return err
-	}
-
-	return outputSignedTx(signedTx)
-}
-
 func gcpPubKeyToEthAddress(gcpPubKey *publicKeyInfo) common.Address {
 	pubKeyAddr := common.BytesToAddress(crypto.Keccak256(gcpPubKey.PublicKey.Bytes[1:])[12:])
 	return pubKeyAddr
@@ -738,15 +693,6 @@ func getPublicKeyByName(ctx context.Context, client *kms.KeyManagementClient, na
 	}
 	return &gcpPubKey, nil
 }
-func bigIntTo32Bytes(num *big.Int) []byte {
-	// Convert big.Int to a 32-byte array
-	b := num.Bytes()
-	if len(b) < 32 {
-		// Left-pad with zeros if needed
-		b = append(make([]byte, 32-len(b)), b...)
-	}
-	return b
-}
 func getKeystorePassword() (string, error) {
 	if *inputSignerOpts.unsafePassword != "" {
 		return *inputSignerOpts.unsafePassword, nil
@@ -771,9 +717,18 @@ func sanityCheck(cmd *cobra.Command, args []string) error {
 	if *inputSignerOpts.keystore != "" {
 		keyStoreMethods += 1
 	}
+	if *inputSignerOpts.ledger != "" {
+		keyStoreMethods += 1
+	}
+	if *inputSignerOpts.signerURL != "" {
+		keyStoreMethods += 1
+	}
 	if keyStoreMethods > 1 {
 		return fmt.Errorf("Multiple conflicting keystore sources were specified")
 	}
+	if *inputSignerOpts.ledger != "" && cmd.Flags().Changed("message") {
+		return polysigner.ErrLedgerPersonalMessage
+	}
 	pwErr := passwordValidation(*inputSignerOpts.unsafePassword)
 	if *inputSignerOpts.unsafePassword != "" && pwErr != nil {
 		return pwErr
@@ -838,11 +793,17 @@ func init() {
 	inputSignerOpts.keystore = SignerCmd.PersistentFlags().String("keystore", "", "Use the keystore in the given folder or file")
 	inputSignerOpts.privateKey = SignerCmd.PersistentFlags().String("private-key", "", "Use the provided hex encoded private key")
 	inputSignerOpts.kms = SignerCmd.PersistentFlags().String("kms", "", "AWS or GCP if the key is stored in the cloud")
+	inputSignerOpts.ledger = SignerCmd.PersistentFlags().String("ledger", "", "Use the account of a Ledger device at the given derivation path")
+	SignerCmd.PersistentFlags().Lookup("ledger").NoOptDefVal = accounts2.DefaultBaseDerivationPath.String()
+	inputSignerOpts.signerURL = SignerCmd.PersistentFlags().String("signer-url", "", "Use an external signer speaking the Clef API at the given HTTP url or IPC path")
 	inputSignerOpts.keyID = SignerCmd.PersistentFlags().String("key-id", "", "The id of the key to be used for signing")
 	inputSignerOpts.unsafePassword = SignerCmd.PersistentFlags().String("unsafe-password", "", "A non-interactively specified password for unlocking the keystore")
 
 	inputSignerOpts.signerType = SignerCmd.PersistentFlags().String("type", "london", "The type of signer to use: latest, cancun, london, eip2930, eip155")
 	inputSignerOpts.dataFile = SignerCmd.PersistentFlags().String("data-file", "", "File name holding data to be signed")
+	inputSignerOpts.message = SignCmd.Flags().String("message", "", "Sign this EIP-191 personal message instead of a transaction, decoded from hex if 0x prefixed")
+	inputSignerOpts.typedData = SignCmd.Flags().String("typed-data", "", "Sign the EIP-712 typed data of this JSON file instead of a transaction")
+	SignCmd.MarkFlagsMutuallyExclusive("message", "typed-data")
 
 	inputSignerOpts.chainID = SignerCmd.PersistentFlags().Uint64("chain-id", 0, "The chain id for the transactions.")
 
@@ -0,0 +1,338 @@
+package statesize
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+// freezerIndexEntrySize is the size of an entry of the index of a freezer
+// table, which has one more entry than the table has items.
+const freezerIndexEntrySize = 6
+
+type (
+	statesizeParams struct {
+		DBPath      *string
+		DBMode      *string
+		Cache       *int
+		Handles     *int
+		AncientPath *string
+		Limit       *uint64
+	}
+
+	// category groups the keys of the geth/bor chaindata schema. A key
+	// matches when it has the prefix and, for a non zero key length, the
+	// length.
+	category struct {
+		name   string
+		prefix []byte
+		keyLen int
+	}
+
+	// StateSize is the breakdown of the disk usage of a chaindata database.
+	StateSize struct {
+		Path       string
+		Categories []*CategorySize
+		Ancient    []*CategorySize `json:",omitempty"`
+		Total      CategorySize
+		Duration   time.Duration
+	}
+	// CategorySize is the usage of the keys of a category, or of a table of
+	// the ancient store whose sizes are the sizes of its files.
+	CategorySize struct {
+		Category         string
+		Count            uint64
+		KeyBytes         uint64
+		ValueBytes       uint64
+		TotalBytes       uint64
+		AverageValueSize float64
+	}
+)
+
+var (
+	//go:embed usage.md
+	usage  string
+	params statesizeParams
+
+	hashLen = common.HashLength
+	numLen  = 8
+
+	// categories are matched in order, so the specific keys come before the
+	// prefixes they share, e.g. the header numbers before the headers.
+	categories = []category{
+		{"Headers", []byte("h"), 1 + numLen + hashLen},
+		{"Total difficulties", []byte("h"), 1 + numLen + hashLen + 1},
+		{"Canonical hashes", []byte("h"), 1 + numLen + 1},
+		{"Header numbers", []byte("H"), 1 + hashLen},
+		{"Bodies", []byte("b"), 1 + numLen + hashLen},
+		{"Receipts", []byte("r"), 1 + numLen + hashLen},
+		{"Transaction lookups", []byte("l"), 1 + hashLen},
+		{"Bloom bits", []byte("B"), 1 + 10 + hashLen},
+		{"Bloom bits index", []byte("iB"), 0},
+		{"Contract codes", []byte("c"), 1 + hashLen},
+		{"Account snapshots", []byte("a"), 1 + hashLen},
+		{"Storage snapshots", []byte("o"), 1 + 2*hashLen},
+		{"Path-based account trie nodes", []byte("A"), 0},
+		{"Path-based storage trie nodes", []byte("O"), 0},
+		{"State ids", []byte("L"), 1 + hashLen},
+		{"Preimages", []byte("secure-key-"), len("secure-key-") + hashLen},
+		{"Chain configs", []byte("ethereum-config-"), 0},
+		{"Genesis states", []byte("ethereum-genesis-"), 0},
+		{"Skeleton headers", []byte("S"), 1 + numLen},
+		{"Clique snapshots", []byte("clique-"), len("clique-") + hashLen},
+		// Bor stores the receipts of its state sync transactions separately.
+		{"Bor receipts", []byte("matic-bor-receipt-"), 0},
+		{"Bor transaction lookups", []byte("matic-bor-tx-lookup-"), 0},
+	}
+)
+
+const (
+	hashTrieCategory = "Hash-based trie nodes"
+	otherCategory    = "Other"
+)
+
+var StateSizeCmd = &cobra.Command{
+	Use:   "state-size",
+	Short: "Report the disk usage of a chaindata database by category.",
+	Long:  usage,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := openDB()
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		cmd.SilenceUsage = true
+
+		start := time.Now()
+		s, err := inspect(db)
+		if err != nil {
+			return err
+		}
+		ancientPath := *params.AncientPath
+		if ancientPath == "" {
+			ancientPath = filepath.Join(*params.DBPath, "ancient")
+		}
+		if s.Ancient, err = inspectAncient(ancientPath); err != nil {
+			return err
+		}
+		for _, c := range s.Ancient {
+			s.Total.add(c)
+		}
+		s.Duration = time.Since(start)
+		return util.NewOutputWriter(os.Stdout).Write(s)
+	},
+}
+
+func init() {
+	flagSet := StateSizeCmd.Flags()
+	params.DBPath = flagSet.String("db-path", "", "The path of the chaindata database")
+	params.DBMode = flagSet.String("db-mode", "leveldb", "The format of the database: leveldb or pebbledb")
+	params.Cache = flagSet.Int("cache-size", 512, "The number of megabytes to use as cache")
+	params.Handles = flagSet.Int("handles", 500, "The number of open files")
+	params.AncientPath = flagSet.String("ancient-path", "", "The path of the ancient store (default: the ancient folder of the database)")
+	params.Limit = flagSet.Uint64("limit", 0, "The maximum number of keys to inspect, 0 for no limit")
+	_ = StateSizeCmd.MarkFlagRequired("db-path")
+}
+
+// openDB opens the database read only in the format given by the db mode.
+func openDB() (ethdb.KeyValueStore, error) {
+	log.Info().Str("path", *params.DBPath).Str("mode", *params.DBMode).Msg("Opening database")
+	switch *params.DBMode {
+	case "leveldb":
+		return leveldb.New(*params.DBPath, *params.Cache, *params.Handles, "", true)
+	case "pebbledb":
+		return pebble.New(*params.DBPath, *params.Cache, *params.Handles, "", true, false)
+	default:
+		return nil, fmt.Errorf("the mode %s is not recognized", *params.DBMode)
+	}
+}
+
+// inspect iterates over the whole database and sums the sizes of the keys
+// and values of each category.
+func inspect(db ethdb.KeyValueStore) (*StateSize, error) {
+	sizes := make(map[string]*CategorySize)
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+
+	var count uint64
+	for it.Next() {
+		if *params.Limit > 0 && count >= *params.Limit {
+			break
+		}
+		key, value := it.Key(), it.Value()
+		name := classify(key, value)
+		c, ok := sizes[name]
+		if !ok {
+			c = &CategorySize{Category: name}
+			sizes[name] = c
+		}
+		c.Count++
+		c.KeyBytes += uint64(len(key))
+		c.ValueBytes += uint64(len(value))
+
+		count++
+		if count%10000000 == 0 {
+			log.Info().Uint64("count", count).Str("currentKey", hexutil.Encode(key)).Msg("Inspecting keys")
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	s := &StateSize{Path: *params.DBPath, Total: CategorySize{Category: "Total"}}
+	for _, c := range sizes {
+		c.TotalBytes = c.KeyBytes + c.ValueBytes
+		c.AverageValueSize = float64(c.ValueBytes) / float64(c.Count)
+		s.Categories = append(s.Categories, c)
+		s.Total.add(c)
+	}
+	// The largest categories first.
+	sort.Slice(s.Categories, func(i, j int) bool {
+		return s.Categories[i].TotalBytes > s.Categories[j].TotalBytes
+	})
+	return s, nil
+}
+
+// classify returns the category of the entry.
+func classify(key, value []byte) string {
+	// The hash based trie nodes are keyed by the bare hash of their value,
+	// which may start like the other prefixes.
+	if len(key) == hashLen && bytes.Equal(key, crypto.Keccak256(value)) {
+		return hashTrieCategory
+	}
+	for _, c := range categories {
+		if bytes.HasPrefix(key, c.prefix) && (c.keyLen == 0 || len(key) == c.keyLen) {
+			return c.name
+		}
+	}
+	return otherCategory
+}
+
+// inspectAncient sums the sizes of the files of each table of the ancient
+// store, the tables being grouped with their folder, e.g. chain/bodies.
+func inspectAncient(path string) ([]*CategorySize, error) {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		log.Debug().Str("path", path).Msg("No ancient store")
+		return nil, nil
+	}
+
+	tables := make(map[string]*CategorySize)
+	err := filepath.WalkDir(path, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(path, name)
+		if err != nil {
+			return err
+		}
+		base, ext, ok := strings.Cut(filepath.Base(rel), ".")
+		// The freezer files are named after their table, e.g. bodies.0000.cdat.
+		if !ok {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		table := filepath.ToSlash(filepath.Join(filepath.Dir(rel), base))
+		c, ok := tables[table]
+		if !ok {
+			c = &CategorySize{Category: "Ancient " + table}
+			tables[table] = c
+		}
+		size := uint64(info.Size())
+		switch ext {
+		case "ridx", "cidx":
+			c.KeyBytes += size
+			if size >= freezerIndexEntrySize {
+				c.Count = size/freezerIndexEntrySize - 1
+			}
+		case "meta":
+			c.KeyBytes += size
+		default:
+			c.ValueBytes += size
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ancient := make([]*CategorySize, 0, len(tables))
+	for _, c := range tables {
+		c.TotalBytes = c.KeyBytes + c.ValueBytes
+		if c.Count > 0 {
+			c.AverageValueSize = float64(c.ValueBytes) / float64(c.Count)
+		}
+		ancient = append(ancient, c)
+	}
+	sort.Slice(ancient, func(i, j int) bool {
+		return ancient[i].TotalBytes > ancient[j].TotalBytes
+	})
+	return ancient, nil
+}
+
+func (c *CategorySize) add(o *CategorySize) {
+	c.Count += o.Count
+	c.KeyBytes += o.KeyBytes
+	c.ValueBytes += o.ValueBytes
+	c.TotalBytes += o.TotalBytes
+	if c.Count > 0 {
+		c.AverageValueSize = float64(c.ValueBytes) / float64(c.Count)
+	}
+}
+
+// WriteText renders the categories as a table, the key/value store first and
+// then the ancient store.
+func (s *StateSize) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetTitle(fmt.Sprintf("%s (inspected in %s)", s.Path, s.Duration.Round(time.Millisecond)))
+	t.AppendHeader(table.Row{"Category", "Count", "Keys", "Values", "Total", "Avg Value"})
+	row := func(c *CategorySize) table.Row {
+		return table.Row{c.Category, c.Count, common.StorageSize(c.KeyBytes), common.StorageSize(c.ValueBytes),
+			common.StorageSize(c.TotalBytes), common.StorageSize(c.AverageValueSize)}
+	}
+	for _, c := range s.Categories {
+		t.AppendRow(row(c))
+	}
+	if len(s.Ancient) > 0 {
+		t.AppendSeparator()
+		for _, c := range s.Ancient {
+			t.AppendRow(row(c))
+		}
+	}
+	t.AppendFooter(row(&s.Total))
+	// The footer is upper cased by default, which would mangle the units.
+	t.Style().Format.Footer = text.FormatDefault
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 2, Align: text.AlignRight, AlignFooter: text.AlignRight},
+		{Number: 3, Align: text.AlignRight, AlignFooter: text.AlignRight},
+		{Number: 4, Align: text.AlignRight, AlignFooter: text.AlignRight},
+		{Number: 5, Align: text.AlignRight, AlignFooter: text.AlignRight},
+		{Number: 6, Align: text.AlignRight, AlignFooter: text.AlignRight},
+	})
+	t.Render()
+	return nil
+}
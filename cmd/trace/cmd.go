@@ -0,0 +1,74 @@
+package trace
+
+import (
+	_ "embed"
+	"fmt"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+type cmdTraceParams struct {
+	RpcUrl     *string
+	Tracer     *string
+	ABIFiles   *[]string
+	Signatures *[]string
+	Flamegraph *bool
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdTraceParams
+
+	txHash ethcommon.Hash
+)
+
+// TraceCmd represents the trace command.
+var TraceCmd = &cobra.Command{
+	Use:   "trace tx-hash",
+	Short: "Render the trace of a transaction as a call tree.",
+	Long:  usage,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("expected a transaction hash")
+		}
+		b, err := hexutil.Decode(args[0])
+		if err != nil || len(b) != ethcommon.HashLength {
+			return fmt.Errorf("%s is not a transaction hash", args[0])
+		}
+		txHash = ethcommon.BytesToHash(b)
+		return nil
+	},
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTrace(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdTraceParams)
+	flagSet := TraceCmd.Flags()
+
+	p.RpcUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8545", "The RPC endpoint url")
+	p.Tracer = flagSet.String("tracer", "callTracer", "The tracer of debug_traceTransaction: callTracer, or structLogger whose opcodes are folded into calls")
+	p.ABIFiles = flagSet.StringSlice("abi-file", nil, "ABI files used to decode the function calls and their arguments")
+	p.Signatures = flagSet.StringSlice("signature", nil, "Function signatures used to decode the function calls, e.g. deposit(address,uint256)")
+	p.Flamegraph = flagSet.Bool("flamegraph", false, "Print the gas used by each call as folded stacks, the input of flamegraph.pl, instead of the tree")
+
+	params = *p
+}
+
+func checkFlags() error {
+	if err := util.ValidateUrl(*params.RpcUrl); err != nil {
+		return err
+	}
+	if *params.Tracer != "callTracer" && *params.Tracer != "structLogger" {
+		return fmt.Errorf("the tracer %s is not supported", *params.Tracer)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package trace
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+type (
+	rpcTransaction struct {
+		From  ethcommon.Address  `json:"from"`
+		To    *ethcommon.Address `json:"to"`
+		Gas   hexutil.Uint64     `json:"gas"`
+		Value *hexutil.Big       `json:"value"`
+		Input hexutil.Bytes      `json:"input"`
+	}
+	openFrame struct {
+		frame *Frame
+		last  *logger.StructLogRes
+	}
+)
+
+// traceStructLogs folds the opcodes of the struct logger into calls. A call starts when the depth increases after a
+// call or create opcode and ends when the depth decreases, so the calls which execute no code, like transfers to
+// accounts and precompiles, don't appear.
+func traceStructLogs(ctx context.Context, rpc *ethrpc.Client, hash ethcommon.Hash) (*Frame, error) {
+	var tx *rpcTransaction
+	if err := rpc.CallContext(ctx, &tx, "eth_getTransactionByHash", hash); err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, errors.New("the transaction was not found")
+	}
+	var result logger.ExecutionResult
+	config := map[string]any{"enableMemory": true, "enableReturnData": true, "disableStorage": true}
+	if err := rpc.CallContext(ctx, &result, "debug_traceTransaction", hash, config); err != nil {
+		return nil, err
+	}
+
+	root := &Frame{Type: "CALL", From: tx.From, To: tx.To, Value: tx.Value, Gas: uint64(tx.Gas), GasUsed: result.Gas, Input: tx.Input}
+	if tx.To == nil {
+		root.Type = "CREATE"
+	}
+	root.Output, _ = hex.DecodeString(strings.TrimPrefix(result.ReturnValue, "0x"))
+
+	frames := []*openFrame{{frame: root}}
+	logs := result.StructLogs
+	for i := range logs {
+		step := &logs[i]
+		for step.Depth > len(frames) && i > 0 {
+			parent := frames[len(frames)-1]
+			child := newCallFrame(&logs[i-1], parent.frame)
+			child.Gas = step.Gas
+			parent.frame.Calls = append(parent.frame.Calls, child)
+			frames = append(frames, &openFrame{frame: child})
+		}
+		for step.Depth < len(frames) && len(frames) > 1 {
+			closeFrame(frames[len(frames)-1])
+			// The return data of the call is seen by the next opcode of the caller.
+			if output, err := hexutil.Decode(step.ReturnData); err == nil {
+				frames[len(frames)-1].frame.Output = output
+			}
+			frames = frames[:len(frames)-1]
+		}
+		frames[len(frames)-1].last = step
+	}
+	for len(frames) > 1 {
+		closeFrame(frames[len(frames)-1])
+		frames = frames[:len(frames)-1]
+	}
+	if result.Failed {
+		root.Error = frameError(frames[0].last)
+	}
+	return root, nil
+}
+
+// newCallFrame returns the call made by the call or create opcode of the step.
+func newCallFrame(step *logger.StructLogRes, parent *Frame) *Frame {
+	f := &Frame{Type: step.Op, From: callee(parent)}
+	var stack []string
+	if step.Stack != nil {
+		stack = *step.Stack
+	}
+	arg := func(n int) *big.Int {
+		v := new(big.Int)
+		if n < len(stack) {
+			v.SetString(strings.TrimPrefix(stack[len(stack)-1-n], "0x"), 16)
+		}
+		return v
+	}
+	var offset, size *big.Int
+	switch step.Op {
+	case "CALL", "CALLCODE":
+		to := ethcommon.BigToAddress(arg(1))
+		f.To, f.Value = &to, (*hexutil.Big)(arg(2))
+		offset, size = arg(3), arg(4)
+	case "DELEGATECALL", "STATICCALL":
+		to := ethcommon.BigToAddress(arg(1))
+		f.To = &to
+		offset, size = arg(2), arg(3)
+	case "CREATE", "CREATE2":
+		f.Value = (*hexutil.Big)(arg(0))
+	}
+	if offset != nil && step.Memory != nil {
+		f.Input = readMemory(*step.Memory, offset, size)
+	}
+	return f
+}
+
+// callee returns the address executing the frame.
+func callee(f *Frame) ethcommon.Address {
+	if f.To == nil {
+		return ethcommon.Address{}
+	}
+	return *f.To
+}
+
+// readMemory returns the memory slice, truncated to the memory captured.
+func readMemory(words []string, offset, size *big.Int) []byte {
+	memory := make([]byte, 0, len(words)*32)
+	for _, w := range words {
+		b, _ := hex.DecodeString(w)
+		memory = append(memory, b...)
+	}
+	if !offset.IsUint64() || offset.Uint64() >= uint64(len(memory)) {
+		return nil
+	}
+	end := uint64(len(memory))
+	if size.IsUint64() && offset.Uint64()+size.Uint64() < end {
+		end = offset.Uint64() + size.Uint64()
+	}
+	return memory[offset.Uint64():end]
+}
+
+// closeFrame sets the gas used and the error of the frame from its last opcode.
+func closeFrame(f *openFrame) {
+	if f.last == nil {
+		return
+	}
+	if err := frameError(f.last); err != "" {
+		f.frame.Error = err
+		if f.last.Op != "REVERT" {
+			// The failures other than reverts consume all the gas.
+			f.frame.GasUsed = f.frame.Gas
+			return
+		}
+	}
+	f.frame.GasUsed = f.frame.Gas - f.last.Gas + f.last.GasCost
+}
+
+func frameError(last *logger.StructLogRes) string {
+	if last == nil {
+		return ""
+	}
+	if last.Error != "" {
+		return last.Error
+	}
+	if last.Op == "REVERT" {
+		return "execution reverted"
+	}
+	return ""
+}
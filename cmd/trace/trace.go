@@ -0,0 +1,263 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/maticnetwork/polygon-cli/abi"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+// commonSignatures are decoded without an ABI.
+var commonSignatures = []string{
+	"transfer(address,uint256)",
+	"transferFrom(address,address,uint256)",
+	"approve(address,uint256)",
+	"balanceOf(address)",
+	"allowance(address,address)",
+	"totalSupply()",
+	"decimals()",
+	"symbol()",
+	"name()",
+	"safeTransferFrom(address,address,uint256)",
+	"safeTransferFrom(address,address,uint256,bytes)",
+	"ownerOf(uint256)",
+	"deposit()",
+	"withdraw(uint256)",
+	"multicall(bytes[])",
+	"aggregate((address,bytes)[])",
+}
+
+type (
+	// Frame is a call of the trace.
+	Frame struct {
+		Type         string
+		From         ethcommon.Address
+		To           *ethcommon.Address `json:",omitempty"`
+		Value        *hexutil.Big       `json:",omitempty"`
+		Gas          uint64
+		GasUsed      uint64
+		Input        hexutil.Bytes `json:",omitempty"`
+		Output       hexutil.Bytes `json:",omitempty"`
+		Function     string        `json:",omitempty"`
+		Arguments    []string      `json:",omitempty"`
+		Error        string        `json:",omitempty"`
+		RevertReason string        `json:",omitempty"`
+		Calls        []*Frame      `json:",omitempty"`
+	}
+	// callFrame is a frame of the callTracer output.
+	callFrame struct {
+		Type    string             `json:"type"`
+		From    ethcommon.Address  `json:"from"`
+		To      *ethcommon.Address `json:"to"`
+		Value   *hexutil.Big       `json:"value"`
+		Gas     hexutil.Uint64     `json:"gas"`
+		GasUsed hexutil.Uint64     `json:"gasUsed"`
+		Input   hexutil.Bytes      `json:"input"`
+		Output  hexutil.Bytes      `json:"output"`
+		Error   string             `json:"error"`
+		Calls   []callFrame        `json:"calls"`
+	}
+	// functionDecoder resolves function selectors from the common signatures and the user's ABIs and signatures.
+	functionDecoder struct {
+		signatures map[string]string
+		methods    map[string]*gethabi.Method
+	}
+)
+
+func runTrace(ctx context.Context) error {
+	decoder, err := newFunctionDecoder(*params.ABIFiles, *params.Signatures)
+	if err != nil {
+		return err
+	}
+	rpc, err := ethrpc.DialContext(ctx, *params.RpcUrl)
+	if err != nil {
+		return err
+	}
+	defer rpc.Close()
+
+	var root *Frame
+	if *params.Tracer == "structLogger" {
+		root, err = traceStructLogs(ctx, rpc, txHash)
+	} else {
+		root, err = traceCalls(ctx, rpc, txHash)
+	}
+	if err != nil {
+		return err
+	}
+	decoder.decode(root)
+
+	if *params.Flamegraph {
+		return writeFoldedStacks(os.Stdout, root, nil)
+	}
+	return util.NewOutputWriter(os.Stdout).Write(root)
+}
+
+func traceCalls(ctx context.Context, rpc *ethrpc.Client, hash ethcommon.Hash) (*Frame, error) {
+	var result callFrame
+	if err := rpc.CallContext(ctx, &result, "debug_traceTransaction", hash, map[string]any{"tracer": "callTracer"}); err != nil {
+		return nil, err
+	}
+	return result.toFrame(), nil
+}
+
+func (c *callFrame) toFrame() *Frame {
+	f := &Frame{
+		Type:    c.Type,
+		From:    c.From,
+		To:      c.To,
+		Value:   c.Value,
+		Gas:     uint64(c.Gas),
+		GasUsed: uint64(c.GasUsed),
+		Input:   c.Input,
+		Output:  c.Output,
+		Error:   c.Error,
+	}
+	for i := range c.Calls {
+		f.Calls = append(f.Calls, c.Calls[i].toFrame())
+	}
+	return f
+}
+
+func newFunctionDecoder(abiFiles, signatures []string) (*functionDecoder, error) {
+	d := &functionDecoder{signatures: make(map[string]string), methods: make(map[string]*gethabi.Method)}
+	for _, sig := range append(append([]string{}, commonSignatures...), signatures...) {
+		selector, err := abi.HashFunctionSelector(sig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to hash the signature %s: %w", sig, err)
+		}
+		d.signatures[selector] = sig
+	}
+	for _, file := range abiFiles {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		contractABI, err := gethabi.JSON(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the ABI %s: %w", file, err)
+		}
+		for _, m := range contractABI.Methods {
+			m := m
+			d.methods[hex.EncodeToString(m.ID)] = &m
+		}
+	}
+	return d, nil
+}
+
+// decode resolves the function and the revert reason of the frame and its sub calls. The arguments are only decoded
+// for the functions of the ABIs.
+func (d *functionDecoder) decode(f *Frame) {
+	if len(f.Input) >= 4 && f.Type != "CREATE" && f.Type != "CREATE2" {
+		selector := hex.EncodeToString(f.Input[:4])
+		if m, ok := d.methods[selector]; ok {
+			f.Function = m.Sig
+			if values, err := m.Inputs.Unpack(f.Input[4:]); err == nil {
+				for _, v := range values {
+					f.Arguments = append(f.Arguments, fmt.Sprint(v))
+				}
+			} else {
+				log.Debug().Err(err).Str("function", m.Sig).Msg("Unable to decode the arguments")
+			}
+		} else if sig, ok := d.signatures[selector]; ok {
+			f.Function = sig
+		} else {
+			f.Function = "0x" + selector
+		}
+	}
+	if f.Error != "" {
+		if reason, err := gethabi.UnpackRevert(f.Output); err == nil {
+			f.RevertReason = reason
+		}
+	}
+	for _, c := range f.Calls {
+		d.decode(c)
+	}
+}
+
+// WriteText renders the frame and its sub calls as an indented tree.
+func (f *Frame) WriteText(w io.Writer) error {
+	return f.writeTree(w, "", "")
+}
+
+func (f *Frame) writeTree(w io.Writer, prefix, childPrefix string) error {
+	if _, err := fmt.Fprintf(w, "%s%s\n", prefix, f.describe()); err != nil {
+		return err
+	}
+	for i, c := range f.Calls {
+		branch, next := "├─ ", "│  "
+		if i == len(f.Calls)-1 {
+			branch, next = "└─ ", "   "
+		}
+		if err := c.writeTree(w, childPrefix+branch, childPrefix+next); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Frame) describe() string {
+	var sb strings.Builder
+	sb.WriteString(f.Type)
+	if f.To != nil {
+		sb.WriteString(" " + f.To.Hex())
+	}
+	if f.Function != "" {
+		sb.WriteString(" " + f.Function)
+		if len(f.Arguments) > 0 {
+			sb.WriteString(" [" + strings.Join(f.Arguments, ", ") + "]")
+		}
+	}
+	if f.Value != nil && f.Value.ToInt().Sign() > 0 {
+		sb.WriteString(fmt.Sprintf(" value=%s", f.Value.ToInt()))
+	}
+	sb.WriteString(fmt.Sprintf(" gas=%d/%d", f.GasUsed, f.Gas))
+	if f.Error != "" {
+		sb.WriteString(" error=" + f.Error)
+		if f.RevertReason != "" {
+			sb.WriteString(fmt.Sprintf(" reason=%q", f.RevertReason))
+		}
+	}
+	return sb.String()
+}
+
+// writeFoldedStacks writes a line per frame with the stack of calls leading to it and the gas used by the frame
+// itself, excluding its sub calls.
+func writeFoldedStacks(w io.Writer, f *Frame, stack []string) error {
+	label := f.Type
+	if f.To != nil {
+		label += " " + f.To.Hex()
+	}
+	if f.Function != "" {
+		label += " " + f.Function
+	}
+	// The semicolon separates the frames of a stack.
+	stack = append(stack, strings.ReplaceAll(label, ";", ","))
+
+	self := f.GasUsed
+	for _, c := range f.Calls {
+		self -= min(self, c.GasUsed)
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %d\n", strings.Join(stack, ";"), self)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	for _, c := range f.Calls {
+		if err := writeFoldedStacks(w, c, stack); err != nil {
+			return err
+		}
+	}
+	return nil
+}
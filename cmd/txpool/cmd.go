@@ -0,0 +1,79 @@
+package txpool
+
+import (
+	_ "embed"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+type cmdTxPoolParams struct {
+	RpcUrl        *string
+	Top           *int
+	GasPriceBands *[]float64
+
+	Watch    *bool
+	Interval *time.Duration
+	Samples  *uint64
+}
+
+var (
+	//go:embed usage.md
+	usage  string
+	params cmdTxPoolParams
+)
+
+// TxPoolCmd represents the txpool command.
+var TxPoolCmd = &cobra.Command{
+	Use:   "txpool",
+	Short: "Inspect the pending and queued transactions of a node's txpool.",
+	Long:  usage,
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if *params.Watch {
+			return watchTxPool(cmd.Context())
+		}
+		return inspectTxPool(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdTxPoolParams)
+	flagSet := TxPoolCmd.Flags()
+
+	p.RpcUrl = flagSet.StringP("rpc-url", "r", "http://localhost:8545", "The RPC endpoint url")
+	p.Top = flagSet.Int("top", 20, "The number of senders with the most transactions to report, 0 for all")
+	p.GasPriceBands = flagSet.Float64Slice("gas-price-bands", []float64{1, 10, 30, 100, 300, 1000}, "The upper bounds in Gwei of the gas price bands")
+
+	p.Watch = flagSet.BoolP("watch", "w", false, "Sample the txpool continuously and report its churn")
+	p.Interval = flagSet.Duration("interval", 5*time.Second, "The interval between two samples in watch mode")
+	p.Samples = flagSet.Uint64("samples", 0, "The number of samples to take in watch mode, 0 to sample until interrupted")
+
+	params = *p
+}
+
+func checkFlags() error {
+	if err := util.ValidateUrl(*params.RpcUrl); err != nil {
+		return err
+	}
+	if *params.Top < 0 {
+		return errors.New("the number of senders to report is negative")
+	}
+	if *params.Interval <= 0 {
+		return errors.New("the sampling interval must be positive")
+	}
+	bands := *params.GasPriceBands
+	for _, b := range bands {
+		if b <= 0 {
+			return errors.New("the gas price bands must be positive")
+		}
+	}
+	sort.Float64s(bands)
+	return nil
+}
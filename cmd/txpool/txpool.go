@@ -0,0 +1,299 @@
+package txpool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethparams "github.com/ethereum/go-ethereum/params"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+type (
+	poolTx struct {
+		Hash         common.Hash    `json:"hash"`
+		Nonce        hexutil.Uint64 `json:"nonce"`
+		GasPrice     *hexutil.Big   `json:"gasPrice"`
+		MaxFeePerGas *hexutil.Big   `json:"maxFeePerGas"`
+	}
+	// poolContent is the result of txpool_content, the transactions keyed by sender and nonce.
+	poolContent struct {
+		Pending map[common.Address]map[string]poolTx `json:"pending"`
+		Queued  map[common.Address]map[string]poolTx `json:"queued"`
+	}
+
+	// TxPoolSnapshot is the aggregated content of the txpool at a point in time.
+	TxPoolSnapshot struct {
+		Time          time.Time
+		StatusPending uint64
+		StatusQueued  uint64
+		Pending       int
+		Queued        int
+		SenderCount   int
+		Senders       []SenderSummary
+		GasPriceBands []GasPriceBand
+	}
+	SenderSummary struct {
+		Address      common.Address
+		Pending      int
+		Queued       int
+		LowestNonce  uint64
+		HighestNonce uint64
+		// NonceGaps is the number of nonces missing between the lowest and highest nonce of the sender.
+		NonceGaps uint64
+	}
+	// GasPriceBand counts the transactions whose gas price, or max fee per gas, is within [MinGwei, MaxGwei).
+	GasPriceBand struct {
+		MinGwei float64
+		MaxGwei float64 `json:",omitempty"`
+		Pending int
+		Queued  int
+	}
+
+	// ChurnSample compares the txpool with the previous sample in watch mode.
+	ChurnSample struct {
+		Time             time.Time
+		Pending          int
+		Queued           int
+		Added            int
+		Removed          int
+		AddedPerSecond   float64
+		RemovedPerSecond float64
+	}
+)
+
+func inspectTxPool(ctx context.Context) error {
+	rpc, err := ethrpc.DialContext(ctx, *params.RpcUrl)
+	if err != nil {
+		return err
+	}
+	defer rpc.Close()
+
+	content, err := getContent(ctx, rpc)
+	if err != nil {
+		return err
+	}
+	snapshot := summarize(content, *params.GasPriceBands, *params.Top)
+	snapshot.StatusPending, snapshot.StatusQueued, err = util.GetTxPoolStatus(rpc)
+	if err != nil {
+		log.Warn().Err(err).Msg("Unable to get the txpool status")
+	}
+	return util.NewOutputWriter(os.Stdout).Write(snapshot)
+}
+
+// watchTxPool samples the content of the txpool at every interval and reports the transactions added and removed
+// since the previous sample.
+func watchTxPool(ctx context.Context) error {
+	rpc, err := ethrpc.DialContext(ctx, *params.RpcUrl)
+	if err != nil {
+		return err
+	}
+	defer rpc.Close()
+
+	out := util.NewOutputWriter(os.Stdout)
+	ticker := time.NewTicker(*params.Interval)
+	defer ticker.Stop()
+
+	var previous map[common.Hash]struct{}
+	var previousTime time.Time
+	for sample := uint64(0); *params.Samples == 0 || sample < *params.Samples; sample++ {
+		if sample > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		}
+
+		content, err := getContent(ctx, rpc)
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to get the txpool content")
+			continue
+		}
+		now := time.Now()
+		hashes := make(map[common.Hash]struct{})
+		s := ChurnSample{Time: now}
+		for _, txs := range content.Pending {
+			s.Pending += len(txs)
+			for _, tx := range txs {
+				hashes[tx.Hash] = struct{}{}
+			}
+		}
+		for _, txs := range content.Queued {
+			s.Queued += len(txs)
+			for _, tx := range txs {
+				hashes[tx.Hash] = struct{}{}
+			}
+		}
+
+		if previous != nil {
+			for h := range hashes {
+				if _, ok := previous[h]; !ok {
+					s.Added++
+				}
+			}
+			for h := range previous {
+				if _, ok := hashes[h]; !ok {
+					s.Removed++
+				}
+			}
+			elapsed := now.Sub(previousTime).Seconds()
+			s.AddedPerSecond = float64(s.Added) / elapsed
+			s.RemovedPerSecond = float64(s.Removed) / elapsed
+		}
+		previous, previousTime = hashes, now
+
+		if err = out.Write(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getContent(ctx context.Context, rpc *ethrpc.Client) (*poolContent, error) {
+	content := new(poolContent)
+	if err := rpc.CallContext(ctx, content, "txpool_content"); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// summarize aggregates the content by sender and gas price band. Only the top senders with the most transactions
+// are kept, or all of them when top is 0. The bands are the sorted upper bounds in Gwei.
+func summarize(content *poolContent, bands []float64, top int) *TxPoolSnapshot {
+	s := &TxPoolSnapshot{Time: time.Now()}
+	s.GasPriceBands = make([]GasPriceBand, len(bands)+1)
+	for i := range s.GasPriceBands {
+		if i > 0 {
+			s.GasPriceBands[i].MinGwei = bands[i-1]
+		}
+		if i < len(bands) {
+			s.GasPriceBands[i].MaxGwei = bands[i]
+		}
+	}
+
+	senders := make(map[common.Address]*SenderSummary)
+	nonces := make(map[common.Address][]uint64)
+	add := func(pool map[common.Address]map[string]poolTx, pending bool) {
+		for from, txs := range pool {
+			sender, ok := senders[from]
+			if !ok {
+				sender = &SenderSummary{Address: from}
+				senders[from] = sender
+			}
+			for key, tx := range txs {
+				nonce := uint64(tx.Nonce)
+				if n, err := strconv.ParseUint(key, 10, 64); err == nil {
+					nonce = n
+				}
+				nonces[from] = append(nonces[from], nonce)
+
+				band := &s.GasPriceBands[bandIndex(bands, gasPriceGwei(tx))]
+				if pending {
+					sender.Pending++
+					band.Pending++
+					s.Pending++
+				} else {
+					sender.Queued++
+					band.Queued++
+					s.Queued++
+				}
+			}
+		}
+	}
+	add(content.Pending, true)
+	add(content.Queued, false)
+
+	s.Senders = make([]SenderSummary, 0, len(senders))
+	for from, sender := range senders {
+		ns := nonces[from]
+		sort.Slice(ns, func(i, j int) bool { return ns[i] < ns[j] })
+		sender.LowestNonce, sender.HighestNonce = ns[0], ns[len(ns)-1]
+		for i := 1; i < len(ns); i++ {
+			if ns[i] > ns[i-1]+1 {
+				sender.NonceGaps += ns[i] - ns[i-1] - 1
+			}
+		}
+		s.Senders = append(s.Senders, *sender)
+	}
+	s.SenderCount = len(s.Senders)
+	sort.Slice(s.Senders, func(i, j int) bool {
+		ti, tj := s.Senders[i].Pending+s.Senders[i].Queued, s.Senders[j].Pending+s.Senders[j].Queued
+		if ti != tj {
+			return ti > tj
+		}
+		return s.Senders[i].Address.Hex() < s.Senders[j].Address.Hex()
+	})
+	if top > 0 && len(s.Senders) > top {
+		s.Senders = s.Senders[:top]
+	}
+	return s
+}
+
+// gasPriceGwei returns the max fee per gas of dynamic fee transactions and the gas price of the others.
+func gasPriceGwei(tx poolTx) float64 {
+	price := tx.GasPrice
+	if tx.MaxFeePerGas != nil {
+		price = tx.MaxFeePerGas
+	}
+	if price == nil {
+		return 0
+	}
+	gwei, _ := new(big.Float).Quo(new(big.Float).SetInt(price.ToInt()), big.NewFloat(ethparams.GWei)).Float64()
+	return gwei
+}
+
+func bandIndex(bands []float64, gwei float64) int {
+	return sort.Search(len(bands), func(i int) bool { return gwei < bands[i] })
+}
+
+// WriteText renders the snapshot as tables.
+func (s *TxPoolSnapshot) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"", "Pending", "Queued"})
+	t.AppendRow(table.Row{"Transactions", s.Pending, s.Queued})
+	t.AppendRow(table.Row{"Status", s.StatusPending, s.StatusQueued})
+	t.AppendFooter(table.Row{"Senders", s.SenderCount, ""})
+	t.Render()
+
+	t = table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Gas Price (Gwei)", "Pending", "Queued"})
+	for _, b := range s.GasPriceBands {
+		label := fmt.Sprintf("%g - %g", b.MinGwei, b.MaxGwei)
+		if b.MaxGwei == 0 {
+			label = fmt.Sprintf(">= %g", b.MinGwei)
+		} else if b.MinGwei == 0 {
+			label = fmt.Sprintf("< %g", b.MaxGwei)
+		}
+		t.AppendRow(table.Row{label, b.Pending, b.Queued})
+	}
+	t.Render()
+
+	t = table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendHeader(table.Row{"Sender", "Pending", "Queued", "Nonces", "Nonce Gaps"})
+	for _, sender := range s.Senders {
+		t.AppendRow(table.Row{sender.Address.Hex(), sender.Pending, sender.Queued, fmt.Sprintf("%d - %d", sender.LowestNonce, sender.HighestNonce), sender.NonceGaps})
+	}
+	t.Render()
+	return nil
+}
+
+// WriteText renders the sample as a single line.
+func (s ChurnSample) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s pending=%d queued=%d added=%d removed=%d added/s=%.2f removed/s=%.2f\n",
+		s.Time.Format(time.RFC3339), s.Pending, s.Queued, s.Added, s.Removed, s.AddedPerSecond, s.RemovedPerSecond)
+	return err
+}
@@ -0,0 +1,580 @@
+package ulxly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/maticnetwork/polygon-cli/bindings/tokens"
+	"github.com/maticnetwork/polygon-cli/bindings/ulxly"
+	"github.com/maticnetwork/polygon-cli/signer"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	leafTypeAsset   = 0
+	leafTypeMessage = 1
+	// mainnetFlag is the bit of the global index set for the deposits of L1.
+	mainnetFlag = 64
+)
+
+type (
+	// network is the bridge of L1 or L2.
+	network struct {
+		name          string
+		client        *ethclient.Client
+		bridgeAddress common.Address
+		bridge        *ulxly.PolygonZkEVMBridgeV2
+		id            uint32
+	}
+
+	// Deposit is a BridgeEvent, the leaf of the deposit tree at DepositCount.
+	Deposit struct {
+		LeafType           uint8
+		OriginNetwork      uint32
+		OriginAddress      common.Address
+		DestinationNetwork uint32
+		DestinationAddress common.Address
+		Amount             *big.Int
+		Metadata           hexutil.Bytes
+		DepositCount       uint32
+		BlockNumber        uint64
+		TxHash             common.Hash
+	}
+	// Claim is a ClaimEvent.
+	Claim struct {
+		GlobalIndex        *big.Int
+		OriginNetwork      uint32
+		OriginAddress      common.Address
+		DestinationAddress common.Address
+		Amount             *big.Int
+		BlockNumber        uint64
+		TxHash             common.Hash
+	}
+	BridgeEvents struct {
+		Network  string
+		Deposits []Deposit
+		Claims   []Claim
+	}
+
+	// ClaimProof holds the arguments of the claim of a deposit.
+	ClaimProof struct {
+		Deposit                Deposit
+		GlobalIndex            *big.Int
+		MainnetExitRoot        common.Hash
+		RollupExitRoot         common.Hash
+		LocalExitRoot          common.Hash
+		SmtProofLocalExitRoot  [treeHeight]common.Hash
+		SmtProofRollupExitRoot [treeHeight]common.Hash
+	}
+
+	// BridgeTx is the result of a deposit or a claim.
+	BridgeTx struct {
+		Network      string
+		TxHash       common.Hash
+		BlockNumber  uint64
+		GasUsed      uint64
+		DepositCount uint32
+	}
+)
+
+func (d Deposit) leaf() common.Hash {
+	return leafHash(d.LeafType, d.OriginNetwork, d.OriginAddress, d.DestinationNetwork, d.DestinationAddress, d.Amount.Bytes(), d.Metadata)
+}
+
+func dialNetwork(ctx context.Context, name string) (*network, error) {
+	url, address := *params.L1RpcUrl, *params.L1Bridge
+	if name == "l2" {
+		url, address = *params.L2RpcUrl, *params.L2Bridge
+	}
+	rpc, err := params.RPCClient.Dial(ctx, url)
+	if err != nil {
+		log.Error().Err(err).Str("network", name).Msg("Unable to dial")
+		return nil, err
+	}
+	n := &network{name: name, client: ethclient.NewClient(rpc), bridgeAddress: common.HexToAddress(address)}
+	if n.bridge, err = ulxly.NewPolygonZkEVMBridgeV2(n.bridgeAddress, n.client); err != nil {
+		return nil, err
+	}
+	if n.id, err = n.bridge.NetworkID(&bind.CallOpts{Context: ctx}); err != nil {
+		return nil, fmt.Errorf("unable to get the network ID of the %s bridge at %s: %w", name, address, err)
+	}
+	return n, nil
+}
+
+// otherNetwork returns the name of the network which isn't the given one.
+func otherNetwork(name string) string {
+	if name == "l1" {
+		return "l2"
+	}
+	return "l1"
+}
+
+// fetchDeposits returns the deposits of the bridge between the blocks, in
+// deposit count order.
+func fetchDeposits(ctx context.Context, n *network, from, to uint64) ([]Deposit, error) {
+	var deposits []Deposit
+	for start := from; start <= to; start += *params.BlockRange {
+		end := min(start+*params.BlockRange-1, to)
+		it, err := n.bridge.FilterBridgeEvent(&bind.FilterOpts{Start: start, End: &end, Context: ctx})
+		if err != nil {
+			return nil, err
+		}
+		for it.Next() {
+			e := it.Event
+			deposits = append(deposits, Deposit{
+				LeafType:           e.LeafType,
+				OriginNetwork:      e.OriginNetwork,
+				OriginAddress:      e.OriginAddress,
+				DestinationNetwork: e.DestinationNetwork,
+				DestinationAddress: e.DestinationAddress,
+				Amount:             e.Amount,
+				Metadata:           e.Metadata,
+				DepositCount:       e.DepositCount,
+				BlockNumber:        e.Raw.BlockNumber,
+				TxHash:             e.Raw.TxHash,
+			})
+		}
+		if err = it.Error(); err != nil {
+			return nil, err
+		}
+		log.Debug().Str("network", n.name).Uint64("from", start).Uint64("to", end).Int("deposits", len(deposits)).Msg("Fetched the bridge events")
+	}
+	sort.Slice(deposits, func(i, j int) bool { return deposits[i].DepositCount < deposits[j].DepositCount })
+	return deposits, nil
+}
+
+// fetchClaims returns the claims of the bridge between the blocks.
+func fetchClaims(ctx context.Context, n *network, from, to uint64) ([]Claim, error) {
+	var claims []Claim
+	for start := from; start <= to; start += *params.BlockRange {
+		end := min(start+*params.BlockRange-1, to)
+		it, err := n.bridge.FilterClaimEvent(&bind.FilterOpts{Start: start, End: &end, Context: ctx})
+		if err != nil {
+			return nil, err
+		}
+		for it.Next() {
+			e := it.Event
+			claims = append(claims, Claim{
+				GlobalIndex:        e.GlobalIndex,
+				OriginNetwork:      e.OriginNetwork,
+				OriginAddress:      e.OriginAddress,
+				DestinationAddress: e.DestinationAddress,
+				Amount:             e.Amount,
+				BlockNumber:        e.Raw.BlockNumber,
+				TxHash:             e.Raw.TxHash,
+			})
+		}
+		if err = it.Error(); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
+// depositLeaves returns the leaves of the deposit tree, checking no deposit
+// is missing.
+func depositLeaves(deposits []Deposit) ([]common.Hash, error) {
+	leaves := make([]common.Hash, len(deposits))
+	for i, d := range deposits {
+		if d.DepositCount != uint32(i) {
+			return nil, fmt.Errorf("the deposit %d is missing, the bridge events must be scanned from the deployment block of the bridge with --from-block", i)
+		}
+		leaves[i] = d.leaf()
+	}
+	return leaves, nil
+}
+
+// buildClaimProof computes the proofs of the deposit made on the source
+// network against the exit roots of the last global exit root of L1.
+func buildClaimProof(ctx context.Context, src, l1 *network, depositCount uint32) (*ClaimProof, error) {
+	head, err := l1.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(head)}
+	gerAddress, err := l1.bridge.GlobalExitRootManager(opts)
+	if err != nil {
+		return nil, err
+	}
+	ger, err := ulxly.NewPolygonZkEVMGlobalExitRootV2(gerAddress, l1.client)
+	if err != nil {
+		return nil, err
+	}
+	p := new(ClaimProof)
+	if p.MainnetExitRoot, err = ger.LastMainnetExitRoot(opts); err != nil {
+		return nil, err
+	}
+	if p.RollupExitRoot, err = ger.LastRollupExitRoot(opts); err != nil {
+		return nil, err
+	}
+
+	// The local exit root of a rollup is its leaf in the rollup exit tree.
+	p.LocalExitRoot = p.MainnetExitRoot
+	p.GlobalIndex = new(big.Int).SetUint64(uint64(depositCount))
+	if src.id == 0 {
+		p.GlobalIndex.SetBit(p.GlobalIndex, mainnetFlag, 1)
+		for h := range p.SmtProofRollupExitRoot {
+			p.SmtProofRollupExitRoot[h] = zeroHashes[h]
+		}
+	} else {
+		rollups, err := rollupExitLeaves(ctx, l1, ger, opts)
+		if err != nil {
+			return nil, err
+		}
+		if root := treeRoot(rollups); root != p.RollupExitRoot {
+			return nil, fmt.Errorf("the rollup exit root %s doesn't match the local exit roots of the rollups, whose root is %s, retry once the rollup manager is updated", p.RollupExitRoot, root)
+		}
+		if int(src.id) > len(rollups) {
+			return nil, fmt.Errorf("the rollup %d isn't registered in the rollup manager", src.id)
+		}
+		index := int(src.id) - 1
+		p.LocalExitRoot = rollups[index]
+		p.SmtProofRollupExitRoot = merkleProof(rollups, index)
+		p.GlobalIndex.Or(p.GlobalIndex, new(big.Int).Lsh(big.NewInt(int64(index)), 32))
+	}
+
+	srcHead, err := src.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Str("network", src.name).Uint64("from", *params.FromBlock).Uint64("to", srcHead).Msg("Fetching the deposits")
+	deposits, err := fetchDeposits(ctx, src, *params.FromBlock, srcHead)
+	if err != nil {
+		return nil, err
+	}
+	leaves, err := depositLeaves(deposits)
+	if err != nil {
+		return nil, err
+	}
+	if int(depositCount) >= len(leaves) {
+		return nil, fmt.Errorf("the deposit %d wasn't made yet, the bridge has %d deposits", depositCount, len(leaves))
+	}
+	count, ok := countForRoot(leaves, p.LocalExitRoot)
+	if !ok {
+		return nil, fmt.Errorf("no deposit tree of the %s bridge has the exit root %s", src.name, p.LocalExitRoot)
+	}
+	if int(depositCount) >= count {
+		return nil, fmt.Errorf("the deposit %d isn't in the last global exit root yet, which includes %d deposits, retry later", depositCount, count)
+	}
+	p.Deposit = deposits[depositCount]
+	p.SmtProofLocalExitRoot = merkleProof(leaves[:count], int(depositCount))
+
+	// The proofs are checked the way the bridge does before sending them.
+	if !verifyProof(p.Deposit.leaf(), p.SmtProofLocalExitRoot, depositCount, p.LocalExitRoot) {
+		return nil, errors.New("the proof of the deposit doesn't verify against the local exit root")
+	}
+	if src.id != 0 && !verifyProof(p.LocalExitRoot, p.SmtProofRollupExitRoot, src.id-1, p.RollupExitRoot) {
+		return nil, errors.New("the proof of the local exit root doesn't verify against the rollup exit root")
+	}
+	return p, nil
+}
+
+// rollupExitLeaves returns the local exit roots of the rollups, the leaves of
+// the rollup exit tree.
+func rollupExitLeaves(ctx context.Context, l1 *network, ger *ulxly.PolygonZkEVMGlobalExitRootV2, opts *bind.CallOpts) ([]common.Hash, error) {
+	managerAddress, err := ger.RollupManager(opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the rollup manager: %w", err)
+	}
+	manager, err := ulxly.NewPolygonRollupManager(managerAddress, l1.client)
+	if err != nil {
+		return nil, err
+	}
+	count, err := manager.RollupCount(opts)
+	if err != nil {
+		return nil, err
+	}
+	leaves := make([]common.Hash, count)
+	for id := uint32(1); id <= count; id++ {
+		data, err := manager.RollupIDToRollupData(opts, id)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get the data of the rollup %d: %w", id, err)
+		}
+		leaves[id-1] = data.LastLocalExitRoot
+	}
+	return leaves, nil
+}
+
+func proof(ctx context.Context) error {
+	src, err := dialNetwork(ctx, *params.Network)
+	if err != nil {
+		return err
+	}
+	l1 := src
+	if src.name != "l1" {
+		if l1, err = dialNetwork(ctx, "l1"); err != nil {
+			return err
+		}
+	}
+	p, err := buildClaimProof(ctx, src, l1, *proofCount)
+	if err != nil {
+		return err
+	}
+	return util.NewOutputWriter(os.Stdout).Write(p)
+}
+
+func claim(ctx context.Context) error {
+	src, err := dialNetwork(ctx, *params.Network)
+	if err != nil {
+		return err
+	}
+	dst, err := dialNetwork(ctx, otherNetwork(src.name))
+	if err != nil {
+		return err
+	}
+	l1 := src
+	if dst.name == "l1" {
+		l1 = dst
+	}
+
+	depositCount := *claimInput.DepositCount
+	claimed, err := dst.bridge.IsClaimed(&bind.CallOpts{Context: ctx}, depositCount, src.id)
+	if err != nil {
+		return err
+	}
+	if claimed {
+		return fmt.Errorf("the deposit %d of the network %d is already claimed", depositCount, src.id)
+	}
+	p, err := buildClaimProof(ctx, src, l1, depositCount)
+	if err != nil {
+		return err
+	}
+	if p.Deposit.DestinationNetwork != dst.id {
+		return fmt.Errorf("the deposit %d is destined to the network %d, not to the %s bridge of network %d", depositCount, p.Deposit.DestinationNetwork, dst.name, dst.id)
+	}
+
+	// L2 only accepts the claims against the global exit roots it synced.
+	if dst.name != "l1" {
+		gerAddress, err := dst.bridge.GlobalExitRootManager(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return err
+		}
+		ger, err := ulxly.NewPolygonZkEVMGlobalExitRootV2(gerAddress, dst.client)
+		if err != nil {
+			return err
+		}
+		root := crypto.Keccak256Hash(p.MainnetExitRoot[:], p.RollupExitRoot[:])
+		timestamp, err := ger.GlobalExitRootMap(&bind.CallOpts{Context: ctx}, root)
+		if err != nil {
+			return err
+		}
+		if timestamp.Sign() == 0 {
+			return fmt.Errorf("the global exit root %s isn't synced to L2 yet, retry later", root)
+		}
+	}
+
+	tops, err := transactOpts(ctx, dst, claimInput.Signer, *claimInput.PrivateKey)
+	if err != nil {
+		return err
+	}
+	var localProof, rollupProof [treeHeight][32]byte
+	for h := 0; h < treeHeight; h++ {
+		localProof[h], rollupProof[h] = p.SmtProofLocalExitRoot[h], p.SmtProofRollupExitRoot[h]
+	}
+	d := p.Deposit
+	var tx *types.Transaction
+	if d.LeafType == leafTypeMessage {
+		tx, err = dst.bridge.ClaimMessage(tops, localProof, rollupProof, p.GlobalIndex, p.MainnetExitRoot, p.RollupExitRoot,
+			d.OriginNetwork, d.OriginAddress, d.DestinationNetwork, d.DestinationAddress, d.Amount, d.Metadata)
+	} else {
+		tx, err = dst.bridge.ClaimAsset(tops, localProof, rollupProof, p.GlobalIndex, p.MainnetExitRoot, p.RollupExitRoot,
+			d.OriginNetwork, d.OriginAddress, d.DestinationNetwork, d.DestinationAddress, d.Amount, d.Metadata)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to claim the deposit %d: %w", depositCount, err)
+	}
+	log.Info().Str("network", dst.name).Stringer("txHash", tx.Hash()).Msg("Sent the claim")
+	receipt, err := waitMined(ctx, dst, tx)
+	if err != nil {
+		return err
+	}
+	return util.NewOutputWriter(os.Stdout).Write(BridgeTx{
+		Network:      dst.name,
+		TxHash:       tx.Hash(),
+		BlockNumber:  receipt.BlockNumber.Uint64(),
+		GasUsed:      receipt.GasUsed,
+		DepositCount: depositCount,
+	})
+}
+
+func deposit(ctx context.Context) error {
+	src, err := dialNetwork(ctx, *params.Network)
+	if err != nil {
+		return err
+	}
+	destinationNetwork := uint32(*depositInput.DestinationNetwork)
+	if *depositInput.DestinationNetwork < 0 {
+		dst, err := dialNetwork(ctx, otherNetwork(src.name))
+		if err != nil {
+			return err
+		}
+		destinationNetwork = dst.id
+	}
+	tops, err := transactOpts(ctx, src, depositInput.Signer, *depositInput.PrivateKey)
+	if err != nil {
+		return err
+	}
+	destinationAddress := tops.From
+	if *depositInput.DestinationAddress != "" {
+		destinationAddress = common.HexToAddress(*depositInput.DestinationAddress)
+	}
+	amount, _ := new(big.Int).SetString(*depositInput.Amount, 10)
+	token := common.HexToAddress(*depositInput.Token)
+
+	var tx *types.Transaction
+	switch {
+	case *depositInput.CallData != "":
+		tops.Value = amount
+		tx, err = src.bridge.BridgeMessage(tops, destinationNetwork, destinationAddress, *depositInput.ForceUpdateGER, hexutil.MustDecode(*depositInput.CallData))
+	case token == (common.Address{}):
+		tops.Value = amount
+		tx, err = src.bridge.BridgeAsset(tops, destinationNetwork, destinationAddress, amount, token, *depositInput.ForceUpdateGER, nil)
+	default:
+		if err = approve(ctx, src, tops, token, amount); err != nil {
+			return err
+		}
+		tx, err = src.bridge.BridgeAsset(tops, destinationNetwork, destinationAddress, amount, token, *depositInput.ForceUpdateGER, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to deposit: %w", err)
+	}
+	log.Info().Str("network", src.name).Stringer("txHash", tx.Hash()).Msg("Sent the deposit")
+	receipt, err := waitMined(ctx, src, tx)
+	if err != nil {
+		return err
+	}
+
+	result := BridgeTx{Network: src.name, TxHash: tx.Hash(), BlockNumber: receipt.BlockNumber.Uint64(), GasUsed: receipt.GasUsed}
+	for _, l := range receipt.Logs {
+		if e, err := src.bridge.ParseBridgeEvent(*l); err == nil && l.Address == src.bridgeAddress {
+			result.DepositCount = e.DepositCount
+			break
+		}
+	}
+	return util.NewOutputWriter(os.Stdout).Write(result)
+}
+
+// approve lets the bridge spend the amount of tokens when its allowance is
+// lower.
+func approve(ctx context.Context, n *network, tops *bind.TransactOpts, token common.Address, amount *big.Int) error {
+	erc20, err := tokens.NewERC20(token, n.client)
+	if err != nil {
+		return err
+	}
+	allowance, err := erc20.Allowance(&bind.CallOpts{Context: ctx}, tops.From, n.bridgeAddress)
+	if err != nil {
+		return fmt.Errorf("unable to get the allowance of the bridge: %w", err)
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return nil
+	}
+	tx, err := erc20.Approve(tops, n.bridgeAddress, amount)
+	if err != nil {
+		return fmt.Errorf("unable to approve the bridge: %w", err)
+	}
+	log.Info().Stringer("txHash", tx.Hash()).Msg("Sent the approval of the bridge")
+	_, err = waitMined(ctx, n, tx)
+	return err
+}
+
+func transactOpts(ctx context.Context, n *network, flags *signer.Flags, privateKey string) (*bind.TransactOpts, error) {
+	s, err := flags.New(ctx, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := n.client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return signer.TransactOpts(ctx, s, chainID)
+}
+
+func waitMined(ctx context.Context, n *network, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, n.client, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("the transaction %s failed", tx.Hash())
+	}
+	return receipt, nil
+}
+
+func events(ctx context.Context) error {
+	n, err := dialNetwork(ctx, *params.Network)
+	if err != nil {
+		return err
+	}
+	to := *eventsTo
+	if to == 0 {
+		if to, err = n.client.BlockNumber(ctx); err != nil {
+			return err
+		}
+	}
+	result := BridgeEvents{Network: n.name}
+	if result.Deposits, err = fetchDeposits(ctx, n, *params.FromBlock, to); err != nil {
+		return err
+	}
+	if result.Claims, err = fetchClaims(ctx, n, *params.FromBlock, to); err != nil {
+		return err
+	}
+	return util.NewOutputWriter(os.Stdout).Write(result)
+}
+
+// WriteText renders the deposits and claims as tables.
+func (e BridgeEvents) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetTitle(fmt.Sprintf("Deposits on %s", strings.ToUpper(e.Network)))
+	t.AppendHeader(table.Row{"Deposit Count", "Block", "Type", "Origin", "Destination", "Amount", "Tx Hash"})
+	for _, d := range e.Deposits {
+		leafType := "asset"
+		if d.LeafType == leafTypeMessage {
+			leafType = "message"
+		}
+		t.AppendRow(table.Row{d.DepositCount, d.BlockNumber, leafType,
+			fmt.Sprintf("%d/%s", d.OriginNetwork, d.OriginAddress.Hex()),
+			fmt.Sprintf("%d/%s", d.DestinationNetwork, d.DestinationAddress.Hex()),
+			d.Amount, d.TxHash.Hex()})
+	}
+	t.Render()
+
+	t = table.NewWriter()
+	t.SetOutputMirror(w)
+	t.SetTitle(fmt.Sprintf("Claims on %s", strings.ToUpper(e.Network)))
+	t.AppendHeader(table.Row{"Global Index", "Block", "Origin", "Destination Address", "Amount", "Tx Hash"})
+	for _, c := range e.Claims {
+		t.AppendRow(table.Row{c.GlobalIndex, c.BlockNumber,
+			fmt.Sprintf("%d/%s", c.OriginNetwork, c.OriginAddress.Hex()),
+			c.DestinationAddress.Hex(), c.Amount, c.TxHash.Hex()})
+	}
+	t.Render()
+	return nil
+}
+
+// WriteText renders the transaction as a table.
+func (b BridgeTx) WriteText(w io.Writer) error {
+	t := table.NewWriter()
+	t.SetOutputMirror(w)
+	t.AppendRow(table.Row{"Network", strings.ToUpper(b.Network)})
+	t.AppendRow(table.Row{"Tx Hash", b.TxHash.Hex()})
+	t.AppendRow(table.Row{"Block", b.BlockNumber})
+	t.AppendRow(table.Row{"Gas Used", b.GasUsed})
+	t.AppendRow(table.Row{"Deposit Count", b.DepositCount})
+	t.Render()
+	return nil
+}
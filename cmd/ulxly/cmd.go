@@ -0,0 +1,203 @@
+package ulxly
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/maticnetwork/polygon-cli/rpcclient"
+	"github.com/maticnetwork/polygon-cli/signer"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// The default private key used to send transactions.
+const defaultPrivateKey = "0x42b6e34dc21598a807dc19d7784c71b2a7a01f6480dc6f58258f78e539f1a1fa"
+
+// The address of the bridge on Ethereum and the zkEVM mainnet and testnets.
+const defaultBridgeAddress = "0x2a3DD3EB832aF982ec71669E178424b10Dca2EDe"
+
+type cmdUlxlyParams struct {
+	L1RpcUrl  *string
+	L2RpcUrl  *string
+	L1Bridge  *string
+	L2Bridge  *string
+	RPCClient *rpcclient.Flags
+
+	Network    *string
+	FromBlock  *uint64
+	BlockRange *uint64
+}
+
+type cmdDepositParams struct {
+	DestinationNetwork *int64
+	DestinationAddress *string
+	Amount             *string
+	Token              *string
+	CallData           *string
+	ForceUpdateGER     *bool
+	PrivateKey         *string
+	Signer             *signer.Flags
+}
+
+type cmdClaimParams struct {
+	DepositCount *uint32
+	PrivateKey   *string
+	Signer       *signer.Flags
+}
+
+var (
+	//go:embed usage.md
+	usage        string
+	params       cmdUlxlyParams
+	depositInput cmdDepositParams
+	claimInput   cmdClaimParams
+	proofCount   *uint32
+	eventsTo     *uint64
+)
+
+// UlxlyCmd represents the ulxly command.
+var UlxlyCmd = &cobra.Command{
+	Use:   "ulxly",
+	Short: "Interact with the LxLy bridge between L1 and an L2 of the Polygon stack.",
+	Long:  usage,
+}
+
+var depositCmd = &cobra.Command{
+	Use:   "deposit",
+	Short: "Bridge native tokens, ERC20 tokens or a message from the network.",
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkFlags(); err != nil {
+			return err
+		}
+		return checkDepositFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return deposit(cmd.Context())
+	},
+}
+
+var claimCmd = &cobra.Command{
+	Use:   "claim",
+	Short: "Claim on the other network a deposit made on the network.",
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return claim(cmd.Context())
+	},
+}
+
+var proofCmd = &cobra.Command{
+	Use:   "proof",
+	Short: "Compute and verify the merkle proofs claiming a deposit made on the network.",
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return proof(cmd.Context())
+	},
+}
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "List the deposits and claims of the bridge of the network.",
+	Args:  cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return checkFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return events(cmd.Context())
+	},
+}
+
+func init() {
+	p := new(cmdUlxlyParams)
+	flagSet := UlxlyCmd.PersistentFlags()
+	p.L1RpcUrl = flagSet.String("l1-rpc-url", "http://localhost:8545", "The RPC endpoint url of L1")
+	p.L2RpcUrl = flagSet.String("l2-rpc-url", "http://localhost:8123", "The RPC endpoint url of L2")
+	p.L1Bridge = flagSet.String("l1-bridge-address", defaultBridgeAddress, "The address of the bridge on L1")
+	p.L2Bridge = flagSet.String("l2-bridge-address", defaultBridgeAddress, "The address of the bridge on L2")
+	p.RPCClient = rpcclient.AddFlags(flagSet)
+	p.Network = flagSet.StringP("network", "n", "l1", "The network of the deposit: l1 or l2")
+	p.FromBlock = flagSet.Uint64("from-block", 0, "The block from which the bridge events are scanned, at most the deployment block of the bridge to rebuild its deposit tree")
+	p.BlockRange = flagSet.Uint64("block-range", 10_000, "The number of blocks of an eth_getLogs request")
+	params = *p
+
+	d := new(cmdDepositParams)
+	flagSet = depositCmd.Flags()
+	d.DestinationNetwork = flagSet.Int64("destination-network", -1, "The network ID of the destination, -1 for the network ID of the other bridge")
+	d.DestinationAddress = flagSet.String("destination-address", "", "The recipient on the destination network, the sender by default")
+	d.Amount = flagSet.String("amount", "0", "The amount in wei of tokens to bridge")
+	d.Token = flagSet.String("token", "0x0000000000000000000000000000000000000000", "The address of the ERC20 token to bridge, the zero address for the native token")
+	d.CallData = flagSet.String("call-data", "", "Bridge a message with the hex encoded call data instead of an asset, the amount being sent as native tokens")
+	d.ForceUpdateGER = flagSet.Bool("force-update-ger", true, "Update the global exit root with the deposit so it can be claimed sooner")
+	d.PrivateKey = flagSet.String("private-key", defaultPrivateKey, "The hex encoded private key that we'll use to send transactions")
+	d.Signer = signer.AddFlags(flagSet)
+	depositInput = *d
+
+	c := new(cmdClaimParams)
+	flagSet = claimCmd.Flags()
+	c.DepositCount = flagSet.Uint32("deposit-count", 0, "The deposit count of the deposit to claim")
+	c.PrivateKey = flagSet.String("private-key", defaultPrivateKey, "The hex encoded private key that we'll use to send transactions")
+	c.Signer = signer.AddFlags(flagSet)
+	claimInput = *c
+
+	proofCount = proofCmd.Flags().Uint32("deposit-count", 0, "The deposit count of the deposit to prove")
+	eventsTo = eventsCmd.Flags().Uint64("to-block", 0, "The last block scanned, 0 for the latest block")
+
+	UlxlyCmd.AddCommand(depositCmd, claimCmd, proofCmd, eventsCmd)
+}
+
+func checkFlags() error {
+	for _, url := range []string{*params.L1RpcUrl, *params.L2RpcUrl} {
+		if err := util.ValidateUrl(url); err != nil {
+			return err
+		}
+	}
+	for _, address := range []string{*params.L1Bridge, *params.L2Bridge} {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("the bridge address %s is invalid", address)
+		}
+	}
+	*params.Network = strings.ToLower(*params.Network)
+	if *params.Network != "l1" && *params.Network != "l2" {
+		return fmt.Errorf("the network must be l1 or l2, not %s", *params.Network)
+	}
+	if *params.BlockRange == 0 {
+		return errors.New("the block range must be positive")
+	}
+	return nil
+}
+
+func checkDepositFlags() error {
+	if *depositInput.DestinationNetwork < -1 || *depositInput.DestinationNetwork > int64(^uint32(0)) {
+		return errors.New("the destination network must be a network ID or -1")
+	}
+	if a := *depositInput.DestinationAddress; a != "" && !common.IsHexAddress(a) {
+		return fmt.Errorf("the destination address %s is invalid", a)
+	}
+	if !common.IsHexAddress(*depositInput.Token) {
+		return fmt.Errorf("the token address %s is invalid", *depositInput.Token)
+	}
+	amount, ok := new(big.Int).SetString(*depositInput.Amount, 10)
+	if !ok || amount.Sign() < 0 {
+		return fmt.Errorf("the amount %s isn't a number of wei", *depositInput.Amount)
+	}
+	if *depositInput.CallData != "" {
+		if _, err := hexutil.Decode(*depositInput.CallData); err != nil {
+			return fmt.Errorf("unable to decode the call data: %w", err)
+		}
+		if common.HexToAddress(*depositInput.Token) != (common.Address{}) {
+			return errors.New("a message can only be bridged with native tokens")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package ulxly
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// treeHeight is the height of the deposit and rollup exit trees of the bridge.
+const treeHeight = 32
+
+// zeroHashes are the roots of the empty subtrees of every height.
+var zeroHashes = func() (z [treeHeight + 1]common.Hash) {
+	for h := 1; h <= treeHeight; h++ {
+		z[h] = crypto.Keccak256Hash(z[h-1][:], z[h-1][:])
+	}
+	return
+}()
+
+// leafHash returns the hash of a deposit leaf as computed by the bridge.
+func leafHash(leafType uint8, originNetwork uint32, originAddress common.Address, destinationNetwork uint32, destinationAddress common.Address, amount []byte, metadata []byte) common.Hash {
+	var networks [8]byte
+	binary.BigEndian.PutUint32(networks[:4], originNetwork)
+	binary.BigEndian.PutUint32(networks[4:], destinationNetwork)
+	return crypto.Keccak256Hash(
+		[]byte{leafType},
+		networks[:4],
+		originAddress[:],
+		networks[4:],
+		destinationAddress[:],
+		common.LeftPadBytes(amount, 32),
+		crypto.Keccak256(metadata),
+	)
+}
+
+// frontier is the append-only merkle tree of the bridge, keeping only the
+// left siblings needed to compute the root after every append.
+type frontier struct {
+	branch [treeHeight]common.Hash
+	count  uint64
+}
+
+func (f *frontier) add(leaf common.Hash) {
+	f.count++
+	node, size := leaf, f.count
+	for h := 0; h < treeHeight; h++ {
+		if size&1 == 1 {
+			f.branch[h] = node
+			return
+		}
+		node = crypto.Keccak256Hash(f.branch[h][:], node[:])
+		size >>= 1
+	}
+}
+
+func (f *frontier) root() common.Hash {
+	var node common.Hash
+	size := f.count
+	for h := 0; h < treeHeight; h++ {
+		if size&1 == 1 {
+			node = crypto.Keccak256Hash(f.branch[h][:], node[:])
+		} else {
+			node = crypto.Keccak256Hash(node[:], zeroHashes[h][:])
+		}
+		size >>= 1
+	}
+	return node
+}
+
+// countForRoot returns the number of leaves the tree had when its root was
+// the given one.
+func countForRoot(leaves []common.Hash, root common.Hash) (int, bool) {
+	var f frontier
+	if f.root() == root {
+		return 0, true
+	}
+	for i, l := range leaves {
+		f.add(l)
+		if f.root() == root {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// treeRoot returns the root of the tree of the leaves.
+func treeRoot(leaves []common.Hash) common.Hash {
+	var f frontier
+	for _, l := range leaves {
+		f.add(l)
+	}
+	return f.root()
+}
+
+// merkleProof returns the siblings of the leaf at the index, from the leaf to
+// the root, in the tree of the leaves.
+func merkleProof(leaves []common.Hash, index int) (proof [treeHeight]common.Hash) {
+	layer := leaves
+	for h := 0; h < treeHeight; h++ {
+		if sibling := index ^ 1; sibling < len(layer) {
+			proof[h] = layer[sibling]
+		} else {
+			proof[h] = zeroHashes[h]
+		}
+		next := make([]common.Hash, (len(layer)+1)/2)
+		for i := range next {
+			right := zeroHashes[h]
+			if 2*i+1 < len(layer) {
+				right = layer[2*i+1]
+			}
+			next[i] = crypto.Keccak256Hash(layer[2*i][:], right[:])
+		}
+		layer, index = next, index/2
+	}
+	return
+}
+
+// verifyProof checks the proof of the leaf at the index against the root, the
+// way the bridge does before a claim.
+func verifyProof(leaf common.Hash, proof [treeHeight]common.Hash, index uint32, root common.Hash) bool {
+	node := leaf
+	for h := 0; h < treeHeight; h++ {
+		if (index>>h)&1 == 1 {
+			node = crypto.Keccak256Hash(proof[h][:], node[:])
+		} else {
+			node = crypto.Keccak256Hash(node[:], proof[h][:])
+		}
+	}
+	return node == root
+}
@@ -0,0 +1,36 @@
+package ulxly
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestEmptyRoot(t *testing.T) {
+	// The deposit root of a bridge without any deposit.
+	want := common.HexToHash("0x27ae5ba08d7291c96c8cbddcc148bf48a6d68c7974b94356f53754ef6171d757")
+	if got := treeRoot(nil); got != want {
+		t.Errorf("expected the empty root %s, got %s", want, got)
+	}
+}
+
+func TestMerkleProof(t *testing.T) {
+	var leaves []common.Hash
+	for n := 1; n <= 9; n++ {
+		leaves = append(leaves, crypto.Keccak256Hash([]byte{byte(n)}))
+		root := treeRoot(leaves)
+		if count, ok := countForRoot(leaves, root); !ok || count != n {
+			t.Errorf("%d leaves: the root was found at count %d", n, count)
+		}
+		for i, leaf := range leaves {
+			proof := merkleProof(leaves, i)
+			if !verifyProof(leaf, proof, uint32(i), root) {
+				t.Errorf("%d leaves: the proof of leaf %d doesn't verify", n, i)
+			}
+			if verifyProof(leaf, proof, uint32(i+1), root) {
+				t.Errorf("%d leaves: the proof of leaf %d verifies at the wrong index", n, i)
+			}
+		}
+	}
+}
@@ -1,6 +1,19 @@
 package version
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maticnetwork/polygon-cli/util"
 	"github.com/spf13/cobra"
 )
 
@@ -11,12 +24,167 @@ var (
 	BuiltBy = "unknown"
 )
 
-// versionCmd represents the version command
+// latestReleaseURL is the GitHub API endpoint of the latest release.
+const latestReleaseURL = "https://api.github.com/repos/maticnetwork/polygon-cli/releases/latest"
+
+type (
+	// Info is the build metadata of the binary.
+	Info struct {
+		Version   string
+		Commit    string
+		Date      string
+		BuiltBy   string
+		GoVersion string
+		Platform  string
+		// LatestVersion is the version of the latest release with --check.
+		LatestVersion   string `json:",omitempty"`
+		UpdateAvailable bool   `json:",omitempty"`
+	}
+	release struct {
+		TagName string `json:"tag_name"`
+	}
+)
+
+var check *bool
+
+// VersionCmd represents the version command.
 var VersionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Get the current version of this application",
-	Long:  `Nothing fancy. Print the version of this application`,
-	Run: func(cmd *cobra.Command, args []string) {
-		cmd.Printf("Polygon CLI Version %s\n", Version)
+	Long:  "Print the version of this application with its build metadata: the commit, the build date, the Go version and the platform. With --check, the latest release on GitHub tells whether a newer version exists.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := GetInfo()
+		if *check {
+			ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+			defer cancel()
+			latest, err := latestRelease(ctx)
+			if err != nil {
+				return fmt.Errorf("unable to check the latest release: %w", err)
+			}
+			info.LatestVersion = latest.TagName
+			info.UpdateAvailable = compareVersions(info.Version, latest.TagName) < 0
+		}
+		return util.NewOutputWriter(os.Stdout).Write(info)
 	},
 }
+
+func init() {
+	check = VersionCmd.Flags().Bool("check", false, "Query the GitHub releases and report whether a newer version exists")
+}
+
+// GetInfo returns the build metadata, falling back to the version control
+// details embedded by go when the binary wasn't built with the Makefile.
+func GetInfo() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		BuiltBy:   BuiltBy,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	modified := false
+	for _, s := range build.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			if info.Commit == "none" {
+				info.Commit = s.Value
+			}
+		case "vcs.time":
+			if info.Date == "unknown" {
+				info.Date = s.Value
+			}
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if modified && Commit == "none" && info.Commit != "none" {
+		info.Commit += "-dirty"
+	}
+	return info
+}
+
+func latestRelease(ctx context.Context) (*release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	r := new(release)
+	if err = json.NewDecoder(resp.Body).Decode(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// compareVersions compares two vMAJOR.MINOR.PATCH versions. A version which
+// can't be parsed, e.g. a dev build, is older than any release.
+func compareVersions(a, b string) int {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return -1
+	case !okB:
+		return 1
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			if va[i] < vb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func parseVersion(v string) (parsed [3]int, ok bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	// The pre-release and build suffixes are ignored.
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return parsed, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return parsed, false
+		}
+		parsed[i] = n
+	}
+	return parsed, true
+}
+
+// WriteText renders the build metadata, one detail per line.
+func (i Info) WriteText(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "Polygon CLI Version %s\nCommit: %s\nBuild date: %s\nBuilt by: %s\nGo version: %s\nPlatform: %s\n",
+		i.Version, i.Commit, i.Date, i.BuiltBy, i.GoVersion, i.Platform)
+	if err != nil || i.LatestVersion == "" {
+		return err
+	}
+	if i.UpdateAvailable {
+		_, err = fmt.Fprintf(w, "A newer version %s is available at https://github.com/maticnetwork/polygon-cli/releases\n", i.LatestVersion)
+	} else {
+		_, err = fmt.Fprintf(w, "This is the latest version, %s\n", i.LatestVersion)
+	}
+	return err
+}
@@ -1,13 +1,19 @@
 package wallet
 
 import (
-	"encoding/json"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 
 	_ "embed"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/maticnetwork/polygon-cli/gethkeystore"
 	"github.com/maticnetwork/polygon-cli/hdwallet"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
 
@@ -25,15 +31,26 @@ var (
 	inputAddressesToGenerate *uint
 	inputUseRawEntropy       *bool
 	inputRootOnly            *bool
+	inputExportKeystore      *string
+	inputKeystorePassword    *string
+	inputNewKeystorePassword *string
+	inputKeystoreFile        *string
 )
 
 // WalletCmd represents the wallet command
 var WalletCmd = &cobra.Command{
-	Use:   "wallet [create|inspect]",
-	Short: "Create or inspect BIP39(ish) wallets.",
+	Use:   "wallet [create|inspect|import]",
+	Short: "Create, inspect or import BIP39(ish) wallets.",
 	Long:  usage,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		mode := args[0]
+		if mode == "import" {
+			key, err := importKeystore()
+			if err != nil {
+				return err
+			}
+			return util.NewOutputWriter(os.Stdout).Write(key)
+		}
 		var err error
 		var mnemonic string
 		if mode == "inspect" {
@@ -78,25 +95,38 @@ var WalletCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
-			out, _ := json.MarshalIndent(key, " ", " ")
-			fmt.Println(string(out))
-			return nil
+			if err = exportKeystore(key.HexPrivateKey, *inputKeystorePassword); err != nil {
+				return err
+			}
+			return util.NewOutputWriter(os.Stdout).Write(key)
 		}
 		key, err := pw.ExportHDAddresses(int(*inputAddressesToGenerate))
 		if err != nil {
 			return err
 		}
-		// TODO support json vs txt out
-		out, _ := json.MarshalIndent(key, " ", " ")
-		fmt.Println(string(out))
-		return nil
+		for _, addr := range key.Addresses {
+			if err = exportKeystore(addr.HexPrivateKey, *inputKeystorePassword); err != nil {
+				return err
+			}
+		}
+		return util.NewOutputWriter(os.Stdout).Write(key)
 	},
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
-			return fmt.Errorf("expected exactly one argument: create or inspect")
+			return fmt.Errorf("expected exactly one argument: create, inspect or import")
+		}
+		if args[0] != "create" && args[0] != "inspect" && args[0] != "import" {
+			return fmt.Errorf("expected argument to be create, inspect or import. Got: %s", args[0])
 		}
-		if args[0] != "create" && args[0] != "inspect" {
-			return fmt.Errorf("expected argument to be create or inspect. Got: %s", args[0])
+		if args[0] == "import" && *inputKeystoreFile == "" {
+			return fmt.Errorf("--keystore-file is required to import a keystore")
+		}
+		if args[0] != "import" && *inputNewKeystorePassword != "" {
+			return fmt.Errorf("--new-keystore-password is only used to export an imported keystore again")
+		}
+		// Anyone could decrypt a keystore written with an empty password.
+		if *inputExportKeystore != "" && *inputKeystorePassword == "" {
+			return fmt.Errorf("--keystore-password can't be empty when exporting keystore files")
 		}
 		return nil
 	},
@@ -119,6 +149,61 @@ func getFileOrFlag(filename *string, flag *string) (string, error) {
 	return "", fmt.Errorf("unable to determine flat or filename")
 }
 
+// exportKeystore writes the given hex private key as a geth style keystore file
+// encrypted with the password in the directory given by --export-keystore. It's
+// a no-op if the flag isn't set.
+func exportKeystore(hexPrivateKey, password string) error {
+	if *inputExportKeystore == "" {
+		return nil
+	}
+	pk, err := crypto.HexToECDSA(hexPrivateKey)
+	if err != nil {
+		return err
+	}
+	ks := keystore.NewKeyStore(*inputExportKeystore, keystore.StandardScryptN, keystore.StandardScryptP)
+	acc, err := ks.ImportECDSA(pk, password)
+	if err == keystore.ErrAccountAlreadyExists {
+		log.Warn().Str("address", acc.Address.String()).Msg("Key already exists in keystore")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	log.Info().Str("address", acc.Address.String()).Str("file", acc.URL.Path).Msg("Exported key to keystore")
+	return nil
+}
+
+// importKeystore decrypts the geth style keystore file given by --keystore-file
+// with --keystore-password and returns its key. The key can be written again
+// to a keystore with --export-keystore, encrypted with --new-keystore-password
+// to change its password.
+func importKeystore() (*hdwallet.PolyAddressExport, error) {
+	data, err := os.ReadFile(*inputKeystoreFile)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := gethkeystore.DecryptKeystoreFile(data, *inputKeystorePassword)
+	if errors.Is(err, keystore.ErrDecrypt) {
+		return nil, util.NewError(util.AuthError, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the keystore %s: %w", *inputKeystoreFile, err)
+	}
+	key := new(hdwallet.PolyAddressExport)
+	key.HexPrivateKey = hex.EncodeToString(crypto.FromECDSA(pk))
+	key.HexPublicKey = hex.EncodeToString(crypto.CompressPubkey(&pk.PublicKey))
+	key.HexFullPublicKey = hex.EncodeToString(crypto.FromECDSAPub(&pk.PublicKey)[1:])
+	key.ETHAddress = crypto.PubkeyToAddress(pk.PublicKey).String()
+	password := *inputNewKeystorePassword
+	if password == "" {
+		password = *inputKeystorePassword
+	}
+	if err = exportKeystore(key.HexPrivateKey, password); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 func init() {
 	inputKDFIterations = WalletCmd.PersistentFlags().Uint("iterations", 2048, "Number of pbkdf2 iterations to perform")
 	inputWords = WalletCmd.PersistentFlags().Int("words", 24, "The number of words to use in the mnemonic")
@@ -135,4 +220,8 @@ func init() {
 	inputMnemonicFile = WalletCmd.PersistentFlags().String("mnemonic-file", "", "A mneomonic phrase written in a file used to generate entropy")
 	inputUseRawEntropy = WalletCmd.PersistentFlags().Bool("raw-entropy", false, "substrate and polkda dot don't follow strict bip39 and use raw entropy")
 	inputRootOnly = WalletCmd.PersistentFlags().Bool("root-only", false, "don't produce HD accounts. Just produce a single wallet")
+	inputExportKeystore = WalletCmd.PersistentFlags().String("export-keystore", "", "If set, the generated private keys will also be written as geth keystore files in this directory")
+	inputKeystorePassword = WalletCmd.PersistentFlags().String("keystore-password", "", "The password used to encrypt the exported keystore files, or to decrypt the imported keystore file")
+	inputNewKeystorePassword = WalletCmd.PersistentFlags().String("new-keystore-password", "", "The password used to encrypt the keystore file exported again by import, --keystore-password if empty")
+	inputKeystoreFile = WalletCmd.PersistentFlags().String("keystore-file", "", "The geth keystore file to decrypt with import")
 }
@@ -3,19 +3,19 @@ package dashboard
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"sort"
 	"strings"
 
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
 type (
 	DashboardOptions struct {
 		File                string
+		Format              string
 		Prefix              string
 		Title               string
 		Description         string
@@ -112,26 +112,54 @@ func ConvertMetricsToDashboard(input *DashboardOptions) ([]byte, error) {
 	if len(input.TemplateVars) != len(input.TemplateVarDefaults) && len(input.TemplateVarDefaults) > 0 {
 		return nil, fmt.Errorf("the length of the template vars and template var defaults arguments do not match")
 	}
-	metrics, err := ParseMetricsFile(input.File)
+	metrics, err := ParseMetrics(input.File)
 	if err != nil {
 		return nil, err
 	}
-	dd, err := MetricsToDataDog(input, metrics)
+	var dash any
+	switch strings.ToLower(input.Format) {
+	case "", "datadog":
+		dash, err = MetricsToDataDog(input, metrics)
+	case "grafana":
+		dash, err = MetricsToGrafana(input, metrics)
+	default:
+		err = fmt.Errorf("the dashboard format %s is not supported", input.Format)
+	}
 	if err != nil {
 		return nil, err
 	}
-	jsBytes, err := json.Marshal(dd)
+	jsBytes, err := json.Marshal(dash)
 	if err != nil {
 		return nil, err
 	}
 	return jsBytes, nil
 }
 
+// ParseMetrics parses the metrics exposition of the file, or of the endpoint
+// when an http(s) URL is given, e.g. http://localhost:6060/debug/metrics/prometheus.
+func ParseMetrics(input string) (map[string]*dto.MetricFamily, error) {
+	if !strings.HasPrefix(input, "http://") && !strings.HasPrefix(input, "https://") {
+		return ParseMetricsFile(input)
+	}
+	resp, err := http.Get(input)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to get the metrics from %s: %s", input, resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	return parser.TextToMetricFamilies(resp.Body)
+}
+
 func ParseMetricsFile(filePath string) (map[string]*dto.MetricFamily, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
+	defer file.Close()
 
 	var parser expfmt.TextParser
 	mf, err := parser.TextToMetricFamilies(file)
@@ -284,16 +312,8 @@ func NewDataDogHistogramWidget(dopts *DashboardOptions, mf *dto.MetricFamily) *D
 // newDataDogWidget will initialize a basic object with arrays with one item in them
 func newDataDogWidget(dopts *DashboardOptions, mf *dto.MetricFamily) *DataDogWidget {
 	w := new(DataDogWidget)
-	name := *mf.Name
-	for _, strip := range dopts.StripPrefixes {
-		name = strings.TrimPrefix(name, strip)
-	}
-	if dopts.Pretty {
-		name = strings.ReplaceAll(name, "_", " ")
-		name = cases.Title(language.English, cases.Compact).String(name)
-	}
-	w.Definition.Title = name
-	w.Definition.helpText = *mf.Help
+	w.Definition.Title = prettyName(dopts, *mf.Name)
+	w.Definition.helpText = mf.GetHelp()
 	w.Definition.Type = "timeseries"
 	w.Definition.TitleSize = "16"
 	w.Definition.TitleAlign = "left"
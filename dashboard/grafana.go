@@ -0,0 +1,300 @@
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// The Grafana grid is 24 columns wide while the DataDog one is 12, so the
+// widget sizes are scaled to keep the same proportions.
+const (
+	grafanaGridWidth   = 24
+	grafanaWidthScale  = 2
+	grafanaHeightScale = 3
+
+	// groups with fewer metrics are merged into the other group
+	grafanaMinGroupSize = 2
+	grafanaOtherGroup   = "other"
+)
+
+// DefaultGrafanaTemplateVars are the template variables of Grafana dashboards
+// when none are given.
+var DefaultGrafanaTemplateVars = []string{"job", "instance"}
+
+type (
+	GrafanaDataSource struct {
+		Type string `json:"type"`
+		UID  string `json:"uid"`
+	}
+	GrafanaGridPos struct {
+		H int `json:"h"`
+		W int `json:"w"`
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+	GrafanaTarget struct {
+		DataSource   *GrafanaDataSource `json:"datasource,omitempty"`
+		Expr         string             `json:"expr"`
+		LegendFormat string             `json:"legendFormat,omitempty"`
+		RefID        string             `json:"refId"`
+	}
+	GrafanaFieldConfig struct {
+		Defaults struct {
+			Unit string `json:"unit,omitempty"`
+		} `json:"defaults"`
+		Overrides []interface{} `json:"overrides"`
+	}
+	GrafanaPanel struct {
+		ID          int                 `json:"id"`
+		Type        string              `json:"type"`
+		Title       string              `json:"title"`
+		Description string              `json:"description,omitempty"`
+		DataSource  *GrafanaDataSource  `json:"datasource,omitempty"`
+		GridPos     GrafanaGridPos      `json:"gridPos"`
+		Targets     []GrafanaTarget     `json:"targets,omitempty"`
+		FieldConfig *GrafanaFieldConfig `json:"fieldConfig,omitempty"`
+		Collapsed   *bool               `json:"collapsed,omitempty"`
+		Panels      []GrafanaPanel      `json:"panels,omitempty"`
+	}
+	GrafanaTemplateOption struct {
+		Text  string `json:"text"`
+		Value string `json:"value"`
+	}
+	GrafanaTemplateVariable struct {
+		Name       string                 `json:"name"`
+		Label      string                 `json:"label,omitempty"`
+		Type       string                 `json:"type"`
+		DataSource *GrafanaDataSource     `json:"datasource,omitempty"`
+		Query      string                 `json:"query"`
+		Definition string                 `json:"definition,omitempty"`
+		Refresh    int                    `json:"refresh"`
+		IncludeAll bool                   `json:"includeAll"`
+		Multi      bool                   `json:"multi"`
+		AllValue   string                 `json:"allValue,omitempty"`
+		Current    *GrafanaTemplateOption `json:"current,omitempty"`
+		Sort       int                    `json:"sort"`
+	}
+	GrafanaDashboard struct {
+		Title         string   `json:"title"`
+		Description   string   `json:"description,omitempty"`
+		Editable      bool     `json:"editable"`
+		SchemaVersion int      `json:"schemaVersion"`
+		Tags          []string `json:"tags"`
+		Refresh       string   `json:"refresh"`
+		Time          struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"time"`
+		Templating struct {
+			List []GrafanaTemplateVariable `json:"list"`
+		} `json:"templating"`
+		Panels []GrafanaPanel `json:"panels"`
+	}
+)
+
+// MetricsToGrafana builds a Grafana dashboard with a row of panels for each
+// metric prefix. Every query is filtered by the template variables.
+func MetricsToGrafana(dopts *DashboardOptions, metrics map[string]*dto.MetricFamily) (*GrafanaDashboard, error) {
+	if dopts.WidgetWidth <= 0 || dopts.WidgetWidth*grafanaWidthScale > grafanaGridWidth {
+		return nil, fmt.Errorf("the widget width must be between 1 and %d", grafanaGridWidth/grafanaWidthScale)
+	}
+	vars := dopts.TemplateVars
+	if len(vars) == 0 {
+		vars = DefaultGrafanaTemplateVars
+	}
+	ds := &GrafanaDataSource{Type: "prometheus", UID: "${datasource}"}
+
+	names := make([]string, 0, len(metrics))
+	for name, mf := range metrics {
+		if mf.Type == nil || mf.Name == nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("the metrics snapshot doesn't hold any metric")
+	}
+	sort.Strings(names)
+
+	groups := make(map[string][]*dto.MetricFamily)
+	for _, name := range names {
+		group := metricGroup(dopts, name)
+		groups[group] = append(groups[group], metrics[name])
+	}
+	for group, mfs := range groups {
+		if group != grafanaOtherGroup && len(mfs) < grafanaMinGroupSize {
+			groups[grafanaOtherGroup] = append(groups[grafanaOtherGroup], mfs...)
+			delete(groups, group)
+		}
+	}
+	groupNames := make([]string, 0, len(groups))
+	for group := range groups {
+		if group != grafanaOtherGroup {
+			groupNames = append(groupNames, group)
+		}
+	}
+	sort.Strings(groupNames)
+	if _, ok := groups[grafanaOtherGroup]; ok {
+		groupNames = append(groupNames, grafanaOtherGroup)
+	}
+
+	matchers := make([]string, 0, len(vars))
+	for _, v := range vars {
+		matchers = append(matchers, fmt.Sprintf(`%s=~"$%s"`, v, v))
+	}
+	selector := strings.Join(matchers, ",")
+
+	width := dopts.WidgetWidth * grafanaWidthScale
+	height := dopts.WidgetHeight * grafanaHeightScale
+	perRow := grafanaGridWidth / width
+	id := 1
+	y := 0
+	panels := make([]GrafanaPanel, 0)
+	for _, group := range groupNames {
+		collapsed := false
+		panels = append(panels, GrafanaPanel{
+			ID:        id,
+			Type:      "row",
+			Title:     prettyName(dopts, group),
+			GridPos:   GrafanaGridPos{H: 1, W: grafanaGridWidth, X: 0, Y: y},
+			Collapsed: &collapsed,
+		})
+		id++
+		y++
+
+		mfs := groups[group]
+		sort.Slice(mfs, func(i, j int) bool { return *mfs[i].Name < *mfs[j].Name })
+		for k, mf := range mfs {
+			p := newGrafanaPanel(dopts, mf, ds, selector)
+			p.ID = id
+			p.GridPos = GrafanaGridPos{H: height, W: width, X: (k % perRow) * width, Y: y + (k/perRow)*height}
+			panels = append(panels, *p)
+			id++
+		}
+		y += ((len(mfs) + perRow - 1) / perRow) * height
+	}
+
+	dash := new(GrafanaDashboard)
+	dash.Title = dopts.Title
+	dash.Description = dopts.Description
+	dash.Editable = true
+	dash.SchemaVersion = 39
+	dash.Tags = []string{"polycli"}
+	dash.Refresh = "30s"
+	dash.Time.From = "now-1h"
+	dash.Time.To = "now"
+	dash.Panels = panels
+	dash.Templating.List = newGrafanaTemplateVariables(dopts, vars, ds, dopts.Prefix+names[0])
+	return dash, nil
+}
+
+// newGrafanaTemplateVariables returns the data source variable followed by a
+// variable for each label. Each label only lists the values matching the
+// labels before it, e.g. the instances of the selected job.
+func newGrafanaTemplateVariables(dopts *DashboardOptions, vars []string, ds *GrafanaDataSource, metric string) []GrafanaTemplateVariable {
+	list := []GrafanaTemplateVariable{{
+		Name:  "datasource",
+		Label: "Data source",
+		Type:  "datasource",
+		Query: "prometheus",
+	}}
+	matchers := make([]string, 0, len(vars))
+	for k, v := range vars {
+		query := fmt.Sprintf("label_values(%s{%s}, %s)", metric, strings.Join(matchers, ","), v)
+		tv := GrafanaTemplateVariable{
+			Name:       v,
+			Label:      v,
+			Type:       "query",
+			DataSource: ds,
+			Query:      query,
+			Definition: query,
+			// refresh the values when the time range changes
+			Refresh:    2,
+			IncludeAll: true,
+			Multi:      true,
+			AllValue:   ".*",
+			Sort:       1,
+		}
+		if k < len(dopts.TemplateVarDefaults) {
+			tv.Current = &GrafanaTemplateOption{Text: dopts.TemplateVarDefaults[k], Value: dopts.TemplateVarDefaults[k]}
+		}
+		list = append(list, tv)
+		matchers = append(matchers, fmt.Sprintf(`%s=~"$%s"`, v, v))
+	}
+	return list
+}
+
+// newGrafanaPanel returns a time series panel with the query suited to the
+// metric type.
+func newGrafanaPanel(dopts *DashboardOptions, mf *dto.MetricFamily, ds *GrafanaDataSource, selector string) *GrafanaPanel {
+	name := dopts.Prefix + *mf.Name
+	legend := "{{instance}}"
+	var expr string
+	switch *mf.Type {
+	case dto.MetricType_COUNTER:
+		// The increase per second is more telling than the ever growing total.
+		expr = fmt.Sprintf("rate(%s{%s}[$__rate_interval])", name, selector)
+	case dto.MetricType_HISTOGRAM:
+		expr = fmt.Sprintf("histogram_quantile(0.95, sum by (le, instance) (rate(%s_bucket{%s}[$__rate_interval])))", name, selector)
+		legend = "p95 {{instance}}"
+	case dto.MetricType_SUMMARY:
+		expr = fmt.Sprintf("%s{%s}", name, selector)
+		legend = "{{instance}} {{quantile}}"
+	default:
+		expr = fmt.Sprintf("%s{%s}", name, selector)
+	}
+
+	p := new(GrafanaPanel)
+	p.Type = "timeseries"
+	p.Title = prettyName(dopts, *mf.Name)
+	p.Description = mf.GetHelp()
+	p.DataSource = ds
+	p.Targets = []GrafanaTarget{{DataSource: ds, Expr: expr, LegendFormat: legend, RefID: "A"}}
+	p.FieldConfig = &GrafanaFieldConfig{Overrides: []interface{}{}}
+	p.FieldConfig.Defaults.Unit = metricUnit(*mf.Name)
+	return p
+}
+
+// metricGroup returns the prefix of the metric name, once the stripped
+// prefixes are removed, e.g. chain for chain_head_block.
+func metricGroup(dopts *DashboardOptions, name string) string {
+	for _, strip := range dopts.StripPrefixes {
+		name = strings.TrimPrefix(name, strip)
+	}
+	group, _, found := strings.Cut(name, "_")
+	if !found || group == "" {
+		return grafanaOtherGroup
+	}
+	return group
+}
+
+// metricUnit guesses the Grafana unit from the metric name suffix.
+func metricUnit(name string) string {
+	name = strings.TrimSuffix(name, "_total")
+	switch {
+	case strings.HasSuffix(name, "_seconds"):
+		return "s"
+	case strings.HasSuffix(name, "_bytes"):
+		return "bytes"
+	case strings.HasSuffix(name, "_ratio"):
+		return "percentunit"
+	default:
+		return ""
+	}
+}
+
+func prettyName(dopts *DashboardOptions, name string) string {
+	for _, strip := range dopts.StripPrefixes {
+		name = strings.TrimPrefix(name, strip)
+	}
+	if dopts.Pretty {
+		name = strings.ReplaceAll(name, "_", " ")
+		name = cases.Title(language.English, cases.Compact).String(name)
+	}
+	return name
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// changelogFile is the name of the CLI changes section in the output directory.
+const changelogFile = "cli-changes.md"
+
+type (
+	// changelog lists the differences of the CLI surface between two command
+	// tree exports.
+	changelog struct {
+		NewCommands        []commandSchema
+		RemovedCommands    []commandSchema
+		DeprecatedCommands []commandSchema
+		NewFlags           []flagChange
+		RemovedFlags       []flagChange
+		ChangedDefaults    []flagChange
+		ChangedFlags       []flagChange
+		DeprecatedFlags    []flagChange
+	}
+	// flagChange is a flag of a command before and after the change. Either
+	// side is nil when the flag was added or removed.
+	flagChange struct {
+		Path     string
+		Previous *flagSchema
+		Current  *flagSchema
+	}
+)
+
+// genChangelog will compare the command tree with the export of a previous
+// release and write the markdown CLI changes section in the given directory.
+func genChangelog(cmd *cobra.Command, previousFile, dir string) error {
+	data, err := os.ReadFile(previousFile)
+	if err != nil {
+		return err
+	}
+	var previous cliSchema
+	if err = json.Unmarshal(data, &previous); err != nil {
+		return fmt.Errorf("unable to parse %s: %w", previousFile, err)
+	}
+	if previous.SchemaVersion != schemaVersion {
+		return fmt.Errorf("unsupported schema version %d in %s, expected %d", previous.SchemaVersion, previousFile, schemaVersion)
+	}
+
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	current := newCLISchema(cmd)
+	c := diffSchemas(previous.Command, current.Command)
+	return os.WriteFile(filepath.Join(dir, changelogFile), c.markdown(previous.Version, current.Version), 0644)
+}
+
+// diffSchemas compares every command of the trees by path, and the flags
+// declared by the commands present in both trees by name.
+func diffSchemas(previous, current commandSchema) changelog {
+	var c changelog
+	before, after := flattenCommands(previous), flattenCommands(current)
+
+	for _, path := range sortedKeys(after) {
+		cur := after[path]
+		prev, ok := before[path]
+		if !ok {
+			c.NewCommands = append(c.NewCommands, cur)
+			continue
+		}
+		if cur.Deprecated != "" && prev.Deprecated == "" {
+			c.DeprecatedCommands = append(c.DeprecatedCommands, cur)
+		}
+		c.diffFlags(path, prev.Flags, cur.Flags)
+	}
+	for _, path := range sortedKeys(before) {
+		if _, ok := after[path]; !ok {
+			c.RemovedCommands = append(c.RemovedCommands, before[path])
+		}
+	}
+	return c
+}
+
+func (c *changelog) diffFlags(path string, previous, current []flagSchema) {
+	before := make(map[string]*flagSchema, len(previous))
+	for i := range previous {
+		before[previous[i].Name] = &previous[i]
+	}
+	after := make(map[string]*flagSchema, len(current))
+	for i := range current {
+		after[current[i].Name] = &current[i]
+	}
+
+	for i := range current {
+		cur := &current[i]
+		prev, ok := before[cur.Name]
+		change := flagChange{Path: path, Previous: prev, Current: cur}
+		if !ok {
+			c.NewFlags = append(c.NewFlags, change)
+			continue
+		}
+		if cur.Default != prev.Default {
+			c.ChangedDefaults = append(c.ChangedDefaults, change)
+		}
+		if cur.Type != prev.Type || cur.Shorthand != prev.Shorthand || cur.Required != prev.Required || cur.Persistent != prev.Persistent {
+			c.ChangedFlags = append(c.ChangedFlags, change)
+		}
+		if cur.Deprecated != "" && prev.Deprecated == "" {
+			c.DeprecatedFlags = append(c.DeprecatedFlags, change)
+		}
+	}
+	for i := range previous {
+		if _, ok := after[previous[i].Name]; !ok {
+			c.RemovedFlags = append(c.RemovedFlags, flagChange{Path: path, Previous: &previous[i]})
+		}
+	}
+}
+
+// flattenCommands indexes the command and all descendants by path.
+func flattenCommands(cmd commandSchema) map[string]commandSchema {
+	commands := make(map[string]commandSchema)
+	var visit func(commandSchema)
+	visit = func(c commandSchema) {
+		commands[c.Path] = c
+		for _, child := range c.Commands {
+			visit(child)
+		}
+	}
+	visit(cmd)
+	return commands
+}
+
+func sortedKeys(m map[string]commandSchema) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (c changelog) empty() bool {
+	return len(c.NewCommands)+len(c.RemovedCommands)+len(c.DeprecatedCommands)+
+		len(c.NewFlags)+len(c.RemovedFlags)+len(c.ChangedDefaults)+len(c.ChangedFlags)+len(c.DeprecatedFlags) == 0
+}
+
+// markdown renders the changelog as a markdown section, omitting the empty
+// subsections.
+func (c changelog) markdown(previousVersion, currentVersion string) []byte {
+	buf := new(bytes.Buffer)
+	buf.WriteString(fmt.Sprintf("## CLI changes\n\nChanges of the commands and flags from `%s` to `%s`.\n\n", previousVersion, currentVersion))
+	if c.empty() {
+		buf.WriteString("No changes.\n")
+		return buf.Bytes()
+	}
+
+	printCommands := func(title string, commands []commandSchema, describe func(commandSchema) string) {
+		if len(commands) == 0 {
+			return
+		}
+		buf.WriteString("### " + title + "\n\n")
+		for _, cmd := range commands {
+			buf.WriteString(fmt.Sprintf("- `%s` - %s\n", cmd.Path, describe(cmd)))
+		}
+		buf.WriteString("\n")
+	}
+	printFlags := func(title string, changes []flagChange, describe func(flagChange) string) {
+		if len(changes) == 0 {
+			return
+		}
+		buf.WriteString("### " + title + "\n\n")
+		for _, f := range changes {
+			name := f.Current
+			if name == nil {
+				name = f.Previous
+			}
+			buf.WriteString(fmt.Sprintf("- `%s --%s` - %s\n", f.Path, name.Name, describe(f)))
+		}
+		buf.WriteString("\n")
+	}
+
+	printCommands("New commands", c.NewCommands, func(cmd commandSchema) string { return oneLine(cmd.Short) })
+	printCommands("Removed commands", c.RemovedCommands, func(cmd commandSchema) string { return oneLine(cmd.Short) })
+	printCommands("Deprecated commands", c.DeprecatedCommands, func(cmd commandSchema) string { return oneLine(cmd.Deprecated) })
+	printFlags("New flags", c.NewFlags, func(f flagChange) string { return describeFlag(f.Current) })
+	printFlags("Removed flags", c.RemovedFlags, func(f flagChange) string { return describeFlag(f.Previous) })
+	printFlags("Changed defaults", c.ChangedDefaults, func(f flagChange) string {
+		return fmt.Sprintf("`%s` → `%s`", f.Previous.Default, f.Current.Default)
+	})
+	printFlags("Changed flags", c.ChangedFlags, describeFlagChange)
+	printFlags("Deprecated flags", c.DeprecatedFlags, func(f flagChange) string { return oneLine(f.Current.Deprecated) })
+	return append(bytes.TrimRight(buf.Bytes(), "\n"), '\n')
+}
+
+func describeFlag(f *flagSchema) string {
+	s := f.Type
+	if f.Default != "" && f.Default != "[]" {
+		s += fmt.Sprintf(", default `%s`", f.Default)
+	}
+	return s + ": " + oneLine(f.Usage)
+}
+
+// oneLine joins the lines of a multi-line text, e.g. a flag usage listing the
+// values of the flag, so it fits in a markdown bullet.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// describeFlagChange lists the changed attributes of the flag, besides the
+// default value which has its own subsection.
+func describeFlagChange(f flagChange) string {
+	prev, cur := f.Previous, f.Current
+	var s string
+	add := func(format string, a ...any) {
+		if s != "" {
+			s += ", "
+		}
+		s += fmt.Sprintf(format, a...)
+	}
+	if prev.Type != cur.Type {
+		add("type `%s` → `%s`", prev.Type, cur.Type)
+	}
+	if prev.Shorthand != cur.Shorthand {
+		add("shorthand %s → %s", shorthandOrNone(prev.Shorthand), shorthandOrNone(cur.Shorthand))
+	}
+	if prev.Required != cur.Required {
+		if cur.Required {
+			add("now required")
+		} else {
+			add("no longer required")
+		}
+	}
+	if prev.Persistent != cur.Persistent {
+		if cur.Persistent {
+			add("now inherited by the subcommands")
+		} else {
+			add("no longer inherited by the subcommands")
+		}
+	}
+	return s
+}
+
+func shorthandOrNone(shorthand string) string {
+	if shorthand == "" {
+		return "none"
+	}
+	return "`-" + shorthand + "`"
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	previous := commandSchema{
+		Path: "polycli",
+		Commands: []commandSchema{
+			{
+				Path: "polycli old",
+				Flags: []flagSchema{
+					{Name: "rpc-url", Type: "string", Default: "http://localhost:8545", Usage: "The RPC endpoint url"},
+				},
+			},
+			{
+				Path: "polycli kept",
+				Flags: []flagSchema{
+					{Name: "rpc-url", Type: "string", Default: "http://localhost:8545", Usage: "The RPC endpoint url"},
+					{Name: "gone", Type: "bool", Default: "false", Usage: "A removed flag"},
+					{Name: "legacy", Type: "bool", Default: "false", Usage: "A deprecated flag"},
+				},
+			},
+		},
+	}
+	current := commandSchema{
+		Path: "polycli",
+		Commands: []commandSchema{
+			{
+				Path:       "polycli kept",
+				Deprecated: "use polycli new",
+				Flags: []flagSchema{
+					{Name: "rpc-url", Type: "string", Default: "http://127.0.0.1:8545", Usage: "The RPC endpoint url"},
+					{Name: "legacy", Type: "bool", Default: "false", Usage: "A deprecated flag", Deprecated: "use --mode"},
+					{Name: "mode", Type: "string", Default: "fast", Usage: "The mode:\nfast - skip the checks\nslow - run the checks"},
+				},
+			},
+			{Path: "polycli new", Short: "A new\ncommand"},
+		},
+	}
+
+	c := diffSchemas(previous, current)
+	paths := func(commands []commandSchema) string {
+		var s []string
+		for _, cmd := range commands {
+			s = append(s, cmd.Path)
+		}
+		return strings.Join(s, ",")
+	}
+	flags := func(changes []flagChange) string {
+		var s []string
+		for _, f := range changes {
+			name := f.Current
+			if name == nil {
+				name = f.Previous
+			}
+			s = append(s, f.Path+" --"+name.Name)
+		}
+		return strings.Join(s, ",")
+	}
+	for name, tc := range map[string]struct{ got, expected string }{
+		"new commands":        {paths(c.NewCommands), "polycli new"},
+		"removed commands":    {paths(c.RemovedCommands), "polycli old"},
+		"deprecated commands": {paths(c.DeprecatedCommands), "polycli kept"},
+		"new flags":           {flags(c.NewFlags), "polycli kept --mode"},
+		"removed flags":       {flags(c.RemovedFlags), "polycli kept --gone"},
+		"changed defaults":    {flags(c.ChangedDefaults), "polycli kept --rpc-url"},
+		"changed flags":       {flags(c.ChangedFlags), ""},
+		"deprecated flags":    {flags(c.DeprecatedFlags), "polycli kept --legacy"},
+	} {
+		if tc.got != tc.expected {
+			t.Errorf("%s: expected %q, got %q", name, tc.expected, tc.got)
+		}
+	}
+
+	md := string(c.markdown("v1", "v2"))
+	for _, line := range []string{
+		"- `polycli new` - A new command",
+		"- `polycli kept --mode` - string, default `fast`: The mode: fast - skip the checks slow - run the checks",
+		"- `polycli kept --rpc-url` - `http://localhost:8545` → `http://127.0.0.1:8545`",
+		"- `polycli kept --legacy` - use --mode",
+	} {
+		if !strings.Contains(md, line+"\n") {
+			t.Errorf("expected the line %q in:\n%s", line, md)
+		}
+	}
+
+	if c = diffSchemas(current, current); !c.empty() {
+		t.Errorf("expected no changes, got %+v", c)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+// checkMarkdownDoc will generate the Markdown documentation of this command in a temporary
+// directory and compare it with the documentation in dir. It returns the human readable diff
+// of every page which drifted, was not generated yet or is orphaned. An empty string means
+// the documentation is up to date.
+func checkMarkdownDoc(cmd *cobra.Command, dir string, linkHandler func(string) string, frontMatter func(*cobra.Command) string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "polycli-doc-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = genMarkdownDoc(cmd, tmpDir, linkHandler, frontMatter); err != nil {
+		return "", err
+	}
+
+	expected, err := listPages(tmpDir, cmd.Name())
+	if err != nil {
+		return "", err
+	}
+	actual, err := listPages(dir, cmd.Name())
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, page := range expected {
+		want, err := os.ReadFile(filepath.Join(tmpDir, page))
+		if err != nil {
+			return "", err
+		}
+		got, err := os.ReadFile(filepath.Join(dir, page))
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		diff, err := diffPage(filepath.Join(dir, page), string(got), string(want))
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(diff)
+	}
+
+	for _, page := range orphanedPages(actual, expected) {
+		sb.WriteString(fmt.Sprintf("Orphaned page %s: the command no longer exists\n", filepath.Join(dir, page)))
+	}
+
+	return sb.String(), nil
+}
+
+// removeOrphanedPages will delete the pages of dir belonging to commands which no longer exist.
+func removeOrphanedPages(cmd *cobra.Command, dir string) ([]string, error) {
+	expected := []string{}
+	for _, c := range availableCommands(cmd) {
+		expected = append(expected, pageName(c)+".md")
+	}
+	sort.Strings(expected)
+
+	actual, err := listPages(dir, cmd.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	orphans := orphanedPages(actual, expected)
+	for _, page := range orphans {
+		if err := os.Remove(filepath.Join(dir, page)); err != nil {
+			return nil, err
+		}
+	}
+	return orphans, nil
+}
+
+// Return the sorted list of generated Markdown pages of dir. Only the pages prefixed by the root
+// command name are considered so hand written documentation is left alone.
+func listPages(dir, prefix string) ([]string, error) {
+	pages := []string{}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return pages, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, prefix) || filepath.Ext(name) != ".md" {
+			continue
+		}
+		pages = append(pages, name)
+	}
+	sort.Strings(pages)
+	return pages, nil
+}
+
+// Return the pages of actual which are not part of expected.
+func orphanedPages(actual, expected []string) []string {
+	known := make(map[string]struct{}, len(expected))
+	for _, page := range expected {
+		known[page] = struct{}{}
+	}
+	orphans := []string{}
+	for _, page := range actual {
+		if _, ok := known[page]; !ok {
+			orphans = append(orphans, page)
+		}
+	}
+	return orphans
+}
+
+// Return the unified diff between the current and the expected content of a file.
+func diffPage(filename, current, expected string) (string, error) {
+	if current == expected {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(current),
+		B:        difflib.SplitLines(expected),
+		FromFile: filename,
+		ToFile:   filename + " (generated)",
+		Context:  3,
+	})
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// genCompletion will generate the shell completion script of the given shell
+// for this command and all descendants in the `completions` folder of dir.
+func genCompletion(cmd *cobra.Command, shell, dir string) error {
+	dir = filepath.Join(dir, "completions")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	name := cmd.Name()
+	switch shell {
+	case "bash":
+		return cmd.GenBashCompletionFileV2(filepath.Join(dir, name+".bash"), true)
+	case "zsh":
+		return cmd.GenZshCompletionFile(filepath.Join(dir, "_"+name))
+	case "fish":
+		return cmd.GenFishCompletionFile(filepath.Join(dir, name+".fish"), true)
+	default:
+		return fmt.Errorf("unsupported shell: %s", shell)
+	}
+}
+
+// genManPages will generate troff man pages for this command and all
+// descendants in the `man` folder of dir.
+func genManPages(cmd *cobra.Command, dir string) error {
+	dir = filepath.Join(dir, "man")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	header := &doc.GenManHeader{
+		Title:   "POLYCLI",
+		Section: "1",
+		Source:  "Polygon",
+		Manual:  "Polycli Manual",
+	}
+	return doc.GenManTree(cmd, header, dir)
+}
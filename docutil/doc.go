@@ -8,41 +8,48 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/maticnetwork/polygon-cli/util"
 )
 
 // genMarkdownDoc will generate Markdown documentation for this command and all descendants in the
 // directory given. This is a modified fork of Cobra's `GenMarkdownTreeCustom` function.
 // https://pkg.go.dev/github.com/spf13/cobra/doc#GenMarkdownTree
-func genMarkdownDoc(cmd *cobra.Command, dir string) error {
+func genMarkdownDoc(cmd *cobra.Command, dir string, linkHandler func(string) string, frontMatter func(*cobra.Command) string) error {
 	for _, c := range cmd.Commands() {
 		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
 			continue
 		}
-		if err := genMarkdownDoc(c, dir); err != nil {
+		if err := genMarkdownDoc(c, dir, linkHandler, frontMatter); err != nil {
 			return err
 		}
 	}
 
-	basename := strings.ReplaceAll(cmd.CommandPath(), " ", "_") + ".md"
-	filename := filepath.Join(dir, basename)
+	filename := filepath.Join(dir, pageName(cmd)+".md")
 	f, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
 
-	if err := genMarkdownPage(cmd, f); err != nil {
+	if _, err = f.WriteString(frontMatter(cmd)); err != nil {
+		return err
+	}
+	if err := genMarkdownPage(cmd, f, linkHandler, 1); err != nil {
 		return err
 	}
 	return nil
 }
 
-// genMarkdownPage will generate a Markdown page for this command.
+// genMarkdownPage will generate a Markdown page for this command. The level is the heading level
+// of the page title, every other heading of the page is nested below it.
 // This is a modified version of Cobra's `GenMarkdownTreeCustom` function.
 // https://pkg.go.dev/github.com/spf13/cobra/doc#GenMarkdownTreeCustom
-func genMarkdownPage(cmd *cobra.Command, w io.Writer) error {
+func genMarkdownPage(cmd *cobra.Command, w io.Writer, linkHandler func(string) string, level int) error {
 	cmd.InitDefaultHelpCmd()
 	cmd.InitDefaultHelpFlag()
 
@@ -50,86 +57,175 @@ func genMarkdownPage(cmd *cobra.Command, w io.Writer) error {
 	name := cmd.CommandPath()
 
 	short := cmd.Short
+	section := strings.Repeat("#", level+1)
 
-	buf.WriteString("# `" + name + "`\n\n")
+	buf.WriteString(strings.Repeat("#", level) + " `" + name + "`\n\n")
 
 	if !cmd.DisableAutoGenTag {
-		buf.WriteString("> Auto-generated documentation.\n\n")
+		buf.WriteString("> " + tr("Auto-generated documentation.") + "\n\n")
 	}
 
-	printToC(buf, cmd)
+	// Each section anchor would be ambiguous if several commands share the page.
+	if level == 1 {
+		printToC(buf, cmd)
+	}
 
-	buf.WriteString("## Description\n\n")
+	buf.WriteString(section + " " + tr("Description") + "\n\n")
 	buf.WriteString(short + "\n\n")
 	if cmd.Runnable() {
 		buf.WriteString(fmt.Sprintf("```bash\n%s\n```\n\n", cmd.UseLine()))
 	}
 
 	if len(cmd.Long) != 0 {
-		buf.WriteString("## Usage\n\n")
-		buf.WriteString(cmd.Long + "\n")
+		buf.WriteString(section + " " + tr("Usage") + "\n\n")
+		buf.WriteString(demoteHeadings(cmd.Long, level-1) + "\n")
 	}
 
-	if err := printFlags(buf, cmd, name); err != nil {
+	if err := printFlags(buf, cmd, section); err != nil {
 		return err
 	}
 
 	if len(cmd.Example) > 0 {
-		buf.WriteString("## Examples\n\n")
-		buf.WriteString(fmt.Sprintf("```bash\n%s\n```\n\n", cmd.Example))
+		example, outputs, err := runExamples(cmd.Root(), cmd.Example)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(section + " " + tr("Examples") + "\n\n")
+		buf.WriteString(fmt.Sprintf("```bash\n%s\n```\n\n", example))
+		for _, o := range outputs {
+			buf.WriteString(fmt.Sprintf("%s `%s`:\n\n", tr("Output of"), o.Command))
+			buf.WriteString(fmt.Sprintf("```\n%s\n```\n\n", strings.TrimRight(o.Output, "\n")))
+		}
 	}
 
 	if hasSeeAlso(cmd) {
-		buf.WriteString("## See also\n")
-		identity := func(s string) string { return s }
-		printSeeAlso(buf, cmd, name, identity)
+		buf.WriteString(section + " " + tr("See also") + "\n")
+		printSeeAlso(buf, cmd, name, linkHandler)
 	}
 
 	_, err := buf.WriteTo(w)
 	return err
 }
 
+// Return the name of the page of a command, without extension.
+func pageName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "_")
+}
+
+// Add n levels to every markdown heading outside of code blocks.
+func demoteHeadings(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	inCode := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+		if !inCode && strings.HasPrefix(line, "#") {
+			lines[i] = strings.Repeat("#", n) + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // Print the table of content of a command markdown page.
 func printToC(buf *bytes.Buffer, cmd *cobra.Command) {
-	buf.WriteString("## Table of Contents\n\n")
-	buf.WriteString("- [Description](#description)\n")
-	buf.WriteString("- [Usage](#usage)\n")
-	buf.WriteString("- [Flags](#flags)\n")
+	entry := func(title, heading string) {
+		buf.WriteString(fmt.Sprintf("- [%s](#%s)\n", tr(title), headingAnchor(tr(heading))))
+	}
+	buf.WriteString("## " + tr("Table of Contents") + "\n\n")
+	entry("Description", "Description")
+	entry("Usage", "Usage")
+	entry("Flags", "Flags")
 	if len(cmd.Example) > 0 {
-		buf.WriteString("- [Examples](#examples)\n")
+		entry("Examples", "Examples")
 	}
 	if hasSeeAlso(cmd) {
-		buf.WriteString("- [See Also](#see-also)\n")
+		entry("See Also", "See also")
 	}
 	buf.WriteString("\n")
 }
 
 // Print the command flags. This is a modified fork of Cobra's `printOptions` function.
-func printFlags(buf *bytes.Buffer, cmd *cobra.Command, name string) error {
+func printFlags(buf *bytes.Buffer, cmd *cobra.Command, section string) error {
 	flags := cmd.NonInheritedFlags()
 	parentFlags := cmd.InheritedFlags()
 	if flags.HasAvailableFlags() || parentFlags.HasAvailableFlags() {
-		buf.WriteString("## Flags")
+		buf.WriteString(section + " " + tr("Flags"))
 	}
 
-	flags.SetOutput(buf)
 	if flags.HasAvailableFlags() {
 		buf.WriteString("\n\n")
-		buf.WriteString("```bash\n")
-		flags.PrintDefaults()
-		buf.WriteString("```\n\n")
+		printFlagTable(buf, flags)
 	}
 
-	parentFlags.SetOutput(buf)
 	if parentFlags.HasAvailableFlags() {
-		buf.WriteString("The command also inherits flags from parent commands.\n\n")
-		buf.WriteString("```bash\n")
-		parentFlags.PrintDefaults()
-		buf.WriteString("```\n\n")
+		buf.WriteString(tr("The command also inherits flags from parent commands.") + "\n\n")
+		printFlagTable(buf, parentFlags)
 	}
 	return nil
 }
 
+// Print a markdown table describing each available flag of the flag set. The
+// environment variable column is only added when at least one flag is backed
+// by an environment variable.
+func printFlagTable(buf *bytes.Buffer, flags *pflag.FlagSet) {
+	hasEnv := false
+	flags.VisitAll(func(f *pflag.Flag) {
+		if len(f.Annotations[util.EnvVarAnnotation]) > 0 {
+			hasEnv = true
+		}
+	})
+
+	columns := []string{"Flag", "Shorthand", "Type", "Default", "Description"}
+	if hasEnv {
+		columns = []string{"Flag", "Shorthand", "Type", "Default", "Environment variable", "Description"}
+	}
+	header, separator := "|", "|"
+	for _, c := range columns {
+		header += " " + tr(c) + " |"
+		separator += strings.Repeat("-", utf8.RuneCountInString(tr(c))+2) + "|"
+	}
+	buf.WriteString(header + "\n" + separator + "\n")
+
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || len(f.Deprecated) > 0 {
+			return
+		}
+
+		shorthand := ""
+		if len(f.Shorthand) > 0 && len(f.ShorthandDeprecated) == 0 {
+			shorthand = "`-" + f.Shorthand + "`"
+		}
+		defValue := ""
+		if len(f.DefValue) > 0 && f.DefValue != "[]" {
+			defValue = "`" + escapeTableCell(f.DefValue) + "`"
+		}
+		_, usage := pflag.UnquoteUsage(f)
+
+		buf.WriteString(fmt.Sprintf("| `--%s` | %s | %s | %s |", f.Name, shorthand, f.Value.Type(), defValue))
+		if hasEnv {
+			env := ""
+			if vars := f.Annotations[util.EnvVarAnnotation]; len(vars) > 0 {
+				env = "`" + strings.Join(vars, "`, `") + "`"
+			}
+			buf.WriteString(fmt.Sprintf(" %s |", env))
+		}
+		buf.WriteString(fmt.Sprintf(" %s |\n", escapeTableCell(usage)))
+	})
+	buf.WriteString("\n")
+}
+
+// Escape the characters which would otherwise break a markdown table cell.
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(strings.TrimSpace(s), "\n", "<br>")
+	return s
+}
+
 // Test to see if we have a reason to print See Also information in docs.
 // Basically this is a test for a parent command or a subcommand which is
 // both not deprecated and not the autogenerated help command.
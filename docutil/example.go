@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/maticnetwork/polygon-cli/cmd"
+	"github.com/maticnetwork/polygon-cli/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// Marker added at the end of an example line to have it executed during the generation.
+	exampleRunMarker = "# docutil:run"
+
+	// Environment variable used to run the docutil binary as `polycli` when executing examples.
+	exampleExecEnv = "POLYCLI_DOCUTIL_EXEC"
+
+	// Maximum duration of an executed example.
+	exampleTimeout = 30 * time.Second
+)
+
+// exampleOutput is the captured output of an executed example.
+type exampleOutput struct {
+	Command string
+	Output  string
+}
+
+// runExamples will execute every line of the example annotated with the run marker and return
+// the example without the markers along with the captured outputs. An example which fails makes
+// the generation fail so examples stay accurate.
+func runExamples(root *cobra.Command, example string) (string, []exampleOutput, error) {
+	lines := strings.Split(example, "\n")
+	outputs := []exampleOutput{}
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasSuffix(trimmed, exampleRunMarker) {
+			continue
+		}
+		command := strings.TrimSpace(strings.TrimSuffix(trimmed, exampleRunMarker))
+		lines[i] = strings.TrimRight(strings.TrimSuffix(strings.TrimRight(line, " "), exampleRunMarker), " ")
+
+		out, err := runExample(root, strings.TrimPrefix(command, "$ "))
+		if err != nil {
+			return "", nil, err
+		}
+		outputs = append(outputs, exampleOutput{Command: strings.TrimPrefix(command, "$ "), Output: out})
+	}
+	return strings.Join(lines, "\n"), outputs, nil
+}
+
+// runExample will execute a `polycli` command line in a temporary home and working directory. Only
+// the commands annotated as side effect free can be executed, so generating the documentation never
+// sends a transaction or writes a file.
+func runExample(root *cobra.Command, command string) (string, error) {
+	args, err := splitArgs(command)
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 0 || args[0] != "polycli" {
+		return "", fmt.Errorf("only polycli commands can be executed in examples: %s", command)
+	}
+	target, _, err := root.Find(args[1:])
+	if err != nil {
+		return "", fmt.Errorf("unable to find the command of the example `%s`: %w", command, err)
+	}
+	if target.Annotations[util.SideEffectFreeAnnotation] != "true" {
+		return "", fmt.Errorf("example `%s` can't be executed since `%s` isn't annotated as side effect free", command, target.CommandPath())
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	tmpDir, err := os.MkdirTemp("", "polycli-example-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), exampleTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, self, args[1:]...)
+	c.Dir = tmpDir
+	c.Env = append(os.Environ(), exampleExecEnv+"=1", "HOME="+tmpDir)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err = c.Run(); err != nil {
+		return "", fmt.Errorf("example `%s` failed: %w\n%s", command, err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// runPolycli will run the `polycli` command with the arguments of the process. It's used when the
+// docutil binary is executed as an example.
+func runPolycli() {
+	polycli := cmd.NewPolycliCommand()
+	polycli.SetArgs(os.Args[1:])
+	if err := polycli.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// Split a command line into arguments, handling single and double quotes.
+func splitArgs(s string) ([]string, error) {
+	args := []string{}
+	var (
+		current strings.Builder
+		quote   rune
+		inArg   bool
+	)
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command: %s", s)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+)
+
+type (
+	// locale holds the translations of the documentation in a language, loaded from the
+	// `<name>.json` file of the translations directory. The text without translation is kept
+	// in English.
+	locale struct {
+		name string
+		// Headings maps the English headings and sentences of the pages to their translation,
+		// e.g. "Usage" or "Table of Contents".
+		Headings map[string]string
+		// Commands overrides the descriptions of the commands by path, e.g. `polycli loadtest`.
+		Commands map[string]commandTranslation
+	}
+	// commandTranslation overrides the descriptions of a command. The long description can
+	// also be written in the `<name>/<page>.md` file of the translations directory, which takes
+	// precedence.
+	commandTranslation struct {
+		Short string
+		Long  string
+	}
+)
+
+// currentLocale is the locale of the documentation being generated, English when nil.
+var currentLocale *locale
+
+// tr returns the translation of the English text in the current locale.
+func tr(s string) string {
+	if currentLocale == nil {
+		return s
+	}
+	if t := currentLocale.Headings[s]; t != "" {
+		return t
+	}
+	return s
+}
+
+// headingAnchor returns the anchor of a markdown heading, as generated by GitHub and most
+// docs sites: lower case, without punctuation and with dashes instead of spaces.
+func headingAnchor(heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			sb.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// loadLocales reads the `<name>.json` translation maps of the directory, sorted by name.
+func loadLocales(dir string) ([]*locale, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	locales := []*locale{}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		l := &locale{name: strings.TrimSuffix(e.Name(), ".json")}
+		// Unknown fields are rejected so a misspelled key isn't silently left untranslated.
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err = decoder.Decode(l); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %w", e.Name(), err)
+		}
+		locales = append(locales, l)
+	}
+	if len(locales) == 0 {
+		return nil, fmt.Errorf("no translation found in %s", dir)
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i].name < locales[j].name })
+	return locales, nil
+}
+
+// withLocale runs fn with the descriptions of the command tree and the headings translated in
+// the locale, restoring the English text afterwards. The translations of commands which don't
+// exist anymore are reported, since they were likely left behind by a rename.
+func withLocale(cmd *cobra.Command, l *locale, dir string, fn func() error) error {
+	type descriptions struct{ short, long string }
+	original := make(map[*cobra.Command]descriptions)
+	defer func() {
+		for c, d := range original {
+			c.Short, c.Long = d.short, d.long
+		}
+		currentLocale = nil
+	}()
+
+	known := make(map[string]struct{})
+	for _, c := range availableCommands(cmd) {
+		known[c.CommandPath()] = struct{}{}
+		original[c] = descriptions{c.Short, c.Long}
+		t := l.Commands[c.CommandPath()]
+		if t.Short != "" {
+			c.Short = t.Short
+		}
+		if t.Long != "" {
+			c.Long = t.Long
+		}
+		long, err := os.ReadFile(filepath.Join(dir, l.name, pageName(c)+".md"))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if err == nil {
+			c.Long = string(long)
+		}
+	}
+	for path := range l.Commands {
+		if _, ok := known[path]; !ok {
+			fmt.Printf("Ignoring the %s translation of the unknown command %s\n", l.name, path)
+		}
+	}
+
+	currentLocale = l
+	return fn()
+}
+
+// forEachLocale will run gen with every locale of the translations directory, passing the
+// subdirectory of dir in which the locale is generated.
+func forEachLocale(cmd *cobra.Command, translationsDir, dir string, gen func(localeDir string) error) error {
+	if translationsDir == "" {
+		return nil
+	}
+	locales, err := loadLocales(translationsDir)
+	if err != nil {
+		return err
+	}
+	for _, l := range locales {
+		localeDir := filepath.Join(dir, l.name)
+		if err = withLocale(cmd, l, translationsDir, func() error { return gen(localeDir) }); err != nil {
+			return fmt.Errorf("locale %s: %w", l.name, err)
+		}
+	}
+	return nil
+}
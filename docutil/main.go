@@ -1,10 +1,15 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/maticnetwork/polygon-cli/cmd"
+	"github.com/spf13/cobra"
 )
 
 var (
@@ -13,22 +18,186 @@ var (
 
 	// Tag used to delimitate the section of the README which is generated.
 	delimiter = "generated"
+
+	// Comma separated list of artifacts to generate.
+	format = flag.String("format", "markdown", "Comma separated list of artifacts to generate [markdown, bash, zsh, fish, man, json, changelog]")
+
+	// Directory in which the completions, man pages and command tree export will be generated.
+	outDir = flag.String("out-dir", "out", "Directory in which the completions, man pages and command tree export will be generated")
+
+	// Layout of the generated markdown documentation.
+	layout = flag.String("layout", "pages", "Layout of the markdown documentation [pages, single, mkdocs, docusaurus]")
+
+	// Directory in which the documentation is generated when not using the pages layout.
+	siteDir = flag.String("site-dir", "site", "Directory in which the documentation is generated when not using the pages layout")
+
+	// Prefix and extension used for the links between pages.
+	linkPrefix = flag.String("link-prefix", "", "Prefix added to the links between command pages")
+	linkExt    = flag.String("link-ext", ".md", "Extension used for the links between command pages")
+
+	// Command tree export of a previous release to compare against.
+	previous = flag.String("previous", "", "Command tree export of a previous release to compare against with the changelog format")
+
+	// Directory of the translation maps, each locale being generated in its own subdirectory.
+	translationsDir = flag.String("translations-dir", "", "Directory of the <locale>.json translation maps, each locale being generated in its own subdirectory of the documentation")
+
+	// Verify that the documentation is up to date instead of generating it.
+	check = flag.Bool("check", false, "Verify that the markdown documentation and README.md are up to date instead of generating them")
 )
 
 func main() {
+	// The binary is executed as `polycli` to run the documentation examples.
+	if os.Getenv(exampleExecEnv) != "" {
+		runPolycli()
+		return
+	}
+
+	flag.Parse()
 	polycli := cmd.NewPolycliCommand()
 
+	for _, f := range strings.Split(*format, ",") {
+		switch f = strings.TrimSpace(f); f {
+		case "markdown":
+			genMarkdown(polycli)
+		case "bash", "zsh", "fish":
+			if err := genCompletion(polycli, f, *outDir); err != nil {
+				fmt.Printf("Unable to generate %s completion.\n", f)
+				log.Fatal(err)
+			}
+			fmt.Printf("%s completion generated!\n", f)
+		case "man":
+			if err := genManPages(polycli, *outDir); err != nil {
+				fmt.Println("Unable to generate man pages.")
+				log.Fatal(err)
+			}
+			fmt.Println("Man pages generated!")
+		case "json":
+			if err := genSchema(polycli, *outDir); err != nil {
+				fmt.Println("Unable to export the command tree.")
+				log.Fatal(err)
+			}
+			fmt.Println("Command tree exported!")
+		case "changelog":
+			if *previous == "" {
+				log.Fatal("The changelog format requires --previous")
+			}
+			if err := genChangelog(polycli, *previous, *outDir); err != nil {
+				fmt.Println("Unable to generate the CLI changes.")
+				log.Fatal(err)
+			}
+			fmt.Println("CLI changes generated!")
+		default:
+			log.Fatalf("Unsupported format: %s", f)
+		}
+	}
+}
+
+func genMarkdown(polycli *cobra.Command) {
+	linkHandler := func(s string) string { return *linkPrefix + strings.TrimSuffix(s, ".md") + *linkExt }
+	noFrontMatter := func(*cobra.Command) string { return "" }
+
+	if *check {
+		checkMarkdown(polycli, linkHandler, noFrontMatter)
+		return
+	}
+
+	if *layout != "pages" {
+		if err := genSite(polycli, *layout, *siteDir, linkHandler); err != nil {
+			fmt.Println("Unable to generate documentation.")
+			log.Fatal(err)
+		}
+		fmt.Printf("Documentation generated in %s!\n", *siteDir)
+		err := forEachLocale(polycli, *translationsDir, *siteDir, func(dir string) error {
+			if err := genSite(polycli, *layout, dir, linkHandler); err != nil {
+				return err
+			}
+			fmt.Printf("Documentation generated in %s!\n", dir)
+			return nil
+		})
+		if err != nil {
+			fmt.Println("Unable to generate the translated documentation.")
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Generate documentation for the `polycli` command.
-	if err := genMarkdownDoc(polycli, docDir); err != nil {
+	if err := genMarkdownDoc(polycli, docDir, linkHandler, noFrontMatter); err != nil {
 		fmt.Println("Unable to generate documentation.")
 		log.Fatal(err)
 	}
 	fmt.Println("Documentation generated!")
 
+	orphans, err := removeOrphanedPages(polycli, docDir)
+	if err != nil {
+		fmt.Println("Unable to remove orphaned pages.")
+		log.Fatal(err)
+	}
+	for _, page := range orphans {
+		fmt.Printf("Removed orphaned page %s\n", page)
+	}
+
 	// Update the summary of commands in the `README.md` (located inside <tag></tag>)
 	if err := updateReadmeCommands(polycli, delimiter, docDir); err != nil {
 		fmt.Println("Unable to update `README.md`.")
 		log.Fatal(err)
 	}
 	fmt.Println("`README.md` updated!")
+
+	err = forEachLocale(polycli, *translationsDir, docDir, func(dir string) error {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		if err := genMarkdownDoc(polycli, dir, linkHandler, noFrontMatter); err != nil {
+			return err
+		}
+		orphans, err := removeOrphanedPages(polycli, dir)
+		if err != nil {
+			return err
+		}
+		for _, page := range orphans {
+			fmt.Printf("Removed orphaned page %s\n", filepath.Join(dir, page))
+		}
+		fmt.Printf("Documentation generated in %s!\n", dir)
+		return nil
+	})
+	if err != nil {
+		fmt.Println("Unable to generate the translated documentation.")
+		log.Fatal(err)
+	}
+}
+
+func checkMarkdown(polycli *cobra.Command, linkHandler func(string) string, frontMatter func(*cobra.Command) string) {
+	diff, err := checkMarkdownDoc(polycli, docDir, linkHandler, frontMatter)
+	if err != nil {
+		fmt.Println("Unable to check documentation.")
+		log.Fatal(err)
+	}
+
+	err = forEachLocale(polycli, *translationsDir, docDir, func(dir string) error {
+		localeDiff, err := checkMarkdownDoc(polycli, dir, linkHandler, frontMatter)
+		diff += localeDiff
+		return err
+	})
+	if err != nil {
+		fmt.Println("Unable to check the translated documentation.")
+		log.Fatal(err)
+	}
+
+	current, updated, err := readmeCommands(polycli, delimiter, docDir)
+	if err != nil {
+		fmt.Println("Unable to check `README.md`.")
+		log.Fatal(err)
+	}
+	readmeDiff, err := diffPage("README.md", current, updated)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if diff+readmeDiff != "" {
+		fmt.Print(diff + readmeDiff)
+		fmt.Println("Documentation is out of date. Run `make gen-doc` to update it.")
+		os.Exit(1)
+	}
+	fmt.Println("Documentation is up to date!")
 }
@@ -12,6 +12,19 @@ import (
 // updateReadme will update the list of `polycli` commands.
 // The section is identified by the HTML tags `<startTag></endTag>“.
 func updateReadmeCommands(cmd *cobra.Command, delimiter, docDir string) error {
+	_, newData, err := readmeCommands(cmd, delimiter, docDir)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile("README.md", []byte(newData), 0644)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// readmeCommands will return the current and the updated content of the `README.md`.
+func readmeCommands(cmd *cobra.Command, delimiter, docDir string) (string, string, error) {
 	// Generate the list of commands.
 	buf := new(bytes.Buffer)
 	name := cmd.CommandPath()
@@ -21,18 +34,13 @@ func updateReadmeCommands(cmd *cobra.Command, delimiter, docDir string) error {
 	// Update the `README.md``
 	data, err := os.ReadFile("README.md")
 	if err != nil {
-		return err
-	}
-	var newData string
-	newData, err = updateContent(string(data), delimiter, buf)
-	if err != nil {
-		return err
+		return "", "", err
 	}
-	err = os.WriteFile("README.md", []byte(newData), 0644)
+	newData, err := updateContent(string(data), delimiter, buf)
 	if err != nil {
-		return err
+		return "", "", err
 	}
-	return nil
+	return string(data), newData, nil
 }
 
 // Take a piece of data and update the content between the start and end tags with new content.
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/maticnetwork/polygon-cli/cmd/version"
+	"github.com/maticnetwork/polygon-cli/util"
+)
+
+// schemaVersion is bumped whenever the layout of the command tree export changes.
+const schemaVersion = 1
+
+// schemaFile is the name of the command tree export in the output directory.
+const schemaFile = "commands.json"
+
+type (
+	// cliSchema is the machine-readable description of the whole CLI surface.
+	cliSchema struct {
+		SchemaVersion int           `json:"schemaVersion"`
+		Version       string        `json:"version"`
+		Command       commandSchema `json:"command"`
+	}
+	commandSchema struct {
+		Name    string   `json:"name"`
+		Path    string   `json:"path"`
+		Use     string   `json:"use"`
+		Aliases []string `json:"aliases,omitempty"`
+		Short   string   `json:"short,omitempty"`
+		Long    string   `json:"long,omitempty"`
+		Example string   `json:"example,omitempty"`
+		// Deprecated is the deprecation message of the command.
+		Deprecated string `json:"deprecated,omitempty"`
+		Runnable   bool   `json:"runnable"`
+		// Flags are the flags declared by the command, the persistent ones are
+		// also inherited by the subcommands.
+		Flags    []flagSchema    `json:"flags,omitempty"`
+		Commands []commandSchema `json:"commands,omitempty"`
+	}
+	flagSchema struct {
+		Name       string   `json:"name"`
+		Shorthand  string   `json:"shorthand,omitempty"`
+		Type       string   `json:"type"`
+		Default    string   `json:"default"`
+		Usage      string   `json:"usage"`
+		Env        []string `json:"env,omitempty"`
+		Persistent bool     `json:"persistent,omitempty"`
+		Required   bool     `json:"required,omitempty"`
+		Hidden     bool     `json:"hidden,omitempty"`
+		// Deprecated is the deprecation message of the flag.
+		Deprecated string `json:"deprecated,omitempty"`
+	}
+)
+
+// genSchema will serialize the command tree in the given directory as JSON.
+func genSchema(cmd *cobra.Command, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(newCLISchema(cmd), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, schemaFile), append(data, '\n'), 0644)
+}
+
+func newCLISchema(cmd *cobra.Command) cliSchema {
+	return cliSchema{
+		SchemaVersion: schemaVersion,
+		Version:       version.Version,
+		Command:       newCommandSchema(cmd),
+	}
+}
+
+// newCommandSchema describes this command and all descendants. Unlike the
+// markdown documentation, the deprecated commands and flags are kept so that
+// the tooling can report them.
+func newCommandSchema(cmd *cobra.Command) commandSchema {
+	s := commandSchema{
+		Name:       cmd.Name(),
+		Path:       cmd.CommandPath(),
+		Use:        cmd.Use,
+		Aliases:    cmd.Aliases,
+		Short:      cmd.Short,
+		Long:       cmd.Long,
+		Example:    cmd.Example,
+		Deprecated: cmd.Deprecated,
+		Runnable:   cmd.Runnable(),
+	}
+
+	persistent := cmd.PersistentFlags()
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		// The help flag is only added to the commands which were documented.
+		if f.Name == "help" {
+			return
+		}
+		s.Flags = append(s.Flags, newFlagSchema(f, persistent.Lookup(f.Name) != nil))
+	})
+
+	for _, c := range cmd.Commands() {
+		if c.Hidden || c.Name() == "help" || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		s.Commands = append(s.Commands, newCommandSchema(c))
+	}
+	return s
+}
+
+func newFlagSchema(f *pflag.Flag, persistent bool) flagSchema {
+	return flagSchema{
+		Name:       f.Name,
+		Shorthand:  f.Shorthand,
+		Type:       f.Value.Type(),
+		Default:    f.DefValue,
+		Usage:      f.Usage,
+		Env:        f.Annotations[util.EnvVarAnnotation],
+		Persistent: persistent,
+		Required:   len(f.Annotations[cobra.BashCompOneRequiredFlag]) > 0,
+		Hidden:     f.Hidden,
+		Deprecated: f.Deprecated,
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// genSinglePage will generate one Markdown page documenting this command and all descendants,
+// starting with a table of content linking to each command.
+func genSinglePage(cmd *cobra.Command, filename string) error {
+	cmds := availableCommands(cmd)
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("# `" + cmd.Name() + "` " + tr("reference") + "\n\n")
+	buf.WriteString("> " + tr("Auto-generated documentation.") + "\n\n")
+	buf.WriteString("## " + tr("Table of Contents") + "\n\n")
+	for _, c := range cmds {
+		indent := strings.Repeat("  ", strings.Count(c.CommandPath(), " "))
+		buf.WriteString(fmt.Sprintf("%s- [%s](%s)\n", indent, c.CommandPath(), anchorLink(pageName(c)+".md")))
+	}
+	buf.WriteString("\n")
+
+	for _, c := range cmds {
+		if err := genMarkdownPage(c, buf, anchorLink, 2); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+// Convert the link of a command page into the anchor of the command section in the single page.
+func anchorLink(link string) string {
+	return "#" + strings.ReplaceAll(strings.TrimSuffix(link, ".md"), "_", "-")
+}
+
+// genMkDocsNav will generate a `mkdocs.yml` file with the navigation of the command tree.
+func genMkDocsNav(cmd *cobra.Command, filename string) error {
+	buf := new(bytes.Buffer)
+	buf.WriteString("site_name: " + cmd.Name() + "\n")
+	buf.WriteString("nav:\n")
+	writeMkDocsNav(buf, cmd, 1)
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+func writeMkDocsNav(buf *bytes.Buffer, cmd *cobra.Command, depth int) {
+	indent := strings.Repeat("  ", depth)
+	children := availableChildren(cmd)
+	if len(children) == 0 {
+		buf.WriteString(fmt.Sprintf("%s- '%s': %s.md\n", indent, cmd.CommandPath(), pageName(cmd)))
+		return
+	}
+	buf.WriteString(fmt.Sprintf("%s- '%s':\n", indent, cmd.CommandPath()))
+	buf.WriteString(fmt.Sprintf("%s  - '%s': %s.md\n", indent, cmd.CommandPath(), pageName(cmd)))
+	for _, c := range children {
+		writeMkDocsNav(buf, c, depth+1)
+	}
+}
+
+// genDocusaurusSidebar will generate a `sidebars.js` file with the navigation of the command tree.
+func genDocusaurusSidebar(cmd *cobra.Command, filename string) error {
+	buf := new(bytes.Buffer)
+	buf.WriteString("module.exports = {\n")
+	buf.WriteString(fmt.Sprintf("  %s: [\n", cmd.Name()))
+	writeDocusaurusItems(buf, cmd, 2)
+	buf.WriteString("  ],\n")
+	buf.WriteString("};\n")
+	return os.WriteFile(filename, buf.Bytes(), 0644)
+}
+
+func writeDocusaurusItems(buf *bytes.Buffer, cmd *cobra.Command, depth int) {
+	indent := strings.Repeat("  ", depth)
+	children := availableChildren(cmd)
+	if len(children) == 0 {
+		buf.WriteString(fmt.Sprintf("%s'%s',\n", indent, pageName(cmd)))
+		return
+	}
+	buf.WriteString(fmt.Sprintf("%s{\n", indent))
+	buf.WriteString(fmt.Sprintf("%s  type: 'category',\n", indent))
+	buf.WriteString(fmt.Sprintf("%s  label: '%s',\n", indent, cmd.CommandPath()))
+	buf.WriteString(fmt.Sprintf("%s  link: { type: 'doc', id: '%s' },\n", indent, pageName(cmd)))
+	buf.WriteString(fmt.Sprintf("%s  items: [\n", indent))
+	for _, c := range children {
+		writeDocusaurusItems(buf, c, depth+2)
+	}
+	buf.WriteString(fmt.Sprintf("%s  ],\n", indent))
+	buf.WriteString(fmt.Sprintf("%s},\n", indent))
+}
+
+// Return the front matter expected by docs site generators for the page of a command.
+func siteFrontMatter(layout string) func(*cobra.Command) string {
+	return func(cmd *cobra.Command) string {
+		switch layout {
+		case "mkdocs":
+			return fmt.Sprintf("---\ntitle: %s\n---\n\n", cmd.CommandPath())
+		case "docusaurus":
+			return fmt.Sprintf("---\nid: %s\ntitle: %s\nsidebar_label: %s\n---\n\n", pageName(cmd), cmd.CommandPath(), cmd.CommandPath())
+		default:
+			return ""
+		}
+	}
+}
+
+// Return the available commands of the tree in depth first order.
+func availableCommands(cmd *cobra.Command) []*cobra.Command {
+	cmds := []*cobra.Command{cmd}
+	for _, c := range availableChildren(cmd) {
+		cmds = append(cmds, availableCommands(c)...)
+	}
+	return cmds
+}
+
+// Return the available sub commands sorted by name.
+func availableChildren(cmd *cobra.Command) []*cobra.Command {
+	children := []*cobra.Command{}
+	for _, c := range cmd.Commands() {
+		if !c.IsAvailableCommand() || c.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+		children = append(children, c)
+	}
+	sort.Sort(byName(children))
+	return children
+}
+
+// genSite will generate the documentation of the command tree with the given layout. The docs
+// site layouts write the pages in the `docs` folder of dir and the navigation file in dir.
+func genSite(cmd *cobra.Command, layout, dir string, linkHandler func(string) string) error {
+	if layout == "single" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		return genSinglePage(cmd, filepath.Join(dir, pageName(cmd)+".md"))
+	}
+
+	pagesDir := filepath.Join(dir, "docs")
+	if err := os.MkdirAll(pagesDir, 0755); err != nil {
+		return err
+	}
+
+	switch layout {
+	case "mkdocs":
+		if err := genMarkdownDoc(cmd, pagesDir, linkHandler, siteFrontMatter(layout)); err != nil {
+			return err
+		}
+		return genMkDocsNav(cmd, filepath.Join(dir, "mkdocs.yml"))
+	case "docusaurus":
+		if err := genMarkdownDoc(cmd, pagesDir, linkHandler, siteFrontMatter(layout)); err != nil {
+			return err
+		}
+		return genDocusaurusSidebar(cmd, filepath.Join(dir, "sidebars.js"))
+	default:
+		return fmt.Errorf("unsupported layout: %s", layout)
+	}
+}
@@ -12,6 +12,8 @@ import (
 type RawKeystoreData struct {
 	Address string              `json:"address"`
 	Crypto  keystore.CryptoJSON `json:"crypto"`
+	ID      string              `json:"id"`
+	Version int                 `json:"version"`
 }
 
 func DecryptKeystoreFile(fileData []byte, password string) (*ecdsa.PrivateKey, error) {
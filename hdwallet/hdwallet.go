@@ -5,8 +5,10 @@ import (
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -478,6 +480,71 @@ func NewMnemonic(wordCount int, lang string) (string, error) {
 	return mnemonic, nil
 }
 
+// MnemonicFromEntropy returns the mnemonic of the entropy in the given language.
+func MnemonicFromEntropy(entropy []byte, lang string) (string, error) {
+	wordList, hasKey := langToWordlist[strings.ToLower(lang)]
+	if !hasKey {
+		return "", fmt.Errorf("the language %s is not recognized", lang)
+	}
+	bip39.SetWordList(wordList)
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", fmt.Errorf("there was an error creating the mnemonic: %s", err.Error())
+	}
+	return mnemonic, nil
+}
+
+// DetectMnemonicLanguage returns the language of the wordlist holding every word of the mnemonic.
+func DetectMnemonicLanguage(mnemonic string) (string, error) {
+	langs := make([]string, 0, len(langToWordlist))
+	for lang := range langToWordlist {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	words := strings.Fields(mnemonic)
+	for _, lang := range langs {
+		bip39.SetWordList(langToWordlist[lang])
+		found := true
+		for _, w := range words {
+			if _, ok := bip39.GetWordIndex(w); !ok {
+				found = false
+				break
+			}
+		}
+		if found {
+			return lang, nil
+		}
+	}
+	return "", fmt.Errorf("the mnemonic words don't belong to any known wordlist")
+}
+
+// MnemonicToEntropy checks the words and the checksum of the mnemonic in the given language and
+// returns its entropy.
+func MnemonicToEntropy(mnemonic, lang string) ([]byte, error) {
+	wordList, hasKey := langToWordlist[strings.ToLower(lang)]
+	if !hasKey {
+		return nil, fmt.Errorf("the language %s is not recognized", lang)
+	}
+	bip39.SetWordList(wordList)
+
+	words := strings.Fields(mnemonic)
+	if _, hasKey = wordsToBits[len(words)]; !hasKey {
+		return nil, fmt.Errorf("the word count needs to be 12, 15, 18, 21, or 24. Got %d", len(words))
+	}
+	for _, w := range words {
+		if _, ok := bip39.GetWordIndex(w); !ok {
+			return nil, fmt.Errorf("the word %s is not in the %s wordlist", w, lang)
+		}
+	}
+	entropy, err := bip39.EntropyFromMnemonic(strings.Join(words, " "))
+	if errors.Is(err, bip39.ErrChecksumIncorrect) {
+		return nil, fmt.Errorf("the mnemonic checksum is invalid")
+	}
+	return entropy, err
+}
+
 func init() {
 	rePathValidator = regexp.MustCompile(pathValidator)
 }
@@ -315,3 +315,24 @@ func TestGetPublicKeyFromSeed(t *testing.T) {
 	}
 
 }
+
+func TestMnemonicEntropyRoundTrip(t *testing.T) {
+	entropy, _ := hex.DecodeString("00000000000000000000000000000000")
+	for _, lang := range []string{"english", "japanese", "spanish"} {
+		mnemonic, err := MnemonicFromEntropy(entropy, lang)
+		if err != nil {
+			t.Fatalf("Failed to create %s mnemonic: %v", lang, err)
+		}
+		detected, err := DetectMnemonicLanguage(mnemonic)
+		assert.NoError(t, err)
+		assert.Equal(t, lang, detected)
+
+		decoded, err := MnemonicToEntropy(mnemonic, lang)
+		assert.NoError(t, err)
+		assert.Equal(t, entropy, decoded)
+	}
+
+	// The last word holds the checksum.
+	_, err := MnemonicToEntropy("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "english")
+	assert.EqualError(t, err, "the mnemonic checksum is invalid")
+}
@@ -35,6 +35,17 @@ type Database interface {
 	// ShouldWriteTransactionEvents return true, respectively.
 	WriteTransactions(context.Context, *enode.Node, []*types.Transaction)
 
+	// WriteTransactionHashes will write the transaction events of the
+	// announced transaction hashes if ShouldWriteTransactionEvents returns
+	// true.
+	WriteTransactionHashes(context.Context, *enode.Node, []common.Hash)
+
+	// WritePooledTransactions will write the pooled transactions if
+	// ShouldWriteTransactions returns true. It will not write transaction
+	// events because pooled transactions are only sent when requested, their
+	// events are written on announcement by WriteTransactionHashes.
+	WritePooledTransactions(context.Context, []*types.Transaction)
+
 	// HasBlock will return whether the block is in the database. If the database
 	// client has not been initialized this will always return true.
 	HasBlock(context.Context, common.Hash) bool
@@ -142,9 +142,12 @@ func (d *Datastore) WriteBlock(ctx context.Context, peer *enode.Node, block *typ
 	}
 
 	if d.ShouldWriteBlockEvents() {
+		// The time is taken before waiting for a job so it is the time the block
+		// was received.
+		now := time.Now()
 		d.jobs <- struct{}{}
 		go func() {
-			d.writeEvent(peer, BlockEventsKind, block.Hash(), BlocksKind)
+			d.writeEvent(peer, BlockEventsKind, block.Hash(), BlocksKind, now)
 			<-d.jobs
 		}()
 	}
@@ -199,9 +202,10 @@ func (d *Datastore) WriteBlockHashes(ctx context.Context, peer *enode.Node, hash
 		return
 	}
 
+	now := time.Now()
 	d.jobs <- struct{}{}
 	go func() {
-		d.writeEvents(ctx, peer, BlockEventsKind, hashes, BlocksKind)
+		d.writeEvents(ctx, peer, BlockEventsKind, hashes, BlocksKind, now)
 		<-d.jobs
 	}()
 }
@@ -226,14 +230,44 @@ func (d *Datastore) WriteTransactions(ctx context.Context, peer *enode.Node, txs
 			hashes = append(hashes, tx.Hash())
 		}
 
+		now := time.Now()
 		d.jobs <- struct{}{}
 		go func() {
-			d.writeEvents(ctx, peer, TransactionEventsKind, hashes, TransactionsKind)
+			d.writeEvents(ctx, peer, TransactionEventsKind, hashes, TransactionsKind, now)
 			<-d.jobs
 		}()
 	}
 }
 
+// WriteTransactionHashes will write the transaction events of the announced
+// transaction hashes to datastore.
+func (d *Datastore) WriteTransactionHashes(ctx context.Context, peer *enode.Node, hashes []common.Hash) {
+	if d.client == nil || !d.ShouldWriteTransactionEvents() || len(hashes) == 0 {
+		return
+	}
+
+	now := time.Now()
+	d.jobs <- struct{}{}
+	go func() {
+		d.writeEvents(ctx, peer, TransactionEventsKind, hashes, TransactionsKind, now)
+		<-d.jobs
+	}()
+}
+
+// WritePooledTransactions will write the requested pooled transactions to
+// datastore.
+func (d *Datastore) WritePooledTransactions(ctx context.Context, txs []*types.Transaction) {
+	if d.client == nil || !d.ShouldWriteTransactions() {
+		return
+	}
+
+	d.jobs <- struct{}{}
+	go func() {
+		d.writeTransactions(ctx, txs)
+		<-d.jobs
+	}()
+}
+
 func (d *Datastore) MaxConcurrentWrites() int {
 	return d.maxConcurrency
 }
@@ -387,9 +421,8 @@ func (d *Datastore) writeBlock(ctx context.Context, block *types.Block, td *big.
 
 // writeEvent writes either a block or transaction event to datastore depending
 // on the provided eventKind and hashKind.
-func (d *Datastore) writeEvent(peer *enode.Node, eventKind string, hash common.Hash, hashKind string) {
+func (d *Datastore) writeEvent(peer *enode.Node, eventKind string, hash common.Hash, hashKind string, now time.Time) {
 	key := datastore.IncompleteKey(eventKind, nil)
-	now := time.Now()
 
 	event := DatastoreEvent{
 		SensorId: d.sensorID,
@@ -406,10 +439,9 @@ func (d *Datastore) writeEvent(peer *enode.Node, eventKind string, hash common.H
 // writeEvents writes either block or transaction events to datastore depending
 // on the provided eventKind and hashKind. This is similar to writeEvent but
 // batches the request.
-func (d *Datastore) writeEvents(ctx context.Context, peer *enode.Node, eventKind string, hashes []common.Hash, hashKind string) {
+func (d *Datastore) writeEvents(ctx context.Context, peer *enode.Node, eventKind string, hashes []common.Hash, hashKind string, now time.Time) {
 	keys := make([]*datastore.Key, 0, len(hashes))
 	events := make([]*DatastoreEvent, 0, len(hashes))
-	now := time.Now()
 
 	for _, hash := range hashes {
 		keys = append(keys, datastore.IncompleteKey(eventKind, nil))
@@ -0,0 +1,491 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/rs/zerolog/log"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema creates the tables of the SQLite database. The times are unix
+// milliseconds so the latencies can be computed in the queries. An event is
+// unique per hash and peer, so only the first announcement of each peer is
+// kept. The propagation views give the latency of each peer relative to the
+// first peer which announced the block or transaction.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS blocks (
+	hash             TEXT PRIMARY KEY,
+	parent_hash      TEXT,
+	number           INTEGER,
+	time             INTEGER,
+	coinbase         TEXT,
+	difficulty       TEXT,
+	total_difficulty TEXT,
+	gas_limit        INTEGER,
+	gas_used         INTEGER,
+	base_fee         TEXT,
+	tx_count         INTEGER,
+	uncle_count      INTEGER,
+	time_first_seen  INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS blocks_number ON blocks (number);
+
+CREATE TABLE IF NOT EXISTS transactions (
+	hash            TEXT PRIMARY KEY,
+	block_hash      TEXT,
+	type            INTEGER,
+	"from"          TEXT,
+	"to"            TEXT,
+	nonce           INTEGER,
+	value           TEXT,
+	gas             INTEGER,
+	gas_price       TEXT,
+	gas_fee_cap     TEXT,
+	gas_tip_cap     TEXT,
+	data            BLOB,
+	time_first_seen INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS block_events (
+	hash      TEXT NOT NULL,
+	peer_id   TEXT NOT NULL,
+	sensor_id TEXT NOT NULL,
+	time      INTEGER NOT NULL,
+	PRIMARY KEY (hash, peer_id)
+);
+CREATE INDEX IF NOT EXISTS block_events_time ON block_events (time);
+
+CREATE TABLE IF NOT EXISTS transaction_events (
+	hash      TEXT NOT NULL,
+	peer_id   TEXT NOT NULL,
+	sensor_id TEXT NOT NULL,
+	time      INTEGER NOT NULL,
+	PRIMARY KEY (hash, peer_id)
+);
+CREATE INDEX IF NOT EXISTS transaction_events_time ON transaction_events (time);
+
+CREATE VIEW IF NOT EXISTS block_propagation AS
+SELECT hash, peer_id, time, time - MIN(time) OVER (PARTITION BY hash) AS latency
+FROM block_events;
+
+CREATE VIEW IF NOT EXISTS transaction_propagation AS
+SELECT hash, peer_id, time, time - MIN(time) OVER (PARTITION BY hash) AS latency
+FROM transaction_events;
+`
+
+// SQLite writes the blocks, transactions, and their events to a local SQLite
+// database. This doesn't require any cloud resources which makes it suited to
+// short lived network health analyses.
+type SQLite struct {
+	db                           *sql.DB
+	sensorID                     string
+	maxConcurrency               int
+	shouldWriteBlocks            bool
+	shouldWriteBlockEvents       bool
+	shouldWriteTransactions      bool
+	shouldWriteTransactionEvents bool
+	jobs                         chan struct{}
+	ttl                          time.Duration
+}
+
+// SQLiteOptions is used when creating a NewSQLite.
+type SQLiteOptions struct {
+	Path                         string
+	SensorID                     string
+	MaxConcurrency               int
+	ShouldWriteBlocks            bool
+	ShouldWriteBlockEvents       bool
+	ShouldWriteTransactions      bool
+	ShouldWriteTransactionEvents bool
+	TTL                          time.Duration
+}
+
+// NewSQLite opens the SQLite database, creating it and its tables if needed.
+// The rows older than the TTL are deleted every hour until the context is
+// done.
+func NewSQLite(ctx context.Context, opts SQLiteOptions) (Database, error) {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)", opts.Path))
+	if err != nil {
+		return nil, err
+	}
+	// SQLite only allows a single writer, the concurrent writes are queued by
+	// the jobs instead.
+	db.SetMaxOpenConns(1)
+
+	if _, err = db.ExecContext(ctx, sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("unable to create the tables: %w", err)
+	}
+
+	s := &SQLite{
+		db:                           db,
+		sensorID:                     opts.SensorID,
+		maxConcurrency:               opts.MaxConcurrency,
+		shouldWriteBlocks:            opts.ShouldWriteBlocks,
+		shouldWriteBlockEvents:       opts.ShouldWriteBlockEvents,
+		shouldWriteTransactions:      opts.ShouldWriteTransactions,
+		shouldWriteTransactionEvents: opts.ShouldWriteTransactionEvents,
+		jobs:                         make(chan struct{}, opts.MaxConcurrency),
+		ttl:                          opts.TTL,
+	}
+
+	if s.ttl > 0 {
+		go s.prune(ctx)
+	}
+
+	return s, nil
+}
+
+// WriteBlock writes the block and the block event to the database.
+func (s *SQLite) WriteBlock(ctx context.Context, peer *enode.Node, block *types.Block, td *big.Int) {
+	now := time.Now()
+
+	if s.ShouldWriteBlockEvents() {
+		s.jobs <- struct{}{}
+		go func() {
+			s.writeEvents(ctx, peer, "block_events", []common.Hash{block.Hash()}, now)
+			<-s.jobs
+		}()
+	}
+
+	if s.ShouldWriteBlocks() {
+		s.jobs <- struct{}{}
+		go func() {
+			s.writeBlock(ctx, block, td, now)
+			<-s.jobs
+		}()
+	}
+}
+
+// WriteBlockHeaders will write the block headers to the database. Like the
+// datastore, it will not write block events because headers will only be sent
+// to the sensor when requested.
+func (s *SQLite) WriteBlockHeaders(ctx context.Context, headers []*types.Header) {
+	if !s.ShouldWriteBlocks() {
+		return
+	}
+
+	now := time.Now()
+	s.jobs <- struct{}{}
+	go func() {
+		for _, header := range headers {
+			s.writeBlockHeader(ctx, header, now)
+		}
+		<-s.jobs
+	}()
+}
+
+// WriteBlockBody will write the transactions and uncles of the block body to
+// the database.
+func (s *SQLite) WriteBlockBody(ctx context.Context, body *eth.BlockBody, hash common.Hash) {
+	if !s.ShouldWriteBlocks() {
+		return
+	}
+
+	now := time.Now()
+	s.jobs <- struct{}{}
+	go func() {
+		s.writeBlockBody(ctx, body.Transactions, body.Uncles, hash, now)
+		<-s.jobs
+	}()
+}
+
+// WriteBlockHashes will write the block events to the database.
+func (s *SQLite) WriteBlockHashes(ctx context.Context, peer *enode.Node, hashes []common.Hash) {
+	if !s.ShouldWriteBlockEvents() || len(hashes) == 0 {
+		return
+	}
+
+	now := time.Now()
+	s.jobs <- struct{}{}
+	go func() {
+		s.writeEvents(ctx, peer, "block_events", hashes, now)
+		<-s.jobs
+	}()
+}
+
+// WriteTransactions will write the transactions and transaction events to the
+// database.
+func (s *SQLite) WriteTransactions(ctx context.Context, peer *enode.Node, txs []*types.Transaction) {
+	now := time.Now()
+
+	if s.ShouldWriteTransactions() {
+		s.jobs <- struct{}{}
+		go func() {
+			s.writeTransactions(ctx, txs, nil, now)
+			<-s.jobs
+		}()
+	}
+
+	if s.ShouldWriteTransactionEvents() {
+		hashes := make([]common.Hash, 0, len(txs))
+		for _, tx := range txs {
+			hashes = append(hashes, tx.Hash())
+		}
+
+		s.jobs <- struct{}{}
+		go func() {
+			s.writeEvents(ctx, peer, "transaction_events", hashes, now)
+			<-s.jobs
+		}()
+	}
+}
+
+// WriteTransactionHashes will write the transaction events of the announced
+// transaction hashes to the database.
+func (s *SQLite) WriteTransactionHashes(ctx context.Context, peer *enode.Node, hashes []common.Hash) {
+	if !s.ShouldWriteTransactionEvents() || len(hashes) == 0 {
+		return
+	}
+
+	now := time.Now()
+	s.jobs <- struct{}{}
+	go func() {
+		s.writeEvents(ctx, peer, "transaction_events", hashes, now)
+		<-s.jobs
+	}()
+}
+
+// WritePooledTransactions will write the requested pooled transactions to the
+// database.
+func (s *SQLite) WritePooledTransactions(ctx context.Context, txs []*types.Transaction) {
+	if !s.ShouldWriteTransactions() {
+		return
+	}
+
+	now := time.Now()
+	s.jobs <- struct{}{}
+	go func() {
+		s.writeTransactions(ctx, txs, nil, now)
+		<-s.jobs
+	}()
+}
+
+func (s *SQLite) HasBlock(ctx context.Context, hash common.Hash) bool {
+	var number sql.NullInt64
+	err := s.db.QueryRowContext(ctx, "SELECT number FROM blocks WHERE hash = ?", hash.Hex()).Scan(&number)
+	return err == nil && number.Valid
+}
+
+func (s *SQLite) MaxConcurrentWrites() int {
+	return s.maxConcurrency
+}
+
+func (s *SQLite) ShouldWriteBlocks() bool {
+	return s.shouldWriteBlocks
+}
+
+func (s *SQLite) ShouldWriteBlockEvents() bool {
+	return s.shouldWriteBlockEvents
+}
+
+func (s *SQLite) ShouldWriteTransactions() bool {
+	return s.shouldWriteTransactions
+}
+
+func (s *SQLite) ShouldWriteTransactionEvents() bool {
+	return s.shouldWriteTransactionEvents
+}
+
+// NodeList returns the peers which most recently announced blocks.
+func (s *SQLite) NodeList(ctx context.Context, limit int) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT peer_id FROM block_events GROUP BY peer_id ORDER BY MAX(time) DESC LIMIT ?", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nodelist := []string{}
+	for rows.Next() {
+		var enode string
+		if err = rows.Scan(&enode); err != nil {
+			return nil, err
+		}
+		nodelist = append(nodelist, enode)
+	}
+
+	return nodelist, rows.Err()
+}
+
+// writeEvents writes the events of the peer into the table, ignoring the
+// hashes the peer has already announced.
+func (s *SQLite) writeEvents(ctx context.Context, peer *enode.Node, table string, hashes []common.Hash, now time.Time) {
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, fmt.Sprintf(
+			"INSERT OR IGNORE INTO %s (hash, peer_id, sensor_id, time) VALUES (?, ?, ?, ?)", table))
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, hash := range hashes {
+			if _, err = stmt.ExecContext(ctx, hash.Hex(), peer.URLv4(), s.sensorID, now.UnixMilli()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to write to %v", table)
+	}
+}
+
+func (s *SQLite) writeBlock(ctx context.Context, block *types.Block, td *big.Int, now time.Time) {
+	s.writeBlockHeader(ctx, block.Header(), now)
+
+	if _, err := s.db.ExecContext(ctx, "UPDATE blocks SET total_difficulty = ? WHERE hash = ? AND total_difficulty IS NULL",
+		td.String(), block.Hash().Hex()); err != nil {
+		log.Error().Err(err).Str("hash", block.Hash().Hex()).Msg("Failed to write total difficulty")
+	}
+
+	s.writeBlockBody(ctx, block.Transactions(), block.Uncles(), block.Hash(), now)
+}
+
+// writeBlockHeader will write the block header to the database if it doesn't
+// exist. The block may already exist without a header when its body arrived
+// first.
+func (s *SQLite) writeBlockHeader(ctx context.Context, header *types.Header, now time.Time) {
+	var baseFee *string
+	if header.BaseFee != nil {
+		fee := header.BaseFee.String()
+		baseFee = &fee
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+INSERT INTO blocks (hash, parent_hash, number, time, coinbase, difficulty, gas_limit, gas_used, base_fee, time_first_seen)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (hash) DO UPDATE SET
+	parent_hash = excluded.parent_hash,
+	number = excluded.number,
+	time = excluded.time,
+	coinbase = excluded.coinbase,
+	difficulty = excluded.difficulty,
+	gas_limit = excluded.gas_limit,
+	gas_used = excluded.gas_used,
+	base_fee = excluded.base_fee
+WHERE blocks.number IS NULL`,
+		header.Hash().Hex(), header.ParentHash.Hex(), header.Number.Uint64(), header.Time*1000,
+		header.Coinbase.Hex(), header.Difficulty.String(), header.GasLimit, header.GasUsed, baseFee, now.UnixMilli())
+
+	if err != nil {
+		log.Error().Err(err).Str("hash", header.Hash().Hex()).Msg("Failed to write block header")
+	}
+}
+
+// writeBlockBody will write the transactions and uncles of the block if they
+// haven't been written yet.
+func (s *SQLite) writeBlockBody(ctx context.Context, txs []*types.Transaction, uncles []*types.Header, hash common.Hash, now time.Time) {
+	res, err := s.db.ExecContext(ctx, `
+INSERT INTO blocks (hash, tx_count, uncle_count, time_first_seen) VALUES (?, ?, ?, ?)
+ON CONFLICT (hash) DO UPDATE SET tx_count = excluded.tx_count, uncle_count = excluded.uncle_count
+WHERE blocks.tx_count IS NULL`,
+		hash.Hex(), len(txs), len(uncles), now.UnixMilli())
+	if err != nil {
+		log.Error().Err(err).Str("hash", hash.Hex()).Msg("Failed to write block body")
+		return
+	}
+
+	// The body was already written.
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return
+	}
+
+	if s.shouldWriteTransactions && len(txs) > 0 {
+		s.writeTransactions(ctx, txs, &hash, now)
+	}
+
+	for _, uncle := range uncles {
+		s.writeBlockHeader(ctx, uncle, now)
+	}
+}
+
+// writeTransactions will write the transactions to the database, setting the
+// block hash of the transactions which were first seen in the txpool.
+func (s *SQLite) writeTransactions(ctx context.Context, txs []*types.Transaction, blockHash *common.Hash, now time.Time) {
+	var block *string
+	if blockHash != nil {
+		hex := blockHash.Hex()
+		block = &hex
+	}
+
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, `
+INSERT INTO transactions (hash, block_hash, type, "from", "to", nonce, value, gas, gas_price, gas_fee_cap, gas_tip_cap, data, time_first_seen)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (hash) DO UPDATE SET block_hash = COALESCE(transactions.block_hash, excluded.block_hash)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, t := range txs {
+			var from, to string
+			if address, err := types.Sender(types.LatestSignerForChainID(t.ChainId()), t); err == nil {
+				from = address.Hex()
+			}
+			if t.To() != nil {
+				to = t.To().Hex()
+			}
+
+			_, err = stmt.ExecContext(ctx, t.Hash().Hex(), block, t.Type(), from, to, t.Nonce(), t.Value().String(),
+				t.Gas(), t.GasPrice().String(), t.GasFeeCap().String(), t.GasTipCap().String(), t.Data(), now.UnixMilli())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to write transactions")
+	}
+}
+
+// prune deletes the rows first seen before the TTL every hour.
+func (s *SQLite) prune(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		expiry := time.Now().Add(-s.ttl).UnixMilli()
+		for _, query := range []string{
+			"DELETE FROM blocks WHERE time_first_seen < ?",
+			"DELETE FROM transactions WHERE time_first_seen < ?",
+			"DELETE FROM block_events WHERE time < ?",
+			"DELETE FROM transaction_events WHERE time < ?",
+		} {
+			if _, err := s.db.ExecContext(ctx, query, expiry); err != nil {
+				log.Error().Err(err).Str("query", query).Msg("Failed to delete expired rows")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			s.db.Close()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *SQLite) inTx(ctx context.Context, f func(*sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
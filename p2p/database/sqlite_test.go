@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// TestSQLiteTransactionEvents checks that an announced transaction has a
+// single event per peer once the pooled transaction is delivered.
+func TestSQLiteTransactionEvents(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewSQLite(ctx, SQLiteOptions{
+		Path:                         filepath.Join(t.TempDir(), "sensor.db"),
+		SensorID:                     "test",
+		MaxConcurrency:               4,
+		ShouldWriteTransactions:      true,
+		ShouldWriteTransactionEvents: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := db.(*SQLite)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer := enode.NewV4(&key.PublicKey, net.IPv4(127, 0, 0, 1), 30303, 30303)
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1, GasPrice: common.Big1, Gas: 21000})
+
+	s.WriteTransactionHashes(ctx, peer, []common.Hash{tx.Hash()})
+	s.WritePooledTransactions(ctx, []*types.Transaction{tx})
+	// Taking every job slot waits for the pending writes.
+	for i := 0; i < cap(s.jobs); i++ {
+		s.jobs <- struct{}{}
+	}
+
+	var events, txs int
+	if err = s.db.QueryRow("SELECT COUNT(*) FROM transaction_events WHERE hash = ?", tx.Hash().Hex()).Scan(&events); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.db.QueryRow("SELECT COUNT(*) FROM transactions WHERE hash = ?", tx.Hash().Hex()).Scan(&txs); err != nil {
+		t.Fatal(err)
+	}
+	if events != 1 || txs != 1 {
+		t.Fatalf("expected 1 event and 1 transaction, got %d and %d", events, txs)
+	}
+}
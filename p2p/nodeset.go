@@ -1,6 +1,8 @@
 package p2p
 
 import (
+	"bytes"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,26 +10,47 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/rs/zerolog/log"
+	_ "modernc.org/sqlite"
 )
 
 const jsonIndent = "    "
 
 type NodeJSON struct {
 	URL    string  `json:"url"`
+	ENR    string  `json:"enr,omitempty"`
 	Hello  *Hello  `json:"hello,omitempty"`
 	Status *Status `json:"status,omitempty"`
 	Error  string  `json:"error,omitempty"`
 	Time   int64   `json:"time,omitempty"`
 	Nodes  int     `json:"nodes,omitempty"`
+	// Country is the ISO code of the country of the node IP, when the crawl
+	// is given a GeoIP database.
+	Country string `json:"country,omitempty"`
 }
 
 type NodeSet map[enode.ID][]NodeJSON
 
-// ReadNodeSet parses a list of discovery node URLs loaded from a JSON file.
+// ReadNodeSet parses the nodes loaded from a JSON file, either a list of
+// discovery node URLs or a node set written by the crawl command. The latest
+// record of each node of a node set is used, its ENR when it was recorded.
 func ReadNodeSet(file string) ([]*enode.Node, error) {
-	// Load the nodes from the config file.
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node list file: %w", err)
+	}
+
 	var nodelist []string
-	if err := common.LoadJSON(file, &nodelist); err != nil {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var ns NodeSet
+		if err = json.Unmarshal(data, &ns); err != nil {
+			return nil, fmt.Errorf("failed to load node set file: %w", err)
+		}
+		for _, events := range ns {
+			if event := latestEvent(events); event != nil {
+				nodelist = append(nodelist, event.ENR)
+			}
+		}
+	} else if err = common.LoadJSON(file, &nodelist); err != nil {
 		return nil, fmt.Errorf("failed to load node list file: %w", err)
 	}
 
@@ -48,6 +71,25 @@ func ReadNodeSet(file string) ([]*enode.Node, error) {
 	return nodes, nil
 }
 
+// latestEvent returns the latest event of a node, with its ENR set to the URL
+// when the ENR wasn't recorded.
+func latestEvent(events []NodeJSON) *NodeJSON {
+	var latest *NodeJSON
+	for i := range events {
+		if latest == nil || events[i].Time >= latest.Time {
+			latest = &events[i]
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	event := *latest
+	if event.ENR == "" {
+		event.ENR = event.URL
+	}
+	return &event
+}
+
 func WriteNodeSet(file string, ns NodeSet, writeErrors bool) error {
 	if !writeErrors {
 		keys := []enode.ID{}
@@ -132,3 +174,148 @@ func WritePeers(file string, nodes map[enode.ID]string) error {
 
 	return os.WriteFile(file, bytes, 0644)
 }
+
+// DiffNodes compares two node lists by node ID and returns the nodes that only
+// exist in b (added), the nodes that only exist in a (removed), the nodes of b
+// whose ENR has a higher sequence number than in a (updated), e.g. after an IP
+// or a fork ID change, and the number of other nodes found in both. Nodes read
+// from an enode URL have no ENR so they are never updated.
+func DiffNodes(a, b []*enode.Node) (added, removed, updated []*enode.Node, unchanged int) {
+	old := make(map[enode.ID]*enode.Node, len(a))
+	for _, n := range a {
+		if o, ok := old[n.ID()]; !ok || n.Seq() > o.Seq() {
+			old[n.ID()] = n
+		}
+	}
+
+	latest := make(map[enode.ID]*enode.Node, len(b))
+	var ids []enode.ID
+	for _, n := range b {
+		l, ok := latest[n.ID()]
+		if !ok {
+			ids = append(ids, n.ID())
+		}
+		if !ok || n.Seq() > l.Seq() {
+			latest[n.ID()] = n
+		}
+	}
+
+	for _, id := range ids {
+		n := latest[id]
+		o, ok := old[id]
+		switch {
+		case !ok:
+			added = append(added, n)
+		case o.Record().Signature() != nil && n.Seq() > o.Seq():
+			updated = append(updated, n)
+		default:
+			unchanged++
+		}
+	}
+
+	seen := make(map[enode.ID]struct{}, len(a))
+	for _, n := range a {
+		if _, ok := seen[n.ID()]; ok {
+			continue
+		}
+		seen[n.ID()] = struct{}{}
+		if _, ok := latest[n.ID()]; !ok {
+			removed = append(removed, n)
+		}
+	}
+
+	return added, removed, updated, unchanged
+}
+
+// sqliteNodesSchema creates the nodes table of a crawl and the view of the
+// geographic distribution of the nodes.
+const sqliteNodesSchema = `
+CREATE TABLE IF NOT EXISTS nodes (
+	id         TEXT NOT NULL,
+	url        TEXT NOT NULL,
+	enr        TEXT,
+	seq        INTEGER,
+	client     TEXT,
+	network_id INTEGER,
+	genesis    TEXT,
+	fork_id    TEXT,
+	country    TEXT,
+	error      TEXT,
+	time       INTEGER NOT NULL,
+	PRIMARY KEY (id, time)
+);
+
+CREATE VIEW IF NOT EXISTS country_distribution AS
+SELECT country, COUNT(*) AS nodes
+FROM nodes n
+WHERE error IS NULL AND time = (SELECT MAX(time) FROM nodes WHERE id = n.id)
+GROUP BY country
+ORDER BY nodes DESC;
+`
+
+// WriteNodeSetSQLite appends the latest event of each node of the set to the
+// nodes table of the SQLite database, creating it if needed. The nodes of
+// successive crawls written to the same database can be compared by time.
+func WriteNodeSetSQLite(path string, ns NodeSet) error {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)", path))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err = db.Exec(sqliteNodesSchema); err != nil {
+		return fmt.Errorf("unable to create the tables: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err = insertNodes(tx, ns); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertNodes(tx *sql.Tx, ns NodeSet) error {
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO nodes
+(id, url, enr, seq, client, network_id, genesis, fork_id, country, error, time)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for id, events := range ns {
+		event := latestEvent(events)
+		if event == nil {
+			continue
+		}
+		var seq *uint64
+		if n, err := enode.Parse(enode.ValidSchemes, event.ENR); err == nil {
+			s := n.Seq()
+			seq = &s
+		}
+		var client, genesis, forkID, country, nodeErr *string
+		var networkID *uint64
+		if event.Hello != nil {
+			client = &event.Hello.Name
+		}
+		if event.Status != nil {
+			networkID = &event.Status.NetworkID
+			g, f := event.Status.Genesis.Hex(), common.Bytes2Hex(event.Status.ForkID.Hash[:])
+			genesis, forkID = &g, &f
+		}
+		if event.Country != "" {
+			country = &event.Country
+		}
+		if event.Error != "" {
+			nodeErr = &event.Error
+		}
+		if _, err = stmt.Exec(id.String(), event.URL, event.ENR, seq, client, networkID, genesis, forkID, country, nodeErr, event.Time); err != nil {
+			return err
+		}
+	}
+	return nil
+}
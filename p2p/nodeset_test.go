@@ -0,0 +1,140 @@
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"database/sql"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/enr"
+)
+
+func signedNode(t *testing.T, key *ecdsa.PrivateKey, seq uint64, ip string) *enode.Node {
+	var r enr.Record
+	r.SetSeq(seq)
+	r.Set(enr.IP(net.ParseIP(ip)))
+	r.Set(enr.TCP(30303))
+	if err := enode.SignV4(&r, key); err != nil {
+		t.Fatal(err)
+	}
+	n, err := enode.New(enode.ValidSchemes, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func writeJSON(t *testing.T, v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "nodes.json")
+	if err = os.WriteFile(file, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestDiffNodeSets(t *testing.T) {
+	var keys []*ecdsa.PrivateKey
+	for i := 0; i < 4; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys = append(keys, key)
+	}
+	kept, moved, left, joined := keys[0], keys[1], keys[2], keys[3]
+
+	// The node moved since the old crawl, its latest record has a new IP.
+	before, after := signedNode(t, moved, 1, "10.0.0.2"), signedNode(t, moved, 2, "10.0.0.5")
+	oldSet := make(NodeSet)
+	for _, n := range []*enode.Node{signedNode(t, kept, 1, "10.0.0.1"), before, signedNode(t, left, 1, "10.0.0.3")} {
+		oldSet[n.ID()] = []NodeJSON{{URL: n.URLv4(), ENR: n.String(), Time: 1}}
+	}
+	newSet := make(NodeSet)
+	var urls []string
+	for _, n := range []*enode.Node{signedNode(t, kept, 1, "10.0.0.1"), signedNode(t, joined, 1, "10.0.0.4")} {
+		newSet[n.ID()] = []NodeJSON{{URL: n.URLv4(), ENR: n.String(), Time: 1}}
+		urls = append(urls, n.URLv4())
+	}
+	newSet[after.ID()] = []NodeJSON{{URL: before.URLv4(), ENR: before.String(), Time: 1}, {URL: after.URLv4(), ENR: after.String(), Time: 2}}
+	urls = append(urls, after.URLv4())
+
+	oldNodes, err := ReadNodeSet(writeJSON(t, oldSet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newNodes, err := ReadNodeSet(writeJSON(t, newSet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newURLNodes, err := ReadNodeSet(writeJSON(t, urls))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(oldNodes) != 3 || len(newNodes) != 3 || len(newURLNodes) != 3 {
+		t.Fatalf("expected 3 nodes in each crawl, got %d, %d and %d", len(oldNodes), len(newNodes), len(newURLNodes))
+	}
+
+	for name, tc := range map[string]struct {
+		nodes     []*enode.Node
+		updated   int
+		unchanged int
+	}{
+		"node set": {newNodes, 1, 1},
+		// The URLs have no ENR to compare.
+		"urls": {newURLNodes, 0, 2},
+	} {
+		added, removed, updated, unchanged := DiffNodes(oldNodes, tc.nodes)
+		if len(added) != 1 || added[0].ID() != enode.PubkeyToIDV4(&joined.PublicKey) {
+			t.Errorf("%s: unexpected added nodes %v", name, added)
+		}
+		if len(removed) != 1 || removed[0].ID() != enode.PubkeyToIDV4(&left.PublicKey) {
+			t.Errorf("%s: unexpected removed nodes %v", name, removed)
+		}
+		if len(updated) != tc.updated || (tc.updated > 0 && (updated[0].ID() != after.ID() || updated[0].Seq() != 2)) {
+			t.Errorf("%s: unexpected updated nodes %v", name, updated)
+		}
+		if unchanged != tc.unchanged {
+			t.Errorf("%s: expected %d unchanged nodes, got %d", name, tc.unchanged, unchanged)
+		}
+	}
+}
+
+func TestWriteNodeSetSQLite(t *testing.T) {
+	ns := make(NodeSet)
+	for i, country := range []string{"DE", "DE", "US"} {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := signedNode(t, key, uint64(i+1), "10.0.0.1")
+		ns[n.ID()] = []NodeJSON{{URL: n.URLv4(), ENR: n.String(), Country: country, Time: 1}}
+	}
+
+	path := filepath.Join(t.TempDir(), "nodes.db")
+	if err := WriteNodeSetSQLite(path, ns); err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var country string
+	var nodes int
+	if err = db.QueryRow("SELECT country, nodes FROM country_distribution LIMIT 1").Scan(&country, &nodes); err != nil {
+		t.Fatal(err)
+	}
+	if country != "DE" || nodes != 2 {
+		t.Fatalf("expected 2 nodes in DE, got %d in %s", nodes, country)
+	}
+}
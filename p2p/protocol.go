@@ -135,7 +135,7 @@ func NewEthProtocol(version uint, opts EthProtocolOptions) ethp2p.Protocol {
 				case eth.NewBlockMsg:
 					err = c.handleNewBlock(ctx, msg)
 				case eth.NewPooledTransactionHashesMsg:
-					err = c.handleNewPooledTransactionHashes(version, msg)
+					err = c.handleNewPooledTransactionHashes(ctx, version, msg)
 				case eth.GetPooledTransactionsMsg:
 					err = c.handleGetPooledTransactions(msg)
 				case eth.PooledTransactionsMsg:
@@ -450,7 +450,7 @@ func (c *conn) handleGetPooledTransactions(msg ethp2p.Msg) error {
 		&eth.PooledTransactionsPacket{RequestId: request.RequestId})
 }
 
-func (c *conn) handleNewPooledTransactionHashes(version uint, msg ethp2p.Msg) error {
+func (c *conn) handleNewPooledTransactionHashes(ctx context.Context, version uint, msg ethp2p.Msg) error {
 	var hashes []common.Hash
 	var name string
 
@@ -475,6 +475,11 @@ func (c *conn) handleNewPooledTransactionHashes(version uint, msg ethp2p.Msg) er
 
 	c.counter.WithLabelValues(fmt.Sprint(msg.Code), name).Add(float64(len(hashes)))
 
+	// The announcement is when the peer first sees the transactions, the pooled
+	// transactions only arrive once requested so their events aren't written
+	// again on delivery.
+	c.db.WriteTransactionHashes(ctx, c.node, hashes)
+
 	if !c.db.ShouldWriteTransactions() || !c.db.ShouldWriteTransactionEvents() {
 		return nil
 	}
@@ -494,7 +499,7 @@ func (c *conn) handlePooledTransactions(ctx context.Context, msg ethp2p.Msg) err
 
 	c.counter.WithLabelValues(fmt.Sprint(msg.Code), packet.Name()).Add(float64(len(packet.PooledTransactionsResponse)))
 
-	c.db.WriteTransactions(ctx, c.node, packet.PooledTransactionsResponse)
+	c.db.WritePooledTransactions(ctx, packet.PooledTransactionsResponse)
 
 	return nil
 }
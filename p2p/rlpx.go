@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -334,3 +335,53 @@ func (c *rlpxConn) ListenHeaders() (eth.BlockHeadersRequest, error) {
 		}
 	}
 }
+
+// QueryHeader requests the header of the block hash and waits for it. Unlike
+// ListenHeaders, it gives up after the wait so an unresponsive peer can be
+// reported.
+func (c *rlpxConn) QueryHeader(hash common.Hash, wait time.Duration) (*types.Header, error) {
+	req := &GetBlockHeaders{
+		RequestId: rand.Uint64(),
+		GetBlockHeadersRequest: &eth.GetBlockHeadersRequest{
+			Origin: eth.HashOrNumber{Hash: hash},
+			Amount: 1,
+		},
+	}
+	if err := c.Write(req); err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = c.SetReadDeadline(time.Time{}) }()
+	if err := c.SetReadDeadline(time.Now().Add(wait)); err != nil {
+		return nil, err
+	}
+
+	for {
+		switch msg := c.Read().(type) {
+		case *BlockHeaders:
+			if msg.RequestId != req.RequestId {
+				continue
+			}
+			if len(msg.BlockHeadersRequest) == 0 {
+				return nil, fmt.Errorf("header %v not found", hash)
+			}
+			return msg.BlockHeadersRequest[0], nil
+		case *GetBlockHeaders:
+			if err := c.Write(&BlockHeaders{RequestId: msg.RequestId}); err != nil {
+				return nil, err
+			}
+		case *Ping:
+			if err := c.Write(&Pong{}); err != nil {
+				c.logger.Error().Err(err).Msg("Write pong failed")
+			}
+		case *Error:
+			return nil, msg.Unwrap()
+		case *Disconnect:
+			return nil, fmt.Errorf("disconnect received: %v", msg)
+		case *Disconnects:
+			return nil, fmt.Errorf("disconnect received: %v", msg)
+		default:
+			c.logger.Trace().Interface("msg", msg).Int("code", msg.Code()).Msg("Received message")
+		}
+	}
+}
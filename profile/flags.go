@@ -0,0 +1,192 @@
+package profile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// refPrefix marks the flag values which are names of the address book. The
+// values really starting with it are escaped by doubling it.
+const refPrefix = "@"
+
+// refValue defers the values of a flag which reference the address book until
+// the flags are parsed, since the path of the address book is a flag too.
+type refValue struct {
+	pflag.Value
+	kind Kind
+	// pending holds the values given to the flag since the first reference,
+	// in order, so the values of slice flags keep accumulating.
+	pending []string
+}
+
+func (v *refValue) Set(s string) error {
+	if len(v.pending) > 0 || v.hasRef(s) {
+		v.pending = append(v.pending, s)
+		return nil
+	}
+	resolved, err := v.resolve(nil, s)
+	if err != nil {
+		return err
+	}
+	return v.Value.Set(resolved)
+}
+
+func (v *refValue) isSlice() bool {
+	t := v.Value.Type()
+	return strings.HasSuffix(t, "Slice") || strings.HasSuffix(t, "Array")
+}
+
+// elements returns the values of a slice flag, or the whole value.
+func (v *refValue) elements(s string) []string {
+	if v.isSlice() {
+		return strings.Split(s, ",")
+	}
+	return []string{s}
+}
+
+func (v *refValue) hasRef(s string) bool {
+	for _, e := range v.elements(s) {
+		if strings.HasPrefix(e, refPrefix) && !strings.HasPrefix(e, refPrefix+refPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolve replaces the references of the value by their value in the store,
+// and unescapes the other values.
+func (v *refValue) resolve(store *Store, s string) (string, error) {
+	elements := v.elements(s)
+	for i, e := range elements {
+		switch {
+		case strings.HasPrefix(e, refPrefix+refPrefix):
+			elements[i] = e[len(refPrefix):]
+		case strings.HasPrefix(e, refPrefix):
+			value, err := store.Lookup(v.kind, e[len(refPrefix):])
+			if err != nil {
+				return "", err
+			}
+			elements[i] = value
+		}
+	}
+	return strings.Join(elements, ","), nil
+}
+
+// accountFlags are the flags holding account addresses. They're listed since
+// other flags named after addresses hold something else, e.g. the host and port
+// of --listen-address. --key-id selects the account of the keystore and of the
+// external signer.
+var accountFlags = map[string]bool{
+	"address":             true,
+	"addresses":           true,
+	"contract-address":    true,
+	"destination-address": true,
+	"erc20-address":       true,
+	"erc721-address":      true,
+	"key-id":              true,
+	"l1-bridge-address":   true,
+	"l2-bridge-address":   true,
+	"lt-address":          true,
+	"to-address":          true,
+	"weth9-address":       true,
+
+	"uniswap-factory-v3-address":                    true,
+	"uniswap-migrator-address":                      true,
+	"uniswap-multicall-address":                     true,
+	"uniswap-nft-descriptor-lib-address":            true,
+	"uniswap-nft-position-descriptor-address":       true,
+	"uniswap-non-fungible-position-manager-address": true,
+	"uniswap-pool-token-0-address":                  true,
+	"uniswap-pool-token-1-address":                  true,
+	"uniswap-proxy-admin-address":                   true,
+	"uniswap-quoter-v2-address":                     true,
+	"uniswap-staker-address":                        true,
+	"uniswap-swap-router-address":                   true,
+	"uniswap-tick-lens-address":                     true,
+	"uniswap-upgradeable-proxy-address":             true,
+}
+
+// flagKind returns the kind of values a flag references by its name: the
+// endpoints for the urls, the chains for the chain ids and the accounts for the
+// account flags.
+func flagKind(f *pflag.Flag) (Kind, bool) {
+	t := f.Value.Type()
+	if t == "bool" {
+		return "", false
+	}
+	switch name := f.Name; {
+	case strings.HasSuffix(name, "url") || strings.HasSuffix(name, "urls"):
+		return Endpoint, true
+	case strings.HasSuffix(name, "chain-id"):
+		return Chain, true
+	// The account flags of other types, e.g. the number of --addresses of
+	// `polycli wallet`, aren't addresses.
+	case accountFlags[name] && (t == "string" || t == "stringSlice"):
+		return Account, true
+	}
+	return "", false
+}
+
+// AddRefs lets the url, chain id and account flags of the command and its
+// descendants reference the address book with `@name`. The references are
+// replaced by Resolve.
+func AddRefs(cmd *cobra.Command) {
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		if _, ok := f.Value.(*refValue); ok {
+			return
+		}
+		if kind, ok := flagKind(f); ok {
+			f.Value = &refValue{Value: f.Value, kind: kind}
+		}
+	})
+	for _, c := range cmd.Commands() {
+		AddRefs(c)
+	}
+}
+
+// Resolve sets the flags of the command and its descendants which reference
+// the address book, whether from the command line, the environment or the
+// config file. The address book is only read when a flag references it.
+func Resolve(cmd *cobra.Command) error {
+	var store *Store
+	var err error
+	visitRefs(cmd, func(f *pflag.Flag, v *refValue) {
+		if err != nil || len(v.pending) == 0 {
+			return
+		}
+		if store == nil {
+			if store, err = Load(); err != nil {
+				return
+			}
+		}
+		for _, s := range v.pending {
+			var resolved string
+			if resolved, err = v.resolve(store, s); err != nil {
+				err = fmt.Errorf("--%s: %w", f.Name, err)
+				return
+			}
+			if err = v.Value.Set(resolved); err != nil {
+				err = fmt.Errorf("invalid value for --%s: %w", f.Name, err)
+				return
+			}
+		}
+		v.pending = nil
+	})
+	return err
+}
+
+// visitRefs calls fn for each flag of the command and its descendants which
+// can reference the address book.
+func visitRefs(cmd *cobra.Command, fn func(*pflag.Flag, *refValue)) {
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		if v, ok := f.Value.(*refValue); ok {
+			fn(f, v)
+		}
+	})
+	for _, c := range cmd.Commands() {
+		visitRefs(c, fn)
+	}
+}
@@ -0,0 +1,193 @@
+// Package profile implements the address book shared by the commands: named
+// RPC endpoints, chains and accounts stored in a YAML file, which flags can
+// reference by name, e.g. `--rpc-url @mumbai`.
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is the kind of the values of a section of the address book.
+type Kind string
+
+const (
+	Endpoint Kind = "endpoint"
+	Chain    Kind = "chain"
+	Account  Kind = "account"
+)
+
+// Kinds are the kinds of the values of the address book.
+var Kinds = []Kind{Endpoint, Chain, Account}
+
+// File is the path of the address book, `~/.polygon-cli/profiles.yaml` when
+// empty.
+var File string
+
+// Store is the content of the address book.
+type Store struct {
+	Endpoints map[string]string `yaml:"endpoints,omitempty"`
+	Chains    map[string]uint64 `yaml:"chains,omitempty"`
+	Accounts  map[string]string `yaml:"accounts,omitempty"`
+}
+
+// Entry is a named value of the address book.
+type Entry struct {
+	Kind  Kind
+	Name  string
+	Value string
+}
+
+// Path returns the path of the address book.
+func Path() (string, error) {
+	if File != "" {
+		return File, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".polygon-cli", "profiles.yaml"), nil
+}
+
+// Load reads the address book, which is empty when the file doesn't exist.
+func Load() (*Store, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	s := new(Store)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the address book, readable by the user only since it may point
+// to private infrastructure.
+func (s *Store) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Lookup returns the value of the name, formatted as a flag value.
+func (s *Store) Lookup(kind Kind, name string) (string, error) {
+	var value string
+	var ok bool
+	switch kind {
+	case Endpoint:
+		value, ok = s.Endpoints[name]
+	case Chain:
+		var id uint64
+		id, ok = s.Chains[name]
+		value = strconv.FormatUint(id, 10)
+	case Account:
+		value, ok = s.Accounts[name]
+	default:
+		return "", fmt.Errorf("unknown kind %s", kind)
+	}
+	if !ok {
+		path, _ := Path()
+		return "", fmt.Errorf("no %s named %s in %s", kind, name, path)
+	}
+	return value, nil
+}
+
+// Set validates the value and stores it under the name, replacing the
+// previous value.
+func (s *Store) Set(kind Kind, name, value string) error {
+	if name == "" {
+		return errors.New("the name is empty")
+	}
+	switch kind {
+	case Endpoint:
+		if s.Endpoints == nil {
+			s.Endpoints = make(map[string]string)
+		}
+		s.Endpoints[name] = value
+	case Chain:
+		id, err := strconv.ParseUint(value, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid chain id %s", value)
+		}
+		if s.Chains == nil {
+			s.Chains = make(map[string]uint64)
+		}
+		s.Chains[name] = id
+	case Account:
+		if !common.IsHexAddress(value) {
+			return fmt.Errorf("invalid address %s", value)
+		}
+		if s.Accounts == nil {
+			s.Accounts = make(map[string]string)
+		}
+		s.Accounts[name] = common.HexToAddress(value).Hex()
+	default:
+		return fmt.Errorf("unknown kind %s", kind)
+	}
+	return nil
+}
+
+// Remove deletes the name, and reports whether it existed.
+func (s *Store) Remove(kind Kind, name string) bool {
+	var ok bool
+	switch kind {
+	case Endpoint:
+		_, ok = s.Endpoints[name]
+		delete(s.Endpoints, name)
+	case Chain:
+		_, ok = s.Chains[name]
+		delete(s.Chains, name)
+	case Account:
+		_, ok = s.Accounts[name]
+		delete(s.Accounts, name)
+	}
+	return ok
+}
+
+// Entries returns the values of the address book sorted by kind and name.
+func (s *Store) Entries() []Entry {
+	var entries []Entry
+	for name, v := range s.Endpoints {
+		entries = append(entries, Entry{Endpoint, name, v})
+	}
+	for name, id := range s.Chains {
+		entries = append(entries, Entry{Chain, name, strconv.FormatUint(id, 10)})
+	}
+	for name, v := range s.Accounts {
+		entries = append(entries, Entry{Account, name, v})
+	}
+	order := map[Kind]int{Endpoint: 0, Chain: 1, Account: 2}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return order[entries[i].Kind] < order[entries[j].Kind]
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
@@ -0,0 +1,65 @@
+package profile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestResolve(t *testing.T) {
+	File = filepath.Join(t.TempDir(), "profiles.yaml")
+	defer func() { File = "" }()
+	store := new(Store)
+	for _, e := range []Entry{
+		{Endpoint, "local", "http://localhost:8545"},
+		{Chain, "local", "0x539"},
+		{Account, "a", "0x85da99c8a7c2c95964c8efd687e95e632fc533d6"},
+		{Account, "b", "0x0000000000000000000000000000000000000001"},
+	} {
+		if err := store.Set(e.Kind, e.Name, e.Value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{Use: "test"}
+	rpcURL := cmd.Flags().String("rpc-url", "", "")
+	chainID := cmd.Flags().Uint64("chain-id", 0, "")
+	addresses := cmd.Flags().StringSlice("addresses", nil, "")
+	name := cmd.Flags().String("name", "", "")
+	keyID := cmd.Flags().String("key-id", "", "")
+	listen := cmd.Flags().String("listen-address", "", "")
+	AddRefs(cmd)
+	err := cmd.ParseFlags([]string{"--rpc-url", "@local", "--chain-id", "@local", "--addresses", "@a,@@b", "--addresses", "@b", "--name", "@local", "--key-id", "@b", "--listen-address", "@local"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Resolve(cmd); err != nil {
+		t.Fatal(err)
+	}
+	if *rpcURL != "http://localhost:8545" || *chainID != 1337 || *name != "@local" || *listen != "@local" {
+		t.Fatalf("unexpected values %s %d %s %s", *rpcURL, *chainID, *name, *listen)
+	}
+	if *keyID != "0x0000000000000000000000000000000000000001" {
+		t.Fatalf("unexpected key id %s", *keyID)
+	}
+	expected := []string{"0x85dA99c8a7C2C95964c8EfD687E95E632Fc533D6", "@b", "0x0000000000000000000000000000000000000001"}
+	if len(*addresses) != len(expected) {
+		t.Fatalf("unexpected addresses %v", *addresses)
+	}
+	for i := range expected {
+		if (*addresses)[i] != expected[i] {
+			t.Fatalf("unexpected addresses %v", *addresses)
+		}
+	}
+
+	if err = cmd.ParseFlags([]string{"--rpc-url", "@missing"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = Resolve(cmd); err == nil {
+		t.Fatal("an unknown endpoint was resolved")
+	}
+}
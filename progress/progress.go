@@ -0,0 +1,167 @@
+// Package progress reports the progress of the long running commands: a progress bar when stderr is a
+// terminal, periodic log lines otherwise, e.g. in CI or when the logs are collected, and nothing with --quiet.
+package progress
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	progressbar "github.com/schollz/progressbar/v3"
+	"golang.org/x/term"
+)
+
+var (
+	quiet      bool
+	isTerminal = term.IsTerminal(int(os.Stderr.Fd()))
+	// LogInterval is the period of the log lines reporting the progress when stderr isn't a terminal.
+	LogInterval = 10 * time.Second
+)
+
+// SetQuiet disables the progress reports, set by the --quiet flag.
+func SetQuiet(q bool) {
+	quiet = q
+}
+
+// Bar reports the progress of a phase towards its total.
+type Bar struct {
+	description string
+	total       int64
+	unit        string
+	start       time.Time
+	done        atomic.Int64
+	bar         *progressbar.ProgressBar
+	stop        chan struct{}
+	wg          sync.WaitGroup
+	finish      sync.Once
+}
+
+// New starts reporting the progress of a phase of total units, -1 when the total is unknown.
+func New(description string, total int64, unit string) *Bar {
+	b := &Bar{description: description, total: total, unit: unit, start: time.Now(), stop: make(chan struct{})}
+	switch {
+	case quiet:
+	case isTerminal:
+		b.bar = newProgressBar(description, total, unit)
+	default:
+		b.wg.Add(1)
+		go b.logProgress()
+	}
+	return b
+}
+
+func newProgressBar(description string, total int64, unit string) *progressbar.ProgressBar {
+	return progressbar.NewOptions64(total,
+		progressbar.OptionEnableColorCodes(false),
+		progressbar.OptionSetDescription(description),
+		progressbar.OptionSetElapsedTime(true),
+		progressbar.OptionSetItsString(unit),
+		progressbar.OptionSetRenderBlankState(true),
+		progressbar.OptionShowCount(),
+		progressbar.OptionShowIts(),
+		progressbar.OptionShowElapsedTimeOnFinish(),
+		progressbar.OptionUseANSICodes(true),
+		progressbar.OptionThrottle(1*time.Second),
+		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionOnCompletion(func() {
+			_, _ = fmt.Fprintln(os.Stderr)
+		}),
+		progressbar.OptionSetTheme(progressbar.Theme{
+			Saucer:        "=",
+			SaucerHead:    ">",
+			SaucerPadding: " ",
+			BarStart:      "[",
+			BarEnd:        "]",
+		}),
+		progressbar.OptionSetWidth(10),
+		progressbar.OptionFullWidth(),
+	)
+}
+
+// logProgress logs the progress every LogInterval until the phase is finished.
+func (b *Bar) logProgress() {
+	defer b.wg.Done()
+	ticker := time.NewTicker(LogInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			done := b.done.Load()
+			elapsed := time.Since(b.start)
+			rate := float64(done) / elapsed.Seconds()
+			event := log.Info().Str("phase", b.description).Int64("done", done)
+			if b.total >= 0 {
+				event = event.Int64("total", b.total)
+				if rate > 0 && done < b.total {
+					event = event.Dur("eta", time.Duration(float64(b.total-done)/rate*float64(time.Second)))
+				}
+			}
+			event.Str("rate", fmt.Sprintf("%.2f %s/s", rate, b.unit)).Msg("Progress")
+		}
+	}
+}
+
+// Add reports n more units done.
+func (b *Bar) Add(n int64) {
+	b.done.Add(n)
+	if b.bar != nil {
+		_ = b.bar.Add64(n)
+	}
+}
+
+// Set reports the number of units done.
+func (b *Bar) Set(n int64) {
+	b.done.Store(n)
+	if b.bar != nil {
+		_ = b.bar.Set64(n)
+	}
+}
+
+// Finish ends the phase, rendering the final state of the bar or logging the total. It can be called
+// more than once.
+func (b *Bar) Finish() {
+	b.finish.Do(func() {
+		close(b.stop)
+		b.wg.Wait()
+		switch {
+		case b.bar != nil:
+			_ = b.bar.Finish()
+		case !quiet:
+			log.Info().Str("phase", b.description).Int64("done", b.done.Load()).Dur("elapsed", time.Since(b.start)).Msg("Finished")
+		}
+	})
+}
+
+// Phases numbers the phases of a command, each one reported by its own bar, e.g. "loadtest [2/3] receipts".
+// A phase can itself be split into phases.
+type Phases struct {
+	prefix  string
+	count   int
+	current int
+}
+
+// NewPhases numbers the count phases of the description.
+func NewPhases(description string, count int) *Phases {
+	return &Phases{prefix: description, count: count}
+}
+
+// Next starts reporting the progress of the next phase.
+func (p *Phases) Next(description string, total int64, unit string) *Bar {
+	p.current++
+	return New(p.name(description), total, unit)
+}
+
+// Split starts the next phase, made of count phases.
+func (p *Phases) Split(description string, count int) *Phases {
+	p.current++
+	return NewPhases(p.name(description), count)
+}
+
+func (p *Phases) name(description string) string {
+	return fmt.Sprintf("%s [%d/%d] %s", p.prefix, min(p.current, p.count), p.count, description)
+}
@@ -0,0 +1,54 @@
+package progress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func TestLogProgress(t *testing.T) {
+	var buf bytes.Buffer
+	logger, interval := log.Logger, LogInterval
+	log.Logger, LogInterval, isTerminal = zerolog.New(&buf), 10*time.Millisecond, false
+	defer func() {
+		log.Logger, LogInterval = logger, interval
+	}()
+
+	phases := NewPhases("test", 2)
+	phases.Next("first", 10, "op").Finish()
+	sub := phases.Split("second", 1)
+	bar := sub.Next("nested", 10, "op")
+	bar.Add(4)
+	time.Sleep(50 * time.Millisecond)
+	bar.Finish()
+	bar.Finish()
+
+	out := buf.String()
+	for _, expected := range []string{
+		`"phase":"test [1/2] first","done":0`,
+		`"phase":"test [2/2] second [1/1] nested","done":4,"total":10`,
+		`"message":"Progress"`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %s in the logs:\n%s", expected, out)
+		}
+	}
+	if n := strings.Count(out, `"message":"Finished"`); n != 2 {
+		t.Errorf("expected a line per finished phase, got %d:\n%s", n, out)
+	}
+
+	buf.Reset()
+	SetQuiet(true)
+	defer SetQuiet(false)
+	bar = New("quiet", 1, "op")
+	time.Sleep(30 * time.Millisecond)
+	bar.Add(1)
+	bar.Finish()
+	if buf.Len() > 0 {
+		t.Errorf("expected no logs when quiet:\n%s", buf.String())
+	}
+}
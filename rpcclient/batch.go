@@ -0,0 +1,52 @@
+package rpcclient
+
+import (
+	"context"
+	"sync"
+
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// BatchCall sends the elements in batches of at most batchSize elements, with
+// up to workers batches in flight. It returns the first transport error, the
+// errors of the elements are set on each element.
+func BatchCall(ctx context.Context, c *ethrpc.Client, elems []ethrpc.BatchElem, batchSize, workers int) error {
+	batchSize, workers = max(batchSize, 1), max(workers, 1)
+	batches := make(chan []ethrpc.BatchElem)
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				if err := c.BatchCallContext(ctx, batch); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for start := 0; start < len(elems); start += batchSize {
+		select {
+		case <-ctx.Done():
+			break feed
+		case batches <- elems[start:min(start+batchSize, len(elems))]:
+		}
+	}
+	close(batches)
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return firstErr
+}
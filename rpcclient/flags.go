@@ -0,0 +1,50 @@
+package rpcclient
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+)
+
+// Flags are the command line flags tuning the behavior of the RPC client of a
+// command against rate limited or flaky endpoints.
+type Flags struct {
+	RateLimit *float64
+	Retries   *uint64
+}
+
+// AddFlags defines the RPC client flags on the flag set.
+func AddFlags(flagSet *pflag.FlagSet) *Flags {
+	f := new(Flags)
+	f.RateLimit = flagSet.Float64("rpc-rate-limit", 0, "The maximum number of HTTP requests per second sent to the RPC endpoint, a batch being a single request, 0 for no limit")
+	f.Retries = flagSet.Uint64("rpc-retries", 3, "The number of times an HTTP request is retried with exponential backoff after a network error, a 429 or a 5xx response. The requests sending transactions are only retried when they didn't reach the node, after a connection failure or a 429")
+	return f
+}
+
+// Dial connects to the RPC endpoint. The rate limit and the retries only
+// apply to HTTP endpoints. The requests and responses are logged at the trace
+// level.
+func (f *Flags) Dial(ctx context.Context, url string) (*ethrpc.Client, error) {
+	if f == nil {
+		return ethrpc.DialContext(ctx, url)
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		log.Debug().Str("url", url).Msg("The RPC rate limit and retries only apply to HTTP endpoints")
+		return ethrpc.DialContext(ctx, url)
+	}
+	limit := rate.Inf
+	if *f.RateLimit > 0 {
+		limit = rate.Limit(*f.RateLimit)
+	}
+	t := &transport{
+		base:    http.DefaultTransport,
+		limiter: rate.NewLimiter(limit, 1),
+		retries: *f.Retries,
+	}
+	return ethrpc.DialOptions(ctx, url, ethrpc.WithHTTPClient(&http.Client{Transport: t}))
+}
@@ -0,0 +1,144 @@
+package rpcclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+type jsonrpcMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+// newTestServer answers eth_blockNumber with 0x1 after failing the first
+// requests with the status.
+func newTestServer(t *testing.T, failures int64, status int) (*httptest.Server, *atomic.Int64) {
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		var batch []jsonrpcMessage
+		decoder := json.NewDecoder(r.Body)
+		if err := decoder.Decode(&batch); err != nil {
+			t.Errorf("unable to decode the batch: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, "[")
+		for i, m := range batch {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0x1"}`, m.ID)
+		}
+		fmt.Fprint(w, "]")
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func dial(t *testing.T, url string, retries uint64) *ethrpc.Client {
+	rateLimit := 0.0
+	f := &Flags{RateLimit: &rateLimit, Retries: &retries}
+	c, err := f.Dial(context.Background(), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestRetries(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway} {
+		srv, requests := newTestServer(t, 2, status)
+		c := dial(t, srv.URL, 2)
+		elems := []ethrpc.BatchElem{{Method: "eth_blockNumber", Result: new(string)}}
+		if err := c.BatchCallContext(context.Background(), elems); err != nil {
+			t.Fatalf("status %d: the request failed after the retries: %v", status, err)
+		}
+		if n := requests.Load(); n != 3 {
+			t.Errorf("status %d: expected 3 requests, got %d", status, n)
+		}
+	}
+
+	srv, requests := newTestServer(t, 2, http.StatusServiceUnavailable)
+	c := dial(t, srv.URL, 1)
+	if err := c.BatchCallContext(context.Background(), []ethrpc.BatchElem{{Method: "eth_blockNumber"}}); err == nil {
+		t.Error("expected an error once the retries are exhausted")
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	// A rate limited transaction didn't reach the node and is sent again.
+	srv, requests := newTestServer(t, 2, http.StatusTooManyRequests)
+	c := dial(t, srv.URL, 2)
+	if err := c.BatchCallContext(context.Background(), []ethrpc.BatchElem{{Method: "eth_sendRawTransaction", Result: new(string)}}); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+
+	// The node may have received the transaction before failing.
+	srv, requests = newTestServer(t, 2, http.StatusBadGateway)
+	c = dial(t, srv.URL, 2)
+	elems := []ethrpc.BatchElem{{Method: "eth_blockNumber"}, {Method: "eth_sendRawTransaction"}}
+	if err := c.BatchCallContext(context.Background(), elems); err == nil {
+		t.Error("expected the failed transaction not to be retried")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+
+	_, err := http.Post("http://127.0.0.1:1", "application/json", nil)
+	if !isNotSent(err) {
+		t.Errorf("expected the refused connection to be retryable: %v", err)
+	}
+}
+
+func TestIsReadRequest(t *testing.T) {
+	for body, expected := range map[string]bool{
+		`{"method":"eth_getBalance"}`:                                        true,
+		`[{"method":"eth_blockNumber"},{"method":"debug_traceTransaction"}]`: true,
+		`{"method":"eth_sendRawTransaction"}`:                                false,
+		`[{"method":"eth_call"},{"method":"eth_sendTransaction"}]`:           false,
+		`not json`: false,
+	} {
+		if isReadRequest([]byte(body)) != expected {
+			t.Errorf("%s: expected %t", body, expected)
+		}
+	}
+}
+
+func TestBatchCall(t *testing.T) {
+	srv, requests := newTestServer(t, 0, http.StatusOK)
+	c := dial(t, srv.URL, 0)
+	results := make([]string, 25)
+	elems := make([]ethrpc.BatchElem, len(results))
+	for i := range elems {
+		elems[i] = ethrpc.BatchElem{Method: "eth_blockNumber", Result: &results[i]}
+	}
+	if err := BatchCall(context.Background(), c, elems, 10, 3); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("expected 3 batches, got %d", n)
+	}
+	for i, e := range elems {
+		if e.Error != nil || results[i] != "0x1" {
+			t.Errorf("element %d: unexpected result %q, error %v", i, results[i], e.Error)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package rpcclient
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	initialReconnectInterval = time.Second
+	maxReconnectInterval     = 30 * time.Second
+	// recentHeads is the number of delivered heads remembered to skip the
+	// duplicates after a reconnection.
+	recentHeads = 128
+	// logsBackfillPage is the number of blocks of a log backfill request.
+	logsBackfillPage = 1000
+)
+
+// Subscriber delivers the events of eth_subscribe over a WebSocket endpoint.
+// When the connection drops, it reconnects, subscribes again and backfills
+// the events missed in between over the backfill client, usually HTTP.
+type Subscriber struct {
+	url      string
+	backfill *ethclient.Client
+}
+
+// NewSubscriber returns a subscriber to the WebSocket endpoint backfilling
+// the missed events with the client.
+func NewSubscriber(url string, backfill *ethrpc.Client) *Subscriber {
+	return &Subscriber{url: url, backfill: ethclient.NewClient(backfill)}
+}
+
+// NewHeads delivers the new heads until the context is done. The heads are
+// delivered in order without gaps, the gaps being backfilled even without
+// disconnections. The heads of a reorg are delivered as received.
+func (s *Subscriber) NewHeads(ctx context.Context, out chan<- *types.Header) error {
+	var last *types.Header
+	delivered := make(map[uint64]common.Hash)
+	deliver := func(h *types.Header) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case out <- h:
+		}
+		last = h
+		delivered[h.Number.Uint64()] = h.Hash()
+		delete(delivered, h.Number.Uint64()-recentHeads)
+		return nil
+	}
+	backfillTo := func(to uint64) error {
+		if last == nil {
+			return nil
+		}
+		for n := last.Number.Uint64() + 1; n <= to; n++ {
+			h, err := s.backfill.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+			if err != nil {
+				return err
+			}
+			if err = deliver(h); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return s.loop(ctx, "newHeads", func(c *ethclient.Client) error {
+		heads := make(chan *types.Header, recentHeads)
+		sub, err := c.SubscribeNewHead(ctx, heads)
+		if err != nil {
+			return err
+		}
+		defer sub.Unsubscribe()
+
+		// The live heads are buffered while the missed ones are backfilled.
+		if last != nil {
+			head, err := s.backfill.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return err
+			}
+			log.Info().Uint64("from", last.Number.Uint64()+1).Uint64("to", head.Number.Uint64()).Msg("Backfilling the missed heads")
+			if err = backfillTo(head.Number.Uint64()); err != nil {
+				return err
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case err = <-sub.Err():
+				return err
+			case h := <-heads:
+				n := h.Number.Uint64()
+				if hash, ok := delivered[n]; ok && hash == h.Hash() {
+					continue
+				}
+				if n > 0 {
+					if err = backfillTo(n - 1); err != nil {
+						return err
+					}
+				}
+				if err = deliver(h); err != nil {
+					return err
+				}
+			}
+		}
+	})
+}
+
+// Logs delivers the logs matching the query until the context is done. The
+// logs emitted while disconnected are backfilled with eth_getLogs.
+func (s *Subscriber) Logs(ctx context.Context, q ethereum.FilterQuery, out chan<- types.Log) error {
+	// Every log of the blocks up to synced was delivered, and the logs of the
+	// block after it are delivered as they come.
+	var (
+		synced     *uint64
+		current    uint64
+		seen       = make(map[uint]struct{})
+		subscribed = false
+	)
+	deliver := func(l types.Log) error {
+		if !l.Removed {
+			if l.BlockNumber != current {
+				current, seen = l.BlockNumber, make(map[uint]struct{})
+			}
+			if _, ok := seen[l.Index]; ok {
+				return nil
+			}
+			seen[l.Index] = struct{}{}
+			if l.BlockNumber > 0 && (synced == nil || *synced < l.BlockNumber-1) {
+				n := l.BlockNumber - 1
+				synced = &n
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case out <- l:
+		}
+		return nil
+	}
+
+	return s.loop(ctx, "logs", func(c *ethclient.Client) error {
+		logs := make(chan types.Log, logsBackfillPage)
+		sub, err := c.SubscribeFilterLogs(ctx, q, logs)
+		if err != nil {
+			return err
+		}
+		defer sub.Unsubscribe()
+
+		head, err := s.backfill.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		if subscribed && synced != nil {
+			log.Info().Uint64("from", *synced+1).Uint64("to", head).Msg("Backfilling the missed logs")
+			for from := *synced + 1; from <= head; from += logsBackfillPage {
+				page := q
+				page.FromBlock = new(big.Int).SetUint64(from)
+				page.ToBlock = new(big.Int).SetUint64(min(from+logsBackfillPage-1, head))
+				missed, err := s.backfill.FilterLogs(ctx, page)
+				if err != nil {
+					return err
+				}
+				for _, l := range missed {
+					if err = deliver(l); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		subscribed = true
+		if synced == nil || *synced < head {
+			synced = &head
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case err = <-sub.Err():
+				return err
+			case l := <-logs:
+				if err = deliver(l); err != nil {
+					return err
+				}
+			}
+		}
+	})
+}
+
+// PendingTransactions delivers the hashes of the new pending transactions
+// until the context is done. The pending transactions seen by the node while
+// disconnected can't be backfilled.
+func (s *Subscriber) PendingTransactions(ctx context.Context, out chan<- common.Hash) error {
+	connected := false
+	return s.loop(ctx, "newPendingTransactions", func(c *ethclient.Client) error {
+		hashes := make(chan common.Hash, recentHeads)
+		sub, err := c.Client().EthSubscribe(ctx, hashes, "newPendingTransactions")
+		if err != nil {
+			return err
+		}
+		defer sub.Unsubscribe()
+		if connected {
+			log.Warn().Msg("The pending transactions seen while disconnected are missed")
+		}
+		connected = true
+
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case err = <-sub.Err():
+				return err
+			case h := <-hashes:
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case out <- h:
+				}
+			}
+		}
+	})
+}
+
+// loop runs the subscription session, connecting again with exponential
+// backoff every time it ends until the context is done.
+func (s *Subscriber) loop(ctx context.Context, name string, session func(c *ethclient.Client) error) error {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = initialReconnectInterval
+	b.MaxInterval = maxReconnectInterval
+	b.MaxElapsedTime = 0
+	for {
+		start := time.Now()
+		c, err := ethclient.DialContext(ctx, s.url)
+		if err == nil {
+			err = session(c)
+			c.Close()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// A session which lasted is a recovered connection, not a failing one.
+		if time.Since(start) > maxReconnectInterval {
+			b.Reset()
+		}
+		wait := b.NextBackOff()
+		log.Warn().Err(err).Str("subscription", name).Dur("wait", wait).Msg("The subscription ended, reconnecting")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package rpcclient
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// testChain is the chain served by the test endpoints. The heads and the
+// logs are only sent to the subscribers when published, so the ones added in
+// between are missed and must be backfilled.
+type testChain struct {
+	mu         sync.Mutex
+	headers    []*types.Header
+	logs       []types.Log
+	subs       map[string]*testSubscription
+	subscribed chan string
+	// queried receives the heads returned by eth_blockNumber.
+	queried chan uint64
+}
+
+type testSubscription struct {
+	notifier *ethrpc.Notifier
+	id       ethrpc.ID
+}
+
+func newTestChain(head uint64) *testChain {
+	c := &testChain{subs: make(map[string]*testSubscription), subscribed: make(chan string, 8), queried: make(chan uint64, 8)}
+	c.extend(head)
+	return c
+}
+
+func (c *testChain) extend(head uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for n := uint64(len(c.headers)); n <= head; n++ {
+		h := &types.Header{Number: new(big.Int).SetUint64(n), Difficulty: common.Big0, Time: n}
+		if n > 0 {
+			h.ParentHash = c.headers[n-1].Hash()
+		}
+		c.headers = append(c.headers, h)
+	}
+}
+
+func (c *testChain) header(n uint64) *types.Header {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n >= uint64(len(c.headers)) {
+		return nil
+	}
+	return c.headers[n]
+}
+
+func (c *testChain) addLog(block uint64, index uint) types.Log {
+	c.extend(block)
+	l := types.Log{
+		Address:     common.HexToAddress("0x01"),
+		Topics:      []common.Hash{common.HexToHash("0x02")},
+		Data:        []byte{1},
+		BlockNumber: block,
+		BlockHash:   c.header(block).Hash(),
+		Index:       index,
+	}
+	c.mu.Lock()
+	c.logs = append(c.logs, l)
+	c.mu.Unlock()
+	return l
+}
+
+func (c *testChain) publish(t *testing.T, name string, v any) {
+	c.mu.Lock()
+	sub := c.subs[name]
+	c.mu.Unlock()
+	if err := sub.notifier.Notify(sub.id, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (c *testChain) subscribe(ctx context.Context, name string) (*ethrpc.Subscription, error) {
+	notifier, ok := ethrpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, ethrpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	c.mu.Lock()
+	c.subs[name] = &testSubscription{notifier: notifier, id: sub.ID}
+	c.mu.Unlock()
+	c.subscribed <- name
+	return sub, nil
+}
+
+// testEthService serves the methods used by the subscriber.
+type testEthService struct {
+	chain *testChain
+}
+
+func (s *testEthService) BlockNumber() hexutil.Uint64 {
+	s.chain.mu.Lock()
+	head := uint64(len(s.chain.headers) - 1)
+	s.chain.mu.Unlock()
+	select {
+	case s.chain.queried <- head:
+	default:
+	}
+	return hexutil.Uint64(head)
+}
+
+func (s *testEthService) GetBlockByNumber(n ethrpc.BlockNumber, full bool) *types.Header {
+	if n < 0 {
+		s.chain.mu.Lock()
+		n = ethrpc.BlockNumber(len(s.chain.headers) - 1)
+		s.chain.mu.Unlock()
+	}
+	return s.chain.header(uint64(n))
+}
+
+func (s *testEthService) GetLogs(crit map[string]any) ([]types.Log, error) {
+	from, err := hexutil.DecodeUint64(crit["fromBlock"].(string))
+	if err != nil {
+		return nil, err
+	}
+	to, err := hexutil.DecodeUint64(crit["toBlock"].(string))
+	if err != nil {
+		return nil, err
+	}
+	s.chain.mu.Lock()
+	defer s.chain.mu.Unlock()
+	logs := []types.Log{}
+	for _, l := range s.chain.logs {
+		if l.BlockNumber >= from && l.BlockNumber <= to {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func (s *testEthService) NewHeads(ctx context.Context) (*ethrpc.Subscription, error) {
+	return s.chain.subscribe(ctx, "newHeads")
+}
+
+func (s *testEthService) Logs(ctx context.Context, crit map[string]any) (*ethrpc.Subscription, error) {
+	return s.chain.subscribe(ctx, "logs")
+}
+
+// dropListener can close the accepted connections, which drops the
+// WebSocket connections mid-stream.
+type dropListener struct {
+	net.Listener
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+func (l *dropListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err == nil {
+		l.mu.Lock()
+		l.conns = append(l.conns, c)
+		l.mu.Unlock()
+	}
+	return c, err
+}
+
+func (l *dropListener) drop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, c := range l.conns {
+		c.Close()
+	}
+	l.conns = nil
+}
+
+// newTestSubscriber returns a subscriber to the WebSocket endpoint of the
+// chain backfilling over its HTTP endpoint.
+func newTestSubscriber(t *testing.T, chain *testChain) (*Subscriber, *dropListener) {
+	srv := ethrpc.NewServer()
+	if err := srv.RegisterName("eth", &testEthService{chain: chain}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Stop)
+
+	httpSrv := httptest.NewServer(srv)
+	t.Cleanup(httpSrv.Close)
+	backfill, err := ethrpc.Dial(httpSrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(backfill.Close)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener := &dropListener{Listener: l}
+	wsSrv := httptest.NewUnstartedServer(srv.WebsocketHandler([]string{"*"}))
+	wsSrv.Listener.Close()
+	wsSrv.Listener = listener
+	wsSrv.Start()
+	t.Cleanup(wsSrv.Close)
+	t.Cleanup(listener.drop)
+
+	return NewSubscriber("ws://"+l.Addr().String(), backfill), listener
+}
+
+func receive[T any](t *testing.T, ctx context.Context, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the subscription")
+	}
+	var zero T
+	return zero
+}
+
+func TestNewHeads(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	chain := newTestChain(2)
+	sub, listener := newTestSubscriber(t, chain)
+
+	heads := make(chan *types.Header)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- sub.NewHeads(ctx, heads)
+	}()
+	expect := func(numbers ...uint64) {
+		t.Helper()
+		for _, n := range numbers {
+			h := receive(t, ctx, heads)
+			if h.Number.Uint64() != n || h.Hash() != chain.header(n).Hash() {
+				t.Fatalf("expected the head %d, got %d", n, h.Number)
+			}
+		}
+	}
+
+	receive(t, ctx, chain.subscribed)
+	chain.publish(t, "newHeads", chain.header(1))
+	chain.publish(t, "newHeads", chain.header(2))
+	expect(1, 2)
+
+	// The heads 3 and 4 are missed while disconnected. The head 4, received
+	// again once subscribed, is a duplicate of the backfilled one.
+	chain.extend(4)
+	listener.drop()
+	receive(t, ctx, chain.subscribed)
+	chain.extend(5)
+	chain.publish(t, "newHeads", chain.header(4))
+	chain.publish(t, "newHeads", chain.header(5))
+	expect(3, 4, 5)
+
+	// A gap is backfilled without disconnection.
+	chain.extend(7)
+	chain.publish(t, "newHeads", chain.header(7))
+	expect(6, 7)
+
+	cancel()
+	if err := <-errCh; err != context.Canceled {
+		t.Fatalf("expected the subscription to end with the context, got %v", err)
+	}
+}
+
+func TestLogs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	chain := newTestChain(2)
+	sub, listener := newTestSubscriber(t, chain)
+
+	logs := make(chan types.Log)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- sub.Logs(ctx, ethereum.FilterQuery{}, logs)
+	}()
+	expect := func(events ...[2]uint64) {
+		t.Helper()
+		for _, e := range events {
+			l := receive(t, ctx, logs)
+			if l.BlockNumber != e[0] || uint64(l.Index) != e[1] {
+				t.Fatalf("expected the log %d of block %d, got the log %d of block %d", e[1], e[0], l.Index, l.BlockNumber)
+			}
+		}
+	}
+
+	// The logs are delivered from the head the subscriber starts at.
+	receive(t, ctx, chain.subscribed)
+	if head := receive(t, ctx, chain.queried); head != 2 {
+		t.Fatalf("expected the subscription to start at the head 2, got %d", head)
+	}
+	chain.publish(t, "logs", chain.addLog(3, 0))
+	expect([2]uint64{3, 0})
+
+	// The logs emitted while disconnected are backfilled, skipping the one
+	// already delivered, and the live log of a backfilled block is skipped.
+	listener.drop()
+	chain.addLog(3, 1)
+	live := chain.addLog(4, 0)
+	receive(t, ctx, chain.subscribed)
+	receive(t, ctx, chain.queried)
+	chain.publish(t, "logs", live)
+	chain.publish(t, "logs", chain.addLog(5, 0))
+	expect([2]uint64{3, 1}, [2]uint64{4, 0}, [2]uint64{5, 0})
+
+	cancel()
+	if err := <-errCh; err != context.Canceled {
+		t.Fatalf("expected the subscription to end with the context, got %v", err)
+	}
+}
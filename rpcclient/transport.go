@@ -0,0 +1,178 @@
+package rpcclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
+)
+
+const (
+	initialRetryInterval = 250 * time.Millisecond
+	maxRetryInterval     = 10 * time.Second
+	// maxLoggedBody is the number of bytes of the requests and responses logged.
+	maxLoggedBody = 2048
+)
+
+// readMethods are the idempotent methods, which only read the state of the
+// node, besides the ones starting with readMethodPrefixes.
+var readMethods = map[string]bool{
+	"eth_blobBaseFee":          true,
+	"eth_blockNumber":          true,
+	"eth_call":                 true,
+	"eth_chainId":              true,
+	"eth_estimateGas":          true,
+	"eth_feeHistory":           true,
+	"eth_gasPrice":             true,
+	"eth_maxPriorityFeePerGas": true,
+	"eth_syncing":              true,
+	"admin_nodeInfo":           true,
+	"admin_peers":              true,
+}
+
+var readMethodPrefixes = []string{"eth_get", "debug_trace", "debug_getRaw", "trace_", "txpool_", "net_", "web3_", "bor_get", "zkevm_"}
+
+func isReadMethod(method string) bool {
+	if readMethods[method] {
+		return true
+	}
+	for _, prefix := range readMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReadRequest returns whether every call of the JSON-RPC request, a single
+// call or a batch, is a read method.
+func isReadRequest(body []byte) bool {
+	var calls []struct{ Method string }
+	if err := json.Unmarshal(body, &calls); err != nil {
+		var call struct{ Method string }
+		if err = json.Unmarshal(body, &call); err != nil {
+			return false
+		}
+		calls = append(calls, call)
+	}
+	for _, c := range calls {
+		if !isReadMethod(c.Method) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNotSent returns whether the request failed before reaching the node,
+// when the connection couldn't be established.
+func isNotSent(err error) bool {
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return (errors.As(err, &opErr) && opErr.Op == "dial") || errors.As(err, &dnsErr)
+}
+
+// transport rate limits the HTTP requests to the RPC endpoint and retries the
+// failed ones. The requests which only call read methods are retried on any
+// failure, the others, e.g. eth_sendRawTransaction, only when the node didn't
+// receive them, so a transaction is never sent twice.
+type transport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+	retries uint64
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body is read once so it can be sent again on retries.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+	log.Trace().Str("url", req.URL.Redacted()).Str("body", truncate(body)).Msg("RPC request")
+	read := isReadRequest(body)
+
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = initialRetryInterval
+	b.MaxInterval = maxRetryInterval
+	b.MaxElapsedTime = 0
+	for attempt := uint64(0); ; attempt++ {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		r := req.Clone(req.Context())
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+
+		start := time.Now()
+		resp, err := t.base.RoundTrip(r)
+		retryable := err != nil && req.Context().Err() == nil && (read || isNotSent(err))
+		if err == nil {
+			retryable = resp.StatusCode == http.StatusTooManyRequests || (read && resp.StatusCode >= http.StatusInternalServerError)
+		}
+		if !retryable || attempt >= t.retries {
+			if err == nil {
+				resp, err = logResponse(resp, time.Since(start))
+			}
+			return resp, err
+		}
+
+		wait := b.NextBackOff()
+		if err == nil {
+			wait = max(wait, retryAfter(resp))
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			err = errors.New(resp.Status)
+		}
+		log.Debug().Err(err).Uint64("attempt", attempt+1).Dur("wait", wait).Msg("Retrying the RPC request")
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// logResponse logs the response at the trace level, reading its body when
+// the trace level is enabled.
+func logResponse(resp *http.Response, elapsed time.Duration) (*http.Response, error) {
+	if zerolog.GlobalLevel() > zerolog.TraceLevel {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	log.Trace().Int("status", resp.StatusCode).Dur("elapsed", elapsed).Str("body", truncate(body)).Msg("RPC response")
+	return resp, nil
+}
+
+// retryAfter returns the delay requested by the Retry-After header, in seconds.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func truncate(body []byte) string {
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + "..."
+	}
+	return string(body)
+}
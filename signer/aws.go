@@ -0,0 +1,40 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// NewAWSKMSSigner returns a signer using an AWS KMS `ECC_SECG_P256K1` key,
+// identified by its ID, ARN or alias. The credentials and the region come from
+// the default AWS configuration, e.g. `AWS_PROFILE` and `AWS_REGION`.
+func NewAWSKMSSigner(ctx context.Context, keyID string) (Signer, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the AWS configuration: %w", err)
+	}
+	client := kms.NewFromConfig(cfg)
+
+	res, err := client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the public key of %s: %w", keyID, err)
+	}
+
+	return newKMSSigner(res.PublicKey, func(ctx context.Context, digest []byte) ([]byte, error) {
+		result, err := client.Sign(ctx, &kms.SignInput{
+			KeyId:            aws.String(keyID),
+			Message:          digest,
+			MessageType:      kmstypes.MessageTypeDigest,
+			SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign digest: %w", err)
+		}
+		return result.Signature, nil
+	})
+}
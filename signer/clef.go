@@ -0,0 +1,163 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ClefSigner delegates the signatures to an external signer speaking the Clef
+// JSON-RPC API, so the key never leaves the signer. Every request may have to
+// be approved on the signer, depending on its rules.
+type ClefSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewClefSigner returns a signer using the account of the external signer at
+// the url, either HTTP or IPC. The account may be omitted when the signer
+// holds a single one.
+func NewClefSigner(ctx context.Context, url, address string) (*ClefSigner, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial the signer %s: %w", url, err)
+	}
+	addresses, err := listClefAccounts(ctx, client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	switch {
+	case address != "":
+		if !common.IsHexAddress(address) {
+			client.Close()
+			return nil, fmt.Errorf("the key id must be the address of the signer account")
+		}
+		account := common.HexToAddress(address)
+		for _, a := range addresses {
+			if a == account {
+				return &ClefSigner{client: client, address: account}, nil
+			}
+		}
+		client.Close()
+		return nil, fmt.Errorf("the account %s isn't available on the signer", account)
+	case len(addresses) == 1:
+		return &ClefSigner{client: client, address: addresses[0]}, nil
+	default:
+		client.Close()
+		return nil, fmt.Errorf("the signer has %d accounts, select one with the key id", len(addresses))
+	}
+}
+
+// ListClefAccounts returns the accounts of the external signer at the url.
+func ListClefAccounts(ctx context.Context, url string) ([]common.Address, error) {
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial the signer %s: %w", url, err)
+	}
+	defer client.Close()
+	return listClefAccounts(ctx, client)
+}
+
+func listClefAccounts(ctx context.Context, client *rpc.Client) ([]common.Address, error) {
+	var addresses []common.Address
+	if err := client.CallContext(ctx, &addresses, "account_list"); err != nil {
+		return nil, fmt.Errorf("unable to list the accounts of the signer: %w", err)
+	}
+	return addresses, nil
+}
+
+// Close closes the connection to the signer.
+func (c *ClefSigner) Close() {
+	c.client.Close()
+}
+
+func (c *ClefSigner) Address() common.Address {
+	return c.address
+}
+
+// SignTx sends the fields of the transaction to account_signTransaction. The
+// signer only signs legacy, access list and dynamic fee transactions.
+func (c *ClefSigner) SignTx(ctx context.Context, tx *types.Transaction, s types.Signer) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := apitypes.SendTxArgs{
+		From:    common.NewMixedcaseAddress(c.address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(s.ChainID()),
+	}
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	default:
+		return nil, fmt.Errorf("the signer doesn't support transactions of type %d", tx.Type())
+	}
+	if tx.Type() != types.LegacyTxType {
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	}
+
+	var res struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := c.client.CallContext(ctx, &res, "account_signTransaction", args); err != nil {
+		return nil, err
+	}
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(res.Raw); err != nil {
+		return nil, fmt.Errorf("unable to decode the signed transaction: %w", err)
+	}
+	// The signer may be configured for another chain, or rewrite the
+	// transaction.
+	from, err := types.Sender(s, signed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature from the signer: %w", err)
+	}
+	if from != c.address || signed.Nonce() != tx.Nonce() {
+		return nil, fmt.Errorf("the signer returned a transaction of %s with nonce %d instead of %s with nonce %d", from, signed.Nonce(), c.address, tx.Nonce())
+	}
+	return signed, nil
+}
+
+func (c *ClefSigner) SignMessage(ctx context.Context, msg []byte) ([]byte, error) {
+	address := common.NewMixedcaseAddress(c.address)
+	return c.signData(ctx, "account_signData", accounts.MimetypeTextPlain, &address, hexutil.Encode(msg))
+}
+
+func (c *ClefSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	address := common.NewMixedcaseAddress(c.address)
+	return c.signData(ctx, "account_signTypedData", &address, typedData)
+}
+
+// signData returns the signature of the method with V set to 27 or 28, which
+// depends on the version of the signer.
+func (c *ClefSigner) signData(ctx context.Context, method string, args ...any) ([]byte, error) {
+	var sig hexutil.Bytes
+	if err := c.client.CallContext(ctx, &sig, method, args...); err != nil {
+		return nil, err
+	}
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("the signer returned a signature of %d bytes", len(sig))
+	}
+	if sig[crypto.RecoveryIDOffset] < 27 {
+		sig[crypto.RecoveryIDOffset] += 27
+	}
+	return sig, nil
+}
@@ -0,0 +1,110 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// testClef emulates the account namespace of Clef with a private key. Its
+// data signatures have V set to 0 or 1.
+type testClef struct {
+	local *PrivateKeySigner
+}
+
+func (c *testClef) List() []common.Address {
+	return []common.Address{c.local.Address()}
+}
+
+func (c *testClef) SignTransaction(ctx context.Context, args apitypes.SendTxArgs) (map[string]any, error) {
+	tx, err := c.local.SignTx(ctx, args.ToTransaction(), types.LatestSignerForChainID(args.ChainID.ToInt()))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"raw": hexutil.Bytes(raw), "tx": tx}, nil
+}
+
+func (c *testClef) SignData(ctx context.Context, contentType string, _ common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	sig, err := c.local.SignMessage(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] -= 27
+	return sig, nil
+}
+
+func (c *testClef) SignTypedData(ctx context.Context, _ common.MixedcaseAddress, typedData apitypes.TypedData) (hexutil.Bytes, error) {
+	return c.local.SignTypedData(ctx, typedData)
+}
+
+func TestClefSignerMatchesPrivateKeySigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := NewPrivateKeySigner(key)
+	server := rpc.NewServer()
+	if err = server.RegisterName("account", &testClef{local: local}); err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	ctx := context.Background()
+	c, err := NewClefSigner(ctx, httpServer.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	if c.Address() != local.Address() {
+		t.Fatalf("address does not match, got %v", c.Address())
+	}
+	if _, err = NewClefSigner(ctx, httpServer.URL, common.Address{1}.Hex()); err == nil {
+		t.Fatal("an account missing from the signer was selected")
+	}
+
+	txSigner := types.LatestSignerForChainID(big.NewInt(1337))
+	to := common.Address{2}
+	for _, tx := range []*types.Transaction{
+		types.NewTx(&types.LegacyTx{Nonce: 1, Gas: 21000, GasPrice: big.NewInt(1), To: &to}),
+		types.NewTx(&types.DynamicFeeTx{Nonce: 2, Gas: 21000, GasFeeCap: big.NewInt(2), GasTipCap: big.NewInt(1), Data: []byte{1}}),
+	} {
+		signedTx, err := c.SignTx(ctx, tx, txSigner)
+		if err != nil {
+			t.Fatalf("could not sign tx: %v", err)
+		}
+		expected, err := local.SignTx(ctx, tx, txSigner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if signedTx.Hash() != expected.Hash() {
+			t.Fatalf("signed tx %v does not match %v", signedTx.Hash(), expected.Hash())
+		}
+	}
+
+	msg := []byte("hello")
+	sig, err := c.SignMessage(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := local.SignMessage(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sig, expected) {
+		t.Fatalf("message signature %x does not match %x", sig, expected)
+	}
+}
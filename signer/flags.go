@@ -0,0 +1,86 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/pflag"
+)
+
+// Flags are the command line flags selecting the backend which holds the
+// signing key of a command.
+type Flags struct {
+	Keystore         *string
+	KeystorePassword *string
+	KMS              *string
+	KeyID            *string
+	Ledger           *string
+	SignerURL        *string
+}
+
+// AddFlags defines the flags selecting the signing backend on the flag set.
+func AddFlags(flagSet *pflag.FlagSet) *Flags {
+	f := new(Flags)
+	f.Keystore = flagSet.String("keystore", "", "Sign with the account of the keystore in the given folder, selected by --key-id")
+	f.KeystorePassword = flagSet.String("keystore-password", "", "The password unlocking the keystore account")
+	f.KMS = flagSet.String("kms", "", "Sign with a key stored in a cloud KMS: AWS or GCP, selected by --key-id")
+	f.KeyID = flagSet.String("key-id", "", "The keystore or external signer account address, the AWS KMS key ID or the GCP KMS key version name")
+	f.Ledger = flagSet.String("ledger", "", "Sign with the account of a Ledger device at the derivation path")
+	flagSet.Lookup("ledger").NoOptDefVal = accounts.DefaultBaseDerivationPath.String()
+	f.SignerURL = flagSet.String("signer-url", "", "Delegate the signatures to an external signer speaking the Clef API at the HTTP url or IPC path, with the account selected by --key-id")
+	return f
+}
+
+// IsSet reports whether a backend is selected by the flags.
+func (f *Flags) IsSet() bool {
+	return *f.Keystore != "" || *f.KMS != "" || *f.Ledger != "" || *f.SignerURL != ""
+}
+
+// New returns the signer of the backend selected by the flags. When no backend
+// is selected, the hex encoded private key is used.
+func (f *Flags) New(ctx context.Context, privateKey string) (Signer, error) {
+	backends := 0
+	for _, v := range []string{*f.Keystore, *f.KMS, *f.Ledger, *f.SignerURL} {
+		if v != "" {
+			backends++
+		}
+	}
+	if backends > 1 {
+		return nil, fmt.Errorf("only one of --keystore, --kms, --ledger and --signer-url can be used")
+	}
+
+	switch {
+	case *f.Keystore != "":
+		if !common.IsHexAddress(*f.KeyID) {
+			return nil, fmt.Errorf("the --key-id flag must be the address of the keystore account")
+		}
+		return NewKeystoreSigner(*f.Keystore, common.HexToAddress(*f.KeyID), *f.KeystorePassword)
+	case *f.KMS != "":
+		return NewKMSSigner(ctx, *f.KMS, *f.KeyID)
+	case *f.Ledger != "":
+		return NewLedgerSigner(*f.Ledger)
+	case *f.SignerURL != "":
+		return NewClefSigner(ctx, *f.SignerURL, *f.KeyID)
+	default:
+		return NewHexSigner(privateKey)
+	}
+}
+
+// NewKMSSigner returns a signer using the key of the cloud KMS, either AWS or
+// GCP.
+func NewKMSSigner(ctx context.Context, kms, keyID string) (Signer, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("a key id is required")
+	}
+	switch strings.ToUpper(kms) {
+	case "AWS":
+		return NewAWSKMSSigner(ctx, keyID)
+	case "GCP":
+		return NewGCPKMSSigner(ctx, keyID)
+	default:
+		return nil, fmt.Errorf("the KMS %s is not supported", kms)
+	}
+}
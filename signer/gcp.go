@@ -0,0 +1,59 @@
+package signer
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"hash/crc32"
+
+	kms "cloud.google.com/go/kms/apiv1"
+	"cloud.google.com/go/kms/apiv1/kmspb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// NewGCPKMSSigner returns a signer using a GCP KMS key version, named
+// `projects/<project>/locations/<location>/keyRings/<keyring>/cryptoKeys/<key>/cryptoKeyVersions/<version>`.
+// The credentials are the application default credentials.
+func NewGCPKMSSigner(ctx context.Context, name string) (Signer, error) {
+	client, err := kms.NewKeyManagementClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kms client: %w", err)
+	}
+
+	res, err := client.GetPublicKey(ctx, &kmspb.GetPublicKeyRequest{Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the public key of %s: %w", name, err)
+	}
+	block, _ := pem.Decode([]byte(res.Pem))
+	if block == nil {
+		return nil, fmt.Errorf("unable to decode the public key of %s", name)
+	}
+
+	return newKMSSigner(block.Bytes, func(ctx context.Context, digest []byte) ([]byte, error) {
+		req := &kmspb.AsymmetricSignRequest{
+			Name: name,
+			Digest: &kmspb.Digest{
+				Digest: &kmspb.Digest_Sha256{Sha256: digest},
+			},
+			DigestCrc32C: wrapperspb.Int64(int64(crc32c(digest))),
+		}
+		result, err := client.AsymmetricSign(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign digest: %w", err)
+		}
+
+		// Perform integrity verification on the result.
+		// https://cloud.google.com/kms/docs/data-integrity-guidelines
+		if !result.VerifiedDigestCrc32C || result.Name != req.Name {
+			return nil, fmt.Errorf("AsymmetricSign: request corrupted in-transit")
+		}
+		if int64(crc32c(result.Signature)) != result.SignatureCrc32C.Value {
+			return nil, fmt.Errorf("AsymmetricSign: response corrupted in-transit")
+		}
+		return result.Signature, nil
+	})
+}
+
+func crc32c(data []byte) uint32 {
+	return crc32.Checksum(data, crc32.MakeTable(crc32.Castagnoli))
+}
@@ -0,0 +1,119 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// secp256k1 group order, used to normalize the S value of KMS signatures.
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// The DER encoded public key returned by the KMS.
+type publicKeyInfo struct {
+	Raw       asn1.RawContent
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// kmsSigner signs with a secp256k1 key which never leaves a cloud KMS. The KMS
+// only signs digests and returns DER encoded signatures without recovery ID,
+// so the signature is converted and the recovery ID is found by matching the
+// recovered public key.
+type kmsSigner struct {
+	// The uncompressed public key.
+	publicKey []byte
+	address   common.Address
+	// signDigest returns the DER encoded signature of the 32 bytes digest.
+	signDigest func(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+func newKMSSigner(derPublicKey []byte, signDigest func(context.Context, []byte) ([]byte, error)) (*kmsSigner, error) {
+	var info publicKeyInfo
+	if _, err := asn1.Unmarshal(derPublicKey, &info); err != nil {
+		return nil, fmt.Errorf("unable to parse the public key: %w", err)
+	}
+	pub, err := crypto.UnmarshalPubkey(info.PublicKey.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("the key is not a secp256k1 key: %w", err)
+	}
+	return &kmsSigner{
+		publicKey:  info.PublicKey.Bytes,
+		address:    crypto.PubkeyToAddress(*pub),
+		signDigest: signDigest,
+	}, nil
+}
+
+func (k *kmsSigner) Address() common.Address {
+	return k.address
+}
+
+func (k *kmsSigner) SignTx(ctx context.Context, tx *types.Transaction, s types.Signer) (*types.Transaction, error) {
+	sig, err := k.sign(ctx, s.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(s, sig)
+}
+
+func (k *kmsSigner) SignMessage(ctx context.Context, msg []byte) ([]byte, error) {
+	sig, err := k.sign(ctx, accounts.TextHash(msg))
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+func (k *kmsSigner) SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := k.sign(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+// Return the [R || S || V] signature of the digest, V being 0 or 1.
+func (k *kmsSigner) sign(ctx context.Context, digest []byte) ([]byte, error) {
+	der, err := k.signDigest(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	var parsed struct{ R, S *big.Int }
+	if _, err = asn1.Unmarshal(der, &parsed); err != nil {
+		return nil, fmt.Errorf("unable to parse the signature: %w", err)
+	}
+	// Ethereum only accepts signatures in the lower half of the curve order.
+	if parsed.S.Cmp(secp256k1HalfN) > 0 {
+		parsed.S = new(big.Int).Sub(secp256k1N, parsed.S)
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	parsed.R.FillBytes(sig[:32])
+	parsed.S.FillBytes(sig[32:64])
+	for v := byte(0); v < 2; v++ {
+		sig[crypto.RecoveryIDOffset] = v
+		pub, err := crypto.Ecrecover(digest, sig)
+		if err == nil && bytes.Equal(pub, k.publicKey) {
+			return sig, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to determine the recovery identifier of the signature")
+}
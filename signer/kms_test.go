@@ -0,0 +1,74 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Emulate a KMS holding the key, optionally returning signatures with a high S.
+func newTestKMSSigner(t *testing.T, key *ecdsa.PrivateKey, highS bool) *kmsSigner {
+	der, err := asn1.Marshal(publicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}},
+		PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(&key.PublicKey), BitLength: 520},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := newKMSSigner(der, func(_ context.Context, digest []byte) ([]byte, error) {
+		sig, err := crypto.Sign(digest, key)
+		if err != nil {
+			return nil, err
+		}
+		s := new(big.Int).SetBytes(sig[32:64])
+		if highS {
+			s.Sub(secp256k1N, s)
+		}
+		return asn1.Marshal(struct{ R, S *big.Int }{new(big.Int).SetBytes(sig[:32]), s})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestKMSSignerMatchesPrivateKeySigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := NewPrivateKeySigner(key)
+	txSigner := types.LatestSignerForChainID(big.NewInt(1337))
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 1, Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)})
+
+	for _, highS := range []bool{false, true} {
+		k := newTestKMSSigner(t, key, highS)
+		if k.Address() != local.Address() {
+			t.Fatalf("address does not match, got %v", k.Address())
+		}
+
+		signedTx, err := k.SignTx(context.Background(), tx, txSigner)
+		if err != nil {
+			t.Fatalf("could not sign tx: %v", err)
+		}
+		from, err := types.Sender(txSigner, signedTx)
+		if err != nil || from != local.Address() {
+			t.Errorf("tx sender does not match, got %v: %v", from, err)
+		}
+
+		sig, err := k.SignMessage(context.Background(), []byte("hello"))
+		if err != nil {
+			t.Fatalf("could not sign message: %v", err)
+		}
+		expected, _ := local.SignMessage(context.Background(), []byte("hello"))
+		if string(sig) != string(expected) {
+			t.Errorf("message signature does not match, got %x", sig)
+		}
+	}
+}
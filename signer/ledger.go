@@ -0,0 +1,89 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// ErrLedgerPersonalMessage is returned when signing a personal message with a
+// Ledger, which the USB driver of go-ethereum doesn't support.
+var ErrLedgerPersonalMessage = errors.New("the Ledger signer can't sign personal messages, only transactions and typed data")
+
+// LedgerSigner signs with the Ethereum app of a Ledger device. Every signature
+// has to be confirmed on the device.
+type LedgerSigner struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+}
+
+// NewLedgerSigner returns a signer using the account of the first connected
+// Ledger device at the derivation path, e.g. `m/44'/60'/0'/0/0`.
+func NewLedgerSigner(path string) (*LedgerSigner, error) {
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid derivation path %s: %w", path, err)
+	}
+	hub, err := usbwallet.NewLedgerHub()
+	if err != nil {
+		return nil, fmt.Errorf("unable to access the USB devices: %w", err)
+	}
+	wallets := hub.Wallets()
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("no Ledger device found")
+	}
+
+	wallet := wallets[0]
+	if err = wallet.Open(""); err != nil {
+		return nil, fmt.Errorf("unable to open the Ledger device, make sure it's unlocked with the Ethereum app open: %w", err)
+	}
+	account, err := wallet.Derive(derivationPath, true)
+	if err != nil {
+		_ = wallet.Close()
+		return nil, fmt.Errorf("unable to derive the account at %s: %w", path, err)
+	}
+	return &LedgerSigner{wallet: wallet, account: account}, nil
+}
+
+// Close releases the device.
+func (l *LedgerSigner) Close() error {
+	return l.wallet.Close()
+}
+
+func (l *LedgerSigner) Address() common.Address {
+	return l.account.Address
+}
+
+func (l *LedgerSigner) SignTx(_ context.Context, tx *types.Transaction, s types.Signer) (*types.Transaction, error) {
+	return l.wallet.SignTx(l.account, tx, s.ChainID())
+}
+
+// SignMessage always fails, the USB driver only signs transactions and typed
+// data.
+func (l *LedgerSigner) SignMessage(_ context.Context, msg []byte) ([]byte, error) {
+	return nil, ErrLedgerPersonalMessage
+}
+
+// SignTypedData sends the domain separator and the hash of the message to the
+// device, which displays them for confirmation.
+func (l *LedgerSigner) SignTypedData(_ context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	_, rawData, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := l.wallet.SignData(l.account, accounts.MimetypeTypedData, []byte(rawData))
+	if err != nil {
+		return nil, err
+	}
+	if sig[crypto.RecoveryIDOffset] < 27 {
+		sig[crypto.RecoveryIDOffset] += 27
+	}
+	return sig, nil
+}
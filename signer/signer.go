@@ -0,0 +1,136 @@
+// Package signer signs transactions and messages with keys held by different
+// backends: a hex encoded private key, a geth keystore, a cloud KMS (AWS or
+// GCP), a Ledger device or an external signer like Clef.
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/maticnetwork/polygon-cli/gethkeystore"
+)
+
+// Signer signs on behalf of a single account.
+type Signer interface {
+	// Address returns the address of the account.
+	Address() common.Address
+	// SignTx returns a copy of the transaction signed with the given signer.
+	SignTx(ctx context.Context, tx *types.Transaction, s types.Signer) (*types.Transaction, error)
+	// SignMessage returns the 65 bytes [R || S || V] signature of the EIP-191
+	// personal message. V is 27 or 28, as expected by `personal_sign`.
+	SignMessage(ctx context.Context, msg []byte) ([]byte, error)
+	// SignTypedData returns the 65 bytes [R || S || V] signature of the
+	// EIP-712 typed data. V is 27 or 28, as expected by `eth_signTypedData_v4`.
+	SignTypedData(ctx context.Context, typedData apitypes.TypedData) ([]byte, error)
+}
+
+// PrivateKeySigner signs with a private key held in memory.
+type PrivateKeySigner struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewPrivateKeySigner returns a signer using the private key.
+func NewPrivateKeySigner(key *ecdsa.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key}
+}
+
+// NewHexSigner returns a signer using the hex encoded private key, with or
+// without the 0x prefix.
+func NewHexSigner(hexKey string) (*PrivateKeySigner, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the private key: %w", err)
+	}
+	return NewPrivateKeySigner(key), nil
+}
+
+// NewKeystoreSigner returns a signer using the account of the keystore
+// directory matching the address.
+func NewKeystoreSigner(dir string, address common.Address, password string) (*PrivateKeySigner, error) {
+	ks := keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP)
+	account, err := ks.Find(accounts.Account{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the account %s in %s: %w", address, dir, err)
+	}
+	encryptedKey, err := os.ReadFile(account.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := gethkeystore.DecryptKeystoreFile(encryptedKey, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKeySigner(key), nil
+}
+
+func (p *PrivateKeySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(p.key.PublicKey)
+}
+
+func (p *PrivateKeySigner) SignTx(_ context.Context, tx *types.Transaction, s types.Signer) (*types.Transaction, error) {
+	return types.SignTx(tx, s, p.key)
+}
+
+func (p *PrivateKeySigner) SignMessage(_ context.Context, msg []byte) ([]byte, error) {
+	sig, err := crypto.Sign(accounts.TextHash(msg), p.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+func (p *PrivateKeySigner) SignTypedData(_ context.Context, typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(hash, p.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+// TransactOpts returns the options to send transactions through contract
+// bindings using the signer. It's the counterpart of geth's
+// `bind.NewKeyedTransactorWithChainID`.
+func TransactOpts(ctx context.Context, s Signer, chainID *big.Int) (*bind.TransactOpts, error) {
+	if chainID == nil {
+		return nil, bind.ErrNoChainID
+	}
+	txSigner := types.LatestSignerForChainID(chainID)
+	return &bind.TransactOpts{
+		From: s.Address(),
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != s.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTx(ctx, tx, txSigner)
+		},
+		Context: ctx,
+	}, nil
+}
+
+// PrivateKey returns the private key of the signer if it holds one in memory.
+// Some features, e.g. calling the ecrecover precompile, need the raw key.
+func PrivateKey(s Signer) (*ecdsa.PrivateKey, bool) {
+	p, ok := s.(*PrivateKeySigner)
+	if !ok {
+		return nil, false
+	}
+	return p.key, true
+}
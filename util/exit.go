@@ -0,0 +1,206 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+
+	gethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/cobra"
+)
+
+// ExitError is an error making polycli exit with the given code instead of 1,
+// for the commands whose exit code carries a result, e.g. a failed health check.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorCategory classifies the errors of the commands, so the automation
+// built around polycli can tell what failed from the exit code, or from the
+// error printed with --error-format json, without parsing the logs.
+type ErrorCategory string
+
+const (
+	// ApplicationError is any other failure of the command.
+	ApplicationError ErrorCategory = "application"
+	// ResultError is a failed check of the command, e.g. an unhealthy node,
+	// carried by an ExitError.
+	ResultError ErrorCategory = "result"
+	// ValidationError is an invalid flag, argument or input.
+	ValidationError ErrorCategory = "validation"
+	// NetworkError is an endpoint which can't be reached or which fails.
+	NetworkError ErrorCategory = "network"
+	// AuthError is a rejected credential or key.
+	AuthError ErrorCategory = "auth"
+	// TimeoutError is an operation which didn't complete in time.
+	TimeoutError ErrorCategory = "timeout"
+)
+
+// The exit codes of the error categories. ResultExitCode is the code of the
+// ExitErrors of the commands whose exit code carries a result.
+const (
+	ApplicationExitCode = 1
+	ResultExitCode      = 2
+	ValidationExitCode  = 3
+	NetworkExitCode     = 4
+	AuthExitCode        = 5
+	TimeoutExitCode     = 6
+)
+
+var exitCodes = map[ErrorCategory]int{
+	ApplicationError: ApplicationExitCode,
+	ResultError:      ResultExitCode,
+	ValidationError:  ValidationExitCode,
+	NetworkError:     NetworkExitCode,
+	AuthError:        AuthExitCode,
+	TimeoutError:     TimeoutExitCode,
+}
+
+// CategorizedError is an error of a known category.
+type CategorizedError struct {
+	Category ErrorCategory
+	Err      error
+}
+
+func (e *CategorizedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CategorizedError) Unwrap() error {
+	return e.Err
+}
+
+// NewError returns the error with the category, or nil when err is nil.
+func NewError(category ErrorCategory, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CategorizedError{Category: category, Err: err}
+}
+
+// Errorf formats an error of the category.
+func Errorf(category ErrorCategory, format string, a ...any) error {
+	return NewError(category, fmt.Errorf(format, a...))
+}
+
+// NewNetworkError returns the error of a call to an endpoint as a network
+// error, unless it's a timeout or a rejected credential, which keep their
+// category. It returns nil when err is nil.
+func NewNetworkError(err error) error {
+	if err == nil {
+		return nil
+	}
+	category := classify(err)
+	if category == ApplicationError {
+		category = NetworkError
+	}
+	return NewError(category, err)
+}
+
+// ClassifyError returns the category and the exit code of the error. The
+// category of a CategorizedError is kept, an ExitError is a result error
+// keeping its code, and the others are inferred from the timeouts, the network failures and the
+// rejected credentials found in the chain of errors.
+func ClassifyError(err error) (ErrorCategory, int) {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return ResultError, exitErr.Code
+	}
+	category := classify(err)
+	return category, exitCodes[category]
+}
+
+func classify(err error) ErrorCategory {
+	var categorized *CategorizedError
+	if errors.As(err, &categorized) {
+		return categorized.Category
+	}
+
+	var netErr net.Error
+	isNetErr := errors.As(err, &netErr)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) || (isNetErr && netErr.Timeout()) {
+		return TimeoutError
+	}
+
+	var httpErr ethrpc.HTTPError
+	if errors.As(err, &httpErr) {
+		if httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden {
+			return AuthError
+		}
+		return NetworkError
+	}
+	if errors.Is(err, gethkeystore.ErrDecrypt) {
+		return AuthError
+	}
+	if isNetErr {
+		return NetworkError
+	}
+	return ApplicationError
+}
+
+// MarkValidationErrors makes the errors of the argument checks and of the
+// pre-run hooks of the command and its descendants, which check the flags,
+// validation errors unless they are known to be of another category, e.g. a
+// network error.
+func MarkValidationErrors(cmd *cobra.Command) {
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(cmd *cobra.Command, a []string) error {
+			return asValidationError(args(cmd, a))
+		}
+	}
+	if preRunE := cmd.PreRunE; preRunE != nil {
+		cmd.PreRunE = func(cmd *cobra.Command, a []string) error {
+			return asValidationError(preRunE(cmd, a))
+		}
+	}
+	for _, c := range cmd.Commands() {
+		MarkValidationErrors(c)
+	}
+}
+
+func asValidationError(err error) error {
+	if err == nil || classify(err) != ApplicationError {
+		return err
+	}
+	return NewError(ValidationError, err)
+}
+
+// ErrorReport is the error printed with --error-format json.
+type ErrorReport struct {
+	Error    string        `json:"error"`
+	Category ErrorCategory `json:"category"`
+	ExitCode int           `json:"exitCode"`
+	Command  string        `json:"command,omitempty"`
+}
+
+// WriteError writes the error of the command, either as text or as a JSON
+// error report, and returns the exit code.
+func WriteError(w io.Writer, err error, command string, format OutputFormat) int {
+	category, code := ClassifyError(err)
+	if format != JSONOutput {
+		fmt.Fprintln(w, "Error:", err)
+		return code
+	}
+	b, mErr := json.Marshal(ErrorReport{Error: err.Error(), Category: category, ExitCode: code, Command: command})
+	if mErr != nil {
+		fmt.Fprintln(w, "Error:", err)
+		return code
+	}
+	fmt.Fprintln(w, string(b))
+	return code
+}
@@ -0,0 +1,74 @@
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	ethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestClassifyError(t *testing.T) {
+	unauthorized := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer unauthorized.Close()
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer slow.Close()
+
+	call := func(url string, timeout time.Duration) error {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		c, err := ethrpc.DialContext(ctx, url)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		var head string
+		return fmt.Errorf("unable to get the head: %w", c.CallContext(ctx, &head, "eth_blockNumber"))
+	}
+
+	for name, tc := range map[string]struct {
+		err      error
+		category ErrorCategory
+		code     int
+	}{
+		"application": {errors.New("failed"), ApplicationError, ApplicationExitCode},
+		"result":      {&ExitError{Code: ResultExitCode, Err: errors.New("unhealthy")}, ResultError, ResultExitCode},
+		"validation":  {ValidateUrl("localhost:8545"), ValidationError, ValidationExitCode},
+		"network":     {call("http://127.0.0.1:1", time.Second), NetworkError, NetworkExitCode},
+		"auth":        {call(unauthorized.URL, time.Second), AuthError, AuthExitCode},
+		"keystore":    {fmt.Errorf("wrapped: %w", gethkeystore.ErrDecrypt), AuthError, AuthExitCode},
+		"timeout":     {call(slow.URL, 10*time.Millisecond), TimeoutError, TimeoutExitCode},
+		"rpc error":   {NewNetworkError(errors.New("method not found")), NetworkError, NetworkExitCode},
+		"rpc timeout": {NewNetworkError(call(slow.URL, 10*time.Millisecond)), TimeoutError, TimeoutExitCode},
+		"rpc auth":    {NewNetworkError(call(unauthorized.URL, time.Second)), AuthError, AuthExitCode},
+	} {
+		category, code := ClassifyError(tc.err)
+		if category != tc.category || code != tc.code {
+			t.Errorf("%s: got %s %d for %v, expected %s %d", name, category, code, tc.err, tc.category, tc.code)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	var buf bytes.Buffer
+	code := WriteError(&buf, Errorf(NetworkError, "unreachable"), "polycli test", JSONOutput)
+	var report ErrorReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	expected := ErrorReport{Error: "unreachable", Category: NetworkError, ExitCode: NetworkExitCode, Command: "polycli test"}
+	if code != NetworkExitCode || report != expected {
+		t.Fatalf("got %d %+v", code, report)
+	}
+}
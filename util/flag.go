@@ -0,0 +1,102 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// EnvVarAnnotation is the flag annotation holding the name of the environment
+// variable which backs the flag. It's used to document the flags.
+const EnvVarAnnotation = "polycli_env_var"
+
+// SideEffectFreeAnnotation is the command annotation of the commands without
+// side effects, e.g. which send no transaction and write no file. Only their
+// examples can be executed by docutil.
+const SideEffectFreeAnnotation = "polycli_side_effect_free"
+
+// EnvVarPrefix is the prefix of the environment variables which back the flags.
+const EnvVarPrefix = "POLYCLI"
+
+// Flags which shouldn't be read from the config file or the environment.
+var unboundFlags = map[string]bool{"config": true, "help": true}
+
+// FlagConfigKey returns the config key of a flag defined by the command. The
+// key is made of the command path without the root command and the flag
+// name, e.g. `loadtest.rpc-url` for the `--rpc-url` flag of `polycli loadtest`.
+func FlagConfigKey(cmd *cobra.Command, name string) string {
+	path := strings.Fields(cmd.CommandPath())[1:]
+	return strings.Join(append(path, name), ".")
+}
+
+// FlagEnvVar returns the environment variable which backs a config key, e.g.
+// `POLYCLI_LOADTEST_RPC_URL` for `loadtest.rpc-url`.
+func FlagEnvVar(key string) string {
+	return EnvVarPrefix + "_" + strings.ToUpper(envKeyReplacer.Replace(key))
+}
+
+var envKeyReplacer = strings.NewReplacer("-", "_", ".", "_")
+
+// ConfigureViper makes viper look up the `POLYCLI_*` environment variables
+// matching the config keys.
+func ConfigureViper() {
+	viper.SetEnvPrefix(EnvVarPrefix)
+	viper.SetEnvKeyReplacer(envKeyReplacer)
+	viper.AutomaticEnv()
+}
+
+// AnnotateFlagEnvVars records the environment variable of every flag of the
+// command and its descendants so it can be documented.
+func AnnotateFlagEnvVars(cmd *cobra.Command) {
+	visitFlags(cmd, func(c *cobra.Command, f *pflag.Flag) {
+		if unboundFlags[f.Name] {
+			return
+		}
+		env := FlagEnvVar(FlagConfigKey(c, f.Name))
+		_ = c.LocalFlags().SetAnnotation(f.Name, EnvVarAnnotation, []string{env})
+	})
+}
+
+// BindFlags sets every flag of the command and its descendants which wasn't
+// given on the command line from the environment or the config file. The
+// precedence is command line, then environment variable, then config file,
+// then the flag default.
+func BindFlags(cmd *cobra.Command) error {
+	var err error
+	visitFlags(cmd, func(c *cobra.Command, f *pflag.Flag) {
+		if err != nil || f.Changed || unboundFlags[f.Name] {
+			return
+		}
+		key := FlagConfigKey(c, f.Name)
+		if !viper.IsSet(key) {
+			return
+		}
+		value := viper.Get(key)
+		if values, ok := value.([]any); ok {
+			s := make([]string, len(values))
+			for i, v := range values {
+				s[i] = fmt.Sprint(v)
+			}
+			value = strings.Join(s, ",")
+		}
+		if setErr := f.Value.Set(fmt.Sprint(value)); setErr != nil {
+			err = fmt.Errorf("invalid value for %s: %w", key, setErr)
+			return
+		}
+		f.Changed = true
+	})
+	return err
+}
+
+// Call fn for each flag defined by the command or one of its descendants.
+func visitFlags(cmd *cobra.Command, fn func(*cobra.Command, *pflag.Flag)) {
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		fn(cmd, f)
+	})
+	for _, c := range cmd.Commands() {
+		visitFlags(c, fn)
+	}
+}
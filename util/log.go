@@ -3,6 +3,8 @@ package util
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -23,6 +25,41 @@ const (
 	Trace  VerbosityLevel = 700
 )
 
+// ParseVerbosity parses a log level given either by name, as a geth-style
+// verbosity from 0 (silent) to 5 (trace), or as a verbosity of the polycli
+// scale from 0 (silent) to 700 (trace).
+func ParseVerbosity(s string) (VerbosityLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "silent":
+		return Silent, nil
+	case "panic":
+		return Panic, nil
+	case "fatal":
+		return Fatal, nil
+	case "error":
+		return Error, nil
+	case "warn", "warning":
+		return Warn, nil
+	case "info":
+		return Info, nil
+	case "debug":
+		return Debug, nil
+	case "trace":
+		return Trace, nil
+	}
+	v, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid verbosity %q, expected a level name or a number", s)
+	}
+	if v <= len(gethVerbosity)-1 {
+		return gethVerbosity[v], nil
+	}
+	return VerbosityLevel(v), nil
+}
+
+// gethVerbosity maps the geth verbosities to the polycli ones.
+var gethVerbosity = []VerbosityLevel{Silent, Error, Warn, Info, Debug, Trace}
+
 // SetLogLevel sets the log level based on the flags.
 // https://logging.apache.org/log4j/2.x/manual/customloglevels.html
 func SetLogLevel(verbosity int) {
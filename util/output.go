@@ -0,0 +1,108 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormat represents the format of the results printed by the commands.
+type OutputFormat string
+
+const (
+	TextOutput OutputFormat = "text"
+	JSONOutput OutputFormat = "json"
+	YAMLOutput OutputFormat = "yaml"
+)
+
+var (
+	outputFormat    = TextOutput
+	outputFormatSet bool
+)
+
+// SetOutputFormat sets the output format used by the output writers.
+func SetOutputFormat(format string) error {
+	switch f := OutputFormat(format); f {
+	case TextOutput, JSONOutput, YAMLOutput:
+		outputFormat = f
+		outputFormatSet = true
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// GetOutputFormat returns the output format set with the --output flag.
+func GetOutputFormat() OutputFormat {
+	return outputFormat
+}
+
+// TextWriter is implemented by results which have a human readable
+// representation. Results which don't implement it are printed as indented
+// JSON in text mode.
+type TextWriter interface {
+	WriteText(w io.Writer) error
+}
+
+// OutputWriter writes the results of a command in the output format.
+type OutputWriter interface {
+	Write(v any) error
+}
+
+type outputWriter struct {
+	w      io.Writer
+	format OutputFormat
+}
+
+// NewOutputWriter returns an OutputWriter writing to w in the output format
+// set with the --output flag.
+func NewOutputWriter(w io.Writer) OutputWriter {
+	return &outputWriter{w: w, format: outputFormat}
+}
+
+// NewOutputWriterWithDefault returns an OutputWriter writing to w in the
+// output format set with the --output flag, or in the given format when the
+// flag isn't set, for the commands which printed their results in another
+// format before the --output flag existed.
+func NewOutputWriterWithDefault(w io.Writer, format OutputFormat) OutputWriter {
+	if outputFormatSet {
+		format = outputFormat
+	}
+	return &outputWriter{w: w, format: format}
+}
+
+func (o *outputWriter) Write(v any) error {
+	switch o.format {
+	case JSONOutput:
+		return writeJSON(o.w, v, "")
+	case YAMLOutput:
+		// Going through JSON first so the json struct tags are respected.
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic any
+		if err = json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		_, err = o.w.Write(out)
+		return err
+	default:
+		if tw, ok := v.(TextWriter); ok {
+			return tw.WriteText(o.w)
+		}
+		return writeJSON(o.w, v, "  ")
+	}
+}
+
+func writeJSON(w io.Writer, v any, indent string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", indent)
+	return enc.Encode(v)
+}
@@ -2,18 +2,18 @@ package util
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/maticnetwork/polygon-cli/signer"
 )
 
 // SendTx is a simple wrapper to send a transaction from one Ethereum address to another.
-func SendTx(ctx context.Context, c *ethclient.Client, privateKey *ecdsa.PrivateKey, to *common.Address, amount *big.Int, data []byte, gasLimit uint64) error {
+func SendTx(ctx context.Context, c *ethclient.Client, s signer.Signer, to *common.Address, amount *big.Int, data []byte, gasLimit uint64) error {
 	// Get the chaind id.
 	chainID, err := c.ChainID(ctx)
 	if err != nil {
@@ -21,9 +21,8 @@ func SendTx(ctx context.Context, c *ethclient.Client, privateKey *ecdsa.PrivateK
 	}
 
 	// Get the nonce.
-	from := crypto.PubkeyToAddress(privateKey.PublicKey)
 	var nonce uint64
-	nonce, err = c.PendingNonceAt(ctx, from)
+	nonce, err = c.PendingNonceAt(ctx, s.Address())
 	if err != nil {
 		return err
 	}
@@ -45,7 +44,7 @@ func SendTx(ctx context.Context, c *ethclient.Client, privateKey *ecdsa.PrivateK
 		Data:     data,
 	})
 	var signedTx *types.Transaction
-	signedTx, err = types.SignTx(tx, types.NewEIP155Signer(chainID), privateKey)
+	signedTx, err = s.SignTx(ctx, tx, types.NewEIP155Signer(chainID))
 	if err != nil {
 		return err
 	}
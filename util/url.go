@@ -2,7 +2,6 @@ package util
 
 import (
 	"errors"
-	"fmt"
 	"net/url"
 
 	"github.com/rs/zerolog/log"
@@ -13,16 +12,16 @@ func ValidateUrl(input string) error {
 	url, err := url.Parse(input)
 	if err != nil {
 		log.Error().Err(err).Msg("Unable to parse url input error")
-		return err
+		return NewError(ValidationError, err)
 	}
 
 	if url.Scheme == "" {
-		return errors.New("the scheme has not been specified")
+		return NewError(ValidationError, errors.New("the scheme has not been specified"))
 	}
 	switch url.Scheme {
 	case "http", "https", "ws", "wss":
 		return nil
 	default:
-		return fmt.Errorf("the scheme '%s' is not supported", url.Scheme)
+		return Errorf(ValidationError, "the scheme '%s' is not supported", url.Scheme)
 	}
 }